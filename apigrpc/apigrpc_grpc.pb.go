@@ -20,6 +20,8 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type NakamaClient interface {
+	// Add a reaction to a message in a channel's message history.
+	AddChannelMessageReaction(ctx context.Context, in *ChannelMessageReactionRequest, opts ...grpc.CallOption) (*ChannelMessageReactions, error)
 	// Add friends by ID or username to a user's account.
 	AddFriends(ctx context.Context, in *api.AddFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Add users to a group.
@@ -52,6 +54,8 @@ type NakamaClient interface {
 	BlockFriends(ctx context.Context, in *api.BlockFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Create a new group with the current user as the owner.
 	CreateGroup(ctx context.Context, in *api.CreateGroupRequest, opts ...grpc.CallOption) (*api.Group, error)
+	// Remove the user's reaction from a message in a channel's message history.
+	DeleteChannelMessageReaction(ctx context.Context, in *ChannelMessageReactionRequest, opts ...grpc.CallOption) (*ChannelMessageReactions, error)
 	// Delete one or more users by ID or username.
 	DeleteFriends(ctx context.Context, in *api.DeleteFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Delete a group by ID.
@@ -104,14 +108,20 @@ type NakamaClient interface {
 	LinkSteam(ctx context.Context, in *api.LinkSteamRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// List a channel's message history.
 	ListChannelMessages(ctx context.Context, in *api.ListChannelMessagesRequest, opts ...grpc.CallOption) (*api.ChannelMessageList, error)
+	// List a channel's message history with the thread references and reactions of each message, optionally only the messages of a thread.
+	ListChannelMessagesWithOptions(ctx context.Context, in *ListChannelMessagesWithOptionsRequest, opts ...grpc.CallOption) (*ThreadedChannelMessageList, error)
 	// List all friends for the current user.
 	ListFriends(ctx context.Context, in *api.ListFriendsRequest, opts ...grpc.CallOption) (*api.FriendList, error)
 	// List groups based on given filters.
 	ListGroups(ctx context.Context, in *api.ListGroupsRequest, opts ...grpc.CallOption) (*api.GroupList, error)
 	// List all users that are part of a group.
 	ListGroupUsers(ctx context.Context, in *api.ListGroupUsersRequest, opts ...grpc.CallOption) (*api.GroupUserList, error)
+	// List past periods of a leaderboard with a reset schedule, with their final top records.
+	ListLeaderboardPeriods(ctx context.Context, in *ListLeaderboardPeriodsRequest, opts ...grpc.CallOption) (*LeaderboardPeriodList, error)
 	// List leaderboard records.
 	ListLeaderboardRecords(ctx context.Context, in *api.ListLeaderboardRecordsRequest, opts ...grpc.CallOption) (*api.LeaderboardRecordList, error)
+	// List leaderboard records, ranked among a subset of owners or from a past period.
+	ListLeaderboardRecordsWithOptions(ctx context.Context, in *ListLeaderboardRecordsWithOptionsRequest, opts ...grpc.CallOption) (*api.LeaderboardRecordList, error)
 	// List leaderboard records that belong to a user.
 	ListLeaderboardRecordsAroundOwner(ctx context.Context, in *api.ListLeaderboardRecordsAroundOwnerRequest, opts ...grpc.CallOption) (*api.LeaderboardRecordList, error)
 	// Fetch list of running matches.
@@ -120,12 +130,16 @@ type NakamaClient interface {
 	ListNotifications(ctx context.Context, in *api.ListNotificationsRequest, opts ...grpc.CallOption) (*api.NotificationList, error)
 	// List publicly readable storage objects in a given collection.
 	ListStorageObjects(ctx context.Context, in *api.ListStorageObjectsRequest, opts ...grpc.CallOption) (*api.StorageObjectList, error)
+	// Query a storage index for objects the caller can read.
+	ListStorageIndex(ctx context.Context, in *ListStorageIndexRequest, opts ...grpc.CallOption) (*api.StorageObjects, error)
 	// List user's subscriptions.
 	ListSubscriptions(ctx context.Context, in *api.ListSubscriptionsRequest, opts ...grpc.CallOption) (*api.SubscriptionList, error)
 	// List current or upcoming tournaments.
 	ListTournaments(ctx context.Context, in *api.ListTournamentsRequest, opts ...grpc.CallOption) (*api.TournamentList, error)
 	// List tournament records.
 	ListTournamentRecords(ctx context.Context, in *api.ListTournamentRecordsRequest, opts ...grpc.CallOption) (*api.TournamentRecordList, error)
+	// List tournament records, ranked among a subset of owners.
+	ListTournamentRecordsWithOptions(ctx context.Context, in *ListTournamentRecordsWithOptionsRequest, opts ...grpc.CallOption) (*api.TournamentRecordList, error)
 	// List tournament records for a given owner.
 	ListTournamentRecordsAroundOwner(ctx context.Context, in *api.ListTournamentRecordsAroundOwnerRequest, opts ...grpc.CallOption) (*api.TournamentRecordList, error)
 	// List groups the current user belongs to.
@@ -136,6 +150,8 @@ type NakamaClient interface {
 	DemoteGroupUsers(ctx context.Context, in *api.DemoteGroupUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Get storage objects.
 	ReadStorageObjects(ctx context.Context, in *api.ReadStorageObjectsRequest, opts ...grpc.CallOption) (*api.StorageObjects, error)
+	// Send a message in reply to a message in a channel's message history.
+	ReplyChannelMessage(ctx context.Context, in *ReplyChannelMessageRequest, opts ...grpc.CallOption) (*ThreadedChannelMessage, error)
 	// Execute a Lua function on the server.
 	RpcFunc(ctx context.Context, in *api.Rpc, opts ...grpc.CallOption) (*api.Rpc, error)
 	// Remove the Apple ID from the social profiles on the current user's account.
@@ -174,6 +190,8 @@ type NakamaClient interface {
 	WriteLeaderboardRecord(ctx context.Context, in *api.WriteLeaderboardRecordRequest, opts ...grpc.CallOption) (*api.LeaderboardRecord, error)
 	// Write objects into the storage engine.
 	WriteStorageObjects(ctx context.Context, in *api.WriteStorageObjectsRequest, opts ...grpc.CallOption) (*api.StorageObjectAcks, error)
+	// Write objects into the storage engine, with per-object write options.
+	WriteStorageObjectsWithOptions(ctx context.Context, in *WriteStorageObjectsWithOptionsRequest, opts ...grpc.CallOption) (*api.StorageObjectAcks, error)
 	// Write a record to a tournament.
 	WriteTournamentRecord(ctx context.Context, in *api.WriteTournamentRecordRequest, opts ...grpc.CallOption) (*api.LeaderboardRecord, error)
 }
@@ -186,6 +204,15 @@ func NewNakamaClient(cc grpc.ClientConnInterface) NakamaClient {
 	return &nakamaClient{cc}
 }
 
+func (c *nakamaClient) AddChannelMessageReaction(ctx context.Context, in *ChannelMessageReactionRequest, opts ...grpc.CallOption) (*ChannelMessageReactions, error) {
+	out := new(ChannelMessageReactions)
+	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/AddChannelMessageReaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nakamaClient) AddFriends(ctx context.Context, in *api.AddFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/AddFriends", in, out, opts...)
@@ -330,6 +357,15 @@ func (c *nakamaClient) CreateGroup(ctx context.Context, in *api.CreateGroupReque
 	return out, nil
 }
 
+func (c *nakamaClient) DeleteChannelMessageReaction(ctx context.Context, in *ChannelMessageReactionRequest, opts ...grpc.CallOption) (*ChannelMessageReactions, error) {
+	out := new(ChannelMessageReactions)
+	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/DeleteChannelMessageReaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nakamaClient) DeleteFriends(ctx context.Context, in *api.DeleteFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/DeleteFriends", in, out, opts...)
@@ -564,6 +600,15 @@ func (c *nakamaClient) ListChannelMessages(ctx context.Context, in *api.ListChan
 	return out, nil
 }
 
+func (c *nakamaClient) ListChannelMessagesWithOptions(ctx context.Context, in *ListChannelMessagesWithOptionsRequest, opts ...grpc.CallOption) (*ThreadedChannelMessageList, error) {
+	out := new(ThreadedChannelMessageList)
+	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/ListChannelMessagesWithOptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nakamaClient) ListFriends(ctx context.Context, in *api.ListFriendsRequest, opts ...grpc.CallOption) (*api.FriendList, error) {
 	out := new(api.FriendList)
 	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/ListFriends", in, out, opts...)
@@ -591,6 +636,15 @@ func (c *nakamaClient) ListGroupUsers(ctx context.Context, in *api.ListGroupUser
 	return out, nil
 }
 
+func (c *nakamaClient) ListLeaderboardPeriods(ctx context.Context, in *ListLeaderboardPeriodsRequest, opts ...grpc.CallOption) (*LeaderboardPeriodList, error) {
+	out := new(LeaderboardPeriodList)
+	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/ListLeaderboardPeriods", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nakamaClient) ListLeaderboardRecords(ctx context.Context, in *api.ListLeaderboardRecordsRequest, opts ...grpc.CallOption) (*api.LeaderboardRecordList, error) {
 	out := new(api.LeaderboardRecordList)
 	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/ListLeaderboardRecords", in, out, opts...)
@@ -600,6 +654,15 @@ func (c *nakamaClient) ListLeaderboardRecords(ctx context.Context, in *api.ListL
 	return out, nil
 }
 
+func (c *nakamaClient) ListLeaderboardRecordsWithOptions(ctx context.Context, in *ListLeaderboardRecordsWithOptionsRequest, opts ...grpc.CallOption) (*api.LeaderboardRecordList, error) {
+	out := new(api.LeaderboardRecordList)
+	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/ListLeaderboardRecordsWithOptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nakamaClient) ListLeaderboardRecordsAroundOwner(ctx context.Context, in *api.ListLeaderboardRecordsAroundOwnerRequest, opts ...grpc.CallOption) (*api.LeaderboardRecordList, error) {
 	out := new(api.LeaderboardRecordList)
 	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/ListLeaderboardRecordsAroundOwner", in, out, opts...)
@@ -636,6 +699,15 @@ func (c *nakamaClient) ListStorageObjects(ctx context.Context, in *api.ListStora
 	return out, nil
 }
 
+func (c *nakamaClient) ListStorageIndex(ctx context.Context, in *ListStorageIndexRequest, opts ...grpc.CallOption) (*api.StorageObjects, error) {
+	out := new(api.StorageObjects)
+	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/ListStorageIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nakamaClient) ListSubscriptions(ctx context.Context, in *api.ListSubscriptionsRequest, opts ...grpc.CallOption) (*api.SubscriptionList, error) {
 	out := new(api.SubscriptionList)
 	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/ListSubscriptions", in, out, opts...)
@@ -663,6 +735,15 @@ func (c *nakamaClient) ListTournamentRecords(ctx context.Context, in *api.ListTo
 	return out, nil
 }
 
+func (c *nakamaClient) ListTournamentRecordsWithOptions(ctx context.Context, in *ListTournamentRecordsWithOptionsRequest, opts ...grpc.CallOption) (*api.TournamentRecordList, error) {
+	out := new(api.TournamentRecordList)
+	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/ListTournamentRecordsWithOptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nakamaClient) ListTournamentRecordsAroundOwner(ctx context.Context, in *api.ListTournamentRecordsAroundOwnerRequest, opts ...grpc.CallOption) (*api.TournamentRecordList, error) {
 	out := new(api.TournamentRecordList)
 	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/ListTournamentRecordsAroundOwner", in, out, opts...)
@@ -708,6 +789,15 @@ func (c *nakamaClient) ReadStorageObjects(ctx context.Context, in *api.ReadStora
 	return out, nil
 }
 
+func (c *nakamaClient) ReplyChannelMessage(ctx context.Context, in *ReplyChannelMessageRequest, opts ...grpc.CallOption) (*ThreadedChannelMessage, error) {
+	out := new(ThreadedChannelMessage)
+	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/ReplyChannelMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nakamaClient) RpcFunc(ctx context.Context, in *api.Rpc, opts ...grpc.CallOption) (*api.Rpc, error) {
 	out := new(api.Rpc)
 	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/RpcFunc", in, out, opts...)
@@ -879,6 +969,15 @@ func (c *nakamaClient) WriteStorageObjects(ctx context.Context, in *api.WriteSto
 	return out, nil
 }
 
+func (c *nakamaClient) WriteStorageObjectsWithOptions(ctx context.Context, in *WriteStorageObjectsWithOptionsRequest, opts ...grpc.CallOption) (*api.StorageObjectAcks, error) {
+	out := new(api.StorageObjectAcks)
+	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/WriteStorageObjectsWithOptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nakamaClient) WriteTournamentRecord(ctx context.Context, in *api.WriteTournamentRecordRequest, opts ...grpc.CallOption) (*api.LeaderboardRecord, error) {
 	out := new(api.LeaderboardRecord)
 	err := c.cc.Invoke(ctx, "/nakama.api.Nakama/WriteTournamentRecord", in, out, opts...)
@@ -892,6 +991,8 @@ func (c *nakamaClient) WriteTournamentRecord(ctx context.Context, in *api.WriteT
 // All implementations must embed UnimplementedNakamaServer
 // for forward compatibility
 type NakamaServer interface {
+	// Add a reaction to a message in a channel's message history.
+	AddChannelMessageReaction(context.Context, *ChannelMessageReactionRequest) (*ChannelMessageReactions, error)
 	// Add friends by ID or username to a user's account.
 	AddFriends(context.Context, *api.AddFriendsRequest) (*emptypb.Empty, error)
 	// Add users to a group.
@@ -924,6 +1025,8 @@ type NakamaServer interface {
 	BlockFriends(context.Context, *api.BlockFriendsRequest) (*emptypb.Empty, error)
 	// Create a new group with the current user as the owner.
 	CreateGroup(context.Context, *api.CreateGroupRequest) (*api.Group, error)
+	// Remove the user's reaction from a message in a channel's message history.
+	DeleteChannelMessageReaction(context.Context, *ChannelMessageReactionRequest) (*ChannelMessageReactions, error)
 	// Delete one or more users by ID or username.
 	DeleteFriends(context.Context, *api.DeleteFriendsRequest) (*emptypb.Empty, error)
 	// Delete a group by ID.
@@ -976,14 +1079,20 @@ type NakamaServer interface {
 	LinkSteam(context.Context, *api.LinkSteamRequest) (*emptypb.Empty, error)
 	// List a channel's message history.
 	ListChannelMessages(context.Context, *api.ListChannelMessagesRequest) (*api.ChannelMessageList, error)
+	// List a channel's message history with the thread references and reactions of each message, optionally only the messages of a thread.
+	ListChannelMessagesWithOptions(context.Context, *ListChannelMessagesWithOptionsRequest) (*ThreadedChannelMessageList, error)
 	// List all friends for the current user.
 	ListFriends(context.Context, *api.ListFriendsRequest) (*api.FriendList, error)
 	// List groups based on given filters.
 	ListGroups(context.Context, *api.ListGroupsRequest) (*api.GroupList, error)
 	// List all users that are part of a group.
 	ListGroupUsers(context.Context, *api.ListGroupUsersRequest) (*api.GroupUserList, error)
+	// List past periods of a leaderboard with a reset schedule, with their final top records.
+	ListLeaderboardPeriods(context.Context, *ListLeaderboardPeriodsRequest) (*LeaderboardPeriodList, error)
 	// List leaderboard records.
 	ListLeaderboardRecords(context.Context, *api.ListLeaderboardRecordsRequest) (*api.LeaderboardRecordList, error)
+	// List leaderboard records, ranked among a subset of owners or from a past period.
+	ListLeaderboardRecordsWithOptions(context.Context, *ListLeaderboardRecordsWithOptionsRequest) (*api.LeaderboardRecordList, error)
 	// List leaderboard records that belong to a user.
 	ListLeaderboardRecordsAroundOwner(context.Context, *api.ListLeaderboardRecordsAroundOwnerRequest) (*api.LeaderboardRecordList, error)
 	// Fetch list of running matches.
@@ -992,12 +1101,16 @@ type NakamaServer interface {
 	ListNotifications(context.Context, *api.ListNotificationsRequest) (*api.NotificationList, error)
 	// List publicly readable storage objects in a given collection.
 	ListStorageObjects(context.Context, *api.ListStorageObjectsRequest) (*api.StorageObjectList, error)
+	// Query a storage index for objects the caller can read.
+	ListStorageIndex(context.Context, *ListStorageIndexRequest) (*api.StorageObjects, error)
 	// List user's subscriptions.
 	ListSubscriptions(context.Context, *api.ListSubscriptionsRequest) (*api.SubscriptionList, error)
 	// List current or upcoming tournaments.
 	ListTournaments(context.Context, *api.ListTournamentsRequest) (*api.TournamentList, error)
 	// List tournament records.
 	ListTournamentRecords(context.Context, *api.ListTournamentRecordsRequest) (*api.TournamentRecordList, error)
+	// List tournament records, ranked among a subset of owners.
+	ListTournamentRecordsWithOptions(context.Context, *ListTournamentRecordsWithOptionsRequest) (*api.TournamentRecordList, error)
 	// List tournament records for a given owner.
 	ListTournamentRecordsAroundOwner(context.Context, *api.ListTournamentRecordsAroundOwnerRequest) (*api.TournamentRecordList, error)
 	// List groups the current user belongs to.
@@ -1008,6 +1121,8 @@ type NakamaServer interface {
 	DemoteGroupUsers(context.Context, *api.DemoteGroupUsersRequest) (*emptypb.Empty, error)
 	// Get storage objects.
 	ReadStorageObjects(context.Context, *api.ReadStorageObjectsRequest) (*api.StorageObjects, error)
+	// Send a message in reply to a message in a channel's message history.
+	ReplyChannelMessage(context.Context, *ReplyChannelMessageRequest) (*ThreadedChannelMessage, error)
 	// Execute a Lua function on the server.
 	RpcFunc(context.Context, *api.Rpc) (*api.Rpc, error)
 	// Remove the Apple ID from the social profiles on the current user's account.
@@ -1046,6 +1161,8 @@ type NakamaServer interface {
 	WriteLeaderboardRecord(context.Context, *api.WriteLeaderboardRecordRequest) (*api.LeaderboardRecord, error)
 	// Write objects into the storage engine.
 	WriteStorageObjects(context.Context, *api.WriteStorageObjectsRequest) (*api.StorageObjectAcks, error)
+	// Write objects into the storage engine, with per-object write options.
+	WriteStorageObjectsWithOptions(context.Context, *WriteStorageObjectsWithOptionsRequest) (*api.StorageObjectAcks, error)
 	// Write a record to a tournament.
 	WriteTournamentRecord(context.Context, *api.WriteTournamentRecordRequest) (*api.LeaderboardRecord, error)
 	mustEmbedUnimplementedNakamaServer()
@@ -1055,6 +1172,9 @@ type NakamaServer interface {
 type UnimplementedNakamaServer struct {
 }
 
+func (UnimplementedNakamaServer) AddChannelMessageReaction(context.Context, *ChannelMessageReactionRequest) (*ChannelMessageReactions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddChannelMessageReaction not implemented")
+}
 func (UnimplementedNakamaServer) AddFriends(context.Context, *api.AddFriendsRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddFriends not implemented")
 }
@@ -1103,6 +1223,9 @@ func (UnimplementedNakamaServer) BlockFriends(context.Context, *api.BlockFriends
 func (UnimplementedNakamaServer) CreateGroup(context.Context, *api.CreateGroupRequest) (*api.Group, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
 }
+func (UnimplementedNakamaServer) DeleteChannelMessageReaction(context.Context, *ChannelMessageReactionRequest) (*ChannelMessageReactions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteChannelMessageReaction not implemented")
+}
 func (UnimplementedNakamaServer) DeleteFriends(context.Context, *api.DeleteFriendsRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteFriends not implemented")
 }
@@ -1181,6 +1304,9 @@ func (UnimplementedNakamaServer) LinkSteam(context.Context, *api.LinkSteamReques
 func (UnimplementedNakamaServer) ListChannelMessages(context.Context, *api.ListChannelMessagesRequest) (*api.ChannelMessageList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListChannelMessages not implemented")
 }
+func (UnimplementedNakamaServer) ListChannelMessagesWithOptions(context.Context, *ListChannelMessagesWithOptionsRequest) (*ThreadedChannelMessageList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChannelMessagesWithOptions not implemented")
+}
 func (UnimplementedNakamaServer) ListFriends(context.Context, *api.ListFriendsRequest) (*api.FriendList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListFriends not implemented")
 }
@@ -1190,9 +1316,15 @@ func (UnimplementedNakamaServer) ListGroups(context.Context, *api.ListGroupsRequ
 func (UnimplementedNakamaServer) ListGroupUsers(context.Context, *api.ListGroupUsersRequest) (*api.GroupUserList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListGroupUsers not implemented")
 }
+func (UnimplementedNakamaServer) ListLeaderboardPeriods(context.Context, *ListLeaderboardPeriodsRequest) (*LeaderboardPeriodList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLeaderboardPeriods not implemented")
+}
 func (UnimplementedNakamaServer) ListLeaderboardRecords(context.Context, *api.ListLeaderboardRecordsRequest) (*api.LeaderboardRecordList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListLeaderboardRecords not implemented")
 }
+func (UnimplementedNakamaServer) ListLeaderboardRecordsWithOptions(context.Context, *ListLeaderboardRecordsWithOptionsRequest) (*api.LeaderboardRecordList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLeaderboardRecordsWithOptions not implemented")
+}
 func (UnimplementedNakamaServer) ListLeaderboardRecordsAroundOwner(context.Context, *api.ListLeaderboardRecordsAroundOwnerRequest) (*api.LeaderboardRecordList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListLeaderboardRecordsAroundOwner not implemented")
 }
@@ -1205,6 +1337,9 @@ func (UnimplementedNakamaServer) ListNotifications(context.Context, *api.ListNot
 func (UnimplementedNakamaServer) ListStorageObjects(context.Context, *api.ListStorageObjectsRequest) (*api.StorageObjectList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListStorageObjects not implemented")
 }
+func (UnimplementedNakamaServer) ListStorageIndex(context.Context, *ListStorageIndexRequest) (*api.StorageObjects, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStorageIndex not implemented")
+}
 func (UnimplementedNakamaServer) ListSubscriptions(context.Context, *api.ListSubscriptionsRequest) (*api.SubscriptionList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSubscriptions not implemented")
 }
@@ -1214,6 +1349,9 @@ func (UnimplementedNakamaServer) ListTournaments(context.Context, *api.ListTourn
 func (UnimplementedNakamaServer) ListTournamentRecords(context.Context, *api.ListTournamentRecordsRequest) (*api.TournamentRecordList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTournamentRecords not implemented")
 }
+func (UnimplementedNakamaServer) ListTournamentRecordsWithOptions(context.Context, *ListTournamentRecordsWithOptionsRequest) (*api.TournamentRecordList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTournamentRecordsWithOptions not implemented")
+}
 func (UnimplementedNakamaServer) ListTournamentRecordsAroundOwner(context.Context, *api.ListTournamentRecordsAroundOwnerRequest) (*api.TournamentRecordList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTournamentRecordsAroundOwner not implemented")
 }
@@ -1229,6 +1367,9 @@ func (UnimplementedNakamaServer) DemoteGroupUsers(context.Context, *api.DemoteGr
 func (UnimplementedNakamaServer) ReadStorageObjects(context.Context, *api.ReadStorageObjectsRequest) (*api.StorageObjects, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadStorageObjects not implemented")
 }
+func (UnimplementedNakamaServer) ReplyChannelMessage(context.Context, *ReplyChannelMessageRequest) (*ThreadedChannelMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplyChannelMessage not implemented")
+}
 func (UnimplementedNakamaServer) RpcFunc(context.Context, *api.Rpc) (*api.Rpc, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RpcFunc not implemented")
 }
@@ -1286,6 +1427,9 @@ func (UnimplementedNakamaServer) WriteLeaderboardRecord(context.Context, *api.Wr
 func (UnimplementedNakamaServer) WriteStorageObjects(context.Context, *api.WriteStorageObjectsRequest) (*api.StorageObjectAcks, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteStorageObjects not implemented")
 }
+func (UnimplementedNakamaServer) WriteStorageObjectsWithOptions(context.Context, *WriteStorageObjectsWithOptionsRequest) (*api.StorageObjectAcks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteStorageObjectsWithOptions not implemented")
+}
 func (UnimplementedNakamaServer) WriteTournamentRecord(context.Context, *api.WriteTournamentRecordRequest) (*api.LeaderboardRecord, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteTournamentRecord not implemented")
 }
@@ -1302,6 +1446,24 @@ func RegisterNakamaServer(s grpc.ServiceRegistrar, srv NakamaServer) {
 	s.RegisterService(&Nakama_ServiceDesc, srv)
 }
 
+func _Nakama_AddChannelMessageReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelMessageReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NakamaServer).AddChannelMessageReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.api.Nakama/AddChannelMessageReaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NakamaServer).AddChannelMessageReaction(ctx, req.(*ChannelMessageReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Nakama_AddFriends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(api.AddFriendsRequest)
 	if err := dec(in); err != nil {
@@ -1590,6 +1752,24 @@ func _Nakama_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Nakama_DeleteChannelMessageReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelMessageReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NakamaServer).DeleteChannelMessageReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.api.Nakama/DeleteChannelMessageReaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NakamaServer).DeleteChannelMessageReaction(ctx, req.(*ChannelMessageReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Nakama_DeleteFriends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(api.DeleteFriendsRequest)
 	if err := dec(in); err != nil {
@@ -2058,6 +2238,24 @@ func _Nakama_ListChannelMessages_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Nakama_ListChannelMessagesWithOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChannelMessagesWithOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NakamaServer).ListChannelMessagesWithOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.api.Nakama/ListChannelMessagesWithOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NakamaServer).ListChannelMessagesWithOptions(ctx, req.(*ListChannelMessagesWithOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Nakama_ListFriends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(api.ListFriendsRequest)
 	if err := dec(in); err != nil {
@@ -2112,6 +2310,24 @@ func _Nakama_ListGroupUsers_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Nakama_ListLeaderboardPeriods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeaderboardPeriodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NakamaServer).ListLeaderboardPeriods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.api.Nakama/ListLeaderboardPeriods",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NakamaServer).ListLeaderboardPeriods(ctx, req.(*ListLeaderboardPeriodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Nakama_ListLeaderboardRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(api.ListLeaderboardRecordsRequest)
 	if err := dec(in); err != nil {
@@ -2130,6 +2346,24 @@ func _Nakama_ListLeaderboardRecords_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Nakama_ListLeaderboardRecordsWithOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeaderboardRecordsWithOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NakamaServer).ListLeaderboardRecordsWithOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.api.Nakama/ListLeaderboardRecordsWithOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NakamaServer).ListLeaderboardRecordsWithOptions(ctx, req.(*ListLeaderboardRecordsWithOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Nakama_ListLeaderboardRecordsAroundOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(api.ListLeaderboardRecordsAroundOwnerRequest)
 	if err := dec(in); err != nil {
@@ -2202,6 +2436,24 @@ func _Nakama_ListStorageObjects_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Nakama_ListStorageIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStorageIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NakamaServer).ListStorageIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.api.Nakama/ListStorageIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NakamaServer).ListStorageIndex(ctx, req.(*ListStorageIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Nakama_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(api.ListSubscriptionsRequest)
 	if err := dec(in); err != nil {
@@ -2256,6 +2508,24 @@ func _Nakama_ListTournamentRecords_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Nakama_ListTournamentRecordsWithOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTournamentRecordsWithOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NakamaServer).ListTournamentRecordsWithOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.api.Nakama/ListTournamentRecordsWithOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NakamaServer).ListTournamentRecordsWithOptions(ctx, req.(*ListTournamentRecordsWithOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Nakama_ListTournamentRecordsAroundOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(api.ListTournamentRecordsAroundOwnerRequest)
 	if err := dec(in); err != nil {
@@ -2346,6 +2616,24 @@ func _Nakama_ReadStorageObjects_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Nakama_ReplyChannelMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplyChannelMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NakamaServer).ReplyChannelMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.api.Nakama/ReplyChannelMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NakamaServer).ReplyChannelMessage(ctx, req.(*ReplyChannelMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Nakama_RpcFunc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(api.Rpc)
 	if err := dec(in); err != nil {
@@ -2688,6 +2976,24 @@ func _Nakama_WriteStorageObjects_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Nakama_WriteStorageObjectsWithOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteStorageObjectsWithOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NakamaServer).WriteStorageObjectsWithOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.api.Nakama/WriteStorageObjectsWithOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NakamaServer).WriteStorageObjectsWithOptions(ctx, req.(*WriteStorageObjectsWithOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Nakama_WriteTournamentRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(api.WriteTournamentRecordRequest)
 	if err := dec(in); err != nil {
@@ -2713,6 +3019,10 @@ var Nakama_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "nakama.api.Nakama",
 	HandlerType: (*NakamaServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddChannelMessageReaction",
+			Handler:    _Nakama_AddChannelMessageReaction_Handler,
+		},
 		{
 			MethodName: "AddFriends",
 			Handler:    _Nakama_AddFriends_Handler,
@@ -2777,6 +3087,10 @@ var Nakama_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateGroup",
 			Handler:    _Nakama_CreateGroup_Handler,
 		},
+		{
+			MethodName: "DeleteChannelMessageReaction",
+			Handler:    _Nakama_DeleteChannelMessageReaction_Handler,
+		},
 		{
 			MethodName: "DeleteFriends",
 			Handler:    _Nakama_DeleteFriends_Handler,
@@ -2881,6 +3195,10 @@ var Nakama_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListChannelMessages",
 			Handler:    _Nakama_ListChannelMessages_Handler,
 		},
+		{
+			MethodName: "ListChannelMessagesWithOptions",
+			Handler:    _Nakama_ListChannelMessagesWithOptions_Handler,
+		},
 		{
 			MethodName: "ListFriends",
 			Handler:    _Nakama_ListFriends_Handler,
@@ -2893,10 +3211,18 @@ var Nakama_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListGroupUsers",
 			Handler:    _Nakama_ListGroupUsers_Handler,
 		},
+		{
+			MethodName: "ListLeaderboardPeriods",
+			Handler:    _Nakama_ListLeaderboardPeriods_Handler,
+		},
 		{
 			MethodName: "ListLeaderboardRecords",
 			Handler:    _Nakama_ListLeaderboardRecords_Handler,
 		},
+		{
+			MethodName: "ListLeaderboardRecordsWithOptions",
+			Handler:    _Nakama_ListLeaderboardRecordsWithOptions_Handler,
+		},
 		{
 			MethodName: "ListLeaderboardRecordsAroundOwner",
 			Handler:    _Nakama_ListLeaderboardRecordsAroundOwner_Handler,
@@ -2913,6 +3239,10 @@ var Nakama_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListStorageObjects",
 			Handler:    _Nakama_ListStorageObjects_Handler,
 		},
+		{
+			MethodName: "ListStorageIndex",
+			Handler:    _Nakama_ListStorageIndex_Handler,
+		},
 		{
 			MethodName: "ListSubscriptions",
 			Handler:    _Nakama_ListSubscriptions_Handler,
@@ -2925,6 +3255,10 @@ var Nakama_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTournamentRecords",
 			Handler:    _Nakama_ListTournamentRecords_Handler,
 		},
+		{
+			MethodName: "ListTournamentRecordsWithOptions",
+			Handler:    _Nakama_ListTournamentRecordsWithOptions_Handler,
+		},
 		{
 			MethodName: "ListTournamentRecordsAroundOwner",
 			Handler:    _Nakama_ListTournamentRecordsAroundOwner_Handler,
@@ -2945,6 +3279,10 @@ var Nakama_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReadStorageObjects",
 			Handler:    _Nakama_ReadStorageObjects_Handler,
 		},
+		{
+			MethodName: "ReplyChannelMessage",
+			Handler:    _Nakama_ReplyChannelMessage_Handler,
+		},
 		{
 			MethodName: "RpcFunc",
 			Handler:    _Nakama_RpcFunc_Handler,
@@ -3021,6 +3359,10 @@ var Nakama_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "WriteStorageObjects",
 			Handler:    _Nakama_WriteStorageObjects_Handler,
 		},
+		{
+			MethodName: "WriteStorageObjectsWithOptions",
+			Handler:    _Nakama_WriteStorageObjectsWithOptions_Handler,
+		},
 		{
 			MethodName: "WriteTournamentRecord",
 			Handler:    _Nakama_WriteTournamentRecord_Handler,
@@ -54,6 +54,8 @@ type ConsoleClient interface {
 	DeleteLeaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Delete leaderboard record
 	DeleteLeaderboardRecord(ctx context.Context, in *DeleteLeaderboardRecordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Remove a ticket from the matchmaker pool, without notifying its users.
+	DeleteMatchmakerTicket(ctx context.Context, in *DeleteMatchmakerTicketRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Delete console user.
 	DeleteUser(ctx context.Context, in *Username, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Delete a wallet ledger item.
@@ -78,8 +80,12 @@ type ConsoleClient interface {
 	GetGroups(ctx context.Context, in *AccountId, opts ...grpc.CallOption) (*api.UserGroupList, error)
 	// Get leaderboard.
 	GetLeaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*Leaderboard, error)
+	// Get the score validation rules of a leaderboard and its rejected submissions.
+	GetLeaderboardValidation(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardValidation, error)
 	// Get current state of a running match
 	GetMatchState(ctx context.Context, in *MatchStateRequest, opts ...grpc.CallOption) (*MatchState, error)
+	// Get statistics of the matchmaker pool, overall and per query.
+	GetMatchmakerStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MatchmakerStats, error)
 	// Get runtime info
 	GetRuntime(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RuntimeInfo, error)
 	// Get current status data for all nodes.
@@ -106,6 +112,10 @@ type ConsoleClient interface {
 	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*GroupList, error)
 	// List ongoing matches
 	ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*MatchList, error)
+	// List matches recently formed by the matchmaker on this node.
+	ListMatchmakerMatches(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MatchmakerMatchList, error)
+	// List (and optionally filter) tickets in the matchmaker pool, longest waiting first.
+	ListMatchmakerTickets(ctx context.Context, in *ListMatchmakerTicketsRequest, opts ...grpc.CallOption) (*MatchmakerTicketList, error)
 	// List validated purchases
 	ListPurchases(ctx context.Context, in *ListPurchasesRequest, opts ...grpc.CallOption) (*api.PurchaseList, error)
 	// List validated subscriptions
@@ -303,6 +313,15 @@ func (c *consoleClient) DeleteLeaderboardRecord(ctx context.Context, in *DeleteL
 	return out, nil
 }
 
+func (c *consoleClient) DeleteMatchmakerTicket(ctx context.Context, in *DeleteMatchmakerTicketRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/nakama.console.Console/DeleteMatchmakerTicket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *consoleClient) DeleteUser(ctx context.Context, in *Username, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/nakama.console.Console/DeleteUser", in, out, opts...)
@@ -411,6 +430,15 @@ func (c *consoleClient) GetLeaderboard(ctx context.Context, in *LeaderboardReque
 	return out, nil
 }
 
+func (c *consoleClient) GetLeaderboardValidation(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardValidation, error) {
+	out := new(LeaderboardValidation)
+	err := c.cc.Invoke(ctx, "/nakama.console.Console/GetLeaderboardValidation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *consoleClient) GetMatchState(ctx context.Context, in *MatchStateRequest, opts ...grpc.CallOption) (*MatchState, error) {
 	out := new(MatchState)
 	err := c.cc.Invoke(ctx, "/nakama.console.Console/GetMatchState", in, out, opts...)
@@ -420,6 +448,15 @@ func (c *consoleClient) GetMatchState(ctx context.Context, in *MatchStateRequest
 	return out, nil
 }
 
+func (c *consoleClient) GetMatchmakerStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MatchmakerStats, error) {
+	out := new(MatchmakerStats)
+	err := c.cc.Invoke(ctx, "/nakama.console.Console/GetMatchmakerStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *consoleClient) GetRuntime(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RuntimeInfo, error) {
 	out := new(RuntimeInfo)
 	err := c.cc.Invoke(ctx, "/nakama.console.Console/GetRuntime", in, out, opts...)
@@ -537,6 +574,24 @@ func (c *consoleClient) ListMatches(ctx context.Context, in *ListMatchesRequest,
 	return out, nil
 }
 
+func (c *consoleClient) ListMatchmakerMatches(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MatchmakerMatchList, error) {
+	out := new(MatchmakerMatchList)
+	err := c.cc.Invoke(ctx, "/nakama.console.Console/ListMatchmakerMatches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consoleClient) ListMatchmakerTickets(ctx context.Context, in *ListMatchmakerTicketsRequest, opts ...grpc.CallOption) (*MatchmakerTicketList, error) {
+	out := new(MatchmakerTicketList)
+	err := c.cc.Invoke(ctx, "/nakama.console.Console/ListMatchmakerTickets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *consoleClient) ListPurchases(ctx context.Context, in *ListPurchasesRequest, opts ...grpc.CallOption) (*api.PurchaseList, error) {
 	out := new(api.PurchaseList)
 	err := c.cc.Invoke(ctx, "/nakama.console.Console/ListPurchases", in, out, opts...)
@@ -728,6 +783,8 @@ type ConsoleServer interface {
 	DeleteLeaderboard(context.Context, *LeaderboardRequest) (*emptypb.Empty, error)
 	// Delete leaderboard record
 	DeleteLeaderboardRecord(context.Context, *DeleteLeaderboardRecordRequest) (*emptypb.Empty, error)
+	// Remove a ticket from the matchmaker pool, without notifying its users.
+	DeleteMatchmakerTicket(context.Context, *DeleteMatchmakerTicketRequest) (*emptypb.Empty, error)
 	// Delete console user.
 	DeleteUser(context.Context, *Username) (*emptypb.Empty, error)
 	// Delete a wallet ledger item.
@@ -752,8 +809,12 @@ type ConsoleServer interface {
 	GetGroups(context.Context, *AccountId) (*api.UserGroupList, error)
 	// Get leaderboard.
 	GetLeaderboard(context.Context, *LeaderboardRequest) (*Leaderboard, error)
+	// Get the score validation rules of a leaderboard and its rejected submissions.
+	GetLeaderboardValidation(context.Context, *LeaderboardRequest) (*LeaderboardValidation, error)
 	// Get current state of a running match
 	GetMatchState(context.Context, *MatchStateRequest) (*MatchState, error)
+	// Get statistics of the matchmaker pool, overall and per query.
+	GetMatchmakerStats(context.Context, *emptypb.Empty) (*MatchmakerStats, error)
 	// Get runtime info
 	GetRuntime(context.Context, *emptypb.Empty) (*RuntimeInfo, error)
 	// Get current status data for all nodes.
@@ -780,6 +841,10 @@ type ConsoleServer interface {
 	ListGroups(context.Context, *ListGroupsRequest) (*GroupList, error)
 	// List ongoing matches
 	ListMatches(context.Context, *ListMatchesRequest) (*MatchList, error)
+	// List matches recently formed by the matchmaker on this node.
+	ListMatchmakerMatches(context.Context, *emptypb.Empty) (*MatchmakerMatchList, error)
+	// List (and optionally filter) tickets in the matchmaker pool, longest waiting first.
+	ListMatchmakerTickets(context.Context, *ListMatchmakerTicketsRequest) (*MatchmakerTicketList, error)
 	// List validated purchases
 	ListPurchases(context.Context, *ListPurchasesRequest) (*api.PurchaseList, error)
 	// List validated subscriptions
@@ -872,6 +937,9 @@ func (UnimplementedConsoleServer) DeleteLeaderboard(context.Context, *Leaderboar
 func (UnimplementedConsoleServer) DeleteLeaderboardRecord(context.Context, *DeleteLeaderboardRecordRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteLeaderboardRecord not implemented")
 }
+func (UnimplementedConsoleServer) DeleteMatchmakerTicket(context.Context, *DeleteMatchmakerTicketRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMatchmakerTicket not implemented")
+}
 func (UnimplementedConsoleServer) DeleteUser(context.Context, *Username) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
 }
@@ -908,9 +976,15 @@ func (UnimplementedConsoleServer) GetGroups(context.Context, *AccountId) (*api.U
 func (UnimplementedConsoleServer) GetLeaderboard(context.Context, *LeaderboardRequest) (*Leaderboard, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
 }
+func (UnimplementedConsoleServer) GetLeaderboardValidation(context.Context, *LeaderboardRequest) (*LeaderboardValidation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboardValidation not implemented")
+}
 func (UnimplementedConsoleServer) GetMatchState(context.Context, *MatchStateRequest) (*MatchState, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMatchState not implemented")
 }
+func (UnimplementedConsoleServer) GetMatchmakerStats(context.Context, *emptypb.Empty) (*MatchmakerStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatchmakerStats not implemented")
+}
 func (UnimplementedConsoleServer) GetRuntime(context.Context, *emptypb.Empty) (*RuntimeInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRuntime not implemented")
 }
@@ -950,6 +1024,12 @@ func (UnimplementedConsoleServer) ListGroups(context.Context, *ListGroupsRequest
 func (UnimplementedConsoleServer) ListMatches(context.Context, *ListMatchesRequest) (*MatchList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListMatches not implemented")
 }
+func (UnimplementedConsoleServer) ListMatchmakerMatches(context.Context, *emptypb.Empty) (*MatchmakerMatchList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMatchmakerMatches not implemented")
+}
+func (UnimplementedConsoleServer) ListMatchmakerTickets(context.Context, *ListMatchmakerTicketsRequest) (*MatchmakerTicketList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMatchmakerTickets not implemented")
+}
 func (UnimplementedConsoleServer) ListPurchases(context.Context, *ListPurchasesRequest) (*api.PurchaseList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPurchases not implemented")
 }
@@ -1320,6 +1400,24 @@ func _Console_DeleteLeaderboardRecord_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Console_DeleteMatchmakerTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMatchmakerTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsoleServer).DeleteMatchmakerTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.console.Console/DeleteMatchmakerTicket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsoleServer).DeleteMatchmakerTicket(ctx, req.(*DeleteMatchmakerTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Console_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Username)
 	if err := dec(in); err != nil {
@@ -1536,6 +1634,24 @@ func _Console_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Console_GetLeaderboardValidation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsoleServer).GetLeaderboardValidation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.console.Console/GetLeaderboardValidation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsoleServer).GetLeaderboardValidation(ctx, req.(*LeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Console_GetMatchState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MatchStateRequest)
 	if err := dec(in); err != nil {
@@ -1554,6 +1670,24 @@ func _Console_GetMatchState_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Console_GetMatchmakerStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsoleServer).GetMatchmakerStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.console.Console/GetMatchmakerStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsoleServer).GetMatchmakerStats(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Console_GetRuntime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1788,6 +1922,42 @@ func _Console_ListMatches_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Console_ListMatchmakerMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsoleServer).ListMatchmakerMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.console.Console/ListMatchmakerMatches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsoleServer).ListMatchmakerMatches(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Console_ListMatchmakerTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMatchmakerTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsoleServer).ListMatchmakerTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nakama.console.Console/ListMatchmakerTickets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsoleServer).ListMatchmakerTickets(ctx, req.(*ListMatchmakerTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Console_ListPurchases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListPurchasesRequest)
 	if err := dec(in); err != nil {
@@ -2169,6 +2339,10 @@ var Console_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteLeaderboardRecord",
 			Handler:    _Console_DeleteLeaderboardRecord_Handler,
 		},
+		{
+			MethodName: "DeleteMatchmakerTicket",
+			Handler:    _Console_DeleteMatchmakerTicket_Handler,
+		},
 		{
 			MethodName: "DeleteUser",
 			Handler:    _Console_DeleteUser_Handler,
@@ -2217,10 +2391,18 @@ var Console_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLeaderboard",
 			Handler:    _Console_GetLeaderboard_Handler,
 		},
+		{
+			MethodName: "GetLeaderboardValidation",
+			Handler:    _Console_GetLeaderboardValidation_Handler,
+		},
 		{
 			MethodName: "GetMatchState",
 			Handler:    _Console_GetMatchState_Handler,
 		},
+		{
+			MethodName: "GetMatchmakerStats",
+			Handler:    _Console_GetMatchmakerStats_Handler,
+		},
 		{
 			MethodName: "GetRuntime",
 			Handler:    _Console_GetRuntime_Handler,
@@ -2273,6 +2455,14 @@ var Console_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListMatches",
 			Handler:    _Console_ListMatches_Handler,
 		},
+		{
+			MethodName: "ListMatchmakerMatches",
+			Handler:    _Console_ListMatchmakerMatches_Handler,
+		},
+		{
+			MethodName: "ListMatchmakerTickets",
+			Handler:    _Console_ListMatchmakerTickets_Handler,
+		},
 		{
 			MethodName: "ListPurchases",
 			Handler:    _Console_ListPurchases_Handler,
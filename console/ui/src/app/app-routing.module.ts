@@ -34,6 +34,7 @@ import {GroupsComponent, GroupsResolver} from './account/groups/groups.component
 import {GroupDetailsComponent} from './group/details/groupDetailsComponent';
 import {GroupMembersComponent, GroupMembersResolver} from './group/members/groupMembers.component';
 import {MatchesComponent, MatchesResolver, NodesResolver} from './matches/matches.component';
+import {MatchmakerComponent, MatchmakerResolver} from './matchmaker/matchmaker.component';
 import {GroupListComponent, GroupSearchResolver} from './groups/groups.component';
 import {GroupComponent, GroupResolver} from './group/group.component';
 import {LeaderboardComponent, LeaderboardResolver} from './leaderboard/leaderboard.component';
@@ -67,6 +68,7 @@ const routes: Routes = [
         ]
       },
       {path: 'matches', component: MatchesComponent, resolve: [MatchesResolver, NodesResolver]},
+      {path: 'matchmaker', component: MatchmakerComponent, resolve: [MatchmakerResolver]},
       {path: 'groups', component: GroupListComponent, resolve: [GroupSearchResolver]},
       {
         path: 'groups/:id', component: GroupComponent, resolve: [GroupResolver],
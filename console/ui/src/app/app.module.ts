@@ -53,6 +53,7 @@ import {GroupsComponent} from './account/groups/groups.component';
 import {GroupMembersComponent} from "./group/members/groupMembers.component";
 import {ChatListComponent} from "./channels/chatMessages.component";
 import {MatchesComponent} from './matches/matches.component';
+import {MatchmakerComponent} from './matchmaker/matchmaker.component';
 import {LeaderboardsComponent} from './leaderboards/leaderboards.component';
 import {LeaderboardComponent} from './leaderboard/leaderboard.component';
 import {LeaderboardDetailsComponent} from './leaderboard/details/details.component';
@@ -84,6 +85,7 @@ import {SubscriptionsComponent} from './account/subscriptions/subscriptions.comp
     GroupDetailsComponent,
     GroupMembersComponent,
     MatchesComponent,
+    MatchmakerComponent,
     LeaderboardsComponent,
     LeaderboardComponent,
     LeaderboardDetailsComponent,
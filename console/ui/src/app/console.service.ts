@@ -199,6 +199,28 @@ export interface LeaderboardList {
 	leaderboards?:Array<Leaderboard>
 }
 
+/** Score validation rules of a leaderboard and the submissions they rejected. */
+export interface LeaderboardValidation {
+  // Client score submissions rejected by the validation rules, for each reason.
+	rejections?:Map<string, string>
+  // The validation rules, unset if the leaderboard has none.
+	validation?:LeaderboardValidationRules
+}
+
+/** Score validation rules. */
+export interface LeaderboardValidationRules {
+  // Highest score accepted on each submission, if any.
+	max_score?:string
+  // Largest change to the owner's score a single submission may make.
+	max_score_delta?:string
+  // Largest number of submissions each owner may make in each window.
+	max_submissions?:number
+  // Lowest score accepted on each submission, if any.
+	min_score?:string
+  // Length of the submission window in seconds.
+	submission_window_sec?:number
+}
+
 export enum ListChannelMessagesRequestType {
   UNKNOWN = 0,
   ROOM = 1,
@@ -227,6 +249,106 @@ export interface MatchState {
 	tick?:string
 }
 
+/** A match recently formed by the matchmaker. */
+export interface MatchmakerMatch {
+  // Whether the users filled open slots of a running match.
+	backfill?:boolean
+  // When the match was formed.
+	create_time?:string
+  // The authoritative match the users were sent to, empty if they received a token for a new relayed match.
+	match_id?:string
+  // The users in the match.
+	presences?:Array<MatchmakerPresence>
+  // The tickets the match was formed from.
+	tickets?:Array<string>
+}
+
+/** A list of matches recently formed by the matchmaker. */
+export interface MatchmakerMatchList {
+  // Matches, most recently formed first.
+	matches?:Array<MatchmakerMatch>
+}
+
+/** A user in a matchmaker ticket or formed match. */
+export interface MatchmakerPresence {
+  // Node the session is connected to.
+	node?:string
+  // Session ID.
+	session_id?:string
+  // User ID.
+	user_id?:string
+  // Username.
+	username?:string
+}
+
+/** Statistics of the tickets in the matchmaker pool that share a query. */
+export interface MatchmakerQueryStats {
+  // Number of tickets still searching for matches themselves.
+	active_tickets?:number
+  // Average time tickets have waited, in seconds.
+	avg_age_sec?:number
+  // Longest time a ticket has waited, in seconds.
+	max_age_sec?:string
+  // Number of users in the tickets.
+	players?:number
+  // The query.
+	query?:string
+  // Number of tickets.
+	tickets?:number
+}
+
+/** Statistics of the matchmaker pool. */
+export interface MatchmakerStats {
+  // Number of tickets still searching for matches themselves.
+	active_tickets?:number
+  // Number of users in the tickets.
+	players?:number
+  // Statistics per query.
+	queries?:Array<MatchmakerQueryStats>
+  // Number of tickets.
+	tickets?:number
+}
+
+/** A ticket in the matchmaker pool. */
+export interface MatchmakerTicket {
+  // Whether the ticket still searches for matches itself.
+	active?:boolean
+  // Time the ticket has waited, in seconds.
+	age_sec?:string
+  // Multiple the match size must be.
+	count_multiple?:number
+  // When the ticket was submitted.
+	create_time?:string
+  // Processing intervals the ticket has taken part in.
+	intervals?:number
+  // Maximum match size.
+	max_count?:number
+  // Minimum match size.
+	min_count?:number
+  // Node the ticket was submitted on.
+	node?:string
+  // Numeric properties.
+	numeric_properties?:Map<string, number>
+  // Party that submitted the ticket, if any.
+	party_id?:string
+  // The users in the ticket.
+	presences?:Array<MatchmakerPresence>
+  // The query the ticket matches others with.
+	query?:string
+  // Session that submitted the ticket, if not submitted by a party.
+	session_id?:string
+  // String properties.
+	string_properties?:Map<string, string>
+  // Ticket ID.
+	ticket?:string
+}
+
+/** A list of matchmaker tickets. */
+export interface MatchmakerTicketList {
+  // Tickets, longest waiting first.
+	tickets?:Array<MatchmakerTicket>
+}
+
 export interface RuntimeInfo {
   // Go loaded modules
 	go_modules?:Array<RuntimeInfoModuleInfo>
@@ -1191,6 +1313,14 @@ export class ConsoleService {
     return this.httpClient.delete(this.config.host + urlPath, { params: params, headers: this.getTokenAuthHeaders(auth_token) })
   }
 
+  /** Get the score validation rules of a leaderboard and its rejected submissions. */
+  getLeaderboardValidation(auth_token: string, id: string): Observable<LeaderboardValidation> {
+		id = encodeURIComponent(String(id))
+		const urlPath = `/v2/console/leaderboard/${id}/validation`;
+    let params = new HttpParams();
+    return this.httpClient.get<LeaderboardValidation>(this.config.host + urlPath, { params: params, headers: this.getTokenAuthHeaders(auth_token) })
+  }
+
   /** List leaderboard records. */
   listLeaderboardRecords(auth_token: string, leaderboard_id: string, owner_ids?: Array<string>, limit?: number, cursor?: string, expiry?: string): Observable<ApiLeaderboardRecordList> {
 		leaderboard_id = encodeURIComponent(String(leaderboard_id))
@@ -1250,6 +1380,41 @@ export class ConsoleService {
     return this.httpClient.get<MatchState>(this.config.host + urlPath, { params: params, headers: this.getTokenAuthHeaders(auth_token) })
   }
 
+  /** List matches recently formed by the matchmaker on this node. */
+  listMatchmakerMatches(auth_token: string): Observable<MatchmakerMatchList> {
+		const urlPath = `/v2/console/matchmaker/match`;
+    let params = new HttpParams();
+    return this.httpClient.get<MatchmakerMatchList>(this.config.host + urlPath, { params: params, headers: this.getTokenAuthHeaders(auth_token) })
+  }
+
+  /** Get statistics of the matchmaker pool, overall and per query. */
+  getMatchmakerStats(auth_token: string): Observable<MatchmakerStats> {
+		const urlPath = `/v2/console/matchmaker/stats`;
+    let params = new HttpParams();
+    return this.httpClient.get<MatchmakerStats>(this.config.host + urlPath, { params: params, headers: this.getTokenAuthHeaders(auth_token) })
+  }
+
+  /** List (and optionally filter) tickets in the matchmaker pool, longest waiting first. */
+  listMatchmakerTickets(auth_token: string, query?: string, limit?: number): Observable<MatchmakerTicketList> {
+		const urlPath = `/v2/console/matchmaker/ticket`;
+    let params = new HttpParams();
+    if (query) {
+      params = params.set('query', query);
+    }
+    if (limit) {
+      params = params.set('limit', String(limit));
+    }
+    return this.httpClient.get<MatchmakerTicketList>(this.config.host + urlPath, { params: params, headers: this.getTokenAuthHeaders(auth_token) })
+  }
+
+  /** Remove a ticket from the matchmaker pool, without notifying its users. */
+  deleteMatchmakerTicket(auth_token: string, ticket: string): Observable<any> {
+		ticket = encodeURIComponent(String(ticket))
+		const urlPath = `/v2/console/matchmaker/ticket/${ticket}`;
+    let params = new HttpParams();
+    return this.httpClient.delete(this.config.host + urlPath, { params: params, headers: this.getTokenAuthHeaders(auth_token) })
+  }
+
   /** Delete messages. */
   deleteChannelMessages(auth_token: string, before?: string, ids?: Array<string>): Observable<DeleteChannelMessagesResponse> {
 		const urlPath = `/v2/console/message`;
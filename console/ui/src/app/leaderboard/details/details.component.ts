@@ -13,7 +13,7 @@
 // limitations under the License.
 
 import {AfterViewInit, Component, ElementRef, OnInit, ViewChild} from '@angular/core';
-import {Leaderboard} from '../../console.service';
+import {ConsoleService, Leaderboard, LeaderboardValidation} from '../../console.service';
 import {ActivatedRoute} from '@angular/router';
 import * as ace from 'ace-builds';
 
@@ -35,22 +35,46 @@ export class LeaderboardDetailsComponent implements OnInit, AfterViewInit {
     3: 'Decrement',
   };
 
+  public rejectionString = {
+    min_score: 'Below Min Score',
+    max_score: 'Above Max Score',
+    max_score_delta: 'Above Max Score Delta',
+    max_submissions: 'Too Many Submissions',
+  };
+
   private aceEditor: ace.Ace.Editor;
   public leaderboard: Leaderboard;
+  public validation: LeaderboardValidation;
   public error = '';
 
-  constructor(private readonly route: ActivatedRoute) {}
+  constructor(
+    private readonly route: ActivatedRoute,
+    private readonly consoleService: ConsoleService,
+  ) {}
 
   ngOnInit(): void {
     this.route.parent.data.subscribe(
       d => {
         this.leaderboard = d[0];
+        this.loadValidation();
       },
       err => {
         this.error = err;
       });
   }
 
+  private loadValidation(): void {
+    this.consoleService.getLeaderboardValidation('', this.leaderboard.id).subscribe(v => {
+      this.validation = v;
+    }, err => {
+      this.error = err;
+    });
+  }
+
+  rejectionReasons(): string[] {
+    return Object.keys(this.validation?.rejections || {});
+  }
+
   ngAfterViewInit(): void {
     ace.config.set('fontSize', '14px');
     ace.config.set('printMarginColumn', 0);
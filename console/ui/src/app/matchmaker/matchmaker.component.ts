@@ -0,0 +1,161 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import {Component, Injectable, OnInit} from '@angular/core';
+import {ActivatedRoute, ActivatedRouteSnapshot, Params, Resolve, Router, RouterStateSnapshot} from '@angular/router';
+import {FormBuilder, FormGroup} from '@angular/forms';
+import {forkJoin, Observable, of} from 'rxjs';
+import {catchError} from 'rxjs/operators';
+import {
+  ConsoleService,
+  MatchmakerMatch,
+  MatchmakerMatchList,
+  MatchmakerPresence,
+  MatchmakerStats,
+  MatchmakerTicket,
+  MatchmakerTicketList,
+  UserRole
+} from '../console.service';
+import {AuthenticationService} from '../authentication.service';
+
+export interface MatchmakerView {
+  stats?: MatchmakerStats;
+  tickets?: MatchmakerTicketList;
+  matches?: MatchmakerMatchList;
+}
+
+@Component({
+  templateUrl: './matchmaker.component.html',
+  styleUrls: ['./matchmaker.component.scss']
+})
+export class MatchmakerComponent implements OnInit {
+  public error = '';
+  public stats: MatchmakerStats = {};
+  public tickets: Array<MatchmakerTicket> = [];
+  public ticketsOpen: Array<boolean> = [];
+  public matches: Array<MatchmakerMatch> = [];
+  public searchForm: FormGroup;
+
+  constructor(
+    private readonly route: ActivatedRoute,
+    private readonly router: Router,
+    private readonly formBuilder: FormBuilder,
+    private readonly consoleService: ConsoleService,
+    private readonly authService: AuthenticationService,
+  ) {
+    this.searchForm = this.formBuilder.group({
+      query: '',
+    });
+  }
+
+  ngOnInit(): void {
+    this.f.query.setValue(this.route.snapshot.queryParamMap.get('query'));
+
+    this.route.data.subscribe(
+      d => {
+        if (d) {
+          if (d[0]) {
+            this.postData(d[0]);
+          }
+          if (d.error) {
+            this.error = d.error;
+          }
+        }
+      },
+      err => {
+        this.error = err;
+      });
+  }
+
+  search(): void {
+    loadMatchmaker(this.consoleService, this.f.query.value).subscribe(d => {
+      this.postData(d);
+      const params: Params = {query: this.f.query.value};
+      this.router.navigate([], {
+        relativeTo: this.route,
+        queryParams: params,
+      });
+    }, err => {
+      this.error = err;
+    });
+  }
+
+  filterQuery(query: string): void {
+    this.f.query.setValue(query);
+    this.search();
+  }
+
+  postData(d: MatchmakerView): void {
+    this.error = '';
+    this.stats = d.stats || {};
+    this.tickets.length = 0;
+    this.tickets.push(...(d.tickets.tickets || []));
+    this.ticketsOpen.length = this.tickets.length;
+    this.matches.length = 0;
+    this.matches.push(...(d.matches.matches || []));
+  }
+
+  deleteTicket(event, i: number, t: MatchmakerTicket): void {
+    event.target.disabled = true;
+    event.preventDefault();
+    this.error = '';
+    this.consoleService.deleteMatchmakerTicket('', t.ticket).subscribe(() => {
+      this.tickets.splice(i, 1);
+      this.ticketsOpen.splice(i, 1);
+    }, err => {
+      this.error = err;
+    });
+  }
+
+  deleteAllowed(): boolean {
+    // only admin, developers and maintainers are allowed.
+    return this.authService.sessionRole <= UserRole.USER_ROLE_MAINTAINER;
+  }
+
+  getPresencesString(ps: Array<MatchmakerPresence>): string {
+    return JSON.stringify(ps);
+  }
+
+  getPropertiesString(t: MatchmakerTicket): string {
+    return JSON.stringify({...t.string_properties, ...t.numeric_properties});
+  }
+
+  getUsernames(ps: Array<MatchmakerPresence>): string {
+    return (ps || []).map(p => p.username).join(', ');
+  }
+
+  get f(): any {
+    return this.searchForm.controls;
+  }
+}
+
+@Injectable({providedIn: 'root'})
+export class MatchmakerResolver implements Resolve<MatchmakerView> {
+  constructor(private readonly consoleService: ConsoleService) {}
+
+  resolve(route: ActivatedRouteSnapshot, state: RouterStateSnapshot): Observable<MatchmakerView> {
+    return loadMatchmaker(this.consoleService, route.queryParamMap.get('query')).pipe(catchError(error => {
+      route.data = {...route.data, error};
+      return of(null);
+    }));
+  }
+}
+
+function loadMatchmaker(consoleService: ConsoleService, query: string): Observable<MatchmakerView> {
+  return forkJoin({
+    stats: consoleService.getMatchmakerStats(''),
+    tickets: consoleService.listMatchmakerTickets('', query || ''),
+    matches: consoleService.listMatchmakerMatches(''),
+  });
+}
@@ -140,35 +140,51 @@ func main() {
 	cookie := newOrLoadCookie(config)
 	metrics := server.NewLocalMetrics(logger, startupLogger, db, config)
 	sessionRegistry := server.NewLocalSessionRegistry(metrics)
-	sessionCache := server.NewLocalSessionCache(config.GetSession().TokenExpirySec)
+	clusterTransport := server.NewClusterTransport(logger, startupLogger, config)
+	sessionCache := server.NewSessionCache(logger, db, clusterTransport, config)
 	consoleSessionCache := server.NewLocalSessionCache(config.GetConsole().TokenExpirySec)
-	loginAttemptCache := server.NewLocalLoginAttemptCache()
+	loginAttemptCache := server.NewLoginAttemptCache(logger, db, clusterTransport, config)
 	statusRegistry := server.NewStatusRegistry(logger, config, sessionRegistry, jsonpbMarshaler)
-	tracker := server.StartLocalTracker(logger, config, sessionRegistry, statusRegistry, metrics, jsonpbMarshaler)
-	router := server.NewLocalMessageRouter(sessionRegistry, tracker, jsonpbMarshaler)
+	tracker := server.StartLocalTracker(logger, config, sessionRegistry, statusRegistry, metrics, jsonpbMarshaler, clusterTransport)
+	router := server.NewClusterMessageRouter(logger, sessionRegistry, tracker, clusterTransport, jsonpbMarshaler)
 	leaderboardCache := server.NewLocalLeaderboardCache(logger, startupLogger, db)
-	leaderboardRankCache := server.NewLocalLeaderboardRankCache(ctx, startupLogger, db, config.GetLeaderboard(), leaderboardCache)
-	leaderboardScheduler := server.NewLocalLeaderboardScheduler(logger, db, config, leaderboardCache, leaderboardRankCache)
-	matchRegistry := server.NewLocalMatchRegistry(logger, startupLogger, config, sessionRegistry, tracker, router, metrics, config.GetName())
+	leaderboardRankCache := server.NewLocalLeaderboardRankCache(ctx, logger, startupLogger, db, config, leaderboardCache)
+	leaderboardScheduler := server.NewLocalLeaderboardScheduler(logger, db, config, leaderboardCache, leaderboardRankCache, router)
+	matchRegistry := server.NewLocalMatchRegistry(logger, startupLogger, config, sessionRegistry, tracker, router, metrics, config.GetName(), clusterTransport)
 	tracker.SetMatchJoinListener(matchRegistry.Join)
 	tracker.SetMatchLeaveListener(matchRegistry.Leave)
 	streamManager := server.NewLocalStreamManager(config, sessionRegistry, tracker)
-	runtime, runtimeInfo, err := server.NewRuntime(ctx, logger, startupLogger, db, jsonpbMarshaler, jsonpbUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router)
+	storageIndex := server.NewLocalStorageIndex(logger, db, clusterTransport)
+	storageFeed := server.NewLocalStorageFeed(logger, tracker, router, jsonpbMarshaler)
+	leaderboardFeed := server.NewLeaderboardFeed(logger, config, leaderboardRankCache, tracker, router)
+	leaderboardRankCache.SetRankChangeListener(leaderboardFeed.RankChange)
+	runtime, runtimeInfo, err := server.NewRuntime(ctx, logger, startupLogger, db, jsonpbMarshaler, jsonpbUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, storageIndex, storageFeed)
 	if err != nil {
 		startupLogger.Fatal("Failed initializing runtime modules", zap.Error(err))
 	}
-	matchmaker := server.NewLocalMatchmaker(logger, startupLogger, config, router, metrics, runtime)
+	storageIndex.RegisterFilters(runtime)
+	if err := storageIndex.Load(ctx); err != nil {
+		startupLogger.Fatal("Failed to load storage indexes", zap.Error(err))
+	}
+	storageExpiry := server.StartStorageExpiry(logger, db, config, storageIndex, storageFeed, runtime)
+	matchmaker := server.NewLocalMatchmaker(logger, startupLogger, config, db, router, metrics, runtime, clusterTransport)
+	matchRegistry.SetBackfillListener(matchmaker.Backfill)
 	partyRegistry := server.NewLocalPartyRegistry(logger, matchmaker, tracker, streamManager, router, config.GetName())
 	tracker.SetPartyJoinListener(partyRegistry.Join)
 	tracker.SetPartyLeaveListener(partyRegistry.Leave)
 
 	leaderboardScheduler.Start(runtime)
 
+	// All cluster message handlers are registered, begin accepting traffic from other nodes.
+	if err := clusterTransport.Start(); err != nil {
+		startupLogger.Fatal("Failed starting cluster transport", zap.Error(err))
+	}
+
 	pipeline := server.NewPipeline(logger, config, db, jsonpbMarshaler, jsonpbUnmarshaler, sessionRegistry, statusRegistry, matchRegistry, partyRegistry, matchmaker, tracker, router, runtime)
 	statusHandler := server.NewLocalStatusHandler(logger, sessionRegistry, matchRegistry, tracker, metrics, config.GetName())
 
-	apiServer := server.StartApiServer(logger, startupLogger, db, jsonpbMarshaler, jsonpbUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, sessionRegistry, sessionCache, statusRegistry, matchRegistry, matchmaker, tracker, router, streamManager, metrics, pipeline, runtime)
-	consoleServer := server.StartConsoleServer(logger, startupLogger, db, config, tracker, router, streamManager, metrics, sessionCache, consoleSessionCache, loginAttemptCache, statusRegistry, statusHandler, runtimeInfo, matchRegistry, configWarnings, semver, leaderboardCache, leaderboardRankCache, apiServer, cookie)
+	apiServer := server.StartApiServer(logger, startupLogger, db, jsonpbMarshaler, jsonpbUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, storageIndex, storageFeed, sessionRegistry, sessionCache, statusRegistry, matchRegistry, matchmaker, tracker, router, streamManager, metrics, pipeline, runtime)
+	consoleServer := server.StartConsoleServer(logger, startupLogger, db, config, tracker, router, streamManager, metrics, sessionCache, consoleSessionCache, loginAttemptCache, statusRegistry, statusHandler, runtimeInfo, matchRegistry, matchmaker, configWarnings, semver, leaderboardCache, leaderboardRankCache, storageIndex, storageFeed, apiServer, cookie)
 
 	gaenabled := len(os.Getenv("NAKAMA_TELEMETRY")) < 1
 	console.UIFS.Nt = !gaenabled
@@ -230,7 +246,11 @@ func main() {
 	consoleServer.Stop()
 	matchmaker.Stop()
 	leaderboardScheduler.Stop()
+	leaderboardRankCache.Stop()
+	storageExpiry.Stop()
+	storageIndex.Stop()
 	tracker.Stop()
+	clusterTransport.Stop()
 	statusRegistry.Stop()
 	sessionCache.Stop()
 	sessionRegistry.Stop()
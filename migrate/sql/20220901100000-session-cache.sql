@@ -0,0 +1,51 @@
+/*
+ * Copyright 2022 The Nakama Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS user_session_token (
+    PRIMARY KEY (token_hash),
+
+    token_hash  BYTEA       NOT NULL,
+    user_id     UUID        NOT NULL,
+    refresh     BOOLEAN     NOT NULL DEFAULT FALSE,
+    expire_time TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS user_session_token_user_id_idx ON user_session_token (user_id);
+CREATE INDEX IF NOT EXISTS user_session_token_expire_time_idx ON user_session_token (expire_time);
+
+CREATE TABLE IF NOT EXISTS login_attempt (
+    PRIMARY KEY (id),
+
+    id           UUID         NOT NULL,
+    lockout_type SMALLINT     NOT NULL, -- Account(1), Ip(2)
+    key          VARCHAR(512) NOT NULL,
+    create_time  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS login_attempt_lockout_type_key_create_time_idx ON login_attempt (lockout_type, key, create_time);
+CREATE INDEX IF NOT EXISTS login_attempt_create_time_idx ON login_attempt (create_time);
+
+CREATE TABLE IF NOT EXISTS login_lockout (
+    PRIMARY KEY (lockout_type, key),
+
+    lockout_type SMALLINT     NOT NULL, -- Account(1), Ip(2)
+    key          VARCHAR(512) NOT NULL,
+    locked_until TIMESTAMPTZ  NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS login_lockout;
+DROP TABLE IF EXISTS login_attempt;
+DROP TABLE IF EXISTS user_session_token;
@@ -0,0 +1,26 @@
+/*
+ * Copyright 2022 The Nakama Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+-- +migrate Up
+-- Subscore sort orders are not set for existing leaderboards, which sort subscores in the same order as scores.
+ALTER TABLE leaderboard
+    ADD COLUMN IF NOT EXISTS subscore_sort_order SMALLINT CHECK (subscore_sort_order >= 0), -- asc(0), desc(1)
+    ADD COLUMN IF NOT EXISTS tie_break           SMALLINT NOT NULL DEFAULT 0 CHECK (tie_break >= 0); -- owner(0), time(1)
+
+-- +migrate Down
+ALTER TABLE leaderboard
+    DROP COLUMN IF EXISTS subscore_sort_order,
+    DROP COLUMN IF EXISTS tie_break;
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2022 The Nakama Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+-- +migrate Up
+ALTER TABLE leaderboard
+    ADD COLUMN IF NOT EXISTS bucket_size INT NOT NULL DEFAULT 0 CHECK (bucket_size >= 0); -- 0 for a single ranking.
+
+-- Records of bucketed tournaments are ranked only among records in the same bucket, numbered from 1.
+ALTER TABLE leaderboard_record
+    ADD COLUMN IF NOT EXISTS bucket INT NOT NULL DEFAULT 0 CHECK (bucket >= 0);
+
+CREATE TABLE IF NOT EXISTS leaderboard_bucket (
+    PRIMARY KEY (leaderboard_id, expiry_time, bucket),
+    FOREIGN KEY (leaderboard_id) REFERENCES leaderboard (id) ON DELETE CASCADE,
+
+    leaderboard_id VARCHAR(128) NOT NULL,
+    expiry_time    TIMESTAMPTZ  NOT NULL DEFAULT '1970-01-01 00:00:00 UTC',
+    bucket         INT          NOT NULL CHECK (bucket > 0),
+    -- Rating band of the players in the bucket, NULL for players joining without a rating.
+    band           BIGINT,
+    size           INT          NOT NULL DEFAULT 0 CHECK (size >= 0)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS leaderboard_bucket;
+
+ALTER TABLE leaderboard_record
+    DROP COLUMN IF EXISTS bucket;
+
+ALTER TABLE leaderboard
+    DROP COLUMN IF EXISTS bucket_size;
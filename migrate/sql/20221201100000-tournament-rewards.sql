@@ -0,0 +1,36 @@
+/*
+ * Copyright 2022 The Nakama Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+-- +migrate Up
+-- Rank ranges and the wallet changes and notifications paid out to them when each tournament period ends.
+ALTER TABLE leaderboard
+    ADD COLUMN IF NOT EXISTS rewards JSONB NOT NULL DEFAULT '[]';
+
+-- Tournament periods whose rewards have already been paid out.
+CREATE TABLE IF NOT EXISTS leaderboard_reward_payout (
+    PRIMARY KEY (leaderboard_id, expiry_time),
+    FOREIGN KEY (leaderboard_id) REFERENCES leaderboard (id) ON DELETE CASCADE,
+
+    leaderboard_id VARCHAR(128) NOT NULL,
+    expiry_time    TIMESTAMPTZ  NOT NULL DEFAULT '1970-01-01 00:00:00 UTC',
+    create_time    TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS leaderboard_reward_payout;
+
+ALTER TABLE leaderboard
+    DROP COLUMN IF EXISTS rewards;
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2022 The Nakama Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+-- +migrate Up
+-- Limits on scores submitted by clients, NULL if client scores are not validated.
+ALTER TABLE leaderboard
+    ADD COLUMN IF NOT EXISTS validation JSONB;
+
+-- Client score submissions in the current submission window of each owner.
+CREATE TABLE IF NOT EXISTS leaderboard_submission (
+    PRIMARY KEY (leaderboard_id, owner_id),
+    FOREIGN KEY (leaderboard_id) REFERENCES leaderboard (id) ON DELETE CASCADE,
+
+    leaderboard_id VARCHAR(128) NOT NULL,
+    owner_id       UUID         NOT NULL,
+    window_start   TIMESTAMPTZ  NOT NULL,
+    count          INT          NOT NULL DEFAULT 0 CHECK (count >= 0)
+);
+
+-- Client score submissions rejected by validation rules, for each reason.
+CREATE TABLE IF NOT EXISTS leaderboard_rejection (
+    PRIMARY KEY (leaderboard_id, reason),
+    FOREIGN KEY (leaderboard_id) REFERENCES leaderboard (id) ON DELETE CASCADE,
+
+    leaderboard_id VARCHAR(128) NOT NULL,
+    reason         VARCHAR(32)  NOT NULL,
+    count          BIGINT       NOT NULL DEFAULT 0 CHECK (count >= 0),
+    update_time    TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS leaderboard_rejection;
+DROP TABLE IF EXISTS leaderboard_submission;
+
+ALTER TABLE leaderboard
+    DROP COLUMN IF EXISTS validation;
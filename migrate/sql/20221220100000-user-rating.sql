@@ -0,0 +1,35 @@
+/*
+ * Copyright 2022 The Nakama Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+-- +migrate Up
+-- Glicko-2 skill ratings of users, in each rating queue.
+CREATE TABLE IF NOT EXISTS user_rating (
+    PRIMARY KEY (user_id, queue),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+
+    user_id     UUID             NOT NULL,
+    queue       VARCHAR(128)     NOT NULL,
+    rating      DOUBLE PRECISION NOT NULL,
+    deviation   DOUBLE PRECISION NOT NULL CHECK (deviation > 0),
+    volatility  DOUBLE PRECISION NOT NULL CHECK (volatility > 0),
+    games       INT              NOT NULL DEFAULT 0 CHECK (games >= 0),
+    create_time TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    update_time TIMESTAMPTZ      NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS user_rating;
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2022 The Nakama Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+-- +migrate Up
+-- Replies reference the message they reply to, and the message that started their thread.
+ALTER TABLE message
+    ADD COLUMN reply_to_id UUID,
+    ADD COLUMN thread_id   UUID,
+    ADD COLUMN reactions   JSONB NOT NULL DEFAULT '{}'; -- reaction counts by emoji.
+
+CREATE INDEX IF NOT EXISTS message_thread_id_create_time_id_idx ON message (thread_id, create_time, id);
+
+-- Reactions of users to channel messages, at most one of each emoji per user.
+CREATE TABLE IF NOT EXISTS message_reaction (
+    PRIMARY KEY (message_id, emoji, user_id),
+    FOREIGN KEY (message_id) REFERENCES message (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+
+    message_id  UUID        NOT NULL,
+    user_id     UUID        NOT NULL,
+    emoji       VARCHAR(64) NOT NULL,
+    create_time TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS message_reaction;
+
+DROP INDEX IF EXISTS message_thread_id_create_time_id_idx;
+
+ALTER TABLE IF EXISTS message
+    DROP COLUMN IF EXISTS reactions,
+    DROP COLUMN IF EXISTS thread_id,
+    DROP COLUMN IF EXISTS reply_to_id;
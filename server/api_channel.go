@@ -16,16 +16,218 @@ package server
 
 import (
 	"context"
-	"github.com/heroiclabs/nakama-common/runtime"
+	"fmt"
+	"strings"
 
 	"github.com/gofrs/uuid"
 	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama/v3/apigrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// Replies are channel messages, so they run the same before and after hooks as messages sent with a socket.
+var channelMessageSendNameID = strings.ToLower(fmt.Sprintf("%T", &rtapi.Envelope_ChannelMessageSend{}))
+
+func (s *ApiServer) AddChannelMessageReaction(ctx context.Context, in *apigrpc.ChannelMessageReactionRequest) (*apigrpc.ChannelMessageReactions, error) {
+	return s.channelMessageReact(ctx, in, true)
+}
+
+func (s *ApiServer) DeleteChannelMessageReaction(ctx context.Context, in *apigrpc.ChannelMessageReactionRequest) (*apigrpc.ChannelMessageReactions, error) {
+	return s.channelMessageReact(ctx, in, false)
+}
+
+func (s *ApiServer) channelMessageReact(ctx context.Context, in *apigrpc.ChannelMessageReactionRequest, add bool) (*apigrpc.ChannelMessageReactions, error) {
+	userID := ctx.Value(ctxUserIDKey{}).(uuid.UUID)
+
+	stream, err := s.channelThreadStream(ctx, userID, in.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	var reactions map[string]int64
+	if add {
+		reactions, err = ChannelMessageReactionAdd(ctx, s.logger, s.db, stream, in.MessageId, in.Emoji, userID)
+	} else {
+		reactions, err = ChannelMessageReactionRemove(ctx, s.logger, s.db, stream, in.MessageId, in.Emoji, userID)
+	}
+	switch err {
+	case nil:
+	case errInvalidMessageId, errInvalidReaction, errReactionsLimit:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errReactionMessageNotFound:
+		return nil, status.Error(codes.NotFound, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, "Error persisting reaction to channel history.")
+	}
+
+	return &apigrpc.ChannelMessageReactions{MessageId: in.MessageId, Reactions: reactions}, nil
+}
+
+func (s *ApiServer) ReplyChannelMessage(ctx context.Context, in *apigrpc.ReplyChannelMessageRequest) (*apigrpc.ThreadedChannelMessage, error) {
+	userID := ctx.Value(ctxUserIDKey{}).(uuid.UUID)
+	username := ctx.Value(ctxUsernameKey{}).(string)
+
+	// Before hook, there is no session for the hook to see.
+	send := &rtapi.Envelope{Message: &rtapi.Envelope_ChannelMessageSend{ChannelMessageSend: &rtapi.ChannelMessageSend{
+		ChannelId: in.ChannelId,
+		Content:   in.Content,
+	}}}
+	if fn := s.runtime.BeforeRt(channelMessageSendNameID); fn != nil {
+		beforeFn := func(clientIP, clientPort string) error {
+			result, err := fn(ctx, s.logger, userID.String(), username, ctx.Value(ctxVarsKey{}).(map[string]string), ctx.Value(ctxExpiryKey{}).(int64), "", clientIP, clientPort, "", send)
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			if result == nil || result.GetChannelMessageSend() == nil {
+				// If result is nil, requested resource is disabled.
+				s.logger.Warn("Intercepted a disabled resource.", zap.Any("resource", ctx.Value(ctxFullMethodKey{}).(string)), zap.String("uid", userID.String()))
+				return status.Error(codes.NotFound, "Requested resource was not found.")
+			}
+			send = result
+			return nil
+		}
+
+		// Execute the before function lambda wrapped in a trace for stats measurement.
+		err := traceApiBefore(ctx, s.logger, s.metrics, ctx.Value(ctxFullMethodKey{}).(string), beforeFn)
+		if err != nil {
+			return nil, err
+		}
+	}
+	channelID := send.GetChannelMessageSend().ChannelId
+	content := send.GetChannelMessageSend().Content
+
+	stream, err := s.channelThreadStream(ctx, userID, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	ack, thread, err := ChannelMessageReply(ctx, s.logger, s.db, s.router, stream, channelID, in.MessageId, content, userID.String(), username)
+	switch err {
+	case nil:
+	case errInvalidMessageId, errInvalidMessageContent:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errReplyMessageNotFound:
+		return nil, status.Error(codes.NotFound, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, "Error persisting message to channel history.")
+	}
+
+	out := &apigrpc.ThreadedChannelMessage{
+		Message: &api.ChannelMessage{
+			ChannelId:  ack.ChannelId,
+			MessageId:  ack.MessageId,
+			Code:       ack.Code,
+			SenderId:   userID.String(),
+			Username:   ack.Username,
+			Content:    content,
+			CreateTime: ack.CreateTime,
+			UpdateTime: ack.UpdateTime,
+			Persistent: ack.Persistent,
+			RoomName:   ack.RoomName,
+			GroupId:    ack.GroupId,
+			UserIdOne:  ack.UserIdOne,
+			UserIdTwo:  ack.UserIdTwo,
+		},
+		ReplyToId: thread.ReplyToID,
+		ThreadId:  thread.ThreadID,
+	}
+
+	// After hook.
+	if fn := s.runtime.AfterRt(channelMessageSendNameID); fn != nil {
+		afterFn := func(clientIP, clientPort string) error {
+			return fn(ctx, s.logger, userID.String(), username, ctx.Value(ctxVarsKey{}).(map[string]string), ctx.Value(ctxExpiryKey{}).(int64), "", clientIP, clientPort, "", &rtapi.Envelope{Message: &rtapi.Envelope_ChannelMessageAck{ChannelMessageAck: ack}}, send)
+		}
+
+		// Execute the after function lambda wrapped in a trace for stats measurement.
+		traceApiAfter(ctx, s.logger, s.metrics, ctx.Value(ctxFullMethodKey{}).(string), afterFn)
+	}
+
+	return out, nil
+}
+
+// Resolve the stream of a channel the user may react and reply in. Reactions and replies are stored with the messages
+// they refer to, so they work whether the user has joined the channel or not.
+func (s *ApiServer) channelThreadStream(ctx context.Context, userID uuid.UUID, channelID string) (PresenceStream, error) {
+	if channelID == "" {
+		return PresenceStream{}, status.Error(codes.InvalidArgument, "Invalid channel ID.")
+	}
+
+	streamConversionResult, err := ChannelIdToStream(channelID)
+	if err != nil {
+		return PresenceStream{}, status.Error(codes.InvalidArgument, "Invalid channel ID.")
+	}
+
+	switch err = channelThreadCheckUser(ctx, s.logger, s.db, streamConversionResult.Stream, userID); err {
+	case nil:
+	case runtime.ErrChannelIDInvalid:
+		return PresenceStream{}, status.Error(codes.InvalidArgument, "Invalid channel ID.")
+	case runtime.ErrChannelGroupNotFound:
+		return PresenceStream{}, status.Error(codes.InvalidArgument, "Group not found.")
+	default:
+		return PresenceStream{}, status.Error(codes.Internal, "Error checking channel permissions.")
+	}
+
+	return streamConversionResult.Stream, nil
+}
+
 func (s *ApiServer) ListChannelMessages(ctx context.Context, in *api.ListChannelMessagesRequest) (*api.ChannelMessageList, error) {
+	return s.listChannelMessages(ctx, in, uuid.Nil)
+}
+
+func (s *ApiServer) ListChannelMessagesWithOptions(ctx context.Context, in *apigrpc.ListChannelMessagesWithOptionsRequest) (*apigrpc.ThreadedChannelMessageList, error) {
+	threadID := uuid.Nil
+	if in.ThreadId != "" {
+		var err error
+		if threadID, err = uuid.FromString(in.ThreadId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid thread ID.")
+		}
+	}
+
+	// Hooks see a plain channel message listing.
+	messageList, err := s.listChannelMessages(ctx, &api.ListChannelMessagesRequest{
+		ChannelId: in.ChannelId,
+		Limit:     in.Limit,
+		Forward:   in.Forward,
+		Cursor:    in.Cursor,
+	}, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	messageIDs := make([]string, 0, len(messageList.Messages))
+	for _, message := range messageList.Messages {
+		messageIDs = append(messageIDs, message.MessageId)
+	}
+	threads, err := channelMessagesThreadRead(ctx, s.db, messageIDs)
+	if err != nil {
+		s.logger.Error("Error reading channel message threads.", zap.Error(err))
+		return nil, status.Error(codes.Internal, "Error listing messages from channel.")
+	}
+
+	out := &apigrpc.ThreadedChannelMessageList{
+		Messages:        make([]*apigrpc.ThreadedChannelMessage, 0, len(messageList.Messages)),
+		NextCursor:      messageList.NextCursor,
+		PrevCursor:      messageList.PrevCursor,
+		CacheableCursor: messageList.CacheableCursor,
+	}
+	for _, message := range messageList.Messages {
+		threaded, found := threads[message.MessageId]
+		if !found {
+			// Removed since it was listed.
+			continue
+		}
+		threaded.Message = message
+		out.Messages = append(out.Messages, threaded)
+	}
+
+	return out, nil
+}
+
+func (s *ApiServer) listChannelMessages(ctx context.Context, in *api.ListChannelMessagesRequest, threadID uuid.UUID) (*api.ChannelMessageList, error) {
 	userID := ctx.Value(ctxUserIDKey{}).(uuid.UUID)
 
 	// Before hook.
@@ -73,7 +275,7 @@ func (s *ApiServer) ListChannelMessages(ctx context.Context, in *api.ListChannel
 		return nil, status.Error(codes.InvalidArgument, "Invalid channel ID.")
 	}
 
-	messageList, err := ChannelMessagesList(ctx, s.logger, s.db, userID, streamConversionResult.Stream, in.ChannelId, limit, forward, in.Cursor)
+	messageList, err := ChannelMessagesList(ctx, s.logger, s.db, userID, streamConversionResult.Stream, in.ChannelId, threadID, limit, forward, in.Cursor)
 	if err == runtime.ErrChannelCursorInvalid {
 		return nil, status.Error(codes.InvalidArgument, "Cursor is invalid or expired.")
 	} else if err == runtime.ErrChannelGroupNotFound {
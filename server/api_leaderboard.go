@@ -18,11 +18,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"strconv"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama/v3/apigrpc"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -80,7 +85,75 @@ func (s *ApiServer) DeleteLeaderboardRecord(ctx context.Context, in *api.DeleteL
 	return &emptypb.Empty{}, nil
 }
 
+func (s *ApiServer) ListLeaderboardPeriods(ctx context.Context, in *apigrpc.ListLeaderboardPeriodsRequest) (*apigrpc.LeaderboardPeriodList, error) {
+	if in.GetLeaderboardId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Invalid leaderboard ID.")
+	}
+
+	limit := 10
+	if in.GetLimit() != nil {
+		if in.GetLimit().Value < 1 || in.GetLimit().Value > 100 {
+			return nil, status.Error(codes.InvalidArgument, "Invalid limit - limit must be between 1 and 100.")
+		}
+		limit = int(in.GetLimit().Value)
+	}
+
+	topCount := 10
+	if in.GetTopCount() != nil {
+		if in.GetTopCount().Value < 0 || in.GetTopCount().Value > 100 {
+			return nil, status.Error(codes.InvalidArgument, "Invalid top count - top count must be between 0 and 100.")
+		}
+		topCount = int(in.GetTopCount().Value)
+	}
+
+	periods, cursor, err := LeaderboardPeriodsList(ctx, s.logger, s.db, s.leaderboardCache, s.leaderboardRankCache, in.GetLeaderboardId(), limit, topCount, in.GetCursor())
+	if err == ErrLeaderboardNotFound {
+		return nil, status.Error(codes.NotFound, "Leaderboard not found.")
+	} else if err == ErrLeaderboardNoResetSchedule {
+		return nil, status.Error(codes.InvalidArgument, "Leaderboard does not have a reset schedule.")
+	} else if err == ErrLeaderboardInvalidCursor {
+		return nil, status.Error(codes.InvalidArgument, "Cursor is invalid or expired.")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, "Error listing leaderboard periods.")
+	}
+
+	periodList := &apigrpc.LeaderboardPeriodList{Periods: make([]*apigrpc.LeaderboardPeriod, 0, len(periods)), NextCursor: cursor}
+	for _, period := range periods {
+		periodList.Periods = append(periodList.Periods, &apigrpc.LeaderboardPeriod{Expiry: period.ExpiryTime, Records: period.Records})
+	}
+
+	return periodList, nil
+}
+
 func (s *ApiServer) ListLeaderboardRecords(ctx context.Context, in *api.ListLeaderboardRecordsRequest) (*api.LeaderboardRecordList, error) {
+	return s.listLeaderboardRecords(ctx, in, nil, 0)
+}
+
+func (s *ApiServer) ListLeaderboardRecordsWithOptions(ctx context.Context, in *apigrpc.ListLeaderboardRecordsWithOptionsRequest) (*api.LeaderboardRecordList, error) {
+	// Records may be ranked among the caller's friends or a group's members, rather than among all owners.
+	scope, err := NewLeaderboardScope(ctx.Value(ctxUserIDKey{}).(uuid.UUID), in.GetScope(), in.GetGroupId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid leaderboard scope supplied. Group scopes must have a valid group ID.")
+	}
+
+	// Past periods may be selected by how many periods ago they ended, rather than by their expiry time.
+	periodsAgo := int(in.GetPeriodsAgo())
+	if periodsAgo < 0 || periodsAgo > LeaderboardPeriodMaxAgo {
+		return nil, status.Error(codes.InvalidArgument, "Invalid leaderboard period supplied. It must be the number of periods before the current one, up to 1000.")
+	}
+
+	// Hooks see the listing as a plain request.
+	request := &api.ListLeaderboardRecordsRequest{
+		LeaderboardId: in.GetLeaderboardId(),
+		OwnerIds:      in.GetOwnerIds(),
+		Limit:         in.GetLimit(),
+		Cursor:        in.GetCursor(),
+		Expiry:        in.GetExpiry(),
+	}
+	return s.listLeaderboardRecords(ctx, request, scope, periodsAgo)
+}
+
+func (s *ApiServer) listLeaderboardRecords(ctx context.Context, in *api.ListLeaderboardRecordsRequest, scope *LeaderboardScope, periodsAgo int) (*api.LeaderboardRecordList, error) {
 	// Before hook.
 	if fn := s.runtime.BeforeListLeaderboardRecords(); fn != nil {
 		beforeFn := func(clientIP, clientPort string) error {
@@ -126,12 +199,35 @@ func (s *ApiServer) ListLeaderboardRecords(ctx context.Context, in *api.ListLead
 		}
 	}
 
+	if scope != nil && len(in.GetOwnerIds()) != 0 {
+		return nil, status.Error(codes.InvalidArgument, "Owner IDs cannot be combined with a leaderboard scope.")
+	}
+
 	overrideExpiry := int64(0)
 	if in.Expiry != nil {
 		overrideExpiry = in.Expiry.Value
 	}
 
-	records, err := LeaderboardRecordsList(ctx, s.logger, s.db, s.leaderboardCache, s.leaderboardRankCache, in.LeaderboardId, limit, in.Cursor, in.OwnerIds, overrideExpiry)
+	if periodsAgo != 0 {
+		if in.Expiry != nil {
+			return nil, status.Error(codes.InvalidArgument, "Expiry cannot be combined with a leaderboard period.")
+		}
+		leaderboard := s.leaderboardCache.Get(in.LeaderboardId)
+		if leaderboard == nil {
+			return nil, status.Error(codes.NotFound, "Leaderboard not found.")
+		}
+		var err error
+		overrideExpiry, err = LeaderboardPeriodExpiry(leaderboard, periodsAgo, time.Now())
+		if err == ErrLeaderboardNoResetSchedule {
+			return nil, status.Error(codes.InvalidArgument, "Leaderboard does not have a reset schedule.")
+		} else if err == ErrLeaderboardPeriodNotFound {
+			return nil, status.Error(codes.NotFound, "Leaderboard period not found.")
+		} else if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid leaderboard period supplied. It must be the number of periods before the current one, up to 1000.")
+		}
+	}
+
+	records, err := LeaderboardRecordsList(ctx, s.logger, s.db, s.leaderboardCache, s.leaderboardRankCache, in.LeaderboardId, limit, in.Cursor, in.OwnerIds, scope, overrideExpiry)
 	if err == ErrLeaderboardNotFound {
 		return nil, status.Error(codes.NotFound, "Leaderboard not found.")
 	} else if err == ErrLeaderboardInvalidCursor {
@@ -190,11 +286,13 @@ func (s *ApiServer) WriteLeaderboardRecord(ctx context.Context, in *api.WriteLea
 		}
 	}
 
-	record, err := LeaderboardRecordWrite(ctx, s.logger, s.db, s.leaderboardCache, s.leaderboardRankCache, userID, in.LeaderboardId, userID.String(), username, in.Record.Score, in.Record.Subscore, in.Record.Metadata, in.Record.Operator)
+	record, err := LeaderboardRecordWrite(ctx, s.logger, s.db, s.leaderboardCache, s.leaderboardRankCache, s.metrics, userID, in.LeaderboardId, userID.String(), username, in.Record.Score, in.Record.Subscore, in.Record.Metadata, in.Record.Operator)
 	if err == ErrLeaderboardNotFound {
 		return nil, status.Error(codes.NotFound, "Leaderboard not found.")
 	} else if err == ErrLeaderboardAuthoritative {
 		return nil, status.Error(codes.PermissionDenied, "Leaderboard only allows authoritative score submissions.")
+	} else if err == ErrLeaderboardRecordRejected {
+		return nil, status.Error(codes.InvalidArgument, "Score rejected by leaderboard validation rules.")
 	} else if err != nil {
 		return nil, status.Error(codes.Internal, "Error writing score to leaderboard.")
 	}
@@ -268,6 +366,7 @@ func (s *ApiServer) ListLeaderboardRecordsAroundOwner(ctx context.Context, in *a
 	} else if err != nil {
 		return nil, status.Error(codes.Internal, "Error querying records from leaderboard.")
 	}
+	leaderboardRankCountToContext(ctx, s.leaderboardRankCache, in.GetLeaderboardId(), records.OwnerRecords, records.Records)
 
 	// After hook.
 	if fn := s.runtime.AfterListLeaderboardRecordsAroundOwner(); fn != nil {
@@ -281,3 +380,26 @@ func (s *ApiServer) ListLeaderboardRecordsAroundOwner(ctx context.Context, in *a
 
 	return records, nil
 }
+
+// Send the number of records a leaderboard's ranks are out of in the response metadata, so clients can turn ranks
+// into percentiles, along with whether the ranks are approximate.
+func leaderboardRankCountToContext(ctx context.Context, rankCache LeaderboardRankCache, leaderboardId string, ownerRecords, records []*api.LeaderboardRecord) {
+	var record *api.LeaderboardRecord
+	if len(ownerRecords) != 0 {
+		record = ownerRecords[0]
+	} else if len(records) != 0 {
+		record = records[0]
+	} else {
+		return
+	}
+
+	var expiryUnix int64
+	if record.ExpiryTime != nil {
+		expiryUnix = record.ExpiryTime.Seconds
+	}
+	count, approximate := rankCache.Count(leaderboardId, expiryUnix)
+	if count == 0 {
+		return
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(LeaderboardRankCountMetadataKey, strconv.FormatInt(count, 10), LeaderboardRankApproximateMetadataKey, strconv.FormatBool(approximate)))
+}
@@ -15,12 +15,12 @@
 package server
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama/v3/apigrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -153,6 +153,56 @@ func (s *ApiServer) ReadStorageObjects(ctx context.Context, in *api.ReadStorageO
 }
 
 func (s *ApiServer) WriteStorageObjects(ctx context.Context, in *api.WriteStorageObjectsRequest) (*api.StorageObjectAcks, error) {
+	return s.writeStorageObjects(ctx, in, nil)
+}
+
+func (s *ApiServer) WriteStorageObjectsWithOptions(ctx context.Context, in *apigrpc.WriteStorageObjectsWithOptionsRequest) (*api.StorageObjectAcks, error) {
+	// Hooks see the objects as a plain write request, options are matched back to objects by their position.
+	request := &api.WriteStorageObjectsRequest{Objects: make([]*api.WriteStorageObject, 0, len(in.GetObjects()))}
+	options := make([]storageWriteOptions, 0, len(in.GetObjects()))
+	now := time.Now()
+	for _, object := range in.GetObjects() {
+		var expireTime int64
+		if object.GetExpireTime() != nil {
+			if !object.GetExpireTime().IsValid() || !object.GetExpireTime().AsTime().After(now) {
+				return nil, status.Error(codes.InvalidArgument, "Invalid expire time supplied. It must be in the future.")
+			}
+			expireTime = object.GetExpireTime().GetSeconds()
+		}
+
+		var patch StoragePatch
+		switch object.GetPatch() {
+		case apigrpc.WriteStorageObjectWithOptions_NONE:
+			patch = StoragePatchNone
+		case apigrpc.WriteStorageObjectWithOptions_MERGE:
+			patch = StoragePatchMerge
+		case apigrpc.WriteStorageObjectWithOptions_JSON:
+			patch = StoragePatchJson
+		default:
+			return nil, status.Error(codes.InvalidArgument, "Invalid storage patch type supplied. It must be either NONE, MERGE or JSON.")
+		}
+
+		request.Objects = append(request.Objects, &api.WriteStorageObject{
+			Collection:      object.GetCollection(),
+			Key:             object.GetKey(),
+			Value:           object.GetValue(),
+			Version:         object.GetVersion(),
+			PermissionRead:  object.GetPermissionRead(),
+			PermissionWrite: object.GetPermissionWrite(),
+		})
+		options = append(options, storageWriteOptions{expireTime: expireTime, patch: patch})
+	}
+
+	return s.writeStorageObjects(ctx, request, options)
+}
+
+// Options set by clients on individual objects in a storage write.
+type storageWriteOptions struct {
+	expireTime int64
+	patch      StoragePatch
+}
+
+func (s *ApiServer) writeStorageObjects(ctx context.Context, in *api.WriteStorageObjectsRequest, options []storageWriteOptions) (*api.StorageObjectAcks, error) {
 	userID := ctx.Value(ctxUserIDKey{}).(uuid.UUID).String()
 
 	// Before hook.
@@ -182,7 +232,19 @@ func (s *ApiServer) WriteStorageObjects(ctx context.Context, in *api.WriteStorag
 		return &api.StorageObjectAcks{}, nil
 	}
 
-	for _, object := range in.GetObjects() {
+	// Options apply to objects by position, so hooks may rewrite objects but not add or remove them.
+	if options != nil && len(options) != len(in.GetObjects()) {
+		s.logger.Error("Before hook changed the number of objects in a storage write with options.", zap.Int("options", len(options)), zap.Int("objects", len(in.GetObjects())))
+		return nil, status.Error(codes.Internal, "Error writing storage objects.")
+	}
+	option := func(i int) storageWriteOptions {
+		if options == nil {
+			return storageWriteOptions{}
+		}
+		return options[i]
+	}
+
+	for i, object := range in.GetObjects() {
 		if object.GetCollection() == "" || object.GetKey() == "" || object.GetValue() == "" {
 			return nil, status.Error(codes.InvalidArgument, "Invalid collection or key value supplied. They must be set.")
 		}
@@ -201,20 +263,27 @@ func (s *ApiServer) WriteStorageObjects(ctx context.Context, in *api.WriteStorag
 			}
 		}
 
-		if maybeJSON := []byte(object.GetValue()); !json.Valid(maybeJSON) || bytes.TrimSpace(maybeJSON)[0] != byteBracket {
+		// Values may be patches applied to the stored values, rather than replacements.
+		patch := option(i).patch
+		if !StoragePatchValueValid(patch, object.GetValue()) {
+			if patch == StoragePatchJson {
+				return nil, status.Error(codes.InvalidArgument, "Value must be a JSON array of patch operations.")
+			}
 			return nil, status.Error(codes.InvalidArgument, "Value must be a JSON object.")
 		}
 	}
 
 	ops := make(StorageOpWrites, 0, len(in.GetObjects()))
-	for _, object := range in.GetObjects() {
+	for i, object := range in.GetObjects() {
 		ops = append(ops, &StorageOpWrite{
-			OwnerID: userID,
-			Object:  object,
+			OwnerID:    userID,
+			Object:     object,
+			ExpireTime: option(i).expireTime,
+			Patch:      option(i).patch,
 		})
 	}
 
-	acks, code, err := StorageWriteObjects(ctx, s.logger, s.db, s.metrics, false, ops)
+	acks, code, err := StorageWriteObjects(ctx, s.logger, s.db, s.metrics, s.storageIndex, s.storageFeed, false, ops)
 	if err != nil {
 		if code == codes.Internal {
 			return nil, status.Error(codes.Internal, "Error writing storage objects.")
@@ -279,7 +348,7 @@ func (s *ApiServer) DeleteStorageObjects(ctx context.Context, in *api.DeleteStor
 		})
 	}
 
-	if code, err := StorageDeleteObjects(ctx, s.logger, s.db, false, ops); err != nil {
+	if code, err := StorageDeleteObjects(ctx, s.logger, s.db, s.storageIndex, s.storageFeed, false, ops); err != nil {
 		if code == codes.Internal {
 			return nil, status.Error(codes.Internal, "Error deleting storage objects.")
 		}
@@ -298,3 +367,30 @@ func (s *ApiServer) DeleteStorageObjects(ctx context.Context, in *api.DeleteStor
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *ApiServer) ListStorageIndex(ctx context.Context, in *apigrpc.ListStorageIndexRequest) (*api.StorageObjects, error) {
+	userID := ctx.Value(ctxUserIDKey{}).(uuid.UUID)
+
+	if in.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Invalid storage index name - it must be set.")
+	}
+
+	limit := 10
+	if in.GetLimit() != nil {
+		if in.GetLimit().Value < 1 || in.GetLimit().Value > 100 {
+			return nil, status.Error(codes.InvalidArgument, "Invalid limit - limit must be between 1 and 100.")
+		}
+		limit = int(in.GetLimit().Value)
+	}
+
+	// Results are filtered by the caller's read permissions.
+	objects, err := s.storageIndex.List(ctx, userID, in.GetName(), in.GetQuery(), limit)
+	if err != nil {
+		if err == ErrStorageIndexNotFound {
+			return nil, status.Error(codes.NotFound, "Storage index not found.")
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return objects, nil
+}
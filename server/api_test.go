@@ -47,8 +47,10 @@ var (
 	protojsonUnmarshaler = &protojson.UnmarshalOptions{
 		DiscardUnknown: false,
 	}
-	metrics = NewLocalMetrics(logger, logger, nil, cfg)
-	_       = CheckConfig(logger, cfg)
+	metrics     = NewLocalMetrics(logger, logger, nil, cfg)
+	storageIdx  = NewLocalStorageIndex(logger, nil, NewLocalClusterTransport(cfg.GetName()))
+	storageFeed = NewLocalStorageFeed(logger, &testTracker{}, &DummyMessageRouter{}, protojsonMarshaler)
+	_           = CheckConfig(logger, cfg)
 )
 
 type DummyMessageRouter struct{}
@@ -173,7 +175,7 @@ func NewAPIServer(t *testing.T, runtime *Runtime) (*ApiServer, *Pipeline) {
 	router := &DummyMessageRouter{}
 	tracker := &LocalTracker{}
 	pipeline := NewPipeline(logger, cfg, db, protojsonMarshaler, protojsonUnmarshaler, nil, nil, nil, nil, nil, tracker, router, runtime)
-	apiServer := StartApiServer(logger, logger, db, protojsonMarshaler, protojsonUnmarshaler, cfg, nil, nil, nil, nil, nil, nil, nil, nil, tracker, router, nil, metrics, pipeline, runtime)
+	apiServer := StartApiServer(logger, logger, db, protojsonMarshaler, protojsonUnmarshaler, cfg, nil, nil, nil, nil, storageIdx, storageFeed, nil, nil, nil, nil, nil, tracker, router, nil, metrics, pipeline, runtime)
 	return apiServer, pipeline
 }
 
@@ -25,6 +25,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama/v3/apigrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -61,7 +62,7 @@ func (s *ApiServer) JoinTournament(ctx context.Context, in *api.JoinTournamentRe
 
 	tournamentID := in.GetTournamentId()
 
-	if err := TournamentJoin(ctx, s.logger, s.db, s.leaderboardCache, userID.String(), username, tournamentID); err != nil {
+	if err := TournamentJoin(ctx, s.logger, s.db, s.leaderboardCache, s.leaderboardScheduler, userID.String(), username, tournamentID, nil, s.config.GetLeaderboard().BucketRatingRange); err != nil {
 		if err == runtime.ErrTournamentNotFound {
 			return nil, status.Error(codes.NotFound, "Tournament not found.")
 		} else if err == runtime.ErrTournamentMaxSizeReached {
@@ -86,6 +87,28 @@ func (s *ApiServer) JoinTournament(ctx context.Context, in *api.JoinTournamentRe
 }
 
 func (s *ApiServer) ListTournamentRecords(ctx context.Context, in *api.ListTournamentRecordsRequest) (*api.TournamentRecordList, error) {
+	return s.listTournamentRecords(ctx, in, nil)
+}
+
+func (s *ApiServer) ListTournamentRecordsWithOptions(ctx context.Context, in *apigrpc.ListTournamentRecordsWithOptionsRequest) (*api.TournamentRecordList, error) {
+	// Records may be ranked among the caller's friends or a group's members, rather than among all owners.
+	scope, err := NewLeaderboardScope(ctx.Value(ctxUserIDKey{}).(uuid.UUID), in.GetScope(), in.GetGroupId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid leaderboard scope supplied. Group scopes must have a valid group ID.")
+	}
+
+	// Hooks see the listing as a plain request.
+	request := &api.ListTournamentRecordsRequest{
+		TournamentId: in.GetTournamentId(),
+		OwnerIds:     in.GetOwnerIds(),
+		Limit:        in.GetLimit(),
+		Cursor:       in.GetCursor(),
+		Expiry:       in.GetExpiry(),
+	}
+	return s.listTournamentRecords(ctx, request, scope)
+}
+
+func (s *ApiServer) listTournamentRecords(ctx context.Context, in *api.ListTournamentRecordsRequest, scope *LeaderboardScope) (*api.TournamentRecordList, error) {
 	// Before hook.
 	if fn := s.runtime.BeforeListTournamentRecords(); fn != nil {
 		beforeFn := func(clientIP, clientPort string) error {
@@ -121,6 +144,16 @@ func (s *ApiServer) ListTournamentRecords(ctx context.Context, in *api.ListTourn
 		}
 	}
 
+	if scope != nil && len(in.GetOwnerIds()) != 0 {
+		return nil, status.Error(codes.InvalidArgument, "Owner IDs cannot be combined with a leaderboard scope.")
+	}
+	if userID := ctx.Value(ctxUserIDKey{}).(uuid.UUID); scope == nil && len(in.GetOwnerIds()) == 0 && userID != uuid.Nil {
+		// Bucketed tournaments rank callers among the owners in their bucket.
+		if tournament := s.leaderboardCache.Get(in.GetTournamentId()); tournament != nil && tournament.IsBucketed() {
+			scope = &LeaderboardScope{BucketOf: userID}
+		}
+	}
+
 	var limit *wrapperspb.Int32Value
 	if in.GetLimit() != nil {
 		if in.GetLimit().Value < 1 || in.GetLimit().Value > 100 {
@@ -136,7 +169,7 @@ func (s *ApiServer) ListTournamentRecords(ctx context.Context, in *api.ListTourn
 		overrideExpiry = in.Expiry.Value
 	}
 
-	recordList, err := TournamentRecordsList(ctx, s.logger, s.db, s.leaderboardCache, s.leaderboardRankCache, in.GetTournamentId(), in.OwnerIds, limit, in.Cursor, overrideExpiry)
+	recordList, err := TournamentRecordsList(ctx, s.logger, s.db, s.leaderboardCache, s.leaderboardRankCache, in.GetTournamentId(), in.OwnerIds, scope, limit, in.Cursor, overrideExpiry)
 	if err == runtime.ErrTournamentNotFound {
 		return nil, status.Error(codes.NotFound, "Tournament not found.")
 	} else if err == runtime.ErrTournamentOutsideDuration {
@@ -299,7 +332,7 @@ func (s *ApiServer) WriteTournamentRecord(ctx context.Context, in *api.WriteTour
 		return nil, status.Error(codes.NotFound, "Tournament not found or has ended.")
 	}
 
-	record, err := TournamentRecordWrite(ctx, s.logger, s.db, s.leaderboardCache, s.leaderboardRankCache, userID, in.GetTournamentId(), userID, username, in.GetRecord().GetScore(), in.GetRecord().GetSubscore(), in.GetRecord().GetMetadata(), in.GetRecord().GetOperator())
+	record, err := TournamentRecordWrite(ctx, s.logger, s.db, s.leaderboardCache, s.leaderboardRankCache, s.metrics, userID, in.GetTournamentId(), userID, username, in.GetRecord().GetScore(), in.GetRecord().GetSubscore(), in.GetRecord().GetMetadata(), in.GetRecord().GetOperator())
 	if err != nil {
 		if err == runtime.ErrTournamentMaxSizeReached {
 			return nil, status.Error(codes.FailedPrecondition, "Tournament has reached max size.")
@@ -311,6 +344,8 @@ func (s *ApiServer) WriteTournamentRecord(ctx context.Context, in *api.WriteTour
 			return nil, status.Error(codes.FailedPrecondition, "Must join tournament before attempting to write value.")
 		} else if err == runtime.ErrTournamentOutsideDuration {
 			return nil, status.Error(codes.FailedPrecondition, "Tournament is not active and cannot accept new scores.")
+		} else if err == ErrLeaderboardRecordRejected {
+			return nil, status.Error(codes.InvalidArgument, "Score rejected by tournament validation rules.")
 		} else {
 			return nil, status.Error(codes.Internal, "Error writing score to tournament.")
 		}
@@ -385,6 +420,7 @@ func (s *ApiServer) ListTournamentRecordsAroundOwner(ctx context.Context, in *ap
 	} else if err != nil {
 		return nil, status.Error(codes.Internal, "Error querying records from leaderboard.")
 	}
+	leaderboardRankCountToContext(ctx, s.leaderboardRankCache, in.GetTournamentId(), records.OwnerRecords, records.Records)
 
 	// After hook.
 	if fn := s.runtime.AfterListTournamentRecordsAroundOwner(); fn != nil {
@@ -0,0 +1,248 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama/v3/apigrpc"
+	"go.uber.org/zap"
+)
+
+const (
+	channelMessageReactionsMax  = 50
+	channelMessageEmojiMaxBytes = 64
+)
+
+var (
+	errInvalidReaction         = errors.New("Reaction must be an emoji or short code of at most 64 bytes")
+	errReactionsLimit          = errors.New("Message has reached the maximum number of different reactions")
+	errReactionMessageNotFound = errors.New("Could not find message to react to in channel history")
+	errReplyMessageNotFound    = errors.New("Could not find message to reply to in channel history")
+)
+
+// Thread references of a reply.
+type channelMessageThread struct {
+	ReplyToID string
+	ThreadID  string
+}
+
+// Check a user may react and reply to the messages of a channel. Group channels are open to members only, and direct
+// message channels to the two users in them.
+func channelThreadCheckUser(ctx context.Context, logger *zap.Logger, db *sql.DB, channelStream PresenceStream, userID uuid.UUID) error {
+	switch channelStream.Mode {
+	case StreamModeGroup:
+		allowed, err := groupCheckUserPermission(ctx, logger, db, channelStream.Subject, userID, 2)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return runtime.ErrChannelGroupNotFound
+		}
+	case StreamModeDM:
+		if userID != channelStream.Subject && userID != channelStream.Subcontext {
+			return runtime.ErrChannelIDInvalid
+		}
+	}
+	return nil
+}
+
+// ChannelMessageReply sends a message in reply to a persisted message of the same channel. The reply joins the thread
+// of the message it replies to, or starts a thread with it.
+func ChannelMessageReply(ctx context.Context, logger *zap.Logger, db *sql.DB, router MessageRouter, channelStream PresenceStream, channelId, replyToId, content, senderId, senderUsername string) (*rtapi.ChannelMessageAck, *channelMessageThread, error) {
+	if _, err := uuid.FromString(replyToId); err != nil {
+		return nil, nil, errInvalidMessageId
+	}
+
+	var dbThreadID uuid.NullUUID
+	query := "SELECT thread_id FROM message WHERE id = $1 AND stream_mode = $2 AND stream_subject = $3::UUID AND stream_descriptor = $4::UUID AND stream_label = $5"
+	if err := db.QueryRowContext(ctx, query, replyToId, channelStream.Mode, channelStream.Subject, channelStream.Subcontext, channelStream.Label).Scan(&dbThreadID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, errReplyMessageNotFound
+		}
+		logger.Error("Error reading channel message replied to", zap.Error(err))
+		return nil, nil, errMessagePersist
+	}
+
+	thread := &channelMessageThread{ReplyToID: replyToId, ThreadID: replyToId}
+	if dbThreadID.Valid {
+		thread.ThreadID = dbThreadID.UUID.String()
+	}
+
+	ack, err := channelMessageSend(ctx, logger, db, router, channelStream, channelId, content, senderId, senderUsername, true, thread)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ack, thread, nil
+}
+
+// ChannelMessageReactionAdd adds a user's reaction to a persisted channel message, and returns the reaction counts of
+// the message. Adding a reaction the user already has leaves the counts unchanged.
+func ChannelMessageReactionAdd(ctx context.Context, logger *zap.Logger, db *sql.DB, channelStream PresenceStream, messageId, emoji string, userID uuid.UUID) (map[string]int64, error) {
+	return channelMessageReact(ctx, logger, db, channelStream, messageId, emoji, userID, true)
+}
+
+// ChannelMessageReactionRemove removes a user's reaction from a persisted channel message, and returns the reaction
+// counts of the message. Removing a reaction the user does not have leaves the counts unchanged.
+func ChannelMessageReactionRemove(ctx context.Context, logger *zap.Logger, db *sql.DB, channelStream PresenceStream, messageId, emoji string, userID uuid.UUID) (map[string]int64, error) {
+	return channelMessageReact(ctx, logger, db, channelStream, messageId, emoji, userID, false)
+}
+
+func channelMessageReact(ctx context.Context, logger *zap.Logger, db *sql.DB, channelStream PresenceStream, messageId, emoji string, userID uuid.UUID, add bool) (map[string]int64, error) {
+	if _, err := uuid.FromString(messageId); err != nil {
+		return nil, errInvalidMessageId
+	}
+	if !channelMessageReactionValid(emoji) {
+		return nil, errInvalidReaction
+	}
+
+	var reactions map[string]int64
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("Could not begin database transaction.", zap.Error(err))
+		return nil, errMessagePersist
+	}
+	if err = ExecuteInTx(ctx, tx, func() error {
+		// Lock the message, so concurrent reactions do not overwrite each other's counts.
+		var dbReactions string
+		query := "SELECT reactions FROM message WHERE id = $1 AND stream_mode = $2 AND stream_subject = $3::UUID AND stream_descriptor = $4::UUID AND stream_label = $5 FOR UPDATE"
+		if err := tx.QueryRowContext(ctx, query, messageId, channelStream.Mode, channelStream.Subject, channelStream.Subcontext, channelStream.Label).Scan(&dbReactions); err != nil {
+			return err
+		}
+		reactions = make(map[string]int64)
+		if err := json.Unmarshal([]byte(dbReactions), &reactions); err != nil {
+			return err
+		}
+
+		var result sql.Result
+		if add {
+			if _, found := reactions[emoji]; !found && len(reactions) >= channelMessageReactionsMax {
+				return errReactionsLimit
+			}
+			result, err = tx.ExecContext(ctx, "INSERT INTO message_reaction (message_id, user_id, emoji) VALUES ($1, $2, $3) ON CONFLICT (message_id, emoji, user_id) DO NOTHING", messageId, userID, emoji)
+		} else {
+			result, err = tx.ExecContext(ctx, "DELETE FROM message_reaction WHERE message_id = $1 AND user_id = $2 AND emoji = $3", messageId, userID, emoji)
+		}
+		if err != nil {
+			return err
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+			// The user already had this reaction, or did not have it to remove.
+			return nil
+		}
+
+		channelMessageReactionsUpdate(reactions, emoji, add)
+		data, err := json.Marshal(reactions)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.ExecContext(ctx, "UPDATE message SET reactions = $2 WHERE id = $1", messageId, string(data)); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, errReactionMessageNotFound
+		case errReactionsLimit:
+			return nil, err
+		}
+		logger.Error("Error persisting channel message reaction", zap.Error(err))
+		return nil, errMessagePersist
+	}
+
+	return reactions, nil
+}
+
+// A reaction is a single emoji, possibly a sequence of code points, or a short code such as ":wave:". It must not be
+// empty, too long, or contain whitespace or control characters.
+func channelMessageReactionValid(emoji string) bool {
+	if emoji == "" || len(emoji) > channelMessageEmojiMaxBytes || !utf8.ValidString(emoji) {
+		return false
+	}
+	for _, r := range emoji {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply a reaction change to the reaction counts of a message, dropping emoji nobody reacts with anymore.
+func channelMessageReactionsUpdate(reactions map[string]int64, emoji string, add bool) {
+	if add {
+		reactions[emoji]++
+		return
+	}
+	if reactions[emoji] <= 1 {
+		delete(reactions, emoji)
+		return
+	}
+	reactions[emoji]--
+}
+
+// Read the thread references and reaction counts of listed channel messages, by message ID.
+func channelMessagesThreadRead(ctx context.Context, db *sql.DB, messageIDs []string) (map[string]*apigrpc.ThreadedChannelMessage, error) {
+	messages := make(map[string]*apigrpc.ThreadedChannelMessage, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return messages, nil
+	}
+
+	statements := make([]string, 0, len(messageIDs))
+	params := make([]interface{}, 0, len(messageIDs))
+	for _, messageID := range messageIDs {
+		params = append(params, messageID)
+		statements = append(statements, "$"+strconv.Itoa(len(params))+"::UUID")
+	}
+	query := "SELECT id, reply_to_id, thread_id, reactions FROM message WHERE id IN (" + strings.Join(statements, ", ") + ")"
+	rows, err := db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var dbID uuid.UUID
+		var dbReplyToID, dbThreadID uuid.NullUUID
+		var dbReactions string
+		if err := rows.Scan(&dbID, &dbReplyToID, &dbThreadID, &dbReactions); err != nil {
+			return nil, err
+		}
+		message := &apigrpc.ThreadedChannelMessage{Reactions: make(map[string]int64)}
+		if dbReplyToID.Valid {
+			message.ReplyToId = dbReplyToID.UUID.String()
+		}
+		if dbThreadID.Valid {
+			message.ThreadId = dbThreadID.UUID.String()
+		}
+		if err := json.Unmarshal([]byte(dbReactions), &message.Reactions); err != nil {
+			return nil, err
+		}
+		messages[dbID.String()] = message
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
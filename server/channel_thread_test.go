@@ -0,0 +1,158 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelMessageReactionValid(t *testing.T) {
+	for _, emoji := range []string{"👍", "❤️", "👩‍👩‍👧", ":wave:", strings.Repeat("a", channelMessageEmojiMaxBytes)} {
+		assert.True(t, channelMessageReactionValid(emoji), emoji)
+	}
+	for _, emoji := range []string{"", " ", "👍 👍", "a\n", "\x00", "\xff", strings.Repeat("a", channelMessageEmojiMaxBytes+1)} {
+		assert.False(t, channelMessageReactionValid(emoji), emoji)
+	}
+}
+
+func TestChannelMessageReactionsUpdate(t *testing.T) {
+	reactions := map[string]int64{}
+	channelMessageReactionsUpdate(reactions, "👍", true)
+	channelMessageReactionsUpdate(reactions, "👍", true)
+	channelMessageReactionsUpdate(reactions, ":wave:", true)
+	assert.Equal(t, map[string]int64{"👍": 2, ":wave:": 1}, reactions)
+
+	// Emoji nobody reacts with anymore are dropped.
+	channelMessageReactionsUpdate(reactions, "👍", false)
+	channelMessageReactionsUpdate(reactions, ":wave:", false)
+	assert.Equal(t, map[string]int64{"👍": 1}, reactions)
+
+	// Removing a reaction that is not counted leaves the counts unchanged.
+	channelMessageReactionsUpdate(reactions, "❤️", false)
+	assert.Equal(t, map[string]int64{"👍": 1}, reactions)
+}
+
+func TestChannelThreadCheckUser(t *testing.T) {
+	userOne := uuid.Must(uuid.NewV4())
+	userTwo := uuid.Must(uuid.NewV4())
+	other := uuid.Must(uuid.NewV4())
+
+	// Room channels are open to every user, direct message channels only to the two users in them.
+	room := PresenceStream{Mode: StreamModeChannel, Label: "room"}
+	assert.NoError(t, channelThreadCheckUser(context.Background(), logger, nil, room, other))
+	direct := PresenceStream{Mode: StreamModeDM, Subject: userOne, Subcontext: userTwo}
+	assert.NoError(t, channelThreadCheckUser(context.Background(), logger, nil, direct, userOne))
+	assert.NoError(t, channelThreadCheckUser(context.Background(), logger, nil, direct, userTwo))
+	assert.Equal(t, runtime.ErrChannelIDInvalid, channelThreadCheckUser(context.Background(), logger, nil, direct, other))
+}
+
+func TestChannelMessageReactionPersist(t *testing.T) {
+	db := NewDB(t)
+	channelID := "2..." + GenerateString()
+	stream := PresenceStream{Mode: StreamModeChannel, Label: strings.TrimPrefix(channelID, "2...")}
+
+	sender := uuid.Must(uuid.NewV4())
+	other := uuid.Must(uuid.NewV4())
+	InsertUser(t, db, sender)
+	InsertUser(t, db, other)
+
+	ack, err := ChannelMessageSend(context.Background(), logger, db, &DummyMessageRouter{}, stream, channelID, "{}", sender.String(), sender.String(), true)
+	if err != nil {
+		t.Fatalf("error sending message: %v", err)
+	}
+
+	react := func(userID uuid.UUID, emoji string, add bool) map[string]int64 {
+		var reactions map[string]int64
+		var err error
+		if add {
+			reactions, err = ChannelMessageReactionAdd(context.Background(), logger, db, stream, ack.MessageId, emoji, userID)
+		} else {
+			reactions, err = ChannelMessageReactionRemove(context.Background(), logger, db, stream, ack.MessageId, emoji, userID)
+		}
+		if err != nil {
+			t.Fatalf("error reacting to message: %v", err)
+		}
+		return reactions
+	}
+
+	assert.Equal(t, map[string]int64{"👍": 1}, react(sender, "👍", true))
+	// Each user reacts with each emoji at most once.
+	assert.Equal(t, map[string]int64{"👍": 1}, react(sender, "👍", true))
+	assert.Equal(t, map[string]int64{"👍": 2}, react(other, "👍", true))
+	assert.Equal(t, map[string]int64{"👍": 2, ":wave:": 1}, react(other, ":wave:", true))
+	assert.Equal(t, map[string]int64{"👍": 1, ":wave:": 1}, react(sender, "👍", false))
+	// Removing a reaction the user does not have leaves the counts unchanged.
+	assert.Equal(t, map[string]int64{"👍": 1, ":wave:": 1}, react(sender, "👍", false))
+
+	threads, err := channelMessagesThreadRead(context.Background(), db, []string{ack.MessageId})
+	if err != nil {
+		t.Fatalf("error reading message threads: %v", err)
+	}
+	if assert.Contains(t, threads, ack.MessageId) {
+		assert.Equal(t, map[string]int64{"👍": 1, ":wave:": 1}, threads[ack.MessageId].Reactions)
+	}
+
+	_, err = ChannelMessageReactionAdd(context.Background(), logger, db, stream, GenerateString(), "👍", sender)
+	assert.Equal(t, errReactionMessageNotFound, err)
+}
+
+func TestChannelMessageReplyThread(t *testing.T) {
+	db := NewDB(t)
+	channelID := "2..." + GenerateString()
+	stream := PresenceStream{Mode: StreamModeChannel, Label: strings.TrimPrefix(channelID, "2...")}
+
+	sender := uuid.Must(uuid.NewV4())
+	InsertUser(t, db, sender)
+
+	root, err := ChannelMessageSend(context.Background(), logger, db, &DummyMessageRouter{}, stream, channelID, "{}", sender.String(), sender.String(), true)
+	if err != nil {
+		t.Fatalf("error sending message: %v", err)
+	}
+	if _, err := ChannelMessageSend(context.Background(), logger, db, &DummyMessageRouter{}, stream, channelID, "{}", sender.String(), sender.String(), true); err != nil {
+		t.Fatalf("error sending message: %v", err)
+	}
+
+	// A reply starts a thread with the message it replies to, and replies to replies join that thread.
+	reply, thread, err := ChannelMessageReply(context.Background(), logger, db, &DummyMessageRouter{}, stream, channelID, root.MessageId, "{}", sender.String(), sender.String())
+	if err != nil {
+		t.Fatalf("error replying to message: %v", err)
+	}
+	assert.Equal(t, &channelMessageThread{ReplyToID: root.MessageId, ThreadID: root.MessageId}, thread)
+	nested, thread, err := ChannelMessageReply(context.Background(), logger, db, &DummyMessageRouter{}, stream, channelID, reply.MessageId, "{}", sender.String(), sender.String())
+	if err != nil {
+		t.Fatalf("error replying to message: %v", err)
+	}
+	assert.Equal(t, &channelMessageThread{ReplyToID: reply.MessageId, ThreadID: root.MessageId}, thread)
+
+	list, err := ChannelMessagesList(context.Background(), logger, db, uuid.Nil, stream, channelID, uuid.FromStringOrNil(root.MessageId), 10, true, "")
+	if err != nil {
+		t.Fatalf("error listing thread messages: %v", err)
+	}
+	messageIDs := make([]string, 0, len(list.Messages))
+	for _, message := range list.Messages {
+		messageIDs = append(messageIDs, message.MessageId)
+	}
+	// Messages sent within the same second are not listed in a fixed order.
+	assert.ElementsMatch(t, []string{root.MessageId, reply.MessageId, nested.MessageId}, messageIDs)
+
+	_, _, err = ChannelMessageReply(context.Background(), logger, db, &DummyMessageRouter{}, stream, channelID, GenerateString(), "{}", sender.String(), sender.String())
+	assert.Equal(t, errReplyMessageNotFound, err)
+}
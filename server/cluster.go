@@ -0,0 +1,180 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrClusterNodeNotFound   = errors.New("cluster node not found")
+	ErrClusterHandlerMissing = errors.New("cluster message handler not found")
+	ErrClusterRequestTimeout = errors.New("cluster request timed out")
+	ErrClusterStopped        = errors.New("cluster transport stopped")
+
+	clusterRequestTimeout = 10 * time.Second
+)
+
+// ClusterHandler processes a message received from another node. The returned payload or error is only
+// delivered back to the sender if the message was sent as a request, it is discarded for one-way messages.
+type ClusterHandler func(ctx context.Context, node string, payload []byte) ([]byte, error)
+
+// ClusterPeerListener is notified whenever a peer node becomes reachable or unreachable.
+type ClusterPeerListener func(node string, connected bool)
+
+// ClusterMessage is the unit of exchange between nodes.
+type ClusterMessage struct {
+	// Non-zero for requests and their replies.
+	ID uint64
+	// True if this message is the reply to a previous request with the same ID.
+	Reply bool
+	// Name of the handler expected to process this message.
+	Type string
+	// Name of the node that sent this message.
+	Node    string
+	Payload []byte
+	Error   string
+}
+
+// ClusterTransport is responsible for carrying messages between nodes in a cluster.
+// Handlers and listeners must be registered before the transport is started.
+type ClusterTransport interface {
+	// Name of the current node.
+	Node() string
+	// List the names of all peer nodes currently reachable, excluding the current node.
+	Peers() []string
+
+	// Register a handler for a given message type.
+	Handle(msgType string, handler ClusterHandler)
+	// Register a listener for peer node connect and disconnect events.
+	AddPeerListener(listener ClusterPeerListener)
+
+	// Send a one-way message to a single node. Messages to the same node are delivered in order.
+	Send(node, msgType string, payload []byte) error
+	// Send a one-way message to all currently reachable peer nodes.
+	Broadcast(msgType string, payload []byte)
+	// Send a message to a single node and wait for its handler to respond.
+	Request(ctx context.Context, node, msgType string, payload []byte) ([]byte, error)
+
+	Start() error
+	Stop()
+}
+
+// LocalClusterTransport is used when the server runs as a single node, there are never any peers.
+type LocalClusterTransport struct {
+	node string
+}
+
+func NewLocalClusterTransport(node string) ClusterTransport {
+	return &LocalClusterTransport{node: node}
+}
+
+func (t *LocalClusterTransport) Node() string {
+	return t.node
+}
+
+func (t *LocalClusterTransport) Peers() []string {
+	return []string{}
+}
+
+func (t *LocalClusterTransport) Handle(string, ClusterHandler) {}
+
+func (t *LocalClusterTransport) AddPeerListener(ClusterPeerListener) {}
+
+func (t *LocalClusterTransport) Send(string, string, []byte) error {
+	return ErrClusterNodeNotFound
+}
+
+func (t *LocalClusterTransport) Broadcast(string, []byte) {}
+
+func (t *LocalClusterTransport) Request(context.Context, string, string, []byte) ([]byte, error) {
+	return nil, ErrClusterNodeNotFound
+}
+
+func (t *LocalClusterTransport) Start() error {
+	return nil
+}
+
+func (t *LocalClusterTransport) Stop() {}
+
+// NewClusterTransport selects the transport implementation matching the cluster configuration.
+func NewClusterTransport(logger, startupLogger *zap.Logger, config Config) ClusterTransport {
+	clusterConfig := config.GetCluster()
+	if clusterConfig.Port == 0 {
+		startupLogger.Info("Cluster transport disabled, running as a single node")
+		return NewLocalClusterTransport(config.GetName())
+	}
+	return NewTcpClusterTransport(logger, startupLogger, config)
+}
+
+// clusterHandlers holds the registered handlers and peer listeners shared by transport implementations.
+type clusterHandlers struct {
+	sync.RWMutex
+	handlers  map[string]ClusterHandler
+	listeners []ClusterPeerListener
+}
+
+func newClusterHandlers() *clusterHandlers {
+	return &clusterHandlers{
+		handlers:  make(map[string]ClusterHandler),
+		listeners: make([]ClusterPeerListener, 0, 4),
+	}
+}
+
+func (h *clusterHandlers) handle(msgType string, handler ClusterHandler) {
+	h.Lock()
+	h.handlers[msgType] = handler
+	h.Unlock()
+}
+
+func (h *clusterHandlers) addPeerListener(listener ClusterPeerListener) {
+	h.Lock()
+	h.listeners = append(h.listeners, listener)
+	h.Unlock()
+}
+
+func (h *clusterHandlers) dispatch(ctx context.Context, msg *ClusterMessage) ([]byte, error) {
+	h.RLock()
+	handler, found := h.handlers[msg.Type]
+	h.RUnlock()
+	if !found {
+		return nil, ErrClusterHandlerMissing
+	}
+	return handler(ctx, msg.Node, msg.Payload)
+}
+
+func (h *clusterHandlers) notifyPeer(node string, connected bool) {
+	h.RLock()
+	listeners := h.listeners
+	h.RUnlock()
+	for _, listener := range listeners {
+		listener(node, connected)
+	}
+}
+
+func sortedPeers(peers map[string]struct{}) []string {
+	nodes := make([]string, 0, len(peers))
+	for node := range peers {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
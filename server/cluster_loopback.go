@@ -0,0 +1,184 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// LoopbackClusterHub connects any number of in-process cluster transports to each other.
+// Intended for tests and for running several logical nodes within a single process.
+type LoopbackClusterHub struct {
+	sync.RWMutex
+	transports map[string]*LoopbackClusterTransport
+}
+
+func NewLoopbackClusterHub() *LoopbackClusterHub {
+	return &LoopbackClusterHub{
+		transports: make(map[string]*LoopbackClusterTransport),
+	}
+}
+
+// NewTransport creates a transport for the given node name. It becomes visible to other nodes once started.
+func (h *LoopbackClusterHub) NewTransport(node string) *LoopbackClusterTransport {
+	ctx, ctxCancelFn := context.WithCancel(context.Background())
+	return &LoopbackClusterTransport{
+		hub:         h,
+		node:        node,
+		handlers:    newClusterHandlers(),
+		queue:       make(chan *ClusterMessage, 1024),
+		ctx:         ctx,
+		ctxCancelFn: ctxCancelFn,
+	}
+}
+
+func (h *LoopbackClusterHub) get(node string) *LoopbackClusterTransport {
+	h.RLock()
+	t := h.transports[node]
+	h.RUnlock()
+	return t
+}
+
+type LoopbackClusterTransport struct {
+	hub      *LoopbackClusterHub
+	node     string
+	handlers *clusterHandlers
+	queue    chan *ClusterMessage
+
+	ctx         context.Context
+	ctxCancelFn context.CancelFunc
+}
+
+func (t *LoopbackClusterTransport) Node() string {
+	return t.node
+}
+
+func (t *LoopbackClusterTransport) Peers() []string {
+	t.hub.RLock()
+	peers := make(map[string]struct{}, len(t.hub.transports))
+	for node := range t.hub.transports {
+		if node != t.node {
+			peers[node] = struct{}{}
+		}
+	}
+	t.hub.RUnlock()
+	return sortedPeers(peers)
+}
+
+func (t *LoopbackClusterTransport) Handle(msgType string, handler ClusterHandler) {
+	t.handlers.handle(msgType, handler)
+}
+
+func (t *LoopbackClusterTransport) AddPeerListener(listener ClusterPeerListener) {
+	t.handlers.addPeerListener(listener)
+}
+
+func (t *LoopbackClusterTransport) Send(node, msgType string, payload []byte) error {
+	peer := t.hub.get(node)
+	if peer == nil || node == t.node {
+		return ErrClusterNodeNotFound
+	}
+	msg := &ClusterMessage{Type: msgType, Node: t.node, Payload: payload}
+	select {
+	case <-peer.ctx.Done():
+		return ErrClusterNodeNotFound
+	case peer.queue <- msg:
+		return nil
+	}
+}
+
+func (t *LoopbackClusterTransport) Broadcast(msgType string, payload []byte) {
+	for _, node := range t.Peers() {
+		_ = t.Send(node, msgType, payload)
+	}
+}
+
+func (t *LoopbackClusterTransport) Request(ctx context.Context, node, msgType string, payload []byte) ([]byte, error) {
+	peer := t.hub.get(node)
+	if peer == nil || node == t.node {
+		return nil, ErrClusterNodeNotFound
+	}
+
+	type result struct {
+		payload []byte
+		err     error
+	}
+	resultCh := make(chan *result, 1)
+	go func() {
+		reply, err := peer.handlers.dispatch(peer.ctx, &ClusterMessage{Type: msgType, Node: t.node, Payload: payload})
+		if err != nil {
+			// Errors only cross node boundaries as strings.
+			err = errors.New(err.Error())
+		}
+		resultCh <- &result{payload: reply, err: err}
+	}()
+
+	ctx, ctxCancelFn := context.WithTimeout(ctx, clusterRequestTimeout)
+	defer ctxCancelFn()
+	select {
+	case <-ctx.Done():
+		return nil, ErrClusterRequestTimeout
+	case r := <-resultCh:
+		return r.payload, r.err
+	}
+}
+
+func (t *LoopbackClusterTransport) Start() error {
+	t.hub.Lock()
+	peers := make([]*LoopbackClusterTransport, 0, len(t.hub.transports))
+	for _, peer := range t.hub.transports {
+		peers = append(peers, peer)
+	}
+	t.hub.transports[t.node] = t
+	t.hub.Unlock()
+
+	go func() {
+		for {
+			select {
+			case <-t.ctx.Done():
+				return
+			case msg := <-t.queue:
+				_, _ = t.handlers.dispatch(t.ctx, msg)
+			}
+		}
+	}()
+
+	for _, peer := range peers {
+		peer.handlers.notifyPeer(t.node, true)
+		t.handlers.notifyPeer(peer.node, true)
+	}
+	return nil
+}
+
+func (t *LoopbackClusterTransport) Stop() {
+	t.hub.Lock()
+	if t.hub.transports[t.node] != t {
+		t.hub.Unlock()
+		return
+	}
+	delete(t.hub.transports, t.node)
+	peers := make([]*LoopbackClusterTransport, 0, len(t.hub.transports))
+	for _, peer := range t.hub.transports {
+		peers = append(peers, peer)
+	}
+	t.hub.Unlock()
+
+	t.ctxCancelFn()
+	for _, peer := range peers {
+		peer.handlers.notifyPeer(t.node, false)
+	}
+}
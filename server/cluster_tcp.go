@@ -0,0 +1,440 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+const (
+	clusterMessageTypeHello     = "hello"
+	clusterMessageTypeHandshake = "handshake"
+
+	clusterDialRetryInterval = 2 * time.Second
+	clusterHandshakeTimeout  = 5 * time.Second
+	clusterNonceSize         = 32
+)
+
+// clusterHello is the payload of the first message sent on every new connection, a challenge for the peer.
+type clusterHello struct {
+	Nonce []byte
+}
+
+// clusterHandshake answers the peer's challenge, proving knowledge of the shared secret without sending it.
+type clusterHandshake struct {
+	Proof   []byte
+	Address string
+}
+
+// tcpClusterPeer is an outbound connection used to send messages to a single peer node.
+// Messages from that peer arrive over a separate inbound connection opened by the peer itself.
+type tcpClusterPeer struct {
+	sync.Mutex
+	node    string
+	conn    net.Conn
+	encoder *gob.Encoder
+}
+
+func (p *tcpClusterPeer) send(msg *ClusterMessage, writeTimeout time.Duration) error {
+	p.Lock()
+	defer p.Unlock()
+	if err := p.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	return p.encoder.Encode(msg)
+}
+
+// TcpClusterTransport connects nodes in a full mesh of TCP connections. Each node dials every peer it
+// knows about, either from configuration or learned from handshakes of peers that dialed in first.
+type TcpClusterTransport struct {
+	sync.RWMutex
+	logger   *zap.Logger
+	node     string
+	config   *ClusterConfig
+	handlers *clusterHandlers
+
+	listener  net.Listener
+	peers     map[string]*tcpClusterPeer
+	dialing   map[string]struct{}
+	requestID *atomic.Uint64
+	pending   *MapOf[uint64, chan *ClusterMessage]
+
+	ctx         context.Context
+	ctxCancelFn context.CancelFunc
+}
+
+func NewTcpClusterTransport(logger, startupLogger *zap.Logger, config Config) ClusterTransport {
+	ctx, ctxCancelFn := context.WithCancel(context.Background())
+
+	return &TcpClusterTransport{
+		logger:   logger,
+		node:     config.GetName(),
+		config:   config.GetCluster(),
+		handlers: newClusterHandlers(),
+
+		peers:     make(map[string]*tcpClusterPeer),
+		dialing:   make(map[string]struct{}),
+		requestID: atomic.NewUint64(0),
+		pending:   &MapOf[uint64, chan *ClusterMessage]{},
+
+		ctx:         ctx,
+		ctxCancelFn: ctxCancelFn,
+	}
+}
+
+func (t *TcpClusterTransport) Node() string {
+	return t.node
+}
+
+func (t *TcpClusterTransport) Peers() []string {
+	t.RLock()
+	peers := make(map[string]struct{}, len(t.peers))
+	for node := range t.peers {
+		peers[node] = struct{}{}
+	}
+	t.RUnlock()
+	return sortedPeers(peers)
+}
+
+func (t *TcpClusterTransport) Handle(msgType string, handler ClusterHandler) {
+	t.handlers.handle(msgType, handler)
+}
+
+func (t *TcpClusterTransport) AddPeerListener(listener ClusterPeerListener) {
+	t.handlers.addPeerListener(listener)
+}
+
+func (t *TcpClusterTransport) Send(node, msgType string, payload []byte) error {
+	return t.send(node, &ClusterMessage{Type: msgType, Node: t.node, Payload: payload})
+}
+
+func (t *TcpClusterTransport) Broadcast(msgType string, payload []byte) {
+	msg := &ClusterMessage{Type: msgType, Node: t.node, Payload: payload}
+	for _, node := range t.Peers() {
+		if err := t.send(node, msg); err != nil {
+			t.logger.Warn("Failed to broadcast cluster message", zap.String("node", node), zap.String("type", msgType), zap.Error(err))
+		}
+	}
+}
+
+func (t *TcpClusterTransport) Request(ctx context.Context, node, msgType string, payload []byte) ([]byte, error) {
+	id := t.requestID.Inc()
+	replyCh := make(chan *ClusterMessage, 1)
+	t.pending.Store(id, replyCh)
+	defer t.pending.Delete(id)
+
+	if err := t.send(node, &ClusterMessage{ID: id, Type: msgType, Node: t.node, Payload: payload}); err != nil {
+		return nil, err
+	}
+
+	ctx, ctxCancelFn := context.WithTimeout(ctx, clusterRequestTimeout)
+	defer ctxCancelFn()
+	select {
+	case <-t.ctx.Done():
+		return nil, ErrClusterStopped
+	case <-ctx.Done():
+		return nil, ErrClusterRequestTimeout
+	case reply := <-replyCh:
+		if reply.Error != "" {
+			return nil, errors.New(reply.Error)
+		}
+		return reply.Payload, nil
+	}
+}
+
+func (t *TcpClusterTransport) Start() error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(t.config.Address, strconv.Itoa(t.config.Port)))
+	if err != nil {
+		return fmt.Errorf("cluster listener failed: %v", err.Error())
+	}
+	t.listener = listener
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				select {
+				case <-t.ctx.Done():
+					return
+				default:
+				}
+				t.logger.Error("Cluster listener accept error", zap.Error(err))
+				continue
+			}
+			go t.serveInbound(conn)
+		}
+	}()
+
+	for _, address := range t.config.Peers {
+		t.dial(address)
+	}
+
+	return nil
+}
+
+func (t *TcpClusterTransport) Stop() {
+	t.ctxCancelFn()
+	if t.listener != nil {
+		_ = t.listener.Close()
+	}
+	t.Lock()
+	for _, peer := range t.peers {
+		_ = peer.conn.Close()
+	}
+	t.Unlock()
+}
+
+func (t *TcpClusterTransport) send(node string, msg *ClusterMessage) error {
+	t.RLock()
+	peer, found := t.peers[node]
+	t.RUnlock()
+	if !found {
+		return ErrClusterNodeNotFound
+	}
+	if err := peer.send(msg, time.Duration(t.config.WriteTimeoutMs)*time.Millisecond); err != nil {
+		// Force the dial loop to notice the broken connection and reconnect.
+		_ = peer.conn.Close()
+		return err
+	}
+	return nil
+}
+
+// Start a connection loop to the given address unless one is already running.
+func (t *TcpClusterTransport) dial(address string) {
+	if address == "" || address == t.config.Advertise {
+		return
+	}
+	t.Lock()
+	if _, found := t.dialing[address]; found {
+		t.Unlock()
+		return
+	}
+	t.dialing[address] = struct{}{}
+	t.Unlock()
+
+	go func() {
+		for {
+			if err := t.serveOutbound(address); err != nil {
+				t.logger.Debug("Cluster peer connection ended", zap.String("address", address), zap.Error(err))
+			}
+
+			select {
+			case <-t.ctx.Done():
+				return
+			case <-time.After(clusterDialRetryInterval):
+			}
+		}
+	}()
+}
+
+func (t *TcpClusterTransport) serveOutbound(address string) error {
+	dialer := &net.Dialer{Timeout: clusterHandshakeTimeout}
+	conn, err := dialer.DialContext(t.ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	encoder := gob.NewEncoder(conn)
+	peerNode, err := t.handshake(conn, encoder, gob.NewDecoder(conn))
+	if err != nil {
+		return err
+	}
+	peer := &tcpClusterPeer{node: peerNode, conn: conn, encoder: encoder}
+	t.Lock()
+	if _, found := t.peers[peerNode]; found {
+		t.Unlock()
+		return fmt.Errorf("cluster peer %v already connected", peerNode)
+	}
+	t.peers[peerNode] = peer
+	t.Unlock()
+
+	t.logger.Info("Cluster peer connected", zap.String("node", peerNode), zap.String("address", address))
+	t.handlers.notifyPeer(peerNode, true)
+
+	// Outbound connections never receive messages, block until the connection breaks.
+	_, err = conn.Read(make([]byte, 1))
+
+	t.Lock()
+	delete(t.peers, peerNode)
+	t.Unlock()
+
+	t.logger.Info("Cluster peer disconnected", zap.String("node", peerNode), zap.String("address", address))
+	t.handlers.notifyPeer(peerNode, false)
+	return err
+}
+
+// Exchange handshakes on a fresh connection, returning the name of the remote node.
+// The same encoder and decoder must be used for the lifetime of the connection, decoders buffer reads.
+//
+// Both sides send a random challenge, then answer the peer's challenge with a MAC keyed by the shared secret, so the
+// secret never crosses the network and a listener at a peer address learns nothing it can use to join the cluster.
+func (t *TcpClusterTransport) handshake(conn net.Conn, encoder *gob.Encoder, decoder *gob.Decoder) (string, error) {
+	if err := conn.SetDeadline(time.Now().Add(clusterHandshakeTimeout)); err != nil {
+		return "", err
+	}
+	defer conn.SetDeadline(time.Time{})
+
+	nonce := make([]byte, clusterNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	hello, err := gobEncode(&clusterHello{Nonce: nonce})
+	if err != nil {
+		return "", err
+	}
+	if err := encoder.Encode(&ClusterMessage{Type: clusterMessageTypeHello, Node: t.node, Payload: hello}); err != nil {
+		return "", err
+	}
+
+	var msg ClusterMessage
+	if err := decoder.Decode(&msg); err != nil {
+		return "", err
+	}
+	if msg.Type != clusterMessageTypeHello {
+		return "", errors.New("cluster hello expected")
+	}
+	var remoteHello clusterHello
+	if err := gobDecode(msg.Payload, &remoteHello); err != nil {
+		return "", err
+	}
+	if len(remoteHello.Nonce) != clusterNonceSize {
+		return "", errors.New("cluster hello nonce invalid")
+	}
+	remoteNode := msg.Node
+	if remoteNode == t.node {
+		// Also stops a peer reflecting our own challenge and answer back at us.
+		return "", errors.New("cluster peer uses the same node name")
+	}
+
+	hs, err := gobEncode(&clusterHandshake{
+		Proof:   clusterHandshakeProof(t.config.Secret, remoteHello.Nonce, nonce, t.node, t.config.Advertise),
+		Address: t.config.Advertise,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := encoder.Encode(&ClusterMessage{Type: clusterMessageTypeHandshake, Node: t.node, Payload: hs}); err != nil {
+		return "", err
+	}
+
+	msg = ClusterMessage{}
+	if err := decoder.Decode(&msg); err != nil {
+		return "", err
+	}
+	if msg.Type != clusterMessageTypeHandshake || msg.Node != remoteNode {
+		return "", errors.New("cluster handshake expected")
+	}
+	var remote clusterHandshake
+	if err := gobDecode(msg.Payload, &remote); err != nil {
+		return "", err
+	}
+	if !hmac.Equal(remote.Proof, clusterHandshakeProof(t.config.Secret, nonce, remoteHello.Nonce, remoteNode, remote.Address)) {
+		return "", errors.New("cluster handshake secret mismatch")
+	}
+
+	// Make sure there is a connection back to the peer, in case it was not in the configured peer list.
+	t.dial(remote.Address)
+
+	return remoteNode, nil
+}
+
+// The answer to a challenge binds both nonces, in order, with the answering node's name and address, so it cannot be
+// replayed on another connection or passed off as coming from another node.
+func clusterHandshakeProof(secret string, challenge, nonce []byte, node, address string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(challenge)
+	mac.Write(nonce)
+	mac.Write([]byte(node))
+	mac.Write([]byte{0})
+	mac.Write([]byte(address))
+	return mac.Sum(nil)
+}
+
+func (t *TcpClusterTransport) serveInbound(conn net.Conn) {
+	defer conn.Close()
+
+	decoder := gob.NewDecoder(conn)
+	peerNode, err := t.handshake(conn, gob.NewEncoder(conn), decoder)
+	if err != nil {
+		t.logger.Warn("Cluster inbound handshake failed", zap.String("remote", conn.RemoteAddr().String()), zap.Error(err))
+		return
+	}
+
+	for {
+		msg := &ClusterMessage{}
+		if err := decoder.Decode(msg); err != nil {
+			select {
+			case <-t.ctx.Done():
+			default:
+				t.logger.Debug("Cluster inbound connection closed", zap.String("node", peerNode), zap.Error(err))
+			}
+			return
+		}
+		msg.Node = peerNode
+
+		switch {
+		case msg.Reply:
+			if replyCh, found := t.pending.Load(msg.ID); found {
+				replyCh <- msg
+			}
+		case msg.ID != 0:
+			// Requests may block for some time, do not hold up other messages from this peer.
+			go func() {
+				reply := &ClusterMessage{ID: msg.ID, Reply: true, Type: msg.Type, Node: t.node}
+				payload, err := t.handlers.dispatch(t.ctx, msg)
+				if err != nil {
+					reply.Error = err.Error()
+				} else {
+					reply.Payload = payload
+				}
+				if err := t.send(msg.Node, reply); err != nil {
+					t.logger.Warn("Failed to reply to cluster request", zap.String("node", msg.Node), zap.String("type", msg.Type), zap.Error(err))
+				}
+			}()
+		default:
+			// One-way messages are handled in order of arrival.
+			if _, err := t.handlers.dispatch(t.ctx, msg); err != nil {
+				t.logger.Warn("Failed to handle cluster message", zap.String("node", msg.Node), zap.String("type", msg.Type), zap.Error(err))
+			}
+		}
+	}
+}
+
+func gobEncode(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gobDecode(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
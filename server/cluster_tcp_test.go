@@ -0,0 +1,107 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/gob"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// Run a handshake between two transports over a loopback TCP connection.
+func clusterTestHandshake(t *testing.T, a, b *TcpClusterTransport) (string, error, string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+	defer listener.Close()
+
+	type result struct {
+		node string
+		err  error
+	}
+	bResult := make(chan result, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			bResult <- result{err: err}
+			return
+		}
+		defer conn.Close()
+		node, err := b.handshake(conn, gob.NewEncoder(conn), gob.NewDecoder(conn))
+		bResult <- result{node: node, err: err}
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("error dialing: %v", err)
+	}
+	aNode, aErr := a.handshake(conn, gob.NewEncoder(conn), gob.NewDecoder(conn))
+	_ = conn.Close()
+	r := <-bResult
+	return aNode, aErr, r.node, r.err
+}
+
+func newClusterTestTcpTransport(node, secret string) *TcpClusterTransport {
+	config := NewConfig(zap.NewNop())
+	config.Name = node
+	config.Cluster.Secret = secret
+	return NewTcpClusterTransport(zap.NewNop(), zap.NewNop(), config).(*TcpClusterTransport)
+}
+
+func TestTcpClusterTransportHandshake(t *testing.T) {
+	a := newClusterTestTcpTransport("node-a", "secret")
+	b := newClusterTestTcpTransport("node-b", "secret")
+
+	aPeer, aErr, bPeer, bErr := clusterTestHandshake(t, a, b)
+	assert.NoError(t, aErr)
+	assert.NoError(t, bErr)
+	assert.Equal(t, "node-b", aPeer)
+	assert.Equal(t, "node-a", bPeer)
+}
+
+func TestTcpClusterTransportHandshakeSecretMismatch(t *testing.T) {
+	a := newClusterTestTcpTransport("node-a", "secret")
+	b := newClusterTestTcpTransport("node-b", "other secret")
+
+	_, aErr, _, bErr := clusterTestHandshake(t, a, b)
+	assert.Error(t, aErr)
+	assert.Error(t, bErr)
+}
+
+func TestTcpClusterTransportHandshakeSameNode(t *testing.T) {
+	a := newClusterTestTcpTransport("node-a", "secret")
+	b := newClusterTestTcpTransport("node-a", "secret")
+
+	_, aErr, _, bErr := clusterTestHandshake(t, a, b)
+	assert.Error(t, aErr)
+	assert.Error(t, bErr)
+}
+
+func TestClusterHandshakeProof(t *testing.T) {
+	challenge := []byte("challenge")
+	nonce := []byte("nonce")
+	proof := clusterHandshakeProof("secret", challenge, nonce, "node-a", "10.0.0.1:7352")
+
+	assert.Equal(t, proof, clusterHandshakeProof("secret", challenge, nonce, "node-a", "10.0.0.1:7352"))
+	assert.NotEqual(t, proof, clusterHandshakeProof("other secret", challenge, nonce, "node-a", "10.0.0.1:7352"))
+	// Answers are not interchangeable between the two sides of a connection.
+	assert.NotEqual(t, proof, clusterHandshakeProof("secret", nonce, challenge, "node-a", "10.0.0.1:7352"))
+	assert.NotEqual(t, proof, clusterHandshakeProof("secret", challenge, nonce, "node-b", "10.0.0.1:7352"))
+	assert.NotEqual(t, proof, clusterHandshakeProof("secret", challenge, nonce, "node-a", "10.0.0.2:7352"))
+}
@@ -19,10 +19,12 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/heroiclabs/nakama/v3/flags"
@@ -48,6 +50,7 @@ type Config interface {
 	GetLeaderboard() *LeaderboardConfig
 	GetMatchmaker() *MatchmakerConfig
 	GetIAP() *IAPConfig
+	GetCluster() *ClusterConfig
 
 	Clone() (Config, error)
 }
@@ -140,6 +143,12 @@ func CheckConfig(logger *zap.Logger, config Config) map[string]string {
 	if config.GetSession().SingleMatch && !config.GetSession().SingleSocket {
 		logger.Fatal("Single match cannot be enabled without single socket", zap.Strings("param", []string{"session.single_match", "session.single_socket"}))
 	}
+	if config.GetSession().CacheBackend != SessionCacheBackendLocal && config.GetSession().CacheBackend != SessionCacheBackendDatabase {
+		logger.Fatal("Session cache backend must be 'local' or 'database'", zap.String("session.cache_backend", config.GetSession().CacheBackend))
+	}
+	if config.GetSession().CacheTtlMs < 0 {
+		logger.Fatal("Session cache TTL must be >= 0", zap.Int("session.cache_ttl_ms", config.GetSession().CacheTtlMs))
+	}
 	if config.GetRuntime().HTTPKey == "" {
 		logger.Fatal("Runtime HTTP key must be set", zap.String("param", "runtime.http_key"))
 	}
@@ -188,6 +197,9 @@ func CheckConfig(logger *zap.Logger, config Config) map[string]string {
 	if config.GetSocket().IdleTimeoutMs < 1 {
 		logger.Fatal("Socket idle timeout milliseconds must be >= 1", zap.Int("socket.idle_timeout_ms", config.GetSocket().IdleTimeoutMs))
 	}
+	if config.GetSocket().UdpPort < 0 {
+		logger.Fatal("Socket UDP port must be >= 0", zap.Int("socket.udp_port", config.GetSocket().UdpPort))
+	}
 	if config.GetSocket().PingPeriodMs >= config.GetSocket().PongWaitMs {
 		logger.Fatal("Ping period value must be less than pong wait value", zap.Int("socket.ping_period_ms", config.GetSocket().PingPeriodMs), zap.Int("socket.pong_wait_ms", config.GetSocket().PongWaitMs))
 	}
@@ -203,6 +215,9 @@ func CheckConfig(logger *zap.Logger, config Config) map[string]string {
 	if config.GetDatabase().DnsScanIntervalSec < 1 {
 		logger.Fatal("Database DNS scan interval seconds must be > 0", zap.Int("database.dns_scan_interval_sec", config.GetDatabase().DnsScanIntervalSec))
 	}
+	if config.GetDatabase().StorageExpiryIntervalSec < 1 {
+		logger.Fatal("Database storage expiry interval seconds must be > 0", zap.Int("database.storage_expiry_interval_sec", config.GetDatabase().StorageExpiryIntervalSec))
+	}
 	if config.GetRuntime().GetLuaMinCount() < 0 {
 		logger.Fatal("Minimum Lua runtime instance count must be >= 0", zap.Int("runtime.lua_min_count", config.GetRuntime().GetLuaMinCount()))
 	}
@@ -266,6 +281,18 @@ func CheckConfig(logger *zap.Logger, config Config) map[string]string {
 	if config.GetLeaderboard().CallbackQueueWorkers < 1 {
 		logger.Fatal("Leaderboard callback queue workers must be >= 1", zap.Int("leaderboard.callback_queue_workers", config.GetLeaderboard().CallbackQueueWorkers))
 	}
+	if config.GetLeaderboard().SubscriptionTopCount < 0 {
+		logger.Fatal("Leaderboard subscription top count must be >= 0", zap.Int("leaderboard.subscription_top_count", config.GetLeaderboard().SubscriptionTopCount))
+	}
+	if config.GetLeaderboard().SubscriptionQueueSize < 1 {
+		logger.Fatal("Leaderboard subscription queue size must be >= 1", zap.Int("leaderboard.subscription_queue_size", config.GetLeaderboard().SubscriptionQueueSize))
+	}
+	if config.GetLeaderboard().RankCacheSnapshotIntervalSec < 0 {
+		logger.Fatal("Leaderboard rank cache snapshot interval must be >= 0", zap.Int("leaderboard.rank_cache_snapshot_interval_sec", config.GetLeaderboard().RankCacheSnapshotIntervalSec))
+	}
+	if config.GetLeaderboard().BucketRatingRange < 1 {
+		logger.Fatal("Leaderboard bucket rating range must be >= 1", zap.Int64("leaderboard.bucket_rating_range", config.GetLeaderboard().BucketRatingRange))
+	}
 	if config.GetMatchmaker().MaxTickets < 1 {
 		logger.Fatal("Matchmaker maximum ticket count must be >= 1", zap.Int("matchmaker.max_tickets", config.GetMatchmaker().MaxTickets))
 	}
@@ -281,6 +308,18 @@ func CheckConfig(logger *zap.Logger, config Config) map[string]string {
 	if config.GetMatchmaker().RevThreshold < 0 {
 		logger.Fatal("Matchmaker reverse matching threshold must be >= 0", zap.Int("matchmaker.rev_threshold", config.GetMatchmaker().RevThreshold))
 	}
+	if config.GetMatchmaker().RecentMatches < 0 {
+		logger.Fatal("Matchmaker recent matches must be >= 0", zap.Int("matchmaker.recent_matches", config.GetMatchmaker().RecentMatches))
+	}
+	if config.GetCluster().Port < 0 {
+		logger.Fatal("Cluster port must be >= 0", zap.Int("cluster.port", config.GetCluster().Port))
+	}
+	if config.GetCluster().Port != 0 && config.GetCluster().Secret == "" {
+		logger.Fatal("Cluster secret must be set", zap.String("param", "cluster.secret"))
+	}
+	if config.GetCluster().WriteTimeoutMs < 1 {
+		logger.Fatal("Cluster write timeout milliseconds must be >= 1", zap.Int("cluster.write_timeout_ms", config.GetCluster().WriteTimeoutMs))
+	}
 
 	// If the runtime path is not overridden, set it to `datadir/modules`.
 	if config.GetRuntime().Path == "" {
@@ -299,6 +338,11 @@ func CheckConfig(logger *zap.Logger, config Config) map[string]string {
 		}
 	}
 
+	// If the cluster advertise address is not overridden, assume the node name is resolvable by other nodes.
+	if config.GetCluster().Port != 0 && config.GetCluster().Advertise == "" {
+		config.GetCluster().Advertise = net.JoinHostPort(config.GetName(), strconv.Itoa(config.GetCluster().Port))
+	}
+
 	configWarnings := make(map[string]string, 8)
 
 	// Log warnings for insecure default parameter values.
@@ -326,6 +370,10 @@ func CheckConfig(logger *zap.Logger, config Config) map[string]string {
 		logger.Warn("WARNING: insecure default parameter value, change this for production!", zap.String("param", "session.refresh_encryption_key"))
 		configWarnings["session.refresh_encryption_key"] = "Insecure default parameter value, change this for production!"
 	}
+	if config.GetCluster().Port != 0 && config.GetCluster().Secret == "defaultclustersecret" {
+		logger.Warn("WARNING: insecure default parameter value, change this for production!", zap.String("param", "cluster.secret"))
+		configWarnings["cluster.secret"] = "Insecure default parameter value, change this for production!"
+	}
 	if config.GetRuntime().HTTPKey == "defaulthttpkey" {
 		logger.Warn("WARNING: insecure default parameter value, change this for production!", zap.String("param", "runtime.http_key"))
 		configWarnings["runtime.http_key"] = "Insecure default parameter value, change this for production!"
@@ -424,6 +472,7 @@ type config struct {
 	Leaderboard      *LeaderboardConfig `yaml:"leaderboard" json:"leaderboard" usage:"Leaderboard settings."`
 	Matchmaker       *MatchmakerConfig  `yaml:"matchmaker" json:"matchmaker" usage:"Matchmaker settings."`
 	IAP              *IAPConfig         `yaml:"iap" json:"iap" usage:"In-App Purchase settings."`
+	Cluster          *ClusterConfig     `yaml:"cluster" json:"cluster" usage:"Multi-node cluster settings."`
 }
 
 // NewConfig constructs a Config struct which represents server settings, and populates it with default values.
@@ -449,6 +498,7 @@ func NewConfig(logger *zap.Logger) *config {
 		Leaderboard:      NewLeaderboardConfig(),
 		Matchmaker:       NewMatchmakerConfig(),
 		IAP:              NewIAPConfig(),
+		Cluster:          NewClusterConfig(),
 	}
 }
 
@@ -466,6 +516,7 @@ func (c *config) Clone() (Config, error) {
 	configLeaderboard := *(c.Leaderboard)
 	configMatchmaker := *(c.Matchmaker)
 	configIAP := *(c.IAP)
+	configCluster := *(c.Cluster)
 	nc := &config{
 		Name:             c.Name,
 		Datadir:          c.Datadir,
@@ -483,6 +534,7 @@ func (c *config) Clone() (Config, error) {
 		Leaderboard:      &configLeaderboard,
 		Matchmaker:       &configMatchmaker,
 		IAP:              &configIAP,
+		Cluster:          &configCluster,
 	}
 	nc.Socket.CertPEMBlock = make([]byte, len(c.Socket.CertPEMBlock))
 	copy(nc.Socket.CertPEMBlock, c.Socket.CertPEMBlock)
@@ -505,6 +557,10 @@ func (c *config) Clone() (Config, error) {
 	}
 	nc.Leaderboard.BlacklistRankCache = make([]string, len(c.Leaderboard.BlacklistRankCache))
 	copy(nc.Leaderboard.BlacklistRankCache, c.Leaderboard.BlacklistRankCache)
+	nc.Leaderboard.ApproximateRankCache = make([]string, len(c.Leaderboard.ApproximateRankCache))
+	copy(nc.Leaderboard.ApproximateRankCache, c.Leaderboard.ApproximateRankCache)
+	nc.Cluster.Peers = make([]string, len(c.Cluster.Peers))
+	copy(nc.Cluster.Peers, c.Cluster.Peers)
 
 	return nc, nil
 }
@@ -573,6 +629,10 @@ func (c *config) GetIAP() *IAPConfig {
 	return c.IAP
 }
 
+func (c *config) GetCluster() *ClusterConfig {
+	return c.Cluster
+}
+
 // LoggerConfig is configuration relevant to logging levels and output.
 type LoggerConfig struct {
 	Level    string `yaml:"level" json:"level" usage:"Log level to set. Valid values are 'debug', 'info', 'warn', 'error'. Default 'info'."`
@@ -630,6 +690,8 @@ type SessionConfig struct {
 	RefreshTokenExpirySec int64  `yaml:"refresh_token_expiry_sec" json:"refresh_token_expiry_sec" usage:"Refresh token expiry in seconds."`
 	SingleSocket          bool   `yaml:"single_socket" json:"single_socket" usage:"Only allow one socket per user. Older sessions are disconnected. Default false."`
 	SingleMatch           bool   `yaml:"single_match" json:"single_match" usage:"Only allow one match per user. Older matches receive a leave. Requires single socket to enable. Default false."`
+	CacheBackend          string `yaml:"cache_backend" json:"cache_backend" usage:"Where valid session tokens and login lockouts are stored. Possible values are 'local' for each node's memory, or 'database' to share them between all nodes. Default 'local'."`
+	CacheTtlMs            int    `yaml:"cache_ttl_ms" json:"cache_ttl_ms" usage:"With the 'database' cache backend, how long in milliseconds a node trusts a token or login lockout it checked in the database before checking again. Bounds how long a token revoked or a lockout changed on another node goes unnoticed when nodes are not clustered. 0 checks on every use. Default 1000."`
 }
 
 func NewSessionConfig() *SessionConfig {
@@ -638,6 +700,8 @@ func NewSessionConfig() *SessionConfig {
 		TokenExpirySec:        60,
 		RefreshEncryptionKey:  "defaultrefreshencryptionkey",
 		RefreshTokenExpirySec: 3600,
+		CacheBackend:          SessionCacheBackendLocal,
+		CacheTtlMs:            1000,
 	}
 }
 
@@ -646,6 +710,7 @@ type SocketConfig struct {
 	ServerKey            string            `yaml:"server_key" json:"server_key" usage:"Server key to use to establish a connection to the server."`
 	Port                 int               `yaml:"port" json:"port" usage:"The port for accepting connections from the client for the given interface(s), address(es), and protocol(s). Default 7350."`
 	Address              string            `yaml:"address" json:"address" usage:"The IP address of the interface to listen for client traffic on. Default listen on all available addresses/interfaces."`
+	UdpPort              int               `yaml:"udp_port" json:"udp_port" usage:"The port for accepting realtime connections over UDP, supporting unreliable delivery of match data. Default 0, which disables UDP connections."`
 	Protocol             string            `yaml:"protocol" json:"protocol" usage:"The network protocol to listen for traffic on. Possible values are 'tcp' for both IPv4 and IPv6, 'tcp4' for IPv4 only, or 'tcp6' for IPv6 only. Default 'tcp'."`
 	MaxMessageSizeBytes  int64             `yaml:"max_message_size_bytes" json:"max_message_size_bytes" usage:"Maximum amount of data in bytes allowed to be read from the client socket per message. Used for real-time connections."`
 	MaxRequestSizeBytes  int64             `yaml:"max_request_size_bytes" json:"max_request_size_bytes" usage:"Maximum amount of data in bytes allowed to be read from clients per request. Used for gRPC and HTTP connections."`
@@ -671,6 +736,7 @@ func NewSocketConfig() *SocketConfig {
 		ServerKey:            "defaultkey",
 		Port:                 7350,
 		Address:              "",
+		UdpPort:              0,
 		Protocol:             "tcp",
 		MaxMessageSizeBytes:  4096,
 		MaxRequestSizeBytes:  262_144, // 256 KB.
@@ -691,20 +757,22 @@ func NewSocketConfig() *SocketConfig {
 
 // DatabaseConfig is configuration relevant to the Database storage.
 type DatabaseConfig struct {
-	Addresses          []string `yaml:"address" json:"address" usage:"List of database servers (username:password@address:port/dbname). Default 'root@localhost:26257'."`
-	ConnMaxLifetimeMs  int      `yaml:"conn_max_lifetime_ms" json:"conn_max_lifetime_ms" usage:"Time in milliseconds to reuse a database connection before the connection is killed and a new one is created. Default 3600000 (1 hour)."`
-	MaxOpenConns       int      `yaml:"max_open_conns" json:"max_open_conns" usage:"Maximum number of allowed open connections to the database. Default 100."`
-	MaxIdleConns       int      `yaml:"max_idle_conns" json:"max_idle_conns" usage:"Maximum number of allowed open but unused connections to the database. Default 100."`
-	DnsScanIntervalSec int      `yaml:"dns_scan_interval_sec" json:"dns_scan_interval_sec" usage:"Number of seconds between scans looking for DNS resolution changes for the database hostname. Default 60."`
+	Addresses                []string `yaml:"address" json:"address" usage:"List of database servers (username:password@address:port/dbname). Default 'root@localhost:26257'."`
+	ConnMaxLifetimeMs        int      `yaml:"conn_max_lifetime_ms" json:"conn_max_lifetime_ms" usage:"Time in milliseconds to reuse a database connection before the connection is killed and a new one is created. Default 3600000 (1 hour)."`
+	MaxOpenConns             int      `yaml:"max_open_conns" json:"max_open_conns" usage:"Maximum number of allowed open connections to the database. Default 100."`
+	MaxIdleConns             int      `yaml:"max_idle_conns" json:"max_idle_conns" usage:"Maximum number of allowed open but unused connections to the database. Default 100."`
+	DnsScanIntervalSec       int      `yaml:"dns_scan_interval_sec" json:"dns_scan_interval_sec" usage:"Number of seconds between scans looking for DNS resolution changes for the database hostname. Default 60."`
+	StorageExpiryIntervalSec int      `yaml:"storage_expiry_interval_sec" json:"storage_expiry_interval_sec" usage:"Number of seconds between removals of storage objects whose expiry time has passed. Default 60."`
 }
 
 func NewDatabaseConfig() *DatabaseConfig {
 	return &DatabaseConfig{
-		Addresses:          []string{"root@localhost:26257"},
-		ConnMaxLifetimeMs:  3600000,
-		MaxOpenConns:       100,
-		MaxIdleConns:       100,
-		DnsScanIntervalSec: 60,
+		Addresses:                []string{"root@localhost:26257"},
+		ConnMaxLifetimeMs:        3600000,
+		MaxOpenConns:             100,
+		MaxIdleConns:             100,
+		DnsScanIntervalSec:       60,
+		StorageExpiryIntervalSec: 60,
 	}
 }
 
@@ -907,16 +975,26 @@ func NewConsoleConfig() *ConsoleConfig {
 
 // LeaderboardConfig is configuration relevant to the leaderboard system.
 type LeaderboardConfig struct {
-	BlacklistRankCache   []string `yaml:"blacklist_rank_cache" json:"blacklist_rank_cache" usage:"Disable rank cache for leaderboards with matching identifiers. To disable rank cache entirely, use '*', otherwise leave blank to enable rank cache."`
-	CallbackQueueSize    int      `yaml:"callback_queue_size" json:"callback_queue_size" usage:"Size of the leaderboard and tournament callback queue that sequences expiry/reset/end invocations. Default 65536."`
-	CallbackQueueWorkers int      `yaml:"callback_queue_workers" json:"callback_queue_workers" usage:"Number of workers to use for concurrent processing of leaderboard and tournament callbacks. Default 8."`
+	BlacklistRankCache           []string `yaml:"blacklist_rank_cache" json:"blacklist_rank_cache" usage:"Disable rank cache for leaderboards with matching identifiers. To disable rank cache entirely, use '*', otherwise leave blank to enable rank cache."`
+	CallbackQueueSize            int      `yaml:"callback_queue_size" json:"callback_queue_size" usage:"Size of the leaderboard and tournament callback queue that sequences expiry/reset/end invocations. Default 65536."`
+	CallbackQueueWorkers         int      `yaml:"callback_queue_workers" json:"callback_queue_workers" usage:"Number of workers to use for concurrent processing of leaderboard and tournament callbacks. Default 8."`
+	SubscriptionTopCount         int      `yaml:"subscription_top_count" json:"subscription_top_count" usage:"Number of top ranks whose changes are sent to realtime leaderboard subscribers. Default 10."`
+	SubscriptionQueueSize        int      `yaml:"subscription_queue_size" json:"subscription_queue_size" usage:"Size of the queue of rank changes waiting to be sent to realtime leaderboard subscribers, changes are dropped while it is full. Default 65536."`
+	RankCacheSnapshotIntervalSec int      `yaml:"rank_cache_snapshot_interval_sec" json:"rank_cache_snapshot_interval_sec" usage:"Interval in seconds between snapshots of the rank cache written to the data directory, and at shutdown. Snapshots still matching the database are restored at startup instead of reading every record. 0 to disable. Default 300."`
+	BucketRatingRange            int64    `yaml:"bucket_rating_range" json:"bucket_rating_range" usage:"Width of the rating bands used to place players joining bucketed tournaments, players whose ratings fall in the same band share buckets. Default 100."`
+	ApproximateRankCache         []string `yaml:"approximate_rank_cache" json:"approximate_rank_cache" usage:"Keep approximate ranks for leaderboards excluded from the rank cache with matching identifiers, estimated from a histogram of their scores. Use '*' for all excluded leaderboards, otherwise leave blank."`
 }
 
 func NewLeaderboardConfig() *LeaderboardConfig {
 	return &LeaderboardConfig{
-		BlacklistRankCache:   []string{},
-		CallbackQueueSize:    65536,
-		CallbackQueueWorkers: 8,
+		BlacklistRankCache:           []string{},
+		CallbackQueueSize:            65536,
+		CallbackQueueWorkers:         8,
+		SubscriptionTopCount:         10,
+		SubscriptionQueueSize:        65536,
+		RankCacheSnapshotIntervalSec: 300,
+		BucketRatingRange:            100,
+		ApproximateRankCache:         []string{},
 	}
 }
 
@@ -927,6 +1005,10 @@ type MatchmakerConfig struct {
 	BatchPoolSize int  `yaml:"batch_pool_size" json:"batch_pool_size" usage:"Number of concurrent indexing batches that will be allocated."`
 	RevPrecision  bool `yaml:"rev_precision" json:"rev_precision" usage:"Reverse matching precision. Default true."`
 	RevThreshold  int  `yaml:"rev_threshold" json:"rev_threshold" usage:"Reverse matching threshold. Default 1."`
+	Cluster       bool `yaml:"cluster" json:"cluster" usage:"Share matchmaker tickets between all cluster nodes so they can be matched together. A single elected node processes the shared tickets. Default false."`
+	// Tickets with this string property are matched on the skill ratings of their users in the queue it names.
+	RatingQueueProperty string `yaml:"rating_queue_property" json:"rating_queue_property" usage:"String property that names the rating queue of a matchmaker ticket. The rating and rating deviation of the ticket's users in that queue are set as the 'rating' and 'rating_deviation' numeric properties. Empty to disable. Default empty."`
+	RecentMatches       int    `yaml:"recent_matches" json:"recent_matches" usage:"Number of most recently formed matches kept for the console matchmaker view. 0 to disable. Default 100."`
 }
 
 func NewMatchmakerConfig() *MatchmakerConfig {
@@ -937,6 +1019,7 @@ func NewMatchmakerConfig() *MatchmakerConfig {
 		BatchPoolSize: 32,
 		RevPrecision:  false,
 		RevThreshold:  1,
+		RecentMatches: 100,
 	}
 }
 
@@ -970,3 +1053,22 @@ type IAPHuaweiConfig struct {
 	ClientID     string `yaml:"client_id" json:"client_id" usage:"Huawei OAuth client secret."`
 	ClientSecret string `yaml:"client_secret" json:"client_secret" usage:"Huawei OAuth app client secret."`
 }
+
+// ClusterConfig is configuration relevant to communication between nodes in a multi-node deployment.
+type ClusterConfig struct {
+	Port           int      `yaml:"port" json:"port" usage:"The port for accepting connections from other cluster nodes. Default 0, which disables clustering and runs the server as a single node."`
+	Address        string   `yaml:"address" json:"address" usage:"The IP address of the interface to listen for cluster traffic on. Default listen on all available addresses/interfaces."`
+	Advertise      string   `yaml:"advertise" json:"advertise" usage:"The host:port other nodes should use to reach this node. Default is the node name and cluster port."`
+	Peers          []string `yaml:"peers" json:"peers" usage:"The host:port addresses of other cluster nodes to connect to on startup. Nodes that connect in are discovered automatically."`
+	Secret         string   `yaml:"secret" json:"secret" usage:"Shared secret all cluster nodes must prove they know when connecting to each other. It is never sent over the network."`
+	WriteTimeoutMs int      `yaml:"write_timeout_ms" json:"write_timeout_ms" usage:"Maximum duration in milliseconds before timing out writes to another cluster node. Default 5000."`
+}
+
+func NewClusterConfig() *ClusterConfig {
+	return &ClusterConfig{
+		Port:           0,
+		Peers:          []string{},
+		Secret:         "defaultclustersecret",
+		WriteTimeoutMs: 5000,
+	}
+}
@@ -80,16 +80,23 @@ var restrictedMethods = map[string]console.UserRole{
 	"/nakama.console.Console/PromoteGroupMember": console.UserRole_USER_ROLE_MAINTAINER,
 
 	// Leaderboard
-	"/nakama.console.Console/ListLeaderboards":        console.UserRole_USER_ROLE_READONLY,
-	"/nakama.console.Console/GetLeaderboard":          console.UserRole_USER_ROLE_READONLY,
-	"/nakama.console.Console/ListLeaderboardRecords":  console.UserRole_USER_ROLE_READONLY,
-	"/nakama.console.Console/DeleteLeaderboard":       console.UserRole_USER_ROLE_DEVELOPER,
-	"/nakama.console.Console/DeleteLeaderboardRecord": console.UserRole_USER_ROLE_MAINTAINER,
+	"/nakama.console.Console/ListLeaderboards":         console.UserRole_USER_ROLE_READONLY,
+	"/nakama.console.Console/GetLeaderboard":           console.UserRole_USER_ROLE_READONLY,
+	"/nakama.console.Console/GetLeaderboardValidation": console.UserRole_USER_ROLE_READONLY,
+	"/nakama.console.Console/ListLeaderboardRecords":   console.UserRole_USER_ROLE_READONLY,
+	"/nakama.console.Console/DeleteLeaderboard":        console.UserRole_USER_ROLE_DEVELOPER,
+	"/nakama.console.Console/DeleteLeaderboardRecord":  console.UserRole_USER_ROLE_MAINTAINER,
 
 	// Match
 	"/nakama.console.Console/ListMatches":   console.UserRole_USER_ROLE_READONLY,
 	"/nakama.console.Console/GetMatchState": console.UserRole_USER_ROLE_READONLY,
 
+	// Matchmaker
+	"/nakama.console.Console/DeleteMatchmakerTicket": console.UserRole_USER_ROLE_MAINTAINER,
+	"/nakama.console.Console/GetMatchmakerStats":     console.UserRole_USER_ROLE_READONLY,
+	"/nakama.console.Console/ListMatchmakerMatches":  console.UserRole_USER_ROLE_READONLY,
+	"/nakama.console.Console/ListMatchmakerTickets":  console.UserRole_USER_ROLE_READONLY,
+
 	// Channel messages
 	"/nakama.console.Console/ListChannelMessages":   console.UserRole_USER_ROLE_READONLY,
 	"/nakama.console.Console/DeleteChannelMessages": console.UserRole_USER_ROLE_MAINTAINER,
@@ -146,6 +153,7 @@ type ConsoleServer struct {
 	loginAttemptCache    LoginAttemptCache
 	statusRegistry       *StatusRegistry
 	matchRegistry        MatchRegistry
+	matchmaker           Matchmaker
 	statusHandler        StatusHandler
 	runtimeInfo          *RuntimeInfo
 	configWarnings       map[string]string
@@ -155,13 +163,15 @@ type ConsoleServer struct {
 	grpcGatewayServer    *http.Server
 	leaderboardCache     LeaderboardCache
 	leaderboardRankCache LeaderboardRankCache
+	storageIndex         StorageIndex
+	storageFeed          StorageFeed
 	api                  *ApiServer
 	rpcMethodCache       *rpcReflectCache
 	cookie               string
 	httpClient           *http.Client
 }
 
-func StartConsoleServer(logger *zap.Logger, startupLogger *zap.Logger, db *sql.DB, config Config, tracker Tracker, router MessageRouter, streamManager StreamManager, metrics Metrics, sessionCache SessionCache, consoleSessionCache SessionCache, loginAttemptCache LoginAttemptCache, statusRegistry *StatusRegistry, statusHandler StatusHandler, runtimeInfo *RuntimeInfo, matchRegistry MatchRegistry, configWarnings map[string]string, serverVersion string, leaderboardCache LeaderboardCache, leaderboardRankCache LeaderboardRankCache, api *ApiServer, cookie string) *ConsoleServer {
+func StartConsoleServer(logger *zap.Logger, startupLogger *zap.Logger, db *sql.DB, config Config, tracker Tracker, router MessageRouter, streamManager StreamManager, metrics Metrics, sessionCache SessionCache, consoleSessionCache SessionCache, loginAttemptCache LoginAttemptCache, statusRegistry *StatusRegistry, statusHandler StatusHandler, runtimeInfo *RuntimeInfo, matchRegistry MatchRegistry, matchmaker Matchmaker, configWarnings map[string]string, serverVersion string, leaderboardCache LeaderboardCache, leaderboardRankCache LeaderboardRankCache, storageIndex StorageIndex, storageFeed StorageFeed, api *ApiServer, cookie string) *ConsoleServer {
 	var gatewayContextTimeoutMs string
 	if config.GetConsole().IdleTimeoutMs > 500 {
 		// Ensure the GRPC Gateway timeout is just under the idle timeout (if possible) to ensure it has priority.
@@ -192,6 +202,7 @@ func StartConsoleServer(logger *zap.Logger, startupLogger *zap.Logger, db *sql.D
 		loginAttemptCache:    loginAttemptCache,
 		statusRegistry:       statusRegistry,
 		matchRegistry:        matchRegistry,
+		matchmaker:           matchmaker,
 		statusHandler:        statusHandler,
 		configWarnings:       configWarnings,
 		serverVersion:        serverVersion,
@@ -200,6 +211,8 @@ func StartConsoleServer(logger *zap.Logger, startupLogger *zap.Logger, db *sql.D
 		runtimeInfo:          runtimeInfo,
 		leaderboardCache:     leaderboardCache,
 		leaderboardRankCache: leaderboardRankCache,
+		storageIndex:         storageIndex,
+		storageFeed:          storageFeed,
 		api:                  api,
 		cookie:               cookie,
 		httpClient:           &http.Client{Timeout: 5 * time.Second},
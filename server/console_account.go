@@ -69,7 +69,7 @@ func (s *ConsoleServer) UnbanAccount(ctx context.Context, in *console.AccountId)
 		return nil, status.Error(codes.InvalidArgument, "Cannot unban the system user.")
 	}
 
-	if err := UnbanUsers(ctx, s.logger, s.db, s.sessionCache, []uuid.UUID{userID}); err != nil {
+	if err := UnbanUsers(ctx, s.logger, s.db, []uuid.UUID{userID}); err != nil {
 		// Error logged in the core function above.
 		return nil, status.Error(codes.Internal, "An error occurred while trying to unban the user.")
 	}
@@ -125,7 +125,7 @@ func (s *ConsoleServer) ListLeaderboardRecords(ctx context.Context, in *api.List
 		overrideExpiry = in.Expiry.Value
 	}
 
-	records, err := LeaderboardRecordsList(ctx, s.logger, s.db, s.leaderboardCache, s.leaderboardRankCache, in.LeaderboardId, limit, in.Cursor, in.OwnerIds, overrideExpiry)
+	records, err := LeaderboardRecordsList(ctx, s.logger, s.db, s.leaderboardCache, s.leaderboardRankCache, in.LeaderboardId, limit, in.Cursor, in.OwnerIds, nil, overrideExpiry)
 	if err == ErrLeaderboardNotFound {
 		return nil, status.Error(codes.NotFound, "Leaderboard not found.")
 	} else if err == ErrLeaderboardInvalidCursor {
@@ -165,3 +165,37 @@ func (s *ConsoleServer) DeleteLeaderboardRecord(ctx context.Context, in *console
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *ConsoleServer) GetLeaderboardValidation(ctx context.Context, in *console.LeaderboardRequest) (*console.LeaderboardValidation, error) {
+	if in.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "Leaderboard ID must be set.")
+	}
+
+	l := s.leaderboardCache.Get(in.Id)
+	if l == nil {
+		return nil, status.Error(codes.NotFound, "Leaderboard not found.")
+	}
+
+	rejections, err := LeaderboardRejectionsList(ctx, s.db, l.Id)
+	if err != nil {
+		s.logger.Error("Error listing leaderboard rejections.", zap.Error(err))
+		return nil, status.Error(codes.Internal, "Error listing leaderboard rejections.")
+	}
+
+	result := &console.LeaderboardValidation{Rejections: rejections}
+	if v := l.Validation; v != nil {
+		result.Validation = &console.LeaderboardValidation_Rules{
+			MaxScoreDelta:       v.MaxScoreDelta,
+			MaxSubmissions:      int32(v.MaxSubmissions),
+			SubmissionWindowSec: int32(v.SubmissionWindowSec),
+		}
+		if v.MinScore != nil {
+			result.Validation.MinScore = &wrapperspb.Int64Value{Value: *v.MinScore}
+		}
+		if v.MaxScore != nil {
+			result.Validation.MaxScore = &wrapperspb.Int64Value{Value: *v.MaxScore}
+		}
+	}
+
+	return result, nil
+}
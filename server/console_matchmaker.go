@@ -0,0 +1,122 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/heroiclabs/nakama/v3/console"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const consoleMatchmakerTicketsLimitMax = 1000
+
+func (s *ConsoleServer) ListMatchmakerTickets(ctx context.Context, in *console.ListMatchmakerTicketsRequest) (*console.MatchmakerTicketList, error) {
+	limit := 100
+	if in.Limit != 0 {
+		if in.Limit > consoleMatchmakerTicketsLimitMax {
+			return nil, status.Error(codes.InvalidArgument, "Invalid limit - limit must be between 1 and 1000.")
+		}
+		limit = int(in.Limit)
+	}
+
+	tickets := s.matchmaker.Tickets(in.Query, limit)
+	ticketList := &console.MatchmakerTicketList{Tickets: make([]*console.MatchmakerTicket, 0, len(tickets))}
+	for _, ticket := range tickets {
+		ticketList.Tickets = append(ticketList.Tickets, &console.MatchmakerTicket{
+			Ticket:            ticket.Ticket,
+			Query:             ticket.Query,
+			SessionId:         ticket.SessionId,
+			PartyId:           ticket.PartyId,
+			Node:              ticket.Node,
+			Presences:         consoleMatchmakerPresences(ticket.Presences),
+			MinCount:          int32(ticket.MinCount),
+			MaxCount:          int32(ticket.MaxCount),
+			CountMultiple:     int32(ticket.CountMultiple),
+			StringProperties:  ticket.StringProperties,
+			NumericProperties: ticket.NumericProperties,
+			CreateTime:        timestamppb.New(time.Unix(ticket.CreateTime, 0)),
+			AgeSec:            ticket.AgeSec,
+			Intervals:         int32(ticket.Intervals),
+			Active:            ticket.Active,
+		})
+	}
+
+	return ticketList, nil
+}
+
+func (s *ConsoleServer) DeleteMatchmakerTicket(ctx context.Context, in *console.DeleteMatchmakerTicketRequest) (*emptypb.Empty, error) {
+	if in.Ticket == "" {
+		return nil, status.Error(codes.InvalidArgument, "Requires a valid ticket ID.")
+	}
+
+	// Removal is replicated to other nodes if tickets are shared, the users are not notified.
+	s.matchmaker.Remove([]string{in.Ticket})
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ConsoleServer) GetMatchmakerStats(ctx context.Context, in *emptypb.Empty) (*console.MatchmakerStats, error) {
+	queries := s.matchmaker.QueryStats()
+	stats := &console.MatchmakerStats{Queries: make([]*console.MatchmakerQueryStats, 0, len(queries))}
+	for _, queryStats := range queries {
+		stats.Tickets += int32(queryStats.Tickets)
+		stats.ActiveTickets += int32(queryStats.ActiveTickets)
+		stats.Players += int32(queryStats.Players)
+		stats.Queries = append(stats.Queries, &console.MatchmakerQueryStats{
+			Query:         queryStats.Query,
+			Tickets:       int32(queryStats.Tickets),
+			ActiveTickets: int32(queryStats.ActiveTickets),
+			Players:       int32(queryStats.Players),
+			MaxAgeSec:     queryStats.MaxAgeSec,
+			AvgAgeSec:     queryStats.AvgAgeSec,
+		})
+	}
+
+	return stats, nil
+}
+
+func (s *ConsoleServer) ListMatchmakerMatches(ctx context.Context, in *emptypb.Empty) (*console.MatchmakerMatchList, error) {
+	matches := s.matchmaker.RecentMatches()
+	matchList := &console.MatchmakerMatchList{Matches: make([]*console.MatchmakerMatch, 0, len(matches))}
+	for _, match := range matches {
+		matchList.Matches = append(matchList.Matches, &console.MatchmakerMatch{
+			MatchId:    match.MatchId,
+			Backfill:   match.Backfill,
+			Tickets:    match.Tickets,
+			Presences:  consoleMatchmakerPresences(match.Presences),
+			CreateTime: timestamppb.New(time.Unix(match.CreateTime, 0)),
+		})
+	}
+
+	return matchList, nil
+}
+
+func consoleMatchmakerPresences(presences []*MatchmakerPresence) []*console.MatchmakerPresence {
+	consolePresences := make([]*console.MatchmakerPresence, 0, len(presences))
+	for _, presence := range presences {
+		consolePresences = append(consolePresences, &console.MatchmakerPresence{
+			UserId:    presence.UserId,
+			SessionId: presence.SessionId,
+			Username:  presence.Username,
+			Node:      presence.Node,
+		})
+	}
+	return consolePresences
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"github.com/heroiclabs/nakama-common/rtapi"
 	"github.com/heroiclabs/nakama-common/runtime"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -46,13 +47,21 @@ type channelMessageListCursor struct {
 	StreamSubject    string
 	StreamSubcontext string
 	StreamLabel      string
+	ThreadId         string
 	CreateTime       int64
 	Id               string
 	Forward          bool
 	IsNext           bool
 }
 
-func ChannelMessagesList(ctx context.Context, logger *zap.Logger, db *sql.DB, caller uuid.UUID, stream PresenceStream, channelID string, limit int, forward bool, cursor string) (*api.ChannelMessageList, error) {
+// ChannelMessagesList lists the persisted messages of a channel, or only the messages of a thread in it, starting with
+// the message that started the thread, if a thread ID is given.
+func ChannelMessagesList(ctx context.Context, logger *zap.Logger, db *sql.DB, caller uuid.UUID, stream PresenceStream, channelID string, threadID uuid.UUID, limit int, forward bool, cursor string) (*api.ChannelMessageList, error) {
+	var threadIDStr string
+	if threadID != uuid.Nil {
+		threadIDStr = threadID.String()
+	}
+
 	var incomingCursor *channelMessageListCursor
 	if cursor != "" {
 		cb, err := base64.StdEncoding.DecodeString(cursor)
@@ -79,6 +88,9 @@ func ChannelMessagesList(ctx context.Context, logger *zap.Logger, db *sql.DB, ca
 		} else if stream.Label != incomingCursor.StreamLabel {
 			// Stream label does not match.
 			return nil, runtime.ErrChannelCursorInvalid
+		} else if threadIDStr != incomingCursor.ThreadId {
+			// Thread does not match.
+			return nil, runtime.ErrChannelCursorInvalid
 		}
 	}
 
@@ -93,8 +105,17 @@ func ChannelMessagesList(ctx context.Context, logger *zap.Logger, db *sql.DB, ca
 		}
 	}
 
+	params := []interface{}{stream.Mode, stream.Subject, stream.Subcontext, stream.Label, limit + 1}
+	if incomingCursor != nil {
+		params = append(params, time.Unix(incomingCursor.CreateTime, 0).UTC(), incomingCursor.Id)
+	}
+
 	query := `SELECT id, code, sender_id, username, content, create_time, update_time FROM message
 WHERE stream_mode = $1 AND stream_subject = $2::UUID AND stream_descriptor = $3::UUID AND stream_label = $4`
+	if threadID != uuid.Nil {
+		params = append(params, threadID)
+		query += " AND (thread_id = $" + strconv.Itoa(len(params)) + "::UUID OR id = $" + strconv.Itoa(len(params)) + "::UUID)"
+	}
 	if incomingCursor == nil {
 		if forward {
 			query += " ORDER BY create_time ASC, id ASC"
@@ -111,10 +132,6 @@ WHERE stream_mode = $1 AND stream_subject = $2::UUID AND stream_descriptor = $3:
 		}
 	}
 	query += " LIMIT $5"
-	params := []interface{}{stream.Mode, stream.Subject, stream.Subcontext, stream.Label, limit + 1}
-	if incomingCursor != nil {
-		params = append(params, time.Unix(incomingCursor.CreateTime, 0).UTC(), incomingCursor.Id)
-	}
 
 	rows, err := db.QueryContext(ctx, query, params...)
 	if err != nil {
@@ -142,6 +159,7 @@ WHERE stream_mode = $1 AND stream_subject = $2::UUID AND stream_descriptor = $3:
 				StreamSubject:    stream.Subject.String(),
 				StreamSubcontext: stream.Subcontext.String(),
 				StreamLabel:      stream.Label,
+				ThreadId:         threadIDStr,
 				CreateTime:       dbCreateTime.Time.Unix(),
 				Id:               dbID,
 				Forward:          forward,
@@ -187,6 +205,7 @@ WHERE stream_mode = $1 AND stream_subject = $2::UUID AND stream_descriptor = $3:
 				StreamSubject:    stream.Subject.String(),
 				StreamSubcontext: stream.Subcontext.String(),
 				StreamLabel:      stream.Label,
+				ThreadId:         threadIDStr,
 				CreateTime:       dbCreateTime.Time.Unix(),
 				Id:               dbID,
 				Forward:          forward,
@@ -219,6 +238,7 @@ WHERE stream_mode = $1 AND stream_subject = $2::UUID AND stream_descriptor = $3:
 			StreamSubject:    stream.Subject.String(),
 			StreamSubcontext: stream.Subcontext.String(),
 			StreamLabel:      stream.Label,
+			ThreadId:         threadIDStr,
 			CreateTime:       messages[l-1].CreateTime.Seconds,
 			Id:               messages[l-1].MessageId,
 			Forward:          true,
@@ -50,8 +50,12 @@ type leaderboardRecordListCursor struct {
 	ExpiryTime    int64
 	Score         int64
 	Subscore      int64
-	OwnerId       string
-	Rank          int64
+	// Only used by sorts that break ties by time, in microseconds.
+	UpdateTime int64
+	OwnerId    string
+	Rank       int64
+	// Scope the listing was ranked in, if any.
+	Scope string
 }
 
 var (
@@ -115,7 +119,7 @@ func LeaderboardList(logger *zap.Logger, leaderboardCache LeaderboardCache, cate
 	return leaderboardList, nil
 }
 
-func LeaderboardRecordsList(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, leaderboardId string, limit *wrapperspb.Int32Value, cursor string, ownerIds []string, overrideExpiry int64) (*api.LeaderboardRecordList, error) {
+func LeaderboardRecordsList(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, leaderboardId string, limit *wrapperspb.Int32Value, cursor string, ownerIds []string, scope *LeaderboardScope, overrideExpiry int64) (*api.LeaderboardRecordList, error) {
 	leaderboard := leaderboardCache.Get(leaderboardId)
 	if leaderboard == nil {
 		return nil, ErrLeaderboardNotFound
@@ -133,33 +137,27 @@ func LeaderboardRecordsList(ctx context.Context, logger *zap.Logger, db *sql.DB,
 
 	if limit != nil {
 		limitNumber := int(limit.Value)
-		incomingCursor, err := unmarshalLeaderboardRecordsListCursor(leaderboardId, expiryTime, cursor)
+		incomingCursor, err := unmarshalLeaderboardRecordsListCursor(leaderboardId, expiryTime, scope.String(), cursor)
 		if err != nil {
 			return nil, err
 		}
 
 		query := "SELECT owner_id, username, score, subscore, num_score, max_num_score, metadata, create_time, update_time FROM leaderboard_record WHERE leaderboard_id = $1 AND expiry_time = $2"
-		if incomingCursor == nil {
-			if leaderboard.SortOrder == LeaderboardSortOrderAscending {
-				query += " ORDER BY score ASC, subscore ASC, owner_id ASC"
-			} else {
-				query += " ORDER BY score DESC, subscore DESC, owner_id DESC"
-			}
-		} else {
-			if (leaderboard.SortOrder == LeaderboardSortOrderAscending && incomingCursor.IsNext) || (leaderboard.SortOrder == LeaderboardSortOrderDescending && !incomingCursor.IsNext) {
-				// Ascending and next page == descending and previous page.
-				query += " AND (leaderboard_id, expiry_time, score, subscore, owner_id) > ($1, $2, $4, $5, $6) ORDER BY score ASC, subscore ASC, owner_id ASC"
-			} else {
-				// Ascending and previous page == descending and next page.
-				query += " AND (leaderboard_id, expiry_time, score, subscore, owner_id) < ($1, $2, $4, $5, $6) ORDER BY score DESC, subscore DESC, owner_id DESC"
-			}
-		}
-		query += " LIMIT $3"
-		params := make([]interface{}, 0, 6)
+		params := make([]interface{}, 0, 8)
 		params = append(params, leaderboardId, time.Unix(expiryTime, 0).UTC(), limitNumber+1)
+		if scope != nil {
+			// Records are only listed and ranked among owners in the scope.
+			scopeFilter, scopeParam := scope.filter(len(params) + 1)
+			query += scopeFilter
+			params = append(params, scopeParam)
+		}
+		// Previous pages are listed from worst to best, and flipped to their normal order below.
+		sort := leaderboard.Sort()
+		reverse := incomingCursor != nil && !incomingCursor.IsNext
 		if incomingCursor != nil {
-			params = append(params, incomingCursor.Score, incomingCursor.Subscore, incomingCursor.OwnerId)
+			query, params = sort.after(query, params, reverse, incomingCursor.Score, incomingCursor.Subscore, incomingCursor.UpdateTime, incomingCursor.OwnerId)
 		}
+		query += sort.orderBy(reverse) + " LIMIT $3"
 
 		rows, err := db.QueryContext(ctx, query, params...)
 		if err != nil {
@@ -191,8 +189,10 @@ func LeaderboardRecordsList(ctx context.Context, logger *zap.Logger, db *sql.DB,
 					ExpiryTime:    expiryTime,
 					Score:         dbScore,
 					Subscore:      dbSubscore,
+					UpdateTime:    dbUpdateTime.Time.UnixMicro(),
 					OwnerId:       dbOwnerID,
 					Rank:          rank,
+					Scope:         scope.String(),
 				}
 			}
 
@@ -238,8 +238,10 @@ func LeaderboardRecordsList(ctx context.Context, logger *zap.Logger, db *sql.DB,
 					ExpiryTime:    expiryTime,
 					Score:         dbScore,
 					Subscore:      dbSubscore,
+					UpdateTime:    dbUpdateTime.Time.UnixMicro(),
 					OwnerId:       dbOwnerID,
 					Rank:          rank,
+					Scope:         scope.String(),
 				}
 			}
 		}
@@ -357,7 +359,7 @@ func marshalLeaderboardRecordsListCursor(cursor *leaderboardRecordListCursor) (s
 	return base64.URLEncoding.EncodeToString(cursorBuf.Bytes()), nil
 }
 
-func unmarshalLeaderboardRecordsListCursor(leaderboardId string, expiryTime int64, cursor string) (*leaderboardRecordListCursor, error) {
+func unmarshalLeaderboardRecordsListCursor(leaderboardId string, expiryTime int64, scope string, cursor string) (*leaderboardRecordListCursor, error) {
 	var incomingCursor *leaderboardRecordListCursor
 	if cursor != "" {
 		cb, err := base64.URLEncoding.DecodeString(cursor)
@@ -375,13 +377,16 @@ func unmarshalLeaderboardRecordsListCursor(leaderboardId string, expiryTime int6
 		} else if expiryTime != incomingCursor.ExpiryTime {
 			// Leaderboard expiry has rolled over since this cursor was generated.
 			return nil, ErrLeaderboardInvalidCursor
+		} else if scope != incomingCursor.Scope {
+			// Cursor ranks are for a different scope.
+			return nil, ErrLeaderboardInvalidCursor
 		}
 	}
 
 	return incomingCursor, nil
 }
 
-func LeaderboardRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, caller uuid.UUID, leaderboardId, ownerID, username string, score, subscore int64, metadata string, overrideOperator api.Operator) (*api.LeaderboardRecord, error) {
+func LeaderboardRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, metrics Metrics, caller uuid.UUID, leaderboardId, ownerID, username string, score, subscore int64, metadata string, overrideOperator api.Operator) (*api.LeaderboardRecord, error) {
 	leaderboard := leaderboardCache.Get(leaderboardId)
 	if leaderboard == nil {
 		return nil, ErrLeaderboardNotFound
@@ -443,15 +448,9 @@ func LeaderboardRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB,
 	case LeaderboardOperatorBest:
 		fallthrough
 	default:
-		if leaderboard.SortOrder == LeaderboardSortOrderAscending {
-			// Lower score is better.
-			opSQL = "score = LEAST(leaderboard_record.score, $4), subscore = LEAST(leaderboard_record.subscore, $5)"
-			filterSQL = " WHERE leaderboard_record.score > $4 OR leaderboard_record.subscore > $5"
-		} else {
-			// Higher score is better.
-			opSQL = "score = GREATEST(leaderboard_record.score, $4), subscore = GREATEST(leaderboard_record.subscore, $5)"
-			filterSQL = " WHERE leaderboard_record.score < $4 OR leaderboard_record.subscore < $5"
-		}
+		var filter string
+		opSQL, filter = leaderboard.Sort().best("$4", "$5")
+		filterSQL = " WHERE " + filter
 		scoreDelta = score
 		subscoreDelta = subscore
 		scoreAbs = score
@@ -481,6 +480,23 @@ func LeaderboardRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB,
 		params = append(params, scoreDelta, subscoreDelta)
 	}
 
+	// Validation rules may limit the owner's previous score or the change to it, so it must be read before it is
+	// overwritten.
+	validate := caller != uuid.Nil && leaderboard.Validation != nil
+	var previousScore *int64
+	if validate && leaderboard.Validation.needsPreviousScore() {
+		var err error
+		if previousScore, err = leaderboardRecordScore(ctx, db, leaderboardId, ownerID, time.Unix(expiryTime, 0).UTC()); err != nil {
+			logger.Error("Error reading leaderboard record before writing", zap.Error(err))
+			return nil, err
+		}
+	}
+	if validate {
+		if err := leaderboardValidateWrite(ctx, logger, db, metrics, leaderboard, ownerID, operator, score, previousScore, time.Now().UTC()); err != nil {
+			return nil, err
+		}
+	}
+
 	// Track if the database record actually updates or not.
 	var unchanged bool
 
@@ -493,20 +509,51 @@ func LeaderboardRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB,
 	var dbCreateTime pgtype.Timestamptz
 	var dbUpdateTime pgtype.Timestamptz
 
-	if err := db.QueryRowContext(ctx, query, params...).Scan(&dbUsername, &dbScore, &dbSubscore, &dbNumScore, &dbMaxNumScore, &dbMetadata, &dbCreateTime, &dbUpdateTime); err != nil {
+	// If no rows are returned by the write then both of these criteria must have been met:
+	// 1. There was already a record for this leaderboard, user, and expiry time.
+	// 2. This new update did not meet the criteria to be stored, so no update
+	//    occurred. For example the new entry was not better in a "best" leaderboard.
+	// In this case the user's record is unchanged, and we can just read it as is.
+	unchangedQuery := "SELECT username, score, subscore, num_score, max_num_score, metadata, create_time, update_time FROM leaderboard_record WHERE leaderboard_id = $1 AND owner_id = $2 AND expiry_time = $3"
+
+	approximate := rankCache.Approximate(leaderboardId)
+	var approximatePreviousScore *int64
+	if approximate {
+		// Approximate ranks replace the owner's previous score, read in the same transaction as the write.
+		if err := leaderboardApproximateWrite(ctx, db, func(tx *sql.Tx) error {
+			var err error
+			if approximatePreviousScore, err = leaderboardRecordScoreForUpdate(ctx, tx, leaderboardId, ownerID, time.Unix(expiryTime, 0).UTC()); err != nil {
+				return err
+			}
+			unchanged = false
+			if err = tx.QueryRowContext(ctx, query, params...).Scan(&dbUsername, &dbScore, &dbSubscore, &dbNumScore, &dbMaxNumScore, &dbMetadata, &dbCreateTime, &dbUpdateTime); err != nil {
+				var pgErr *pgconn.PgError
+				if approximatePreviousScore == nil && (err == sql.ErrNoRows || (errors.As(err, &pgErr) && pgErr.Code == dbErrorUniqueViolation)) {
+					return errLeaderboardRecordRaced
+				}
+				if err != sql.ErrNoRows {
+					return err
+				}
+				unchanged = true
+				return tx.QueryRowContext(ctx, unchangedQuery, leaderboardId, ownerID, time.Unix(expiryTime, 0).UTC()).Scan(&dbUsername, &dbScore, &dbSubscore, &dbNumScore, &dbMaxNumScore, &dbMetadata, &dbCreateTime, &dbUpdateTime)
+			}
+			if approximatePreviousScore == nil && dbNumScore > 1 {
+				// The write updated a record created after the read.
+				return errLeaderboardRecordRaced
+			}
+			return nil
+		}); err != nil {
+			logger.Error("Error writing leaderboard record", zap.Error(err))
+			return nil, err
+		}
+	} else if err := db.QueryRowContext(ctx, query, params...).Scan(&dbUsername, &dbScore, &dbSubscore, &dbNumScore, &dbMaxNumScore, &dbMetadata, &dbCreateTime, &dbUpdateTime); err != nil {
 		var pgErr *pgconn.PgError
 		if err != sql.ErrNoRows && !(errors.As(err, &pgErr) && pgErr.Code == dbErrorUniqueViolation && strings.Contains(pgErr.Message, "leaderboard_record_pkey")) {
 			logger.Error("Error writing leaderboard record", zap.Error(err))
 			return nil, err
 		}
 
-		// If no rows were returned then both of these criteria must have been met:
-		// 1. There was already a record for this leaderboard, user, and expiry time.
-		// 2. This new update did not meet the criteria to be stored, so no update
-		//    occurred. For example the new entry was not better in a "best" leaderboard.
-		// In this case the user's record is unchanged, and we can just read it as is.
-		query = "SELECT username, score, subscore, num_score, max_num_score, metadata, create_time, update_time FROM leaderboard_record WHERE leaderboard_id = $1 AND owner_id = $2 AND expiry_time = $3"
-		err = db.QueryRowContext(ctx, query, leaderboardId, ownerID, time.Unix(expiryTime, 0).UTC()).Scan(&dbUsername, &dbScore, &dbSubscore, &dbNumScore, &dbMaxNumScore, &dbMetadata, &dbCreateTime, &dbUpdateTime)
+		err = db.QueryRowContext(ctx, unchangedQuery, leaderboardId, ownerID, time.Unix(expiryTime, 0).UTC()).Scan(&dbUsername, &dbScore, &dbSubscore, &dbNumScore, &dbMaxNumScore, &dbMetadata, &dbCreateTime, &dbUpdateTime)
 		if err != nil {
 			logger.Error("Error after writing leaderboard record", zap.Error(err))
 			return nil, err
@@ -515,11 +562,14 @@ func LeaderboardRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB,
 	}
 
 	var rank int64
-	if unchanged {
+	if approximate {
+		// An unchanged record replaces its previous score with the same score.
+		rank = rankCache.UpdateApproximate(leaderboardId, expiryTime, leaderboard.Sort(), approximatePreviousScore, &dbScore)
+	} else if unchanged {
 		rank = rankCache.Get(leaderboardId, expiryTime, uuid.Must(uuid.FromString(ownerID)))
 	} else {
 		// Ensure we have the latest dbscore, dbsubscore if there was an update.
-		rank = rankCache.Insert(leaderboardId, expiryTime, leaderboard.SortOrder, uuid.Must(uuid.FromString(ownerID)), dbScore, dbSubscore)
+		rank = rankCache.Insert(leaderboardId, expiryTime, leaderboard.Sort(), uuid.Must(uuid.FromString(ownerID)), dbScore, dbSubscore, dbUpdateTime.Time.UnixMicro())
 	}
 
 	record := &api.LeaderboardRecord{
@@ -559,13 +609,17 @@ func LeaderboardRecordDelete(ctx context.Context, logger *zap.Logger, db *sql.DB
 		expiryTime = leaderboard.ResetSchedule.Next(time.Now().UTC()).UTC().Unix()
 	}
 
-	query := "DELETE FROM leaderboard_record WHERE leaderboard_id = $1 AND owner_id = $2 AND expiry_time = $3"
-	_, err := db.ExecContext(ctx, query, leaderboardId, ownerID, time.Unix(expiryTime, 0).UTC())
-	if err != nil {
+	query := "DELETE FROM leaderboard_record WHERE leaderboard_id = $1 AND owner_id = $2 AND expiry_time = $3 RETURNING score"
+	var score int64
+	err := db.QueryRowContext(ctx, query, leaderboardId, ownerID, time.Unix(expiryTime, 0).UTC()).Scan(&score)
+	if err != nil && err != sql.ErrNoRows {
 		logger.Error("Error deleting leaderboard record", zap.Error(err))
 		return err
 	}
 
+	if err == nil && rankCache.Approximate(leaderboardId) {
+		rankCache.UpdateApproximate(leaderboardId, expiryTime, leaderboard.Sort(), &score, nil)
+	}
 	rankCache.Delete(leaderboardId, expiryTime, uuid.Must(uuid.FromString(ownerID)))
 	return nil
 }
@@ -579,7 +633,7 @@ func LeaderboardRecordReadAll(ctx context.Context, logger *zap.Logger, db *sql.D
 	}
 	// rows.Close() called in parseLeaderboardRecords
 
-	return parseLeaderboardRecords(logger, rows)
+	return parseLeaderboardRecords(logger, rows, nil)
 }
 
 func LeaderboardRecordsDeleteAll(ctx context.Context, logger *zap.Logger, tx *sql.Tx, userID uuid.UUID) error {
@@ -604,7 +658,7 @@ func LeaderboardRecordsHaystack(ctx context.Context, logger *zap.Logger, db *sql
 		return &api.LeaderboardRecordList{Records: []*api.LeaderboardRecord{}}, nil
 	}
 
-	return getLeaderboardRecordsHaystack(ctx, logger, db, leaderboardCache, rankCache, ownerID, limit, leaderboard.Id, cursor, leaderboard.SortOrder, time.Unix(expiryTime, 0).UTC())
+	return getLeaderboardRecordsHaystack(ctx, logger, db, leaderboardCache, rankCache, ownerID, limit, leaderboard.Id, cursor, leaderboard.Sort(), time.Unix(expiryTime, 0).UTC())
 }
 
 func LeaderboardsGet(leaderboardCache LeaderboardCache, leaderboardIDs []string) []*api.Leaderboard {
@@ -662,7 +716,7 @@ func calculatePrevReset(currentTime time.Time, startTime int64, resetSchedule *c
 	return prevReset.Unix()
 }
 
-func getLeaderboardRecordsHaystack(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, ownerID uuid.UUID, limit int, leaderboardId, cursor string, sortOrder int, expiryTime time.Time) (*api.LeaderboardRecordList, error) {
+func getLeaderboardRecordsHaystack(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, ownerID uuid.UUID, limit int, leaderboardId, cursor string, sort LeaderboardSort, expiryTime time.Time) (*api.LeaderboardRecordList, error) {
 	if cursor == "" {
 		var dbLeaderboardID string
 		var dbOwnerID string
@@ -712,7 +766,7 @@ func getLeaderboardRecordsHaystack(ctx context.Context, logger *zap.Logger, db *
 		}
 
 		if limit == 1 {
-			ownerRecord.Rank = rankCache.Get(leaderboardId, expiryTime.Unix(), ownerID)
+			rankCache.Fill(leaderboardId, expiryTime.Unix(), []*api.LeaderboardRecord{ownerRecord})
 			return &api.LeaderboardRecordList{Records: []*api.LeaderboardRecord{ownerRecord}}, nil
 		}
 
@@ -721,18 +775,15 @@ func getLeaderboardRecordsHaystack(ctx context.Context, logger *zap.Logger, db *
 	WHERE leaderboard_id = $1
 	AND expiry_time = $2`
 
+		// Update times of listed records, for cursors of sorts that break ties by time.
+		updateTimes := map[string]int64{ownerRecord.OwnerId: dbUpdateTime.Time.UnixMicro()}
+
 		// First half.
-		params := []interface{}{leaderboardId, expiryTime, ownerRecord.Score, ownerRecord.Subscore, ownerID}
-		firstQuery := query
-		if sortOrder == LeaderboardSortOrderAscending {
-			// Lower score is better, but get in reverse order from current user to get those immediately above.
-			firstQuery += " AND (score, subscore, owner_id) < ($3, $4, $5) ORDER BY score DESC, subscore DESC, owner_id DESC"
-		} else {
-			// Higher score is better.
-			firstQuery += " AND (score, subscore, owner_id) > ($3, $4, $5) ORDER BY score ASC, subscore ASC, owner_id ASC"
-		}
-		firstParams := append(params, limit+1)
-		firstQuery += " LIMIT $6"
+		params := []interface{}{leaderboardId, expiryTime}
+		// Get in reverse order from current user to get those immediately above.
+		firstQuery, firstParams := sort.after(query, params, true, ownerRecord.Score, ownerRecord.Subscore, updateTimes[ownerRecord.OwnerId], ownerRecord.OwnerId)
+		firstParams = append(firstParams, limit+1)
+		firstQuery += sort.orderBy(true) + " LIMIT $" + strconv.Itoa(len(firstParams))
 
 		firstRows, err := db.QueryContext(ctx, firstQuery, firstParams...)
 		if err != nil {
@@ -741,7 +792,7 @@ func getLeaderboardRecordsHaystack(ctx context.Context, logger *zap.Logger, db *
 		}
 		// firstRows.Close() called in parseLeaderboardRecords
 
-		firstRecords, err := parseLeaderboardRecords(logger, firstRows)
+		firstRecords, err := parseLeaderboardRecords(logger, firstRows, updateTimes)
 		if err != nil {
 			return nil, err
 		}
@@ -758,20 +809,13 @@ func getLeaderboardRecordsHaystack(ctx context.Context, logger *zap.Logger, db *
 			firstRecords[left], firstRecords[right] = firstRecords[right], firstRecords[left]
 		}
 
-		secondQuery := query
-		if sortOrder == LeaderboardSortOrderAscending {
-			// Lower score is better.
-			secondQuery += " AND (score, subscore, owner_id) > ($3, $4, $5) ORDER BY score ASC, subscore ASC, owner_id ASC"
-		} else {
-			// Higher score is better.
-			secondQuery += " AND (score, subscore, owner_id) < ($3, $4, $5) ORDER BY score DESC, subscore DESC, owner_id DESC"
-		}
+		secondQuery, secondParams := sort.after(query, params, false, ownerRecord.Score, ownerRecord.Subscore, updateTimes[ownerRecord.OwnerId], ownerRecord.OwnerId)
 		secondLimit := limit / 2
 		if l := len(firstRecords); l < secondLimit {
 			secondLimit = limit - l
 		}
-		secondParams := append(params, secondLimit+1)
-		secondQuery += " LIMIT $6"
+		secondParams = append(secondParams, secondLimit+1)
+		secondQuery += sort.orderBy(false) + " LIMIT $" + strconv.Itoa(len(secondParams))
 
 		secondRows, err := db.QueryContext(ctx, secondQuery, secondParams...)
 		if err != nil {
@@ -780,7 +824,7 @@ func getLeaderboardRecordsHaystack(ctx context.Context, logger *zap.Logger, db *
 		}
 		// secondRows.Close() called in parseLeaderboardRecords
 
-		secondRecords, err := parseLeaderboardRecords(logger, secondRows)
+		secondRecords, err := parseLeaderboardRecords(logger, secondRows, updateTimes)
 		if err != nil {
 			return nil, err
 		}
@@ -818,6 +862,7 @@ func getLeaderboardRecordsHaystack(ctx context.Context, logger *zap.Logger, db *
 				ExpiryTime:    expiryTime.Unix(),
 				Score:         firstRecord.Score,
 				Subscore:      firstRecord.Subscore,
+				UpdateTime:    updateTimes[firstRecord.OwnerId],
 				OwnerId:       firstRecord.OwnerId,
 				Rank:          firstRecord.Rank,
 			}
@@ -838,6 +883,7 @@ func getLeaderboardRecordsHaystack(ctx context.Context, logger *zap.Logger, db *
 				ExpiryTime:    expiryTime.Unix(),
 				Score:         lastRecord.Score,
 				Subscore:      lastRecord.Subscore,
+				UpdateTime:    updateTimes[lastRecord.OwnerId],
 				OwnerId:       lastRecord.OwnerId,
 				Rank:          lastRecord.Rank,
 			}
@@ -851,11 +897,12 @@ func getLeaderboardRecordsHaystack(ctx context.Context, logger *zap.Logger, db *
 		return &api.LeaderboardRecordList{Records: records, PrevCursor: prevCursorStr, NextCursor: nextCursorStr}, nil
 	} else {
 		// If a cursor is passed, then this becomes a regular record listing operation.
-		return LeaderboardRecordsList(ctx, logger, db, leaderboardCache, rankCache, leaderboardId, wrapperspb.Int32(int32(limit)), cursor, nil, expiryTime.Unix())
+		return LeaderboardRecordsList(ctx, logger, db, leaderboardCache, rankCache, leaderboardId, wrapperspb.Int32(int32(limit)), cursor, nil, nil, expiryTime.Unix())
 	}
 }
 
-func parseLeaderboardRecords(logger *zap.Logger, rows *sql.Rows) ([]*api.LeaderboardRecord, error) {
+// Full precision update times are also collected into updateTimes if set, by owner ID.
+func parseLeaderboardRecords(logger *zap.Logger, rows *sql.Rows, updateTimes map[string]int64) ([]*api.LeaderboardRecord, error) {
 	defer rows.Close()
 	records := make([]*api.LeaderboardRecord, 0, 10)
 
@@ -894,6 +941,9 @@ func parseLeaderboardRecords(logger *zap.Logger, rows *sql.Rows) ([]*api.Leaderb
 		if expiryTime != 0 {
 			record.ExpiryTime = &timestamppb.Timestamp{Seconds: expiryTime}
 		}
+		if updateTimes != nil {
+			updateTimes[dbOwnerID] = dbUpdateTime.Time.UnixMicro()
+		}
 
 		records = append(records, record)
 	}
@@ -22,6 +22,10 @@ var errMessageNotFound = errors.New("Could not find message to update in channel
 var errMessagePersist = errors.New("Error persisting channel message")
 
 func ChannelMessageSend(ctx context.Context, logger *zap.Logger, db *sql.DB, router MessageRouter, channelStream PresenceStream, channelId, content, senderId, senderUsername string, persist bool) (*rtapi.ChannelMessageAck, error) {
+	return channelMessageSend(ctx, logger, db, router, channelStream, channelId, content, senderId, senderUsername, persist, nil)
+}
+
+func channelMessageSend(ctx context.Context, logger *zap.Logger, db *sql.DB, router MessageRouter, channelStream PresenceStream, channelId, content, senderId, senderUsername string, persist bool, thread *channelMessageThread) (*rtapi.ChannelMessageAck, error) {
 	if maybeJSON := []byte(content); !json.Valid(maybeJSON) || bytes.TrimSpace(maybeJSON)[0] != byteBracket {
 		return nil, errInvalidMessageContent
 	}
@@ -59,9 +63,14 @@ func ChannelMessageSend(ctx context.Context, logger *zap.Logger, db *sql.DB, rou
 	}
 
 	if persist {
-		query := `INSERT INTO message (id, code, sender_id, username, stream_mode, stream_subject, stream_descriptor, stream_label, content, create_time, update_time)
-VALUES ($1, $2, $3, $4, $5, $6::UUID, $7::UUID, $8, $9, $10, $10)`
-		_, err := db.ExecContext(ctx, query, message.MessageId, message.Code.Value, message.SenderId, message.Username, channelStream.Mode, channelStream.Subject, channelStream.Subcontext, channelStream.Label, message.Content, time.Unix(message.CreateTime.Seconds, 0).UTC())
+		// Replies reference the message they reply to, and the thread they are part of.
+		var replyToId, threadId interface{}
+		if thread != nil {
+			replyToId, threadId = thread.ReplyToID, thread.ThreadID
+		}
+		query := `INSERT INTO message (id, code, sender_id, username, stream_mode, stream_subject, stream_descriptor, stream_label, content, create_time, update_time, reply_to_id, thread_id)
+VALUES ($1, $2, $3, $4, $5, $6::UUID, $7::UUID, $8, $9, $10, $10, $11::UUID, $12::UUID)`
+		_, err := db.ExecContext(ctx, query, message.MessageId, message.Code.Value, message.SenderId, message.Username, channelStream.Mode, channelStream.Subject, channelStream.Subcontext, channelStream.Label, message.Content, time.Unix(message.CreateTime.Seconds, 0).UTC(), replyToId, threadId)
 		if err != nil {
 			logger.Error("Error persisting channel message", zap.Error(err))
 
@@ -23,12 +23,13 @@ import (
 	"go.uber.org/zap"
 )
 
-func MultiUpdate(ctx context.Context, logger *zap.Logger, db *sql.DB, metrics Metrics, accountUpdates []*accountUpdate, storageWrites StorageOpWrites, walletUpdates []*walletUpdate, updateLedger bool) ([]*api.StorageObjectAck, []*runtime.WalletUpdateResult, error) {
+func MultiUpdate(ctx context.Context, logger *zap.Logger, db *sql.DB, metrics Metrics, storageIndex StorageIndex, storageFeed StorageFeed, accountUpdates []*accountUpdate, storageWrites StorageOpWrites, walletUpdates []*walletUpdate, updateLedger bool) ([]*api.StorageObjectAck, []*runtime.WalletUpdateResult, error) {
 	if len(accountUpdates) == 0 && len(storageWrites) == 0 && len(walletUpdates) == 0 {
 		return nil, nil, nil
 	}
 
 	var storageWriteAcks []*api.StorageObjectAck
+	var storageWriteValues []string
 	var walletUpdateResults []*runtime.WalletUpdateResult
 
 	tx, err := db.BeginTx(ctx, nil)
@@ -39,6 +40,7 @@ func MultiUpdate(ctx context.Context, logger *zap.Logger, db *sql.DB, metrics Me
 
 	if err = ExecuteInTx(ctx, tx, func() error {
 		storageWriteAcks = nil
+		storageWriteValues = nil
 		walletUpdateResults = nil
 
 		// Execute any account updates.
@@ -48,7 +50,7 @@ func MultiUpdate(ctx context.Context, logger *zap.Logger, db *sql.DB, metrics Me
 		}
 
 		// Execute any storage updates.
-		storageWriteAcks, updateErr = storageWriteObjects(ctx, logger, metrics, tx, true, storageWrites)
+		storageWriteAcks, storageWriteValues, updateErr = storageWriteObjects(ctx, logger, metrics, tx, true, storageWrites)
 		if updateErr != nil {
 			return updateErr
 		}
@@ -68,5 +70,9 @@ func MultiUpdate(ctx context.Context, logger *zap.Logger, db *sql.DB, metrics Me
 		return nil, walletUpdateResults, err
 	}
 
+	storageObjects, storageExpireTimes := storageIndexObjects(storageWrites, storageWriteAcks, storageWriteValues)
+	storageIndex.Write(ctx, storageObjects, storageExpireTimes)
+	storageFeed.Write(ctx, storageObjects)
+
 	return storageWriteAcks, walletUpdateResults, nil
 }
@@ -288,6 +288,16 @@ func NotificationDelete(ctx context.Context, logger *zap.Logger, db *sql.DB, use
 }
 
 func NotificationSave(ctx context.Context, logger *zap.Logger, db *sql.DB, notifications map[uuid.UUID][]*api.Notification) error {
+	query, params := notificationSaveQuery(notifications)
+	if _, err := db.ExecContext(ctx, query, params...); err != nil {
+		logger.Error("Could not save notifications.", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func notificationSaveQuery(notifications map[uuid.UUID][]*api.Notification) (string, []interface{}) {
 	statements := make([]string, 0, len(notifications))
 	params := make([]interface{}, 0, len(notifications))
 	counter := 0
@@ -313,11 +323,5 @@ func NotificationSave(ctx context.Context, logger *zap.Logger, db *sql.DB, notif
 	}
 
 	query := "INSERT INTO notification (id, user_id, subject, content, code, sender_id) VALUES " + strings.Join(statements, ", ")
-
-	if _, err := db.ExecContext(ctx, query, params...); err != nil {
-		logger.Error("Could not save notifications.", zap.Error(err))
-		return err
-	}
-
-	return nil
+	return query, params
 }
@@ -0,0 +1,359 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+)
+
+// Glicko-2 system constants. Ratings are stored on the Glicko scale, and converted to the Glicko-2 scale for updates.
+const (
+	RatingDefault           = 1500.0
+	RatingDeviationDefault  = 350.0
+	RatingVolatilityDefault = 0.06
+
+	// Constrains the change in volatility over time, smaller values prevent large rating swings after upsets.
+	ratingTau             = 0.5
+	ratingScale           = 173.7178
+	ratingConvergence     = 0.000001
+	ratingQueueMaxLength  = 128
+	ratingMatchMaxUsers   = 256
+	ratingPropertyName    = "rating"
+	ratingPropertyDevName = "rating_deviation"
+)
+
+var (
+	ErrRatingQueueInvalid = errors.New("rating queue invalid")
+	ErrRatingMatchInvalid = errors.New("rating match result invalid")
+)
+
+// Rating is a user's skill rating in a rating queue, using the Glicko-2 system. Users start at a rating of 1500 with a
+// deviation of 350, and the deviation shrinks as they play more matches.
+type Rating struct {
+	UserID     string  `json:"user_id"`
+	Queue      string  `json:"queue"`
+	Rating     float64 `json:"rating"`
+	Deviation  float64 `json:"deviation"`
+	Volatility float64 `json:"volatility"`
+	// Number of rated matches played in the queue.
+	Games      int   `json:"games"`
+	UpdateTime int64 `json:"update_time"`
+}
+
+// RatingMatchTeam is one team in a match result. Teams with a lower placement beat teams with a higher placement,
+// teams with an equal placement tie. Free-for-all matches use a team per user.
+type RatingMatchTeam struct {
+	UserIDs   []string `json:"user_ids"`
+	Placement int      `json:"placement"`
+}
+
+type ratingOpponent struct {
+	rating    float64
+	deviation float64
+	// 1 for a win against the opponent, 0.5 for a tie, and 0 for a loss.
+	score float64
+}
+
+// RatingsGet returns the ratings of the given users in a queue, in the same order. Users that have not played a rated
+// match in the queue have the default rating.
+func RatingsGet(ctx context.Context, logger *zap.Logger, db *sql.DB, queue string, userIDs []uuid.UUID) ([]*Rating, error) {
+	if queue == "" || len(queue) > ratingQueueMaxLength {
+		return nil, ErrRatingQueueInvalid
+	}
+
+	ratings, err := ratingsRead(ctx, db, queue, userIDs, false)
+	if err != nil {
+		logger.Error("Error reading ratings", zap.Error(err), zap.String("queue", queue))
+		return nil, err
+	}
+
+	result := make([]*Rating, 0, len(userIDs))
+	for _, userID := range userIDs {
+		result = append(result, ratings[userID])
+	}
+	return result, nil
+}
+
+// RatingsSubmitMatch updates the ratings of all users in a match result, and returns their new ratings in the order
+// the users appear in the teams. Each user is rated against the average rating of each other team.
+func RatingsSubmitMatch(ctx context.Context, logger *zap.Logger, db *sql.DB, queue string, teams []*RatingMatchTeam) ([]*Rating, error) {
+	if queue == "" || len(queue) > ratingQueueMaxLength {
+		return nil, ErrRatingQueueInvalid
+	}
+	if len(teams) < 2 {
+		return nil, ErrRatingMatchInvalid
+	}
+	userIDs := make([]uuid.UUID, 0, len(teams))
+	seen := make(map[uuid.UUID]struct{}, len(teams))
+	for _, team := range teams {
+		if team == nil || len(team.UserIDs) == 0 {
+			return nil, ErrRatingMatchInvalid
+		}
+		for _, id := range team.UserIDs {
+			userID, err := uuid.FromString(id)
+			if err != nil {
+				return nil, ErrRatingMatchInvalid
+			}
+			if _, found := seen[userID]; found {
+				// Users may only play for one team.
+				return nil, ErrRatingMatchInvalid
+			}
+			seen[userID] = struct{}{}
+			userIDs = append(userIDs, userID)
+		}
+	}
+	if len(userIDs) > ratingMatchMaxUsers {
+		return nil, ErrRatingMatchInvalid
+	}
+
+	var result []*Rating
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("Could not begin database transaction.", zap.Error(err))
+		return nil, err
+	}
+	if err = ExecuteInTx(ctx, tx, func() error {
+		ratings, err := ratingsRead(ctx, tx, queue, userIDs, true)
+		if err != nil {
+			return err
+		}
+
+		// Rate everyone against the ratings from before the match.
+		updated := ratingsMatchUpdate(teams, userIDs, ratings)
+
+		statements := make([]string, 0, len(updated))
+		params := []interface{}{queue}
+		for _, rating := range updated {
+			i := len(params)
+			statements = append(statements, "($"+strconv.Itoa(i+1)+"::UUID, $1, $"+strconv.Itoa(i+2)+", $"+strconv.Itoa(i+3)+", $"+strconv.Itoa(i+4)+", $"+strconv.Itoa(i+5)+")")
+			params = append(params, rating.UserID, rating.Rating, rating.Deviation, rating.Volatility, rating.Games)
+		}
+		query := `INSERT INTO user_rating (user_id, queue, rating, deviation, volatility, games) VALUES ` + strings.Join(statements, ", ") + `
+ON CONFLICT (user_id, queue) DO UPDATE SET rating = EXCLUDED.rating, deviation = EXCLUDED.deviation, volatility = EXCLUDED.volatility, games = EXCLUDED.games, update_time = now()`
+		if _, err := tx.ExecContext(ctx, query, params...); err != nil {
+			return err
+		}
+
+		result = updated
+		return nil
+	}); err != nil {
+		logger.Error("Error submitting rated match", zap.Error(err), zap.String("queue", queue))
+		return nil, err
+	}
+
+	now := time.Now().UTC().Unix()
+	for _, rating := range result {
+		rating.UpdateTime = now
+	}
+	return result, nil
+}
+
+type ratingsQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Read the ratings of users in a queue, with the default rating for users that have none.
+func ratingsRead(ctx context.Context, q ratingsQuerier, queue string, userIDs []uuid.UUID, forUpdate bool) (map[uuid.UUID]*Rating, error) {
+	ratings := make(map[uuid.UUID]*Rating, len(userIDs))
+	if len(userIDs) == 0 {
+		return ratings, nil
+	}
+
+	statements := make([]string, 0, len(userIDs))
+	params := []interface{}{queue}
+	for _, userID := range userIDs {
+		params = append(params, userID)
+		statements = append(statements, "$"+strconv.Itoa(len(params)))
+	}
+	query := "SELECT user_id, rating, deviation, volatility, games, update_time FROM user_rating WHERE queue = $1 AND user_id IN (" + strings.Join(statements, ", ") + ")"
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+	rows, err := q.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userID uuid.UUID
+		var updateTime time.Time
+		rating := &Rating{Queue: queue}
+		if err := rows.Scan(&userID, &rating.Rating, &rating.Deviation, &rating.Volatility, &rating.Games, &updateTime); err != nil {
+			return nil, err
+		}
+		rating.UserID = userID.String()
+		rating.UpdateTime = updateTime.Unix()
+		ratings[userID] = rating
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, userID := range userIDs {
+		if _, found := ratings[userID]; !found {
+			ratings[userID] = &Rating{
+				UserID:     userID.String(),
+				Queue:      queue,
+				Rating:     RatingDefault,
+				Deviation:  RatingDeviationDefault,
+				Volatility: RatingVolatilityDefault,
+			}
+		}
+	}
+	return ratings, nil
+}
+
+// Compute the new ratings of all users in a match result, given their ratings before the match and the users in the
+// order they appear in the teams.
+func ratingsMatchUpdate(teams []*RatingMatchTeam, userIDs []uuid.UUID, ratings map[uuid.UUID]*Rating) []*Rating {
+	// Each team plays as a single opponent with the mean rating of its users.
+	composites := make([]ratingOpponent, 0, len(teams))
+	i := 0
+	for _, team := range teams {
+		members := make([]*Rating, 0, len(team.UserIDs))
+		for range team.UserIDs {
+			members = append(members, ratings[userIDs[i]])
+			i++
+		}
+		rating, deviation := ratingComposite(members)
+		composites = append(composites, ratingOpponent{rating: rating, deviation: deviation})
+	}
+
+	updated := make([]*Rating, 0, len(userIDs))
+	i = 0
+	for t, team := range teams {
+		opponents := make([]ratingOpponent, 0, len(teams)-1)
+		for o, other := range teams {
+			if o == t {
+				continue
+			}
+			opponent := composites[o]
+			switch {
+			case team.Placement < other.Placement:
+				opponent.score = 1
+			case team.Placement == other.Placement:
+				opponent.score = 0.5
+			}
+			opponents = append(opponents, opponent)
+		}
+		for range team.UserIDs {
+			previous := ratings[userIDs[i]]
+			rating, deviation, volatility := ratingUpdate(previous.Rating, previous.Deviation, previous.Volatility, opponents)
+			updated = append(updated, &Rating{
+				UserID:     previous.UserID,
+				Queue:      previous.Queue,
+				Rating:     rating,
+				Deviation:  deviation,
+				Volatility: volatility,
+				Games:      previous.Games + 1,
+			})
+			i++
+		}
+	}
+	return updated
+}
+
+// The mean rating of a group of users, and the root mean square of their deviations.
+func ratingComposite(ratings []*Rating) (float64, float64) {
+	if len(ratings) == 0 {
+		return RatingDefault, RatingDeviationDefault
+	}
+	var rating, variance float64
+	for _, r := range ratings {
+		rating += r.Rating
+		variance += r.Deviation * r.Deviation
+	}
+	n := float64(len(ratings))
+	return rating / n, math.Sqrt(variance / n)
+}
+
+// Apply one Glicko-2 rating period with the given results to a rating, and return the new rating, deviation and
+// volatility.
+func ratingUpdate(rating, deviation, volatility float64, opponents []ratingOpponent) (float64, float64, float64) {
+	mu := (rating - RatingDefault) / ratingScale
+	phi := deviation / ratingScale
+
+	var vInv, deltaSum float64
+	for _, opponent := range opponents {
+		muJ := (opponent.rating - RatingDefault) / ratingScale
+		g := ratingG(opponent.deviation / ratingScale)
+		e := 1 / (1 + math.Exp(-g*(mu-muJ)))
+		vInv += g * g * e * (1 - e)
+		deltaSum += g * (opponent.score - e)
+	}
+	v := 1 / vInv
+	delta := v * deltaSum
+
+	// Find the new volatility with the Illinois algorithm.
+	a := math.Log(volatility * volatility)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(ratingTau*ratingTau)
+	}
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*ratingTau) < 0 {
+			k++
+		}
+		B = a - k*ratingTau
+	}
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > ratingConvergence {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+	newVolatility := math.Exp(A / 2)
+
+	phiStar := math.Sqrt(phi*phi + newVolatility*newVolatility)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	newMu := mu + newPhi*newPhi*deltaSum
+
+	return newMu*ratingScale + RatingDefault, math.Min(newPhi*ratingScale, RatingDeviationDefault), newVolatility
+}
+
+func ratingG(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// The matchmaker properties of a ticket with the given ratings, which is the composite rating for party tickets.
+func ratingMatchmakerProperties(ratings []*Rating, numericProperties map[string]float64) map[string]float64 {
+	properties := make(map[string]float64, len(numericProperties)+2)
+	for k, v := range numericProperties {
+		properties[k] = v
+	}
+	// Replace any values set by the client, which would otherwise let them pick their own rating.
+	properties[ratingPropertyName], properties[ratingPropertyDevName] = ratingComposite(ratings)
+	return properties
+}
@@ -0,0 +1,160 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatingUpdate(t *testing.T) {
+	// The worked example from the Glicko-2 paper.
+	rating, deviation, volatility := ratingUpdate(1500, 200, 0.06, []ratingOpponent{
+		{rating: 1400, deviation: 30, score: 1},
+		{rating: 1550, deviation: 100, score: 0},
+		{rating: 1700, deviation: 300, score: 0},
+	})
+	assert.InDelta(t, 1464.06, rating, 0.01)
+	assert.InDelta(t, 151.52, deviation, 0.01)
+	assert.InDelta(t, 0.05999, volatility, 0.00001)
+}
+
+func TestRatingsMatchUpdate(t *testing.T) {
+	userIDs := []uuid.UUID{uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())}
+	ratings := make(map[uuid.UUID]*Rating, len(userIDs))
+	for _, userID := range userIDs {
+		ratings[userID] = &Rating{UserID: userID.String(), Queue: "ranked", Rating: RatingDefault, Deviation: RatingDeviationDefault, Volatility: RatingVolatilityDefault, Games: 2}
+	}
+	teams := []*RatingMatchTeam{
+		{UserIDs: []string{userIDs[0].String(), userIDs[1].String()}, Placement: 2},
+		{UserIDs: []string{userIDs[2].String(), userIDs[3].String()}, Placement: 1},
+	}
+
+	updated := ratingsMatchUpdate(teams, userIDs, ratings)
+	if assert.Len(t, updated, 4) {
+		for i, rating := range updated {
+			// Results are in team order.
+			assert.Equal(t, userIDs[i].String(), rating.UserID)
+			assert.Equal(t, 3, rating.Games)
+			assert.Less(t, rating.Deviation, RatingDeviationDefault)
+		}
+		// The second team placed first.
+		assert.Less(t, updated[0].Rating, RatingDefault)
+		assert.Greater(t, updated[2].Rating, RatingDefault)
+		assert.InDelta(t, RatingDefault-updated[0].Rating, updated[2].Rating-RatingDefault, 0.0001)
+	}
+
+	// Equally rated teams that tie keep their rating.
+	teams[0].Placement = 1
+	updated = ratingsMatchUpdate(teams, userIDs, ratings)
+	for _, rating := range updated {
+		assert.InDelta(t, RatingDefault, rating.Rating, 0.0001)
+	}
+}
+
+func TestRatingMatchmakerProperties(t *testing.T) {
+	numericProperties := map[string]float64{"level": 10, "rating": 3000}
+	properties := ratingMatchmakerProperties([]*Rating{
+		{Rating: 1400, Deviation: 50},
+		{Rating: 1600, Deviation: 150},
+	}, numericProperties)
+
+	// Party tickets use the mean rating, and the root mean square deviation.
+	assert.Equal(t, map[string]float64{"level": 10, "rating": 1500, "rating_deviation": 111.80339887498948}, properties)
+	// Properties set by the client are not modified.
+	assert.Equal(t, 3000.0, numericProperties["rating"])
+}
+
+func TestRatingsSubmitMatchPersisted(t *testing.T) {
+	db := NewDB(t)
+	winner, loser := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	InsertUser(t, db, winner)
+	InsertUser(t, db, loser)
+	queue := GenerateString()
+	teams := []*RatingMatchTeam{
+		{UserIDs: []string{winner.String()}, Placement: 1},
+		{UserIDs: []string{loser.String()}, Placement: 2},
+	}
+
+	updated, err := RatingsSubmitMatch(context.Background(), logger, db, queue, teams)
+	if err != nil {
+		t.Fatalf("error submitting match: %v", err)
+	}
+	ratings, err := RatingsGet(context.Background(), logger, db, queue, []uuid.UUID{winner, loser})
+	if err != nil {
+		t.Fatalf("error getting ratings: %v", err)
+	}
+	for i, rating := range ratings {
+		assert.Equal(t, 1, rating.Games)
+		assert.InDelta(t, updated[i].Rating, rating.Rating, 0.0001)
+		assert.InDelta(t, updated[i].Deviation, rating.Deviation, 0.0001)
+	}
+	assert.Greater(t, ratings[0].Rating, RatingDefault)
+	assert.Less(t, ratings[1].Rating, RatingDefault)
+
+	// The next match starts from the stored ratings.
+	updated, err = RatingsSubmitMatch(context.Background(), logger, db, queue, teams)
+	if err != nil {
+		t.Fatalf("error submitting match: %v", err)
+	}
+	assert.Equal(t, 2, updated[0].Games)
+	assert.Greater(t, updated[0].Rating, ratings[0].Rating)
+
+	// Ratings are kept separately for each queue.
+	ratings, err = RatingsGet(context.Background(), logger, db, GenerateString(), []uuid.UUID{winner})
+	if err != nil {
+		t.Fatalf("error getting ratings: %v", err)
+	}
+	assert.Equal(t, 0, ratings[0].Games)
+	assert.Equal(t, RatingDefault, ratings[0].Rating)
+}
+
+func TestRatingsSubmitMatchConcurrent(t *testing.T) {
+	db := NewDB(t)
+	a, b := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	InsertUser(t, db, a)
+	InsertUser(t, db, b)
+	queue := GenerateString()
+
+	// Concurrent results for the same users are applied one after another, none of them are lost.
+	const matches = 5
+	var wg sync.WaitGroup
+	errs := make([]error, matches)
+	for i := 0; i < matches; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = RatingsSubmitMatch(context.Background(), logger, db, queue, []*RatingMatchTeam{
+				{UserIDs: []string{a.String()}, Placement: 1},
+				{UserIDs: []string{b.String()}, Placement: 2},
+			})
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+
+	ratings, err := RatingsGet(context.Background(), logger, db, queue, []uuid.UUID{a, b})
+	if err != nil {
+		t.Fatalf("error getting ratings: %v", err)
+	}
+	assert.Equal(t, matches, ratings[0].Games)
+	assert.Equal(t, matches, ratings[1].Games)
+}
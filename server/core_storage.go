@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"github.com/heroiclabs/nakama-common/runtime"
 	"sort"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/heroiclabs/nakama-common/api"
@@ -47,6 +48,10 @@ type StorageOpWrites []*StorageOpWrite
 type StorageOpWrite struct {
 	OwnerID string
 	Object  *api.WriteStorageObject
+	// Unix time in seconds after which the object is no longer readable and is removed, or 0 to never expire.
+	ExpireTime int64
+	// How the object value is applied to the stored value, by default it replaces it.
+	Patch StoragePatch
 }
 
 func (s StorageOpWrites) Len() int {
@@ -160,14 +165,14 @@ func StorageListObjectsAll(ctx context.Context, logger *zap.Logger, db *sql.DB,
 		query = `
 SELECT collection, key, user_id, value, version, read, write, create_time, update_time
 FROM storage
-WHERE collection = $1` + cursorQuery + `
+WHERE collection = $1 AND (expire_time IS NULL OR expire_time > now())` + cursorQuery + `
 ORDER BY read ASC, key ASC, user_id ASC
 LIMIT $2`
 	} else {
 		query = `
 SELECT collection, key, user_id, value, version, read, write, create_time, update_time
 FROM storage
-WHERE collection = $1 AND read >= 2` + cursorQuery + `
+WHERE collection = $1 AND read >= 2 AND (expire_time IS NULL OR expire_time > now())` + cursorQuery + `
 ORDER BY read ASC, key ASC, user_id ASC
 LIMIT $2`
 	}
@@ -208,7 +213,7 @@ func StorageListObjectsPublicReadUser(ctx context.Context, logger *zap.Logger, d
 	query := `
 SELECT collection, key, user_id, value, version, read, write, create_time, update_time
 FROM storage
-WHERE collection = $1 AND read = 2 AND user_id = $2 ` + cursorQuery + `
+WHERE collection = $1 AND read = 2 AND user_id = $2 AND (expire_time IS NULL OR expire_time > now()) ` + cursorQuery + `
 ORDER BY key ASC
 LIMIT $3`
 
@@ -248,7 +253,7 @@ func StorageListObjectsUser(ctx context.Context, logger *zap.Logger, db *sql.DB,
 	query := `
 SELECT collection, key, user_id, value, version, read, write, create_time, update_time
 FROM storage
-WHERE collection = $1 AND user_id = $2 AND read >= 1 ` + cursorQuery + `
+WHERE collection = $1 AND user_id = $2 AND read >= 1 AND (expire_time IS NULL OR expire_time > now()) ` + cursorQuery + `
 ORDER BY read ASC, key ASC
 LIMIT $3`
 	if authoritative {
@@ -256,7 +261,7 @@ LIMIT $3`
 		query = `
 SELECT collection, key, user_id, value, version, read, write, create_time, update_time
 FROM storage
-WHERE collection = $1 AND user_id = $2 AND read >= 0 ` + cursorQuery + `
+WHERE collection = $1 AND user_id = $2 AND read >= 0 AND (expire_time IS NULL OR expire_time > now()) ` + cursorQuery + `
 ORDER BY read ASC, key ASC
 LIMIT $3`
 	}
@@ -289,7 +294,7 @@ func StorageReadAllUserObjects(ctx context.Context, logger *zap.Logger, db *sql.
 	query := `
 SELECT collection, key, user_id, value, version, read, write, create_time, update_time
 FROM storage
-WHERE user_id = $1`
+WHERE user_id = $1 AND (expire_time IS NULL OR expire_time > now())`
 
 	var objects []*api.StorageObject
 	err := ExecuteRetryable(func() error {
@@ -415,8 +420,8 @@ func StorageReadObjects(ctx context.Context, logger *zap.Logger, db *sql.DB, cal
 	query := `
 SELECT collection, key, user_id, value, version, read, write, create_time, update_time
 FROM storage
-WHERE
-` + whereClause
+WHERE (expire_time IS NULL OR expire_time > now()) AND (
+` + whereClause + `)`
 
 	var objects *api.StorageObjects
 	err := ExecuteRetryable(func() error {
@@ -457,8 +462,9 @@ WHERE
 	return objects, err
 }
 
-func StorageWriteObjects(ctx context.Context, logger *zap.Logger, db *sql.DB, metrics Metrics, authoritativeWrite bool, ops StorageOpWrites) (*api.StorageObjectAcks, codes.Code, error) {
+func StorageWriteObjects(ctx context.Context, logger *zap.Logger, db *sql.DB, metrics Metrics, storageIndex StorageIndex, storageFeed StorageFeed, authoritativeWrite bool, ops StorageOpWrites) (*api.StorageObjectAcks, codes.Code, error) {
 	var acks []*api.StorageObjectAck
+	var values []string
 
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
@@ -469,7 +475,7 @@ func StorageWriteObjects(ctx context.Context, logger *zap.Logger, db *sql.DB, me
 	if err = ExecuteInTx(ctx, tx, func() error {
 		// If the transaction is retried ensure we wipe any acks that may have been prepared by previous attempts.
 		var writeErr error
-		acks, writeErr = storageWriteObjects(ctx, logger, metrics, tx, authoritativeWrite, ops)
+		acks, values, writeErr = storageWriteObjects(ctx, logger, metrics, tx, authoritativeWrite, ops)
 		if writeErr != nil {
 			return writeErr
 		}
@@ -482,10 +488,15 @@ func StorageWriteObjects(ctx context.Context, logger *zap.Logger, db *sql.DB, me
 		return nil, codes.Internal, err
 	}
 
+	objects, expireTimes := storageIndexObjects(ops, acks, values)
+	storageIndex.Write(ctx, objects, expireTimes)
+	storageFeed.Write(ctx, objects)
+
 	return &api.StorageObjectAcks{Acks: acks}, codes.OK, nil
 }
 
-func storageWriteObjects(ctx context.Context, logger *zap.Logger, metrics Metrics, tx *sql.Tx, authoritativeWrite bool, ops StorageOpWrites) ([]*api.StorageObjectAck, error) {
+// Returns the acks and the values written, which differ from the operation values for patches, in the operation order.
+func storageWriteObjects(ctx context.Context, logger *zap.Logger, metrics Metrics, tx *sql.Tx, authoritativeWrite bool, ops StorageOpWrites) ([]*api.StorageObjectAck, []string, error) {
 	// Ensure writes are processed in a consistent order to avoid deadlocks from concurrent operations.
 	// Sorting done on a copy to ensure we don't modify the input, which may be re-used on transaction retries.
 	sortedOps := make(StorageOpWrites, 0, len(ops))
@@ -498,38 +509,55 @@ func storageWriteObjects(ctx context.Context, logger *zap.Logger, metrics Metric
 
 	// Run operations in the sorted order.
 	acks := make([]*api.StorageObjectAck, ops.Len())
+	values := make([]string, ops.Len())
 	for _, op := range sortedOps {
-		ack, writeErr := storageWriteObject(ctx, logger, metrics, tx, authoritativeWrite, op.OwnerID, op.Object)
+		ack, value, writeErr := storageWriteObject(ctx, logger, metrics, tx, authoritativeWrite, op.OwnerID, op.Object, op.ExpireTime, op.Patch)
 		if writeErr != nil {
-			if writeErr == runtime.ErrStorageRejectedVersion || writeErr == runtime.ErrStorageRejectedPermission {
-				return nil, StatusError(codes.InvalidArgument, "Storage write rejected.", writeErr)
+			if writeErr == runtime.ErrStorageRejectedVersion || writeErr == runtime.ErrStorageRejectedPermission || errors.Is(writeErr, ErrStoragePatchRejected) {
+				return nil, nil, StatusError(codes.InvalidArgument, "Storage write rejected.", writeErr)
 			}
 
 			logger.Debug("Error writing storage objects.", zap.Error(writeErr))
-			return nil, writeErr
+			return nil, nil, writeErr
 		}
 		// Acks are returned in the original order.
 		acks[indexedOps[op]] = ack
+		values[indexedOps[op]] = value
 	}
-	return acks, nil
+	return acks, values, nil
 }
 
-func storageWriteObject(ctx context.Context, logger *zap.Logger, metrics Metrics, tx *sql.Tx, authoritativeWrite bool, ownerID string, object *api.WriteStorageObject) (*api.StorageObjectAck, error) {
+func storageWriteObject(ctx context.Context, logger *zap.Logger, metrics Metrics, tx *sql.Tx, authoritativeWrite bool, ownerID string, object *api.WriteStorageObject, expireTime int64, patch StoragePatch) (*api.StorageObjectAck, string, error) {
 	var dbVersion sql.NullString
 	var dbPermissionWrite sql.NullInt64
 	var dbPermissionRead sql.NullInt64
-	err := tx.QueryRowContext(ctx, "SELECT version, read, write FROM storage WHERE collection = $1 AND key = $2 AND user_id = $3", object.Collection, object.Key, ownerID).Scan(&dbVersion, &dbPermissionRead, &dbPermissionWrite)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			if object.Version != "" && object.Version != "*" {
-				// Conditional write with a specific version but the object did not exist at all.
-				metrics.StorageWriteRejectCount(map[string]string{"collection": object.Collection}, 1)
-				return nil, runtime.ErrStorageRejectedVersion
-			}
-		} else {
+	var dbExpireTime pgtype.Timestamptz
+	var dbExpired bool
+	var dbValue sql.NullString
+	preflightQuery := "SELECT version, read, write, expire_time, COALESCE(expire_time <= now(), false), NULL::TEXT FROM storage WHERE collection = $1 AND key = $2 AND user_id = $3"
+	if patch != StoragePatchNone {
+		// Patches are applied to the stored value, which must not change until the patched value is written.
+		preflightQuery = "SELECT version, read, write, expire_time, COALESCE(expire_time <= now(), false), value FROM storage WHERE collection = $1 AND key = $2 AND user_id = $3 FOR UPDATE"
+	}
+	err := tx.QueryRowContext(ctx, preflightQuery, object.Collection, object.Key, ownerID).Scan(&dbVersion, &dbPermissionRead, &dbPermissionWrite, &dbExpireTime, &dbExpired, &dbValue)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Debug("Error in write storage object pre-flight.", zap.Any("object", object), zap.Error(err))
+		return nil, "", err
+	}
+
+	if dbExpired {
+		// An expired object that has not been swept yet is replaced as if it did not exist.
+		if _, err = tx.ExecContext(ctx, "DELETE FROM storage WHERE collection = $1 AND key = $2 AND user_id = $3", object.Collection, object.Key, ownerID); err != nil {
 			logger.Debug("Error in write storage object pre-flight.", zap.Any("object", object), zap.Error(err))
-			return nil, err
+			return nil, "", err
 		}
+		dbVersion, dbPermissionRead, dbPermissionWrite, dbExpireTime, dbValue = sql.NullString{}, sql.NullInt64{}, sql.NullInt64{}, pgtype.Timestamptz{}, sql.NullString{}
+	}
+
+	if !dbVersion.Valid && object.Version != "" && object.Version != "*" {
+		// Conditional write with a specific version but the object did not exist at all.
+		metrics.StorageWriteRejectCount(map[string]string{"collection": object.Collection}, 1)
+		return nil, "", runtime.ErrStorageRejectedVersion
 	}
 
 	if dbVersion.Valid && (object.Version == "*" || (object.Version != "" && object.Version != dbVersion.String)) {
@@ -537,15 +565,29 @@ func storageWriteObject(ctx context.Context, logger *zap.Logger, metrics Metrics
 		// - Expects no object.
 		// - Or expects a given version, but it does not match.
 		metrics.StorageWriteRejectCount(map[string]string{"collection": object.Collection}, 1)
-		return nil, runtime.ErrStorageRejectedVersion
+		return nil, "", runtime.ErrStorageRejectedVersion
 	}
 
 	if dbPermissionWrite.Valid && dbPermissionWrite.Int64 == 0 && !authoritativeWrite {
 		// Non-authoritative write to an existing storage object with permission 0.
-		return nil, runtime.ErrStorageRejectedPermission
+		return nil, "", runtime.ErrStorageRejectedPermission
 	}
 
-	newVersion := fmt.Sprintf("%x", md5.Sum([]byte(object.Value)))
+	newValue := object.Value
+	if patch != StoragePatchNone {
+		// Objects that do not exist yet are patched as empty objects.
+		current := "{}"
+		if dbValue.Valid {
+			current = dbValue.String
+		}
+		if newValue, err = storagePatchValue(patch, current, object.Value); err != nil {
+			logger.Debug("Could not apply storage object patch.", zap.Any("object", object), zap.Error(err))
+			metrics.StorageWriteRejectCount(map[string]string{"collection": object.Collection}, 1)
+			return nil, "", err
+		}
+	}
+
+	newVersion := fmt.Sprintf("%x", md5.Sum([]byte(newValue)))
 	newPermissionRead := int32(1)
 	if object.PermissionRead != nil {
 		newPermissionRead = object.PermissionRead.Value
@@ -554,9 +596,14 @@ func storageWriteObject(ctx context.Context, logger *zap.Logger, metrics Metrics
 	if object.PermissionWrite != nil {
 		newPermissionWrite = object.PermissionWrite.Value
 	}
+	var newExpireTime interface{}
+	if expireTime != 0 {
+		newExpireTime = time.Unix(expireTime, 0).UTC()
+	}
+	sameExpireTime := (dbExpireTime.Status != pgtype.Present && expireTime == 0) || (dbExpireTime.Status == pgtype.Present && dbExpireTime.Time.Unix() == expireTime)
 
-	if dbVersion.Valid && dbVersion.String == newVersion && dbPermissionRead.Int64 == int64(newPermissionRead) && dbPermissionWrite.Int64 == int64(newPermissionWrite) {
-		// Stored object existed, and exactly matches the new object's version and read/write permissions.
+	if dbVersion.Valid && dbVersion.String == newVersion && dbPermissionRead.Int64 == int64(newPermissionRead) && dbPermissionWrite.Int64 == int64(newPermissionWrite) && sameExpireTime {
+		// Stored object existed, and exactly matches the new object's version, read/write permissions and expiry.
 		ack := &api.StorageObjectAck{
 			Collection: object.Collection,
 			Key:        object.Key,
@@ -565,15 +612,15 @@ func storageWriteObject(ctx context.Context, logger *zap.Logger, metrics Metrics
 		if ownerID != uuid.Nil.String() {
 			ack.UserId = ownerID
 		}
-		return ack, nil
+		return ack, newValue, nil
 	}
 
-	params := []interface{}{object.Collection, object.Key, ownerID, object.Value, newVersion, newPermissionRead, newPermissionWrite}
+	params := []interface{}{object.Collection, object.Key, ownerID, newValue, newVersion, newPermissionRead, newPermissionWrite, newExpireTime}
 	var query string
 	switch {
 	case object.Version != "" && object.Version != "*":
 		// OCC if-match.
-		query = "UPDATE storage SET value = $4, version = $5, read = $6, write = $7, update_time = now() WHERE collection = $1 AND key = $2 AND user_id = $3::UUID AND version = $8"
+		query = "UPDATE storage SET value = $4, version = $5, read = $6, write = $7, expire_time = $8, update_time = now() WHERE collection = $1 AND key = $2 AND user_id = $3::UUID AND version = $9"
 		params = append(params, object.Version)
 		// Respect permissions in non-authoritative writes.
 		if !authoritativeWrite {
@@ -581,22 +628,23 @@ func storageWriteObject(ctx context.Context, logger *zap.Logger, metrics Metrics
 		}
 	case dbVersion.Valid && object.Version == "":
 		// An existing storage object was present, but no OCC of any kind is specified.
-		query = "UPDATE storage SET value = $4, version = $5, read = $6, write = $7, update_time = now() WHERE collection = $1 AND key = $2 AND user_id = $3::UUID"
+		query = "UPDATE storage SET value = $4, version = $5, read = $6, write = $7, expire_time = $8, update_time = now() WHERE collection = $1 AND key = $2 AND user_id = $3::UUID"
 		// Respect permissions in non-authoritative writes.
 		if !authoritativeWrite {
 			query += " AND write = 1"
 		}
-	case !dbVersion.Valid && object.Version == "":
+	case !dbVersion.Valid && object.Version == "" && patch == StoragePatchNone:
 		// An existing storage object was not present, and no OCC of any kind is specified.
 		// Separate to the case above to handle concurrent non-OCC object creations, where all but the first must become updates.
-		query = "INSERT INTO storage (collection, key, user_id, value, version, read, write, create_time, update_time) VALUES ($1, $2, $3::UUID, $4, $5, $6, $7, now(), now()) ON CONFLICT (collection, read, key, user_id) DO UPDATE SET value = $4, version = $5, read = $6, write = $7, update_time = now()"
+		// Patches of objects that did not exist are not applied over concurrent creations, they are inserted below.
+		query = "INSERT INTO storage (collection, key, user_id, value, version, read, write, expire_time, create_time, update_time) VALUES ($1, $2, $3::UUID, $4, $5, $6, $7, $8, now(), now()) ON CONFLICT (collection, read, key, user_id) DO UPDATE SET value = $4, version = $5, read = $6, write = $7, expire_time = $8, update_time = now()"
 		// Respect permissions in non-authoritative writes, where this operation also loses the race to insert the object.
 		if !authoritativeWrite {
 			query += " WHERE storage.write = 1"
 		}
 	case dbVersion.Valid && object.Version != "*":
 		// An existing storage object was present, but no OCC if-not-exists required.
-		query = "UPDATE storage SET value = $4, version = $5, read = $6, write = $7, update_time = now() WHERE collection = $1 AND key = $2 AND user_id = $3::UUID AND version = $8"
+		query = "UPDATE storage SET value = $4, version = $5, read = $6, write = $7, expire_time = $8, update_time = now() WHERE collection = $1 AND key = $2 AND user_id = $3::UUID AND version = $9"
 		params = append(params, dbVersion.String)
 		// Respect permissions in non-authoritative writes.
 		if !authoritativeWrite {
@@ -604,7 +652,7 @@ func storageWriteObject(ctx context.Context, logger *zap.Logger, metrics Metrics
 		}
 	default:
 		// OCC if-not-exists, and all other non-OCC cases.
-		query = "INSERT INTO storage (collection, key, user_id, value, version, read, write, create_time, update_time) VALUES ($1, $2, $3::UUID, $4, $5, $6, $7, now(), now())"
+		query = "INSERT INTO storage (collection, key, user_id, value, version, read, write, expire_time, create_time, update_time) VALUES ($1, $2, $3::UUID, $4, $5, $6, $7, $8, now(), now())"
 		// Existing permission checks are not applicable for new storage objects.
 	}
 
@@ -614,14 +662,14 @@ func storageWriteObject(ctx context.Context, logger *zap.Logger, metrics Metrics
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == dbErrorUniqueViolation {
 			metrics.StorageWriteRejectCount(map[string]string{"collection": object.Collection}, 1)
-			return nil, runtime.ErrStorageRejectedVersion
+			return nil, "", runtime.ErrStorageRejectedVersion
 		}
-		return nil, err
+		return nil, "", err
 	}
 	if rowsAffected, err := res.RowsAffected(); rowsAffected != 1 {
 		logger.Debug("Could not write storage object, rowsAffected error.", zap.Any("object", object), zap.String("query", query), zap.Error(err))
 		metrics.StorageWriteRejectCount(map[string]string{"collection": object.Collection}, 1)
-		return nil, runtime.ErrStorageRejectedVersion
+		return nil, "", runtime.ErrStorageRejectedVersion
 	}
 
 	ack := &api.StorageObjectAck{
@@ -631,10 +679,10 @@ func storageWriteObject(ctx context.Context, logger *zap.Logger, metrics Metrics
 		UserId:     ownerID,
 	}
 
-	return ack, nil
+	return ack, newValue, nil
 }
 
-func StorageDeleteObjects(ctx context.Context, logger *zap.Logger, db *sql.DB, authoritativeDelete bool, ops StorageOpDeletes) (codes.Code, error) {
+func StorageDeleteObjects(ctx context.Context, logger *zap.Logger, db *sql.DB, storageIndex StorageIndex, storageFeed StorageFeed, authoritativeDelete bool, ops StorageOpDeletes) (codes.Code, error) {
 	// Ensure deletes are processed in a consistent order.
 	sort.Sort(ops)
 
@@ -644,7 +692,10 @@ func StorageDeleteObjects(ctx context.Context, logger *zap.Logger, db *sql.DB, a
 		return codes.Internal, err
 	}
 
+	var deleted []*api.StorageObject
 	if err = ExecuteInTx(ctx, tx, func() error {
+		// If the transaction is retried ensure we wipe any objects that may have been deleted by previous attempts.
+		deleted = make([]*api.StorageObject, 0, len(ops))
 		for _, op := range ops {
 			params := []interface{}{op.ObjectID.Collection, op.ObjectID.Key, op.OwnerID}
 			var query string
@@ -660,16 +711,23 @@ func StorageDeleteObjects(ctx context.Context, logger *zap.Logger, db *sql.DB, a
 				params = append(params, op.ObjectID.Version)
 				query += fmt.Sprintf(" AND version = $4")
 			}
-
-			result, err := tx.ExecContext(ctx, query, params...)
-			if err != nil {
+			// Read permission of the deleted object decides who is notified of the deletion.
+			query += " RETURNING read"
+
+			var permissionRead int32
+			if err := tx.QueryRowContext(ctx, query, params...).Scan(&permissionRead); err != nil {
+				if err == sql.ErrNoRows {
+					return StatusError(codes.InvalidArgument, "Storage delete rejected.", errors.New("Storage delete rejected - not found, version check failed, or permission denied."))
+				}
 				logger.Debug("Could not delete storage object.", zap.Error(err), zap.String("query", query), zap.Any("object_id", op.ObjectID))
 				return err
 			}
-
-			if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
-				return StatusError(codes.InvalidArgument, "Storage delete rejected.", errors.New("Storage delete rejected - not found, version check failed, or permission denied."))
-			}
+			deleted = append(deleted, &api.StorageObject{
+				Collection:     op.ObjectID.Collection,
+				Key:            op.ObjectID.Key,
+				UserId:         op.OwnerID,
+				PermissionRead: permissionRead,
+			})
 		}
 		return nil
 	}); err != nil {
@@ -680,5 +738,51 @@ func StorageDeleteObjects(ctx context.Context, logger *zap.Logger, db *sql.DB, a
 		return codes.Internal, err
 	}
 
+	storageIndex.Delete(ctx, ops)
+	storageFeed.Delete(ctx, deleted)
+
 	return codes.OK, nil
 }
+
+// StorageExpireObjects removes up to limit storage objects whose expiry time has passed, and returns them.
+func StorageExpireObjects(ctx context.Context, logger *zap.Logger, db *sql.DB, storageIndex StorageIndex, storageFeed StorageFeed, limit int) ([]*api.StorageObject, error) {
+	query := `
+DELETE FROM storage
+WHERE (collection, read, key, user_id) IN (
+	SELECT collection, read, key, user_id FROM storage WHERE expire_time <= now() LIMIT $1
+)
+RETURNING collection, key, user_id, value, version, read, write, create_time, update_time`
+
+	rows, err := db.QueryContext(ctx, query, limit)
+	if err != nil {
+		logger.Error("Could not remove expired storage objects.", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	objects := make([]*api.StorageObject, 0, limit)
+	ops := make(StorageOpDeletes, 0, limit)
+	for rows.Next() {
+		o := &api.StorageObject{CreateTime: &timestamppb.Timestamp{}, UpdateTime: &timestamppb.Timestamp{}}
+		var createTime pgtype.Timestamptz
+		var updateTime pgtype.Timestamptz
+		if err := rows.Scan(&o.Collection, &o.Key, &o.UserId, &o.Value, &o.Version, &o.PermissionRead, &o.PermissionWrite, &createTime, &updateTime); err != nil {
+			logger.Error("Could not remove expired storage objects.", zap.Error(err))
+			return nil, err
+		}
+		o.CreateTime.Seconds = createTime.Time.Unix()
+		o.UpdateTime.Seconds = updateTime.Time.Unix()
+
+		objects = append(objects, o)
+		ops = append(ops, &StorageOpDelete{OwnerID: o.UserId, ObjectID: &api.DeleteStorageObjectId{Collection: o.Collection, Key: o.Key}})
+	}
+	if err = rows.Err(); err != nil {
+		logger.Error("Could not remove expired storage objects.", zap.Error(err))
+		return nil, err
+	}
+
+	storageIndex.Delete(ctx, ops)
+	storageFeed.Delete(ctx, objects)
+
+	return objects, nil
+}
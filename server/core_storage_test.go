@@ -19,6 +19,7 @@ import (
 	"crypto/md5"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/heroiclabs/nakama-common/api"
@@ -42,7 +43,7 @@ func TestStorageWriteRuntimeGlobalSingle(t *testing.T) {
 			PermissionWrite: &wrapperspb.Int32Value{Value: 1},
 		},
 	}}
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -113,7 +114,7 @@ func TestStorageWriteRuntimeUserMultiple(t *testing.T) {
 			},
 		},
 	}
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -128,6 +129,105 @@ func TestStorageWriteRuntimeUserMultiple(t *testing.T) {
 	}
 }
 
+func TestStorageWriteRuntimeGlobalSingleExpiry(t *testing.T) {
+	db := NewDB(t)
+	defer db.Close()
+
+	key := GenerateString()
+	ops := StorageOpWrites{
+		&StorageOpWrite{
+			OwnerID: uuid.Nil.String(),
+			Object: &api.WriteStorageObject{
+				Collection:      "testcollection",
+				Key:             key,
+				Value:           "{\"foo\":\"bar\"}",
+				PermissionRead:  &wrapperspb.Int32Value{Value: 2},
+				PermissionWrite: &wrapperspb.Int32Value{Value: 1},
+			},
+			ExpireTime: time.Now().Add(-time.Minute).Unix(),
+		},
+	}
+	_, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
+	assert.Nil(t, err, "err was not nil")
+	assert.Equal(t, codes.OK, code, "code was not OK")
+
+	// Expired objects are not readable, even before they are removed.
+	ids := []*api.ReadStorageObjectId{{Collection: "testcollection", Key: key}}
+	readData, err := StorageReadObjects(context.Background(), logger, db, uuid.Nil, ids)
+	assert.Nil(t, err, "err was not nil")
+	assert.Len(t, readData.Objects, 0, "readData length was not 0")
+
+	// An expired object does not prevent a write expecting no object.
+	ops[0].Object.Version = "*"
+	ops[0].ExpireTime = time.Now().Add(time.Hour).Unix()
+	_, code, err = StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
+	assert.Nil(t, err, "err was not nil")
+	assert.Equal(t, codes.OK, code, "code was not OK")
+	readData, err = StorageReadObjects(context.Background(), logger, db, uuid.Nil, ids)
+	assert.Nil(t, err, "err was not nil")
+	assert.Len(t, readData.Objects, 1, "readData length was not 1")
+
+	// Only objects whose expiry time passed are removed.
+	expiredKey := GenerateString()
+	ops[0].Object.Key = expiredKey
+	ops[0].ExpireTime = time.Now().Add(-time.Minute).Unix()
+	_, _, err = StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
+	assert.Nil(t, err, "err was not nil")
+	expired, err := StorageExpireObjects(context.Background(), logger, db, storageIdx, storageFeed, 10000)
+	assert.Nil(t, err, "err was not nil")
+	expiredKeys := make([]string, 0, len(expired))
+	for _, o := range expired {
+		expiredKeys = append(expiredKeys, o.Key)
+	}
+	assert.Contains(t, expiredKeys, expiredKey, "expired object was not removed")
+	assert.NotContains(t, expiredKeys, key, "object was removed before expiry")
+}
+
+func TestStorageWriteRuntimeGlobalSinglePatch(t *testing.T) {
+	db := NewDB(t)
+	defer db.Close()
+
+	key := GenerateString()
+	ops := StorageOpWrites{
+		&StorageOpWrite{
+			OwnerID: uuid.Nil.String(),
+			Object: &api.WriteStorageObject{
+				Collection:      "testcollection",
+				Key:             key,
+				Value:           "{\"foo\":\"bar\",\"list\":[1]}",
+				PermissionRead:  &wrapperspb.Int32Value{Value: 2},
+				PermissionWrite: &wrapperspb.Int32Value{Value: 1},
+			},
+			Patch: StoragePatchMerge,
+		},
+	}
+	_, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
+	assert.Nil(t, err, "err was not nil")
+	assert.Equal(t, codes.OK, code, "code was not OK")
+
+	ops[0].Object.Value = "{\"foo\":null,\"baz\":1}"
+	_, _, err = StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
+	assert.Nil(t, err, "err was not nil")
+
+	ops[0].Object.Value = "[{\"op\":\"add\",\"path\":\"/list/-\",\"value\":2}]"
+	ops[0].Patch = StoragePatchJson
+	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
+	assert.Nil(t, err, "err was not nil")
+
+	ids := []*api.ReadStorageObjectId{{Collection: "testcollection", Key: key}}
+	readData, err := StorageReadObjects(context.Background(), logger, db, uuid.Nil, ids)
+	assert.Nil(t, err, "err was not nil")
+	assert.Len(t, readData.Objects, 1, "readData length was not 1")
+	assert.JSONEq(t, "{\"baz\":1,\"list\":[1,2]}", readData.Objects[0].Value, "value did not match")
+	assert.Equal(t, acks.Acks[0].Version, readData.Objects[0].Version, "version did not match")
+
+	// Patches that cannot be applied reject the write.
+	ops[0].Object.Value = "[{\"op\":\"remove\",\"path\":\"/foo\"}]"
+	_, code, err = StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
+	assert.NotNil(t, err, "err was nil")
+	assert.Equal(t, codes.InvalidArgument, code, "code was not InvalidArgument")
+}
+
 func TestStorageWriteRuntimeGlobalSingleIfMatchNotExists(t *testing.T) {
 	db := NewDB(t)
 	defer db.Close()
@@ -146,7 +246,7 @@ func TestStorageWriteRuntimeGlobalSingleIfMatchNotExists(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, acks, "acks was not nil")
 	assert.Equal(t, codes.InvalidArgument, code, "code did not match")
@@ -171,7 +271,7 @@ func TestStorageWriteRuntimeGlobalSingleIfMatchExists(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -196,7 +296,7 @@ func TestStorageWriteRuntimeGlobalSingleIfMatchExists(t *testing.T) {
 		},
 	}
 
-	acks, code, err = StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err = StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not 0")
@@ -225,7 +325,7 @@ func TestStorageWriteRuntimeGlobalSingleIfMatchExistsFail(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -250,7 +350,7 @@ func TestStorageWriteRuntimeGlobalSingleIfMatchExistsFail(t *testing.T) {
 		},
 	}
 
-	acks, code, err = StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err = StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, acks, "acks was not nil")
 	assert.Equal(t, codes.InvalidArgument, code, "code did not match")
@@ -276,7 +376,7 @@ func TestStorageWriteRuntimeGlobalSingleIfNoneMatchNotExists(t *testing.T) {
 		},
 	}
 
-	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.NotNil(t, acks, "acks was nil")
@@ -304,7 +404,7 @@ func TestStorageWriteRuntimeGlobalSingleIfNoneMatchExists(t *testing.T) {
 		},
 	}
 
-	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.NotNil(t, acks, "acks was nil")
@@ -328,7 +428,7 @@ func TestStorageWriteRuntimeGlobalSingleIfNoneMatchExists(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, acks, "acks was not nil")
 	assert.Equal(t, codes.InvalidArgument, code, "code did not match")
@@ -364,7 +464,7 @@ func TestStorageWriteRuntimeGlobalMultipleIfMatchNotExists(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, acks, "acks was not nil")
 	assert.Equal(t, codes.InvalidArgument, code, "code did not match")
@@ -392,7 +492,7 @@ func TestStorageWritePipelineUserSingle(t *testing.T) {
 		},
 	}
 
-	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.NotNil(t, acks, "acks was nil")
@@ -443,7 +543,7 @@ func TestStorageWritePipelineUserMultiple(t *testing.T) {
 		},
 	}
 
-	allAcks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	allAcks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -506,7 +606,7 @@ func TestStorageWriteRuntimeGlobalMultipleSameKey(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -575,7 +675,7 @@ func TestStorageWritePipelineUserMultipleSameKey(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not 0")
@@ -629,7 +729,7 @@ func TestStorageWritePipelineIfMatchNotExists(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, acks, "acks was not nil")
 	assert.Equal(t, codes.InvalidArgument, code, "code did not match")
@@ -657,7 +757,7 @@ func TestStorageWritePipelineIfMatchExistsFail(t *testing.T) {
 		},
 	}
 
-	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.NotNil(t, acks, "acks was nil")
@@ -681,7 +781,7 @@ func TestStorageWritePipelineIfMatchExistsFail(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, acks, "acks was not nil")
 	assert.Equal(t, codes.InvalidArgument, code, "code did not match")
@@ -710,7 +810,7 @@ func TestStorageWritePipelineIfMatchExists(t *testing.T) {
 		},
 	}
 
-	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.NotNil(t, acks, "acks was nil")
@@ -734,7 +834,7 @@ func TestStorageWritePipelineIfMatchExists(t *testing.T) {
 		},
 	}
 
-	acks, _, err = StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, _, err = StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.NotNil(t, acks, "acks was nil")
@@ -766,7 +866,7 @@ func TestStorageWritePipelineIfNoneMatchNotExists(t *testing.T) {
 		},
 	}
 
-	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.NotNil(t, acks, "acks was nil")
@@ -798,7 +898,7 @@ func TestStorageWritePipelineIfNoneMatchExists(t *testing.T) {
 		},
 	}
 
-	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.NotNil(t, acks, "acks was nil")
@@ -822,7 +922,7 @@ func TestStorageWritePipelineIfNoneMatchExists(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, acks, "acks was not nil")
 	assert.Equal(t, codes.InvalidArgument, code, "code did not match")
@@ -851,7 +951,7 @@ func TestStorageWritePipelinePermissionFail(t *testing.T) {
 		},
 	}
 
-	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, _, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.NotNil(t, acks, "acks was nil")
@@ -874,7 +974,7 @@ func TestStorageWritePipelinePermissionFail(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, acks, "acks was not nil")
 	assert.Equal(t, codes.InvalidArgument, code, "code did not match")
@@ -901,7 +1001,7 @@ func TestStorageFetchRuntimeGlobalPrivate(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -949,7 +1049,7 @@ func TestStorageFetchRuntimeMixed(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1004,7 +1104,7 @@ func TestStorageFetchRuntimeUserPrivate(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1055,7 +1155,7 @@ func TestStorageFetchPipelineGlobalPrivate(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1100,7 +1200,7 @@ func TestStorageFetchPipelineUserPrivate(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1146,7 +1246,7 @@ func TestStorageFetchPipelineUserRead(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1199,7 +1299,7 @@ func TestStorageFetchPipelineUserPublic(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1251,7 +1351,7 @@ func TestStorageFetchPipelineUserOtherRead(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1296,7 +1396,7 @@ func TestStorageFetchPipelineUserOtherPublic(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1360,7 +1460,7 @@ func TestStorageFetchPipelineUserOtherPublicMixed(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1426,7 +1526,7 @@ func TestStorageRemoveRuntimeGlobalPublic(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1448,7 +1548,7 @@ func TestStorageRemoveRuntimeGlobalPublic(t *testing.T) {
 		},
 	}
 
-	_, err = StorageDeleteObjects(context.Background(), logger, db, true, deleteOps)
+	_, err = StorageDeleteObjects(context.Background(), logger, db, storageIdx, storageFeed, true, deleteOps)
 	assert.Nil(t, err, "err was not nil")
 }
 
@@ -1471,7 +1571,7 @@ func TestStorageRemoveRuntimeGlobalPrivate(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1493,7 +1593,7 @@ func TestStorageRemoveRuntimeGlobalPrivate(t *testing.T) {
 		},
 	}
 
-	_, err = StorageDeleteObjects(context.Background(), logger, db, true, deleteOps)
+	_, err = StorageDeleteObjects(context.Background(), logger, db, storageIdx, storageFeed, true, deleteOps)
 	assert.Nil(t, err, "err was not nil")
 }
 
@@ -1518,7 +1618,7 @@ func TestStorageRemoveRuntimeUserPublic(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1540,7 +1640,7 @@ func TestStorageRemoveRuntimeUserPublic(t *testing.T) {
 		},
 	}
 
-	_, err = StorageDeleteObjects(context.Background(), logger, db, true, deleteOps)
+	_, err = StorageDeleteObjects(context.Background(), logger, db, storageIdx, storageFeed, true, deleteOps)
 	assert.Nil(t, err, "err was not nil")
 }
 
@@ -1565,7 +1665,7 @@ func TestStorageRemoveRuntimeUserPrivate(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1587,7 +1687,7 @@ func TestStorageRemoveRuntimeUserPrivate(t *testing.T) {
 		},
 	}
 
-	_, err = StorageDeleteObjects(context.Background(), logger, db, true, deleteOps)
+	_, err = StorageDeleteObjects(context.Background(), logger, db, storageIdx, storageFeed, true, deleteOps)
 	assert.Nil(t, err, "err was not nil")
 
 	ids := []*api.ReadStorageObjectId{{
@@ -1622,7 +1722,7 @@ func TestStorageRemovePipelineUserWrite(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1644,7 +1744,7 @@ func TestStorageRemovePipelineUserWrite(t *testing.T) {
 		},
 	}
 
-	_, err = StorageDeleteObjects(context.Background(), logger, db, true, deleteOps)
+	_, err = StorageDeleteObjects(context.Background(), logger, db, storageIdx, storageFeed, true, deleteOps)
 	assert.Nil(t, err, "err was not nil")
 }
 
@@ -1669,7 +1769,7 @@ func TestStorageRemovePipelineUserDenied(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1691,7 +1791,7 @@ func TestStorageRemovePipelineUserDenied(t *testing.T) {
 		},
 	}
 
-	code, err = StorageDeleteObjects(context.Background(), logger, db, false, deleteOps)
+	code, err = StorageDeleteObjects(context.Background(), logger, db, storageIdx, storageFeed, false, deleteOps)
 	assert.NotNil(t, err, "err was nil")
 	assert.Equal(t, code, codes.InvalidArgument, "code did not match InvalidArgument.")
 }
@@ -1711,7 +1811,7 @@ func TestStorageRemoveRuntimeGlobalIfMatchNotExists(t *testing.T) {
 		},
 	}
 
-	code, err := StorageDeleteObjects(context.Background(), logger, db, true, deleteOps)
+	code, err := StorageDeleteObjects(context.Background(), logger, db, storageIdx, storageFeed, true, deleteOps)
 	assert.NotNil(t, err, "err was nil")
 	assert.Equal(t, code, codes.InvalidArgument, "code did not match InvalidArgument.")
 }
@@ -1735,7 +1835,7 @@ func TestStorageRemoveRuntimeGlobalIfMatchRejected(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1758,7 +1858,7 @@ func TestStorageRemoveRuntimeGlobalIfMatchRejected(t *testing.T) {
 		},
 	}
 
-	code, err = StorageDeleteObjects(context.Background(), logger, db, true, deleteOps)
+	code, err = StorageDeleteObjects(context.Background(), logger, db, storageIdx, storageFeed, true, deleteOps)
 	assert.NotNil(t, err, "err was not nil")
 	assert.Equal(t, code, codes.InvalidArgument, "code did not match InvalidArgument.")
 }
@@ -1782,7 +1882,7 @@ func TestStorageRemoveRuntimeGlobalIfMatch(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, true, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, true, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1805,7 +1905,7 @@ func TestStorageRemoveRuntimeGlobalIfMatch(t *testing.T) {
 		},
 	}
 
-	code, err = StorageDeleteObjects(context.Background(), logger, db, true, deleteOps)
+	code, err = StorageDeleteObjects(context.Background(), logger, db, storageIdx, storageFeed, true, deleteOps)
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, code, codes.OK, "code did not match OK.")
 }
@@ -1850,7 +1950,7 @@ func TestStorageListRuntimeUser(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1907,7 +2007,7 @@ func TestStorageListPipelineUserSelf(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -1966,7 +2066,7 @@ func TestStorageListPipelineUserOther(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
@@ -2063,7 +2163,7 @@ func TestStorageListNoRepeats(t *testing.T) {
 		},
 	}
 
-	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, false, ops)
+	acks, code, err := StorageWriteObjects(context.Background(), logger, db, metrics, storageIdx, storageFeed, false, ops)
 
 	assert.Nil(t, err, "err was not nil")
 	assert.Equal(t, codes.OK, code, "code was not OK")
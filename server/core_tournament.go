@@ -46,10 +46,10 @@ type TournamentListCursor struct {
 
 type LeaderboardListCursor = TournamentListCursor
 
-func TournamentCreate(ctx context.Context, logger *zap.Logger, cache LeaderboardCache, scheduler LeaderboardScheduler, leaderboardId string, authoritative bool, sortOrder, operator int, resetSchedule, metadata,
-	title, description string, category, startTime, endTime, duration, maxSize, maxNumScore int, joinRequired bool) error {
+func TournamentCreate(ctx context.Context, logger *zap.Logger, cache LeaderboardCache, scheduler LeaderboardScheduler, leaderboardId string, authoritative bool, sort LeaderboardSort, operator int, resetSchedule, metadata,
+	title, description string, category, startTime, endTime, duration, maxSize, maxNumScore int, joinRequired bool, bucketSize int) error {
 
-	leaderboard, err := cache.CreateTournament(ctx, leaderboardId, authoritative, sortOrder, operator, resetSchedule, metadata, title, description, category, startTime, endTime, duration, maxSize, maxNumScore, joinRequired)
+	leaderboard, err := cache.CreateTournament(ctx, leaderboardId, authoritative, sort, operator, resetSchedule, metadata, title, description, category, startTime, endTime, duration, maxSize, maxNumScore, joinRequired, bucketSize)
 
 	if err != nil {
 		return err
@@ -123,7 +123,9 @@ func TournamentAddAttempt(ctx context.Context, logger *zap.Logger, db *sql.DB, c
 	return nil
 }
 
-func TournamentJoin(ctx context.Context, logger *zap.Logger, db *sql.DB, cache LeaderboardCache, owner, username, tournamentId string) error {
+// TournamentJoin adds the owner to the tournament. Owners joining bucketed tournaments are placed in a bucket with
+// other owners whose rating falls in the same band of the given range, or with other owners joining without a rating.
+func TournamentJoin(ctx context.Context, logger *zap.Logger, db *sql.DB, cache LeaderboardCache, scheduler LeaderboardScheduler, owner, username, tournamentId string, rating *int64, ratingRange int64) error {
 	leaderboard := cache.Get(tournamentId)
 	if leaderboard == nil {
 		// If it does not exist treat it as success.
@@ -146,6 +148,7 @@ func TournamentJoin(ctx context.Context, logger *zap.Logger, db *sql.DB, cache L
 		return runtime.ErrTournamentOutsideDuration
 	}
 
+	var joined bool
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		logger.Error("Could not begin database transaction.", zap.Error(err))
@@ -153,6 +156,7 @@ func TournamentJoin(ctx context.Context, logger *zap.Logger, db *sql.DB, cache L
 	}
 
 	if err = ExecuteInTx(ctx, tx, func() error {
+		joined = false
 		query := `INSERT INTO leaderboard_record
 (leaderboard_id, owner_id, expiry_time, username, num_score, max_num_score)
 VALUES
@@ -185,6 +189,18 @@ ON CONFLICT(owner_id, leaderboard_id, expiry_time) DO NOTHING`
 			}
 		}
 
+		if leaderboard.IsBucketed() {
+			bucket, err := tournamentAssignBucket(ctx, tx, leaderboard, expiryTime, tournamentBucketBand(rating, ratingRange))
+			if err != nil {
+				return err
+			}
+			query = "UPDATE leaderboard_record SET bucket = $4 WHERE leaderboard_id = $1 AND owner_id = $2 AND expiry_time = $3"
+			if _, err = tx.ExecContext(ctx, query, tournamentId, owner, time.Unix(expiryTime, 0).UTC(), bucket); err != nil {
+				return err
+			}
+		}
+
+		joined = true
 		return nil
 	}); err != nil {
 		if err == runtime.ErrTournamentMaxSizeReached {
@@ -196,9 +212,55 @@ ON CONFLICT(owner_id, leaderboard_id, expiry_time) DO NOTHING`
 	}
 
 	logger.Info("Joined tournament.", zap.String("tournament_id", tournamentId), zap.String("owner", owner), zap.String("username", username))
+	if joined {
+		scheduler.Join(tournamentId, owner, username)
+	}
 	return nil
 }
 
+// The rating band an owner joining a bucketed tournament is placed in, nil if they join without a rating.
+func tournamentBucketBand(rating *int64, ratingRange int64) *int64 {
+	if rating == nil {
+		return nil
+	}
+	band := *rating / ratingRange
+	if *rating%ratingRange < 0 {
+		// Round towards negative infinity so bands are the same width on both sides of zero.
+		band--
+	}
+	return &band
+}
+
+// Find a bucket in the band with room for another owner, choosing among several at random, or open a new one.
+func tournamentAssignBucket(ctx context.Context, tx *sql.Tx, leaderboard *Leaderboard, expiryTime int64, band *int64) (int, error) {
+	expiry := time.Unix(expiryTime, 0).UTC()
+
+	// The tournament row serializes concurrent joins, so two of them cannot both fill the last place in a bucket or
+	// open a new bucket with the same number. It is held until the join commits.
+	var bucket int
+	if _, err := tx.ExecContext(ctx, "SELECT 1 FROM leaderboard WHERE id = $1 FOR UPDATE", leaderboard.Id); err != nil {
+		return 0, err
+	}
+
+	query := `SELECT bucket FROM leaderboard_bucket
+WHERE leaderboard_id = $1 AND expiry_time = $2 AND band IS NOT DISTINCT FROM $3 AND size < $4
+ORDER BY random() LIMIT 1`
+	err := tx.QueryRowContext(ctx, query, leaderboard.Id, expiry, band, leaderboard.BucketSize).Scan(&bucket)
+	switch err {
+	case nil:
+		_, err = tx.ExecContext(ctx, "UPDATE leaderboard_bucket SET size = size + 1 WHERE leaderboard_id = $1 AND expiry_time = $2 AND bucket = $3", leaderboard.Id, expiry, bucket)
+		return bucket, err
+	case sql.ErrNoRows:
+		query = `INSERT INTO leaderboard_bucket (leaderboard_id, expiry_time, bucket, band, size)
+SELECT $1, $2, COALESCE(MAX(bucket), 0) + 1, $3, 1 FROM leaderboard_bucket WHERE leaderboard_id = $1 AND expiry_time = $2
+RETURNING bucket`
+		err = tx.QueryRowContext(ctx, query, leaderboard.Id, expiry, band).Scan(&bucket)
+		return bucket, err
+	default:
+		return 0, err
+	}
+}
+
 func TournamentsGet(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboardCache LeaderboardCache, tournamentIDs []string) ([]*api.Tournament, error) {
 	now := time.Now().UTC()
 
@@ -412,7 +474,7 @@ func TournamentList(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderb
 	return tournamentList, nil
 }
 
-func TournamentRecordsList(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, tournamentId string, ownerIds []string, limit *wrapperspb.Int32Value, cursor string, overrideExpiry int64) (*api.TournamentRecordList, error) {
+func TournamentRecordsList(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, tournamentId string, ownerIds []string, scope *LeaderboardScope, limit *wrapperspb.Int32Value, cursor string, overrideExpiry int64) (*api.TournamentRecordList, error) {
 	leaderboard := leaderboardCache.Get(tournamentId)
 	if leaderboard == nil || !leaderboard.IsTournament() {
 		return nil, runtime.ErrTournamentNotFound
@@ -422,7 +484,7 @@ func TournamentRecordsList(ctx context.Context, logger *zap.Logger, db *sql.DB,
 		return nil, runtime.ErrTournamentOutsideDuration
 	}
 
-	records, err := LeaderboardRecordsList(ctx, logger, db, leaderboardCache, rankCache, tournamentId, limit, cursor, ownerIds, overrideExpiry)
+	records, err := LeaderboardRecordsList(ctx, logger, db, leaderboardCache, rankCache, tournamentId, limit, cursor, ownerIds, scope, overrideExpiry)
 	if err != nil {
 		logger.Error("Error listing records from tournament.", zap.Error(err))
 		return nil, err
@@ -438,7 +500,7 @@ func TournamentRecordsList(ctx context.Context, logger *zap.Logger, db *sql.DB,
 	return recordList, nil
 }
 
-func TournamentRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, caller uuid.UUID, tournamentId string, ownerId uuid.UUID, username string, score, subscore int64, metadata string, overrideOperator api.Operator) (*api.LeaderboardRecord, error) {
+func TournamentRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, metrics Metrics, caller uuid.UUID, tournamentId string, ownerId uuid.UUID, username string, score, subscore int64, metadata string, overrideOperator api.Operator) (*api.LeaderboardRecord, error) {
 	leaderboard := leaderboardCache.Get(tournamentId)
 	if leaderboard == nil || !leaderboard.IsTournament() {
 		return nil, runtime.ErrTournamentNotFound
@@ -504,15 +566,9 @@ func TournamentRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB,
 	case LeaderboardOperatorBest:
 		fallthrough
 	default:
-		if leaderboard.SortOrder == LeaderboardSortOrderAscending {
-			// Lower score is better.
-			opSQL = "score = LEAST(leaderboard_record.score, $5), subscore = LEAST(leaderboard_record.subscore, $6)"
-			filterSQL = " WHERE (leaderboard_record.score > $5 OR leaderboard_record.subscore > $6)"
-		} else {
-			// Higher score is better.
-			opSQL = "score = GREATEST(leaderboard_record.score, $5), subscore = GREATEST(leaderboard_record.subscore, $6)"
-			filterSQL = " WHERE (leaderboard_record.score < $5 OR leaderboard_record.subscore < $6)"
-		}
+		var filter string
+		opSQL, filter = leaderboard.Sort().best("$5", "$6")
+		filterSQL = " WHERE (" + filter + ")"
 		scoreDelta = score
 		subscoreDelta = subscore
 		scoreAbs = score
@@ -535,7 +591,56 @@ func TournamentRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB,
 		params = append(params, metadata)
 	}
 
-	if leaderboard.JoinRequired {
+	// Validation rules may limit the owner's previous score or the change to it, so it must be read before it is
+	// overwritten.
+	validate := caller != uuid.Nil && leaderboard.Validation != nil
+	var previousScore *int64
+	if validate && leaderboard.Validation.needsPreviousScore() {
+		var err error
+		if previousScore, err = leaderboardRecordScore(ctx, db, leaderboard.Id, ownerId.String(), expiryTime); err != nil {
+			logger.Error("Error reading tournament record before writing", zap.Error(err))
+			return nil, err
+		}
+	}
+	if validate {
+		if err := leaderboardValidateWrite(ctx, logger, db, metrics, leaderboard, ownerId.String(), operator, score, previousScore, nowTime); err != nil {
+			return nil, err
+		}
+	}
+
+	// Approximate ranks replace the owner's previous score with the score written, both read in the transaction that
+	// writes the record.
+	approximate := rankCache.Approximate(leaderboard.Id)
+	var approximatePreviousScore *int64
+	var approximateScore int64
+
+	if leaderboard.JoinRequired && approximate {
+		query := `UPDATE leaderboard_record
+              SET ` + opSQL + `, num_score = leaderboard_record.num_score + 1, metadata = COALESCE($7, leaderboard_record.metadata), username = COALESCE($3, leaderboard_record.username), update_time = now()
+              WHERE leaderboard_id = $1 AND owner_id = $2 AND expiry_time = $4 AND (max_num_score = 0 OR num_score < max_num_score)` + strings.ReplaceAll(filterSQL, "WHERE", "AND") + `
+              RETURNING score`
+		if err := leaderboardApproximateWrite(ctx, db, func(tx *sql.Tx) error {
+			var err error
+			if approximatePreviousScore, err = leaderboardRecordScoreForUpdate(ctx, tx, leaderboard.Id, ownerId.String(), expiryTime); err != nil {
+				return err
+			}
+			if approximatePreviousScore == nil {
+				// Tournament required join but no row was found to update.
+				return runtime.ErrTournamentWriteJoinRequired
+			}
+			if err = tx.QueryRowContext(ctx, query, params...).Scan(&approximateScore); err == sql.ErrNoRows {
+				// The record was not updated, and keeps its score.
+				approximateScore = *approximatePreviousScore
+				return nil
+			}
+			return err
+		}); err != nil {
+			if err != runtime.ErrTournamentWriteJoinRequired {
+				logger.Error("Error writing tournament record", zap.Error(err))
+			}
+			return nil, err
+		}
+	} else if leaderboard.JoinRequired {
 		// If join is required then the user must already have a record to update.
 		// There's also no need to increment the number of records tracked for this tournament.
 		var exists int
@@ -566,17 +671,24 @@ func TournamentRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB,
             DO UPDATE SET ` + opSQL + `, num_score = leaderboard_record.num_score + 1, metadata = COALESCE($7, leaderboard_record.metadata), username = COALESCE($3, leaderboard_record.username), update_time = now()` + filterSQL
 		params = append(params, leaderboard.MaxNumScore, scoreAbs, subscoreAbs)
 
-		tx, err := db.BeginTx(ctx, nil)
-		if err != nil {
-			logger.Error("Could not begin database transaction.", zap.Error(err))
-			return nil, err
-		}
+		write := func(tx *sql.Tx) error {
+			if approximate {
+				var err error
+				if approximatePreviousScore, err = leaderboardRecordScoreForUpdate(ctx, tx, leaderboard.Id, ownerId.String(), expiryTime); err != nil {
+					return err
+				}
+			}
 
-		if err := ExecuteInTx(ctx, tx, func() error {
 			recordQueryResult, err := tx.ExecContext(ctx, query, params...)
 			if err != nil {
 				var pgErr *pgconn.PgError
 				if errors.As(err, &pgErr) && pgErr.Code == dbErrorUniqueViolation && strings.Contains(pgErr.Message, "leaderboard_record_pkey") {
+					if approximate {
+						if approximatePreviousScore == nil {
+							return errLeaderboardRecordRaced
+						}
+						approximateScore = *approximatePreviousScore
+					}
 					return errTournamentWriteNoop
 				}
 				return err
@@ -587,12 +699,17 @@ func TournamentRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB,
 				var dbNumScore int
 				var dbMaxNumScore int
 
-				err := tx.QueryRowContext(ctx, "SELECT num_score, max_num_score FROM leaderboard_record WHERE leaderboard_id = $1 AND owner_id = $2 AND expiry_time = $3", leaderboard.Id, ownerId, expiryTime).Scan(&dbNumScore, &dbMaxNumScore)
+				err := tx.QueryRowContext(ctx, "SELECT num_score, max_num_score, score FROM leaderboard_record WHERE leaderboard_id = $1 AND owner_id = $2 AND expiry_time = $3", leaderboard.Id, ownerId, expiryTime).Scan(&dbNumScore, &dbMaxNumScore, &approximateScore)
 				if err != nil {
 					logger.Error("Error reading leaderboard record.", zap.Error(err))
 					return err
 				}
 
+				if approximate && approximatePreviousScore == nil && dbNumScore > 1 {
+					// The write updated a record created after the read.
+					return errLeaderboardRecordRaced
+				}
+
 				// Check if the max number of submissions has been reached.
 				if dbMaxNumScore > 0 && dbNumScore > dbMaxNumScore {
 					return runtime.ErrTournamentWriteMaxNumScoreReached
@@ -610,10 +727,30 @@ func TournamentRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB,
 						return runtime.ErrTournamentMaxSizeReached
 					}
 				}
+			} else if approximate {
+				if approximatePreviousScore == nil {
+					// The record was not updated, but was created after the read.
+					return errLeaderboardRecordRaced
+				}
+				// The record was not updated, and keeps its score.
+				approximateScore = *approximatePreviousScore
 			}
 
 			return nil
-		}); err != nil && err != errTournamentWriteNoop {
+		}
+
+		var err error
+		if approximate {
+			err = leaderboardApproximateWrite(ctx, db, write)
+		} else {
+			var tx *sql.Tx
+			if tx, err = db.BeginTx(ctx, nil); err != nil {
+				logger.Error("Could not begin database transaction.", zap.Error(err))
+				return nil, err
+			}
+			err = ExecuteInTx(ctx, tx, func() error { return write(tx) })
+		}
+		if err != nil && err != errTournamentWriteNoop {
 			if err == runtime.ErrTournamentWriteMaxNumScoreReached || err == runtime.ErrTournamentMaxSizeReached {
 				logger.Info("Aborted writing tournament record", zap.String("reason", err.Error()), zap.String("tournament_id", tournamentId), zap.String("owner_id", ownerId.String()))
 			} else {
@@ -659,7 +796,25 @@ func TournamentRecordWrite(ctx context.Context, logger *zap.Logger, db *sql.DB,
 	}
 
 	// Enrich the return record with rank data.
-	record.Rank = rankCache.Insert(leaderboard.Id, expiryUnix, leaderboard.SortOrder, ownerId, record.Score, record.Subscore)
+	if approximate {
+		// The record may have been written again since, ranks estimate the score this write left it with.
+		record.Rank = rankCache.UpdateApproximate(leaderboard.Id, expiryUnix, leaderboard.Sort(), approximatePreviousScore, &approximateScore)
+	} else {
+		record.Rank = rankCache.Insert(leaderboard.Id, expiryUnix, leaderboard.Sort(), ownerId, record.Score, record.Subscore, dbUpdateTime.Time.UnixMicro())
+	}
+	if leaderboard.IsBucketed() {
+		// Owners are ranked within their bucket, which the rank cache does not track.
+		scope := &LeaderboardScope{BucketOf: ownerId}
+		query := "SELECT COUNT(*) + 1 FROM leaderboard_record WHERE leaderboard_id = $1 AND expiry_time = $2"
+		params := []interface{}{leaderboard.Id, expiryTime}
+		scopeFilter, scopeParam := scope.filter(len(params) + 1)
+		params = append(params, scopeParam)
+		query, params = leaderboard.Sort().after(query+scopeFilter, params, true, record.Score, record.Subscore, dbUpdateTime.Time.UnixMicro(), ownerId.String())
+		if err := db.QueryRowContext(ctx, query, params...).Scan(&record.Rank); err != nil {
+			logger.Error("Error ranking tournament record in its bucket", zap.Error(err))
+			return nil, err
+		}
+	}
 
 	return record, nil
 }
@@ -670,8 +825,6 @@ func TournamentRecordsHaystack(ctx context.Context, logger *zap.Logger, db *sql.
 		return nil, ErrLeaderboardNotFound
 	}
 
-	sortOrder := leaderboard.SortOrder
-
 	expiry := expiryOverride
 	if expiry == 0 {
 		now := time.Now().UTC()
@@ -684,7 +837,7 @@ func TournamentRecordsHaystack(ctx context.Context, logger *zap.Logger, db *sql.
 
 	expiryTime := time.Unix(expiry, 0).UTC()
 
-	results, err := getLeaderboardRecordsHaystack(ctx, logger, db, leaderboardCache, rankCache, ownerId, limit, leaderboard.Id, cursor, sortOrder, expiryTime)
+	results, err := getLeaderboardRecordsHaystack(ctx, logger, db, leaderboardCache, rankCache, ownerId, limit, leaderboard.Id, cursor, leaderboard.Sort(), expiryTime)
 	if err != nil {
 		return nil, err
 	}
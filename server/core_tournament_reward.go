@@ -0,0 +1,327 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var ErrTournamentRewardsInvalid = errors.New("tournament rewards must have rank ranges starting from 1 that do not overlap")
+
+// TournamentReward is paid out to the owners of records ranked between RankMin and RankMax, inclusive, when an active
+// period of a tournament ends. Ranks are within buckets for bucketed tournaments.
+type TournamentReward struct {
+	RankMin int64 `json:"rank_min"`
+	RankMax int64 `json:"rank_max"`
+	// Wallet changes, recorded in the wallet ledger with the metadata.
+	Changeset map[string]int64       `json:"changeset,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	// Optional notification sent to rewarded users.
+	Notification *TournamentRewardNotification `json:"notification,omitempty"`
+}
+
+type TournamentRewardNotification struct {
+	Subject    string                 `json:"subject"`
+	Content    map[string]interface{} `json:"content,omitempty"`
+	Code       int                    `json:"code"`
+	Persistent bool                   `json:"persistent"`
+}
+
+// TournamentRewardsSet replaces the reward table of a tournament. Rewards are paid out by the leaderboard scheduler
+// when each active period of the tournament ends, to the owners ranked in each reward's rank range.
+func TournamentRewardsSet(ctx context.Context, logger *zap.Logger, db *sql.DB, cache LeaderboardCache, id string, rewards []*TournamentReward) error {
+	leaderboard := cache.Get(id)
+	if leaderboard == nil || !leaderboard.IsTournament() {
+		return runtime.ErrTournamentNotFound
+	}
+
+	if err := validateTournamentRewards(rewards); err != nil {
+		return err
+	}
+	if rewards == nil {
+		rewards = []*TournamentReward{}
+	}
+	rewardsBytes, err := json.Marshal(rewards)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.ExecContext(ctx, "UPDATE leaderboard SET rewards = $2 WHERE id = $1", id, rewardsBytes); err != nil {
+		logger.Error("Could not set tournament rewards.", zap.Error(err), zap.String("id", id))
+		return err
+	}
+
+	return nil
+}
+
+func validateTournamentRewards(rewards []*TournamentReward) error {
+	sorted := make([]*TournamentReward, 0, len(rewards))
+	for _, reward := range rewards {
+		if reward == nil || reward.RankMin < 1 || reward.RankMax < reward.RankMin {
+			return ErrTournamentRewardsInvalid
+		}
+		sorted = append(sorted, reward)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].RankMin < sorted[j].RankMin
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].RankMin <= sorted[i-1].RankMax {
+			return ErrTournamentRewardsInvalid
+		}
+	}
+	return nil
+}
+
+// The reward for an owner at the given rank, nil if the rank is not rewarded.
+func tournamentRewardForRank(rewards []*TournamentReward, rank int64) *TournamentReward {
+	for _, reward := range rewards {
+		if rank >= reward.RankMin && rank <= reward.RankMax {
+			return reward
+		}
+	}
+	return nil
+}
+
+// Pay out the rewards of a tournament period that has ended. Wallet changes and persistent notifications for all
+// rewarded owners are written in a single transaction, which also records the payout so a period is only paid once.
+// Owners of bucketed tournaments are rewarded by their rank within their bucket.
+func tournamentRewardsPayout(ctx context.Context, logger *zap.Logger, db *sql.DB, router MessageRouter, leaderboard *Leaderboard, expiry int64) error {
+	var notifications map[uuid.UUID][]*api.Notification
+	var paid int
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("Could not begin database transaction.", zap.Error(err))
+		return err
+	}
+
+	if err = ExecuteInTx(ctx, tx, func() error {
+		notifications = make(map[uuid.UUID][]*api.Notification)
+		paid = 0
+
+		var rewardsBytes []byte
+		if err := tx.QueryRowContext(ctx, "SELECT rewards FROM leaderboard WHERE id = $1", leaderboard.Id).Scan(&rewardsBytes); err != nil {
+			if err == sql.ErrNoRows {
+				// Tournament was deleted before it reached the scheduler here.
+				return nil
+			}
+			return err
+		}
+		var rewards []*TournamentReward
+		if err := json.Unmarshal(rewardsBytes, &rewards); err != nil {
+			return err
+		}
+		if len(rewards) == 0 {
+			return nil
+		}
+
+		query := `INSERT INTO leaderboard_reward_payout (leaderboard_id, expiry_time) VALUES ($1, $2)
+ON CONFLICT (leaderboard_id, expiry_time) DO NOTHING`
+		result, err := tx.ExecContext(ctx, query, leaderboard.Id, time.Unix(expiry, 0).UTC())
+		if err != nil {
+			return err
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil {
+			return err
+		} else if rowsAffected != 1 {
+			// Already paid out, possibly by another node.
+			return nil
+		}
+
+		var maxRank int64
+		for _, reward := range rewards {
+			if reward.RankMax > maxRank {
+				maxRank = reward.RankMax
+			}
+		}
+
+		query = `SELECT owner_id, rank FROM (
+SELECT owner_id, ROW_NUMBER() OVER (PARTITION BY bucket` + leaderboard.Sort().orderBy(false) + `) AS rank
+FROM leaderboard_record
+WHERE leaderboard_id = $1 AND expiry_time = $2 AND num_score > 0
+) AS ranked
+WHERE rank <= $3`
+		rows, err := tx.QueryContext(ctx, query, leaderboard.Id, time.Unix(expiry, 0).UTC(), maxRank)
+		if err != nil {
+			return err
+		}
+		walletUpdates := make([]*walletUpdate, 0)
+		now := time.Now().UTC()
+		for rows.Next() {
+			var ownerID uuid.UUID
+			var rank int64
+			if err := rows.Scan(&ownerID, &rank); err != nil {
+				_ = rows.Close()
+				return err
+			}
+
+			reward := tournamentRewardForRank(rewards, rank)
+			if reward == nil {
+				continue
+			}
+			paid++
+
+			if len(reward.Changeset) > 0 {
+				metadata := make(map[string]interface{}, len(reward.Metadata)+2)
+				for k, v := range reward.Metadata {
+					metadata[k] = v
+				}
+				metadata["tournament_id"] = leaderboard.Id
+				metadata["rank"] = rank
+				metadataBytes, err := json.Marshal(metadata)
+				if err != nil {
+					_ = rows.Close()
+					return err
+				}
+				walletUpdates = append(walletUpdates, &walletUpdate{UserID: ownerID, Changeset: reward.Changeset, Metadata: string(metadataBytes)})
+			}
+
+			if reward.Notification != nil {
+				content := make(map[string]interface{}, len(reward.Notification.Content)+2)
+				for k, v := range reward.Notification.Content {
+					content[k] = v
+				}
+				content["tournament_id"] = leaderboard.Id
+				content["rank"] = rank
+				contentBytes, err := json.Marshal(content)
+				if err != nil {
+					_ = rows.Close()
+					return err
+				}
+				notifications[ownerID] = append(notifications[ownerID], &api.Notification{
+					Id:         uuid.Must(uuid.NewV4()).String(),
+					Subject:    reward.Notification.Subject,
+					Content:    string(contentBytes),
+					Code:       int32(reward.Notification.Code),
+					Persistent: reward.Notification.Persistent,
+					CreateTime: &timestamppb.Timestamp{Seconds: now.Unix()},
+				})
+			}
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if _, err := updateWallets(ctx, logger, tx, walletUpdates, true); err != nil {
+			return err
+		}
+
+		persistentNotifications := make(map[uuid.UUID][]*api.Notification, len(notifications))
+		for userID, ns := range notifications {
+			for _, n := range ns {
+				if n.Persistent {
+					persistentNotifications[userID] = append(persistentNotifications[userID], n)
+				}
+			}
+		}
+		if len(persistentNotifications) > 0 {
+			query, params := notificationSaveQuery(persistentNotifications)
+			if _, err := tx.ExecContext(ctx, query, params...); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Deliver live notifications to connected users once the payout is committed.
+	for userID, ns := range notifications {
+		router.SendToStream(logger, PresenceStream{Mode: StreamModeNotifications, Subject: userID}, &rtapi.Envelope{
+			Message: &rtapi.Envelope_Notifications{
+				Notifications: &rtapi.Notifications{
+					Notifications: ns,
+				},
+			},
+		}, true)
+	}
+
+	if paid > 0 {
+		logger.Info("Paid out tournament rewards.", zap.String("id", leaderboard.Id), zap.Int64("expiry", expiry), zap.Int("count", paid))
+	}
+
+	return nil
+}
+
+// Pay out the rewards of tournament periods that ended without being paid, because no node was running when their end
+// timer fired. Periods are paid at most once, so this is safe to run on every node at startup.
+func tournamentRewardsPayoutMissed(ctx context.Context, logger *zap.Logger, db *sql.DB, cache LeaderboardCache, router MessageRouter, now time.Time) error {
+	query := `SELECT DISTINCT r.leaderboard_id, r.expiry_time
+FROM leaderboard_record AS r
+JOIN leaderboard AS l ON l.id = r.leaderboard_id
+WHERE l.rewards <> '[]'::JSONB
+AND NOT EXISTS (SELECT 1 FROM leaderboard_reward_payout AS p WHERE p.leaderboard_id = r.leaderboard_id AND p.expiry_time = r.expiry_time)`
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		logger.Error("Could not list unpaid tournament periods.", zap.Error(err))
+		return err
+	}
+	type unpaidPeriod struct {
+		leaderboard *Leaderboard
+		expiry      int64
+	}
+	var unpaid []*unpaidPeriod
+	nowUnix := now.Unix()
+	for rows.Next() {
+		var id string
+		var expiryTime time.Time
+		if err := rows.Scan(&id, &expiryTime); err != nil {
+			_ = rows.Close()
+			logger.Error("Could not list unpaid tournament periods.", zap.Error(err))
+			return err
+		}
+		leaderboard := cache.Get(id)
+		if leaderboard == nil || !leaderboard.IsTournament() {
+			continue
+		}
+
+		// Periods that expired have ended, and so has the current period if its active time is over.
+		expiry := expiryTime.Unix()
+		if expiry == 0 || expiry > nowUnix {
+			_, endActive, currentExpiry := calculateTournamentDeadlines(leaderboard.StartTime, leaderboard.EndTime, int64(leaderboard.Duration), leaderboard.ResetSchedule, now)
+			if currentExpiry != expiry || endActive > nowUnix {
+				continue
+			}
+		}
+		unpaid = append(unpaid, &unpaidPeriod{leaderboard: leaderboard, expiry: expiry})
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		logger.Error("Could not list unpaid tournament periods.", zap.Error(err))
+		return err
+	}
+
+	for _, period := range unpaid {
+		if err := tournamentRewardsPayout(ctx, logger, db, router, period.leaderboard, period.expiry); err != nil {
+			logger.Error("Failed to pay out tournament rewards", zap.Error(err), zap.String("id", period.leaderboard.Id), zap.Int64("expiry", period.expiry))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama/v3/internal/cronexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTournamentRewardsValidate(t *testing.T) {
+	rewards := []*TournamentReward{
+		{RankMin: 4, RankMax: 10, Changeset: map[string]int64{"coins": 10}},
+		{RankMin: 1, RankMax: 1, Changeset: map[string]int64{"coins": 100}},
+		{RankMin: 2, RankMax: 3, Changeset: map[string]int64{"coins": 50}},
+	}
+	assert.NoError(t, validateTournamentRewards(rewards))
+	assert.NoError(t, validateTournamentRewards(nil))
+
+	assert.Equal(t, int64(100), tournamentRewardForRank(rewards, 1).Changeset["coins"])
+	assert.Equal(t, int64(50), tournamentRewardForRank(rewards, 3).Changeset["coins"])
+	assert.Equal(t, int64(10), tournamentRewardForRank(rewards, 10).Changeset["coins"])
+	assert.Nil(t, tournamentRewardForRank(rewards, 11))
+
+	// Overlapping ranges.
+	assert.Equal(t, ErrTournamentRewardsInvalid, validateTournamentRewards(append(rewards, &TournamentReward{RankMin: 10, RankMax: 20})))
+	// Ranks are numbered from 1.
+	assert.Equal(t, ErrTournamentRewardsInvalid, validateTournamentRewards([]*TournamentReward{{RankMin: 0, RankMax: 1}}))
+	assert.Equal(t, ErrTournamentRewardsInvalid, validateTournamentRewards([]*TournamentReward{{RankMin: 5, RankMax: 4}}))
+}
+
+func TestTournamentNextStart(t *testing.T) {
+	day := func(d, h int) time.Time {
+		return time.Date(2022, 12, d, h, 0, 0, 0, time.UTC)
+	}
+
+	// Tournaments without a reset schedule start once.
+	assert.Equal(t, day(5, 0).Unix(), tournamentNextStart(day(5, 0).Unix(), 0, 3600, nil, day(1, 0)))
+	assert.Equal(t, int64(0), tournamentNextStart(day(5, 0).Unix(), 0, 3600, nil, day(5, 0)))
+
+	// Daily tournaments active for an hour at noon start again at the next reset.
+	daily := cronexpr.MustParse("0 12 * * *")
+	assert.Equal(t, day(2, 12).Unix(), tournamentNextStart(day(1, 0).Unix(), 0, 3600, daily, day(1, 12)))
+	assert.Equal(t, day(2, 12).Unix(), tournamentNextStart(day(1, 0).Unix(), 0, 3600, daily, day(1, 15)))
+	// Not before the first reset after the tournament start time.
+	assert.Equal(t, day(3, 12).Unix(), tournamentNextStart(day(3, 0).Unix(), 0, 3600, daily, day(1, 15)))
+	// Nor at or after the tournament end time.
+	assert.Equal(t, int64(0), tournamentNextStart(day(1, 0).Unix(), day(2, 12).Unix(), 3600, daily, day(1, 15)))
+}
+
+func createTestRewardTournament(t *testing.T, db *sql.DB, startTime, bucketSize int) (LeaderboardCache, *Leaderboard) {
+	cache := NewLocalLeaderboardCache(logger, logger, db)
+	leaderboard, err := cache.CreateTournament(context.Background(), GenerateString(), true, NewLeaderboardSort(LeaderboardSortOrderDescending), LeaderboardOperatorBest, "", "", "", "", 0, startTime, 0, 3600, 0, 0, false, bucketSize)
+	if err != nil {
+		t.Fatalf("error creating tournament: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cache.Delete(context.Background(), leaderboard.Id)
+	})
+
+	rewards := []*TournamentReward{
+		{RankMin: 1, RankMax: 1, Changeset: map[string]int64{"coins": 100}, Notification: &TournamentRewardNotification{Subject: "first", Code: 101, Persistent: true}},
+		{RankMin: 2, RankMax: 2, Changeset: map[string]int64{"coins": 50}},
+	}
+	if err := TournamentRewardsSet(context.Background(), logger, db, cache, leaderboard.Id, rewards); err != nil {
+		t.Fatalf("error setting tournament rewards: %v", err)
+	}
+	return cache, leaderboard
+}
+
+func insertTestRewardRecord(t *testing.T, db *sql.DB, leaderboard *Leaderboard, expiry int64, bucket int, score int64) uuid.UUID {
+	userID := uuid.Must(uuid.NewV4())
+	InsertUser(t, db, userID)
+	query := `INSERT INTO leaderboard_record (leaderboard_id, owner_id, username, score, subscore, num_score, max_num_score, expiry_time, bucket)
+VALUES ($1, $2, $3, $4, 0, 1, 0, $5, $6)`
+	if _, err := db.Exec(query, leaderboard.Id, userID, userID.String(), score, time.Unix(expiry, 0).UTC(), bucket); err != nil {
+		t.Fatalf("error inserting record: %v", err)
+	}
+	return userID
+}
+
+func testWalletCoins(t *testing.T, db *sql.DB, userID uuid.UUID) int64 {
+	var walletBytes []byte
+	if err := db.QueryRow("SELECT wallet FROM users WHERE id = $1", userID).Scan(&walletBytes); err != nil {
+		t.Fatalf("error reading wallet: %v", err)
+	}
+	var wallet map[string]int64
+	if err := json.Unmarshal(walletBytes, &wallet); err != nil {
+		t.Fatalf("error unmarshalling wallet: %v", err)
+	}
+	return wallet["coins"]
+}
+
+func TestTournamentRewardsPayoutBuckets(t *testing.T) {
+	db := NewDB(t)
+	_, leaderboard := createTestRewardTournament(t, db, int(time.Now().Unix()), 10)
+
+	// Owners are ranked within their own bucket.
+	expiry := time.Now().Add(time.Hour).Unix()
+	first1 := insertTestRewardRecord(t, db, leaderboard, expiry, 1, 30)
+	second1 := insertTestRewardRecord(t, db, leaderboard, expiry, 1, 20)
+	third1 := insertTestRewardRecord(t, db, leaderboard, expiry, 1, 10)
+	first2 := insertTestRewardRecord(t, db, leaderboard, expiry, 2, 5)
+
+	if err := tournamentRewardsPayout(context.Background(), logger, db, &testMessageRouter{}, leaderboard, expiry); err != nil {
+		t.Fatalf("error paying out rewards: %v", err)
+	}
+	assert.EqualValues(t, 100, testWalletCoins(t, db, first1))
+	assert.EqualValues(t, 50, testWalletCoins(t, db, second1))
+	assert.EqualValues(t, 0, testWalletCoins(t, db, third1))
+	assert.EqualValues(t, 100, testWalletCoins(t, db, first2))
+
+	var notifications int
+	if err := db.QueryRow("SELECT count(*) FROM notification WHERE user_id = $1 AND code = 101", first1).Scan(&notifications); err != nil {
+		t.Fatalf("error counting notifications: %v", err)
+	}
+	assert.Equal(t, 1, notifications)
+
+	// A period is only paid once.
+	if err := tournamentRewardsPayout(context.Background(), logger, db, &testMessageRouter{}, leaderboard, expiry); err != nil {
+		t.Fatalf("error paying out rewards: %v", err)
+	}
+	assert.EqualValues(t, 100, testWalletCoins(t, db, first1))
+	assert.EqualValues(t, 100, testWalletCoins(t, db, first2))
+}
+
+func TestTournamentRewardsPayoutMissed(t *testing.T) {
+	db := NewDB(t)
+	now := time.Now().UTC()
+
+	// Active for an hour starting two hours ago, with no reset schedule or end time its records never expire.
+	_, ended := createTestRewardTournament(t, db, int(now.Add(-2*time.Hour).Unix()), 0)
+	winner := insertTestRewardRecord(t, db, ended, 0, 0, 10)
+
+	// Still active, so not paid yet.
+	_, active := createTestRewardTournament(t, db, int(now.Unix()), 0)
+	leader := insertTestRewardRecord(t, db, active, 0, 0, 10)
+
+	// As loaded by a node starting up.
+	cache := NewLocalLeaderboardCache(logger, logger, db)
+	if err := tournamentRewardsPayoutMissed(context.Background(), logger, db, cache, &testMessageRouter{}, now); err != nil {
+		t.Fatalf("error paying out missed rewards: %v", err)
+	}
+	assert.EqualValues(t, 100, testWalletCoins(t, db, winner))
+	assert.EqualValues(t, 0, testWalletCoins(t, db, leader))
+
+	// Paid periods are not paid again.
+	if err := tournamentRewardsPayoutMissed(context.Background(), logger, db, cache, &testMessageRouter{}, now); err != nil {
+		t.Fatalf("error paying out missed rewards: %v", err)
+	}
+	assert.EqualValues(t, 100, testWalletCoins(t, db, winner))
+}
@@ -0,0 +1,134 @@
+// Copyright 2017 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// testLeaderboardScheduler implements the LeaderboardScheduler interface and does nothing
+type testLeaderboardScheduler struct{}
+
+func (s *testLeaderboardScheduler) Start(runtime *Runtime)                     {}
+func (s *testLeaderboardScheduler) Pause()                                     {}
+func (s *testLeaderboardScheduler) Resume()                                    {}
+func (s *testLeaderboardScheduler) Stop()                                      {}
+func (s *testLeaderboardScheduler) Update()                                    {}
+func (s *testLeaderboardScheduler) Join(tournamentId, userID, username string) {}
+
+func createTestBucketedTournament(t *testing.T, db *sql.DB, bucketSize int) (LeaderboardCache, *Leaderboard) {
+	cache := NewLocalLeaderboardCache(logger, logger, db)
+	leaderboard, err := cache.CreateTournament(context.Background(), GenerateString(), true, NewLeaderboardSort(LeaderboardSortOrderDescending), LeaderboardOperatorBest, "", "", "", "", 0, int(time.Now().Unix()), 0, 3600, 0, 0, true, bucketSize)
+	if err != nil {
+		t.Fatalf("error creating tournament: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cache.Delete(context.Background(), leaderboard.Id)
+	})
+	return cache, leaderboard
+}
+
+func tournamentBucketSizes(t *testing.T, db *sql.DB, id string) map[int]int {
+	rows, err := db.Query("SELECT bucket, count(*) FROM leaderboard_record WHERE leaderboard_id = $1 GROUP BY bucket", id)
+	if err != nil {
+		t.Fatalf("error listing buckets: %v", err)
+	}
+	defer rows.Close()
+	sizes := make(map[int]int)
+	for rows.Next() {
+		var bucket, size int
+		if err := rows.Scan(&bucket, &size); err != nil {
+			t.Fatalf("error scanning buckets: %v", err)
+		}
+		sizes[bucket] = size
+	}
+	return sizes
+}
+
+func TestTournamentJoinBucketsConcurrent(t *testing.T) {
+	db := NewDB(t)
+	cache, leaderboard := createTestBucketedTournament(t, db, 3)
+
+	// Joins racing for the same places must neither overfill a bucket nor open two buckets with the same number.
+	const owners = 10
+	var wg sync.WaitGroup
+	errs := make([]error, owners)
+	for i := 0; i < owners; i++ {
+		userID := uuid.Must(uuid.NewV4())
+		InsertUser(t, db, userID)
+		wg.Add(1)
+		go func(i int, userID uuid.UUID) {
+			defer wg.Done()
+			errs[i] = TournamentJoin(context.Background(), logger, db, cache, &testLeaderboardScheduler{}, userID.String(), userID.String(), leaderboard.Id, nil, 0)
+		}(i, userID)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+
+	sizes := tournamentBucketSizes(t, db, leaderboard.Id)
+	assert.Equal(t, map[int]int{1: 3, 2: 3, 3: 3, 4: 1}, sizes)
+
+	var total int
+	if err := db.QueryRow("SELECT sum(size) FROM leaderboard_bucket WHERE leaderboard_id = $1", leaderboard.Id).Scan(&total); err != nil {
+		t.Fatalf("error reading bucket sizes: %v", err)
+	}
+	assert.Equal(t, owners, total)
+}
+
+func TestTournamentJoinBucketsByRating(t *testing.T) {
+	db := NewDB(t)
+	cache, leaderboard := createTestBucketedTournament(t, db, 2)
+
+	join := func(rating *int64) string {
+		userID := uuid.Must(uuid.NewV4())
+		InsertUser(t, db, userID)
+		if err := TournamentJoin(context.Background(), logger, db, cache, &testLeaderboardScheduler{}, userID.String(), userID.String(), leaderboard.Id, rating, 100); err != nil {
+			t.Fatalf("error joining tournament: %v", err)
+		}
+		return userID.String()
+	}
+	bucketOf := func(owner string) int {
+		var bucket int
+		if err := db.QueryRow("SELECT bucket FROM leaderboard_record WHERE leaderboard_id = $1 AND owner_id = $2", leaderboard.Id, owner).Scan(&bucket); err != nil {
+			t.Fatalf("error reading bucket: %v", err)
+		}
+		return bucket
+	}
+
+	low, high := int64(120), int64(950)
+	lowA, highA, lowB, unrated := join(&low), join(&high), join(&low), join(nil)
+
+	// Owners in the same rating band share a bucket, other bands and unrated owners are placed apart.
+	assert.Equal(t, bucketOf(lowA), bucketOf(lowB))
+	assert.NotEqual(t, bucketOf(lowA), bucketOf(highA))
+	assert.NotEqual(t, bucketOf(lowA), bucketOf(unrated))
+	assert.NotEqual(t, bucketOf(highA), bucketOf(unrated))
+
+	// Joining again is a no-op and keeps the bucket.
+	lowBucket := bucketOf(lowA)
+	if err := TournamentJoin(context.Background(), logger, db, cache, &testLeaderboardScheduler{}, lowA, lowA, leaderboard.Id, &high, 100); err != nil {
+		t.Fatalf("error joining tournament: %v", err)
+	}
+	assert.Equal(t, lowBucket, bucketOf(lowA))
+}
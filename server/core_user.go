@@ -205,7 +205,7 @@ func BanUsers(ctx context.Context, logger *zap.Logger, db *sql.DB, sessionCache
 	return nil
 }
 
-func UnbanUsers(ctx context.Context, logger *zap.Logger, db *sql.DB, sessionCache SessionCache, ids []uuid.UUID) error {
+func UnbanUsers(ctx context.Context, logger *zap.Logger, db *sql.DB, ids []uuid.UUID) error {
 	statements := make([]string, 0, len(ids))
 	params := make([]interface{}, 0, len(ids))
 	for i, id := range ids {
@@ -220,8 +220,6 @@ func UnbanUsers(ctx context.Context, logger *zap.Logger, db *sql.DB, sessionCach
 		return err
 	}
 
-	sessionCache.Unban(ids)
-
 	return nil
 }
 
@@ -46,28 +46,39 @@ const (
 )
 
 type Leaderboard struct {
-	Id               string
-	Authoritative    bool
-	SortOrder        int
-	Operator         int
-	ResetScheduleStr string
-	ResetSchedule    *cronexpr.Expression
-	Metadata         string
-	CreateTime       int64
-	Category         int
-	Description      string
-	Duration         int
-	EndTime          int64
-	JoinRequired     bool
-	MaxSize          int
-	MaxNumScore      int
-	Title            string
-	StartTime        int64
+	Id                string
+	Authoritative     bool
+	SortOrder         int
+	SubscoreSortOrder int
+	TieBreak          int
+	Operator          int
+	ResetScheduleStr  string
+	ResetSchedule     *cronexpr.Expression
+	Metadata          string
+	CreateTime        int64
+	Category          int
+	Description       string
+	Duration          int
+	EndTime           int64
+	JoinRequired      bool
+	MaxSize           int
+	MaxNumScore       int
+	Title             string
+	StartTime         int64
+	BucketSize        int
+	// Limits on scores submitted by clients, nil if client scores are not validated.
+	Validation *LeaderboardValidation
 }
 
+func (l *Leaderboard) Sort() LeaderboardSort {
+	return LeaderboardSort{SortOrder: l.SortOrder, SubscoreSortOrder: l.SubscoreSortOrder, TieBreak: l.TieBreak}
+}
 func (l *Leaderboard) IsTournament() bool {
 	return l.Duration != 0
 }
+func (l *Leaderboard) IsBucketed() bool {
+	return l.BucketSize > 0
+}
 func (l *Leaderboard) HasMaxSize() bool {
 	return l.MaxSize != math.MaxInt32
 }
@@ -149,12 +160,13 @@ type LeaderboardCache interface {
 	Get(id string) *Leaderboard
 	GetAllLeaderboards() []*Leaderboard
 	RefreshAllLeaderboards(ctx context.Context) error
-	Create(ctx context.Context, id string, authoritative bool, sortOrder, operator int, resetSchedule, metadata string) (*Leaderboard, error)
-	Insert(id string, authoritative bool, sortOrder, operator int, resetSchedule, metadata string, createTime int64)
+	Create(ctx context.Context, id string, authoritative bool, leaderboardSort LeaderboardSort, operator int, resetSchedule, metadata string) (*Leaderboard, error)
+	Insert(id string, authoritative bool, leaderboardSort LeaderboardSort, operator int, resetSchedule, metadata string, createTime int64)
 	List(categoryStart, categoryEnd, limit int, cursor *LeaderboardListCursor) ([]*Leaderboard, *LeaderboardListCursor, error)
-	CreateTournament(ctx context.Context, id string, authoritative bool, sortOrder, operator int, resetSchedule, metadata, title, description string, category, startTime, endTime, duration, maxSize, maxNumScore int, joinRequired bool) (*Leaderboard, error)
-	InsertTournament(id string, authoritative bool, sortOrder, operator int, resetSchedule, metadata, title, description string, category, duration, maxSize, maxNumScore int, joinRequired bool, createTime, startTime, endTime int64)
+	CreateTournament(ctx context.Context, id string, authoritative bool, leaderboardSort LeaderboardSort, operator int, resetSchedule, metadata, title, description string, category, startTime, endTime, duration, maxSize, maxNumScore int, joinRequired bool, bucketSize int) (*Leaderboard, error)
+	InsertTournament(id string, authoritative bool, leaderboardSort LeaderboardSort, operator int, resetSchedule, metadata, title, description string, category, duration, maxSize, maxNumScore int, joinRequired bool, bucketSize int, createTime, startTime, endTime int64)
 	ListTournaments(now int64, categoryStart, categoryEnd int, startTime, endTime int64, limit int, cursor *TournamentListCursor) ([]*Leaderboard, *TournamentListCursor, error)
+	SetValidation(ctx context.Context, id string, validation *LeaderboardValidation) error
 	Delete(ctx context.Context, id string) error
 	Remove(id string)
 }
@@ -190,8 +202,8 @@ func NewLocalLeaderboardCache(logger, startupLogger *zap.Logger, db *sql.DB) Lea
 func (l *LocalLeaderboardCache) RefreshAllLeaderboards(ctx context.Context) error {
 	query := `
 SELECT
-id, authoritative, sort_order, operator, reset_schedule, metadata, create_time,
-category, description, duration, end_time, join_required, max_size, max_num_score, title, start_time
+id, authoritative, sort_order, COALESCE(subscore_sort_order, sort_order), tie_break, operator, reset_schedule, metadata, create_time,
+category, description, duration, end_time, join_required, max_size, max_num_score, title, start_time, bucket_size, validation
 FROM leaderboard`
 
 	rows, err := l.db.QueryContext(ctx, query)
@@ -208,6 +220,8 @@ FROM leaderboard`
 		var id string
 		var authoritative bool
 		var sortOrder int
+		var subscoreSortOrder int
+		var tieBreak int
 		var operator int
 		var resetSchedule sql.NullString
 		var metadata string
@@ -221,9 +235,11 @@ FROM leaderboard`
 		var maxNumScore int
 		var title string
 		var startTime pgtype.Timestamptz
+		var bucketSize int
+		var validation []byte
 
-		err = rows.Scan(&id, &authoritative, &sortOrder, &operator, &resetSchedule, &metadata, &createTime,
-			&category, &description, &duration, &endTime, &joinRequired, &maxSize, &maxNumScore, &title, &startTime)
+		err = rows.Scan(&id, &authoritative, &sortOrder, &subscoreSortOrder, &tieBreak, &operator, &resetSchedule, &metadata, &createTime,
+			&category, &description, &duration, &endTime, &joinRequired, &maxSize, &maxNumScore, &title, &startTime, &bucketSize, &validation)
 		if err != nil {
 			_ = rows.Close()
 			l.logger.Error("Error parsing leaderboard cache from database", zap.Error(err))
@@ -231,10 +247,12 @@ FROM leaderboard`
 		}
 
 		leaderboard := &Leaderboard{
-			Id:            id,
-			Authoritative: authoritative,
-			SortOrder:     sortOrder,
-			Operator:      operator,
+			Id:                id,
+			Authoritative:     authoritative,
+			SortOrder:         sortOrder,
+			SubscoreSortOrder: subscoreSortOrder,
+			TieBreak:          tieBreak,
+			Operator:          operator,
 
 			Metadata:     metadata,
 			CreateTime:   createTime.Time.Unix(),
@@ -247,6 +265,7 @@ FROM leaderboard`
 			MaxNumScore:  maxNumScore,
 			Title:        title,
 			StartTime:    startTime.Time.Unix(),
+			BucketSize:   bucketSize,
 		}
 		if resetSchedule.Valid {
 			expr, err := cronexpr.Parse(resetSchedule.String)
@@ -261,6 +280,13 @@ FROM leaderboard`
 		if endTime.Status == pgtype.Present {
 			leaderboard.EndTime = endTime.Time.Unix()
 		}
+		if validation != nil {
+			if err := json.Unmarshal(validation, &leaderboard.Validation); err != nil {
+				_ = rows.Close()
+				l.logger.Error("Error parsing leaderboard validation from database", zap.Error(err))
+				return err
+			}
+		}
 
 		leaderboards[id] = leaderboard
 
@@ -301,7 +327,7 @@ func (l *LocalLeaderboardCache) GetAllLeaderboards() []*Leaderboard {
 	return leaderboards
 }
 
-func (l *LocalLeaderboardCache) Create(ctx context.Context, id string, authoritative bool, sortOrder, operator int, resetSchedule, metadata string) (*Leaderboard, error) {
+func (l *LocalLeaderboardCache) Create(ctx context.Context, id string, authoritative bool, leaderboardSort LeaderboardSort, operator int, resetSchedule, metadata string) (*Leaderboard, error) {
 	l.RLock()
 	if leaderboard, ok := l.leaderboards[id]; ok {
 		// Creation is an idempotent operation.
@@ -321,16 +347,16 @@ func (l *LocalLeaderboardCache) Create(ctx context.Context, id string, authorita
 	}
 
 	// Insert into database first.
-	query := "INSERT INTO leaderboard (id, authoritative, sort_order, operator, metadata"
+	query := "INSERT INTO leaderboard (id, authoritative, sort_order, subscore_sort_order, tie_break, operator, metadata"
 	if resetSchedule != "" {
 		query += ", reset_schedule"
 	}
-	query += ") VALUES ($1, $2, $3, $4, $5"
+	query += ") VALUES ($1, $2, $3, $4, $5, $6, $7"
 	if resetSchedule != "" {
-		query += ", $6"
+		query += ", $8"
 	}
 	query += ") RETURNING create_time"
-	params := []interface{}{id, authoritative, sortOrder, operator, metadata}
+	params := []interface{}{id, authoritative, leaderboardSort.SortOrder, leaderboardSort.SubscoreSortOrder, leaderboardSort.TieBreak, operator, metadata}
 	if resetSchedule != "" {
 		params = append(params, resetSchedule)
 	}
@@ -340,7 +366,7 @@ func (l *LocalLeaderboardCache) Create(ctx context.Context, id string, authorita
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == dbErrorUniqueViolation {
 			// Concurrent attempt at creating the leaderboard, to keep idempotency query the existing leaderboard data.
-			if err = l.db.QueryRowContext(ctx, "SELECT authoritative, sort_order, operator, COALESCE(reset_schedule, ''), metadata, create_time FROM leaderboard WHERE id = $1", id).Scan(&authoritative, &sortOrder, &operator, &resetSchedule, &metadata, &createTime); err != nil {
+			if err = l.db.QueryRowContext(ctx, "SELECT authoritative, sort_order, COALESCE(subscore_sort_order, sort_order), tie_break, operator, COALESCE(reset_schedule, ''), metadata, create_time FROM leaderboard WHERE id = $1", id).Scan(&authoritative, &leaderboardSort.SortOrder, &leaderboardSort.SubscoreSortOrder, &leaderboardSort.TieBreak, &operator, &resetSchedule, &metadata, &createTime); err != nil {
 				l.logger.Error("Error retrieving leaderboard", zap.Error(err))
 				return nil, err
 			}
@@ -359,14 +385,16 @@ func (l *LocalLeaderboardCache) Create(ctx context.Context, id string, authorita
 
 	// Then add to cache.
 	leaderboard := &Leaderboard{
-		Id:               id,
-		Authoritative:    authoritative,
-		SortOrder:        sortOrder,
-		Operator:         operator,
-		ResetScheduleStr: resetSchedule,
-		ResetSchedule:    expr,
-		Metadata:         metadata,
-		CreateTime:       createTime.Time.Unix(),
+		Id:                id,
+		Authoritative:     authoritative,
+		SortOrder:         leaderboardSort.SortOrder,
+		SubscoreSortOrder: leaderboardSort.SubscoreSortOrder,
+		TieBreak:          leaderboardSort.TieBreak,
+		Operator:          operator,
+		ResetScheduleStr:  resetSchedule,
+		ResetSchedule:     expr,
+		Metadata:          metadata,
+		CreateTime:        createTime.Time.Unix(),
 	}
 
 	l.Lock()
@@ -383,7 +411,7 @@ func (l *LocalLeaderboardCache) Create(ctx context.Context, id string, authorita
 	return leaderboard, nil
 }
 
-func (l *LocalLeaderboardCache) Insert(id string, authoritative bool, sortOrder, operator int, resetSchedule, metadata string, createTime int64) {
+func (l *LocalLeaderboardCache) Insert(id string, authoritative bool, leaderboardSort LeaderboardSort, operator int, resetSchedule, metadata string, createTime int64) {
 	var expr *cronexpr.Expression
 	var err error
 	if resetSchedule != "" {
@@ -396,14 +424,16 @@ func (l *LocalLeaderboardCache) Insert(id string, authoritative bool, sortOrder,
 	}
 
 	leaderboard := &Leaderboard{
-		Id:               id,
-		Authoritative:    authoritative,
-		SortOrder:        sortOrder,
-		Operator:         operator,
-		ResetScheduleStr: resetSchedule,
-		ResetSchedule:    expr,
-		Metadata:         metadata,
-		CreateTime:       createTime,
+		Id:                id,
+		Authoritative:     authoritative,
+		SortOrder:         leaderboardSort.SortOrder,
+		SubscoreSortOrder: leaderboardSort.SubscoreSortOrder,
+		TieBreak:          leaderboardSort.TieBreak,
+		Operator:          operator,
+		ResetScheduleStr:  resetSchedule,
+		ResetSchedule:     expr,
+		Metadata:          metadata,
+		CreateTime:        createTime,
 	}
 
 	l.Lock()
@@ -450,7 +480,7 @@ func (l *LocalLeaderboardCache) List(categoryStart, categoryEnd, limit int, curs
 	return list, newCursor, nil
 }
 
-func (l *LocalLeaderboardCache) CreateTournament(ctx context.Context, id string, authoritative bool, sortOrder, operator int, resetSchedule, metadata, title, description string, category, startTime, endTime, duration, maxSize, maxNumScore int, joinRequired bool) (*Leaderboard, error) {
+func (l *LocalLeaderboardCache) CreateTournament(ctx context.Context, id string, authoritative bool, leaderboardSort LeaderboardSort, operator int, resetSchedule, metadata, title, description string, category, startTime, endTime, duration, maxSize, maxNumScore int, joinRequired bool, bucketSize int) (*Leaderboard, error) {
 	resetCron, err := checkTournamentConfig(resetSchedule, startTime, endTime, duration, maxSize, maxNumScore)
 	if err != nil {
 		l.logger.Error("Error while creating tournament", zap.Error(err))
@@ -469,9 +499,9 @@ func (l *LocalLeaderboardCache) CreateTournament(ctx context.Context, id string,
 		return nil, fmt.Errorf("cannot create tournament as leaderboard is already in use")
 	}
 
-	params := []interface{}{id, authoritative, sortOrder, operator, duration}
-	columns := "id, authoritative, sort_order, operator, duration"
-	values := "$1, $2, $3, $4, $5"
+	params := []interface{}{id, authoritative, leaderboardSort.SortOrder, leaderboardSort.SubscoreSortOrder, leaderboardSort.TieBreak, operator, duration}
+	columns := "id, authoritative, sort_order, subscore_sort_order, tie_break, operator, duration"
+	values := "$1, $2, $3, $4, $5, $6, $7"
 
 	if resetSchedule != "" {
 		params = append(params, resetSchedule)
@@ -536,6 +566,12 @@ func (l *LocalLeaderboardCache) CreateTournament(ctx context.Context, id string,
 		values += ", $" + strconv.Itoa(len(params))
 	}
 
+	if bucketSize > 0 {
+		params = append(params, bucketSize)
+		columns += ", bucket_size"
+		values += ", $" + strconv.Itoa(len(params))
+	}
+
 	query := "INSERT INTO leaderboard (" + columns + ") VALUES (" + values + ") RETURNING metadata, max_size, max_num_score, create_time, start_time, end_time"
 
 	l.logger.Debug("Create tournament query", zap.String("query", query))
@@ -553,23 +589,26 @@ func (l *LocalLeaderboardCache) CreateTournament(ctx context.Context, id string,
 	}
 
 	leaderboard = &Leaderboard{
-		Id:               id,
-		Authoritative:    authoritative,
-		SortOrder:        sortOrder,
-		Operator:         operator,
-		ResetScheduleStr: resetSchedule,
-		ResetSchedule:    resetCron,
-		Metadata:         dbMetadata,
-		CreateTime:       createTime.Time.Unix(),
-		Category:         category,
-		Description:      description,
-		Duration:         duration,
-		EndTime:          0,
-		JoinRequired:     joinRequired,
-		MaxSize:          dbMaxSize,
-		MaxNumScore:      dbMaxNumScore,
-		Title:            title,
-		StartTime:        dbStartTime.Time.Unix(),
+		Id:                id,
+		Authoritative:     authoritative,
+		SortOrder:         leaderboardSort.SortOrder,
+		SubscoreSortOrder: leaderboardSort.SubscoreSortOrder,
+		TieBreak:          leaderboardSort.TieBreak,
+		Operator:          operator,
+		ResetScheduleStr:  resetSchedule,
+		ResetSchedule:     resetCron,
+		Metadata:          dbMetadata,
+		CreateTime:        createTime.Time.Unix(),
+		Category:          category,
+		Description:       description,
+		Duration:          duration,
+		EndTime:           0,
+		JoinRequired:      joinRequired,
+		MaxSize:           dbMaxSize,
+		MaxNumScore:       dbMaxNumScore,
+		Title:             title,
+		StartTime:         dbStartTime.Time.Unix(),
+		BucketSize:        bucketSize,
 	}
 	if dbEndTime.Status == pgtype.Present {
 		leaderboard.EndTime = dbEndTime.Time.Unix()
@@ -584,7 +623,7 @@ func (l *LocalLeaderboardCache) CreateTournament(ctx context.Context, id string,
 	return leaderboard, nil
 }
 
-func (l *LocalLeaderboardCache) InsertTournament(id string, authoritative bool, sortOrder, operator int, resetSchedule, metadata, title, description string, category, duration, maxSize, maxNumScore int, joinRequired bool, createTime, startTime, endTime int64) {
+func (l *LocalLeaderboardCache) InsertTournament(id string, authoritative bool, leaderboardSort LeaderboardSort, operator int, resetSchedule, metadata, title, description string, category, duration, maxSize, maxNumScore int, joinRequired bool, bucketSize int, createTime, startTime, endTime int64) {
 	var expr *cronexpr.Expression
 	var err error
 	if resetSchedule != "" {
@@ -597,23 +636,26 @@ func (l *LocalLeaderboardCache) InsertTournament(id string, authoritative bool,
 	}
 
 	leaderboard := &Leaderboard{
-		Id:               id,
-		Authoritative:    authoritative,
-		SortOrder:        sortOrder,
-		Operator:         operator,
-		ResetScheduleStr: resetSchedule,
-		ResetSchedule:    expr,
-		Metadata:         metadata,
-		CreateTime:       createTime,
-		Category:         category,
-		Description:      description,
-		Duration:         duration,
-		JoinRequired:     joinRequired,
-		MaxSize:          maxSize,
-		MaxNumScore:      maxNumScore,
-		Title:            title,
-		StartTime:        startTime,
-		EndTime:          endTime,
+		Id:                id,
+		Authoritative:     authoritative,
+		SortOrder:         leaderboardSort.SortOrder,
+		SubscoreSortOrder: leaderboardSort.SubscoreSortOrder,
+		TieBreak:          leaderboardSort.TieBreak,
+		Operator:          operator,
+		ResetScheduleStr:  resetSchedule,
+		ResetSchedule:     expr,
+		Metadata:          metadata,
+		CreateTime:        createTime,
+		Category:          category,
+		Description:       description,
+		Duration:          duration,
+		JoinRequired:      joinRequired,
+		MaxSize:           maxSize,
+		MaxNumScore:       maxNumScore,
+		Title:             title,
+		StartTime:         startTime,
+		EndTime:           endTime,
+		BucketSize:        bucketSize,
 	}
 
 	l.Lock()
@@ -672,6 +714,48 @@ func (l *LocalLeaderboardCache) ListTournaments(now int64, categoryStart, catego
 	return list, newCursor, nil
 }
 
+func (l *LocalLeaderboardCache) SetValidation(ctx context.Context, id string, validation *LeaderboardValidation) error {
+	l.RLock()
+	_, leaderboardFound := l.leaderboards[id]
+	l.RUnlock()
+	if !leaderboardFound {
+		return ErrLeaderboardNotFound
+	}
+
+	var validationBytes []byte
+	if validation != nil {
+		var err error
+		if validationBytes, err = json.Marshal(validation); err != nil {
+			return err
+		}
+	}
+
+	if _, err := l.db.ExecContext(ctx, "UPDATE leaderboard SET validation = $2 WHERE id = $1", id, validationBytes); err != nil {
+		l.logger.Error("Error setting leaderboard validation", zap.Error(err))
+		return err
+	}
+
+	l.Lock()
+	// Cached leaderboards are read without holding the lock, so replace the leaderboard rather than update it.
+	if leaderboard, ok := l.leaderboards[id]; ok {
+		updated := *leaderboard
+		updated.Validation = validation
+		l.leaderboards[id] = &updated
+		list := l.leaderboardList
+		if leaderboard.IsTournament() {
+			list = l.tournamentList
+		}
+		for i, currentLeaderboard := range list {
+			if currentLeaderboard == leaderboard {
+				list[i] = &updated
+				break
+			}
+		}
+	}
+	l.Unlock()
+	return nil
+}
+
 func (l *LocalLeaderboardCache) Delete(ctx context.Context, id string) error {
 	l.Lock()
 	leaderboard, leaderboardFound := l.leaderboards[id]
@@ -0,0 +1,179 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"go.uber.org/zap"
+)
+
+// Realtime sessions subscribe to leaderboard and tournament changes with these socket RPC IDs, and a JSON payload with
+// a "leaderboard_id" and optionally a list of friend user IDs to "follow". Events are delivered as stream data messages
+// on a leaderboard stream with the leaderboard ID as label, and the followed user as subject for friend events.
+//
+// Subscribers receive a "rank" event when their own rank changes, a "top" event when a record inside the top ranks
+// changes, and a "friend" event when the record of a user they follow changes. Events are driven from the rank cache,
+// leaderboards excluded from rank caching do not produce events. Runtime RPC functions registered with the same IDs take
+// precedence over them.
+const (
+	LeaderboardSubscribeRpcID   = "nakama.leaderboard_subscribe"
+	LeaderboardUnsubscribeRpcID = "nakama.leaderboard_unsubscribe"
+)
+
+type leaderboardFeedEvent struct {
+	Type          string `json:"type"`
+	LeaderboardID string `json:"leaderboard_id"`
+	// The subscriber's own rank for rank events, 0 if their record was deleted.
+	Rank         int64 `json:"rank,omitempty"`
+	PreviousRank int64 `json:"previous_rank,omitempty"`
+	// The record that changed for top and friend events.
+	Record *leaderboardFeedRecord `json:"record,omitempty"`
+}
+
+type leaderboardFeedRecord struct {
+	OwnerID      string `json:"owner_id"`
+	Score        int64  `json:"score"`
+	Subscore     int64  `json:"subscore"`
+	Rank         int64  `json:"rank"`
+	PreviousRank int64  `json:"previous_rank"`
+}
+
+type LeaderboardFeed struct {
+	logger    *zap.Logger
+	rankCache LeaderboardRankCache
+	tracker   Tracker
+	router    MessageRouter
+	topCount  int64
+}
+
+func NewLeaderboardFeed(logger *zap.Logger, config Config, rankCache LeaderboardRankCache, tracker Tracker, router MessageRouter) *LeaderboardFeed {
+	return &LeaderboardFeed{
+		logger:    logger,
+		rankCache: rankCache,
+		tracker:   tracker,
+		router:    router,
+		topCount:  int64(config.GetLeaderboard().SubscriptionTopCount),
+	}
+}
+
+// RankChange delivers events for a rank cache change to the sessions subscribed to them. Changes arrive after the
+// write that made them, so the ranks of other subscribers are read as they are when the change is delivered.
+func (f *LeaderboardFeed) RankChange(change *LeaderboardRankChange) {
+	record := &leaderboardFeedRecord{
+		OwnerID:      change.OwnerID.String(),
+		Score:        change.Score,
+		Subscore:     change.Subscore,
+		Rank:         change.Rank,
+		PreviousRank: change.PreviousRank,
+	}
+
+	if followers := f.tracker.ListByStream(leaderboardFeedStream(change.LeaderboardId, change.OwnerID), true, false); len(followers) != 0 {
+		f.send(change.LeaderboardId, followers, &leaderboardFeedEvent{Type: "friend", LeaderboardID: change.LeaderboardId, Record: record})
+	}
+
+	subscribers := f.tracker.ListByStream(leaderboardFeedStream(change.LeaderboardId, uuid.Nil), true, false)
+	if len(subscribers) == 0 {
+		return
+	}
+
+	if (change.Rank > 0 && change.Rank <= f.topCount) || (change.PreviousRank > 0 && change.PreviousRank <= f.topCount) {
+		f.send(change.LeaderboardId, subscribers, &leaderboardFeedEvent{Type: "top", LeaderboardID: change.LeaderboardId, Record: record})
+	}
+
+	// Subscribers whose rank moved because of the change, grouped by user since a user may have several sessions.
+	moved := make(map[uuid.UUID][]*Presence)
+	ranks := make(map[uuid.UUID][2]int64)
+	for _, presence := range subscribers {
+		if _, found := ranks[presence.UserID]; !found {
+			if presence.UserID == change.OwnerID {
+				ranks[presence.UserID] = [2]int64{change.Rank, change.PreviousRank}
+			} else {
+				rank := f.rankCache.Get(change.LeaderboardId, change.Expiry, presence.UserID)
+				ranks[presence.UserID] = [2]int64{rank, leaderboardRankShift(change, rank)}
+			}
+		}
+		if r := ranks[presence.UserID]; r[0] != r[1] {
+			moved[presence.UserID] = append(moved[presence.UserID], presence)
+		}
+	}
+	for userID, presences := range moved {
+		r := ranks[userID]
+		f.send(change.LeaderboardId, presences, &leaderboardFeedEvent{Type: "rank", LeaderboardID: change.LeaderboardId, Rank: r[0], PreviousRank: r[1]})
+	}
+}
+
+func (f *LeaderboardFeed) send(leaderboardID string, presences []*Presence, event *leaderboardFeedEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		f.logger.Error("Could not marshal leaderboard event", zap.Error(err))
+		return
+	}
+
+	presenceIDs := make([]*PresenceID, 0, len(presences))
+	for _, presence := range presences {
+		presenceIDs = append(presenceIDs, &presence.ID)
+	}
+	streamWire := &rtapi.Stream{
+		Mode:  int32(StreamModeLeaderboard),
+		Label: leaderboardID,
+	}
+	if event.Type == "friend" {
+		streamWire.Subject = event.Record.OwnerID
+	}
+	envelope := &rtapi.Envelope{Message: &rtapi.Envelope_StreamData{StreamData: &rtapi.StreamData{
+		Stream: streamWire,
+		// No sender.
+		Data:     string(data),
+		Reliable: true,
+	}}}
+	f.router.SendToPresenceIDs(f.logger, presenceIDs, envelope, true)
+}
+
+// The stream followed by subscribers of a leaderboard, or by followers of a user's record on it.
+func leaderboardFeedStream(leaderboardID string, userID uuid.UUID) PresenceStream {
+	return PresenceStream{Mode: StreamModeLeaderboard, Label: leaderboardID, Subject: userID}
+}
+
+// Find the rank an owner had before another owner's change, the same as their current rank if the change did not move them.
+func leaderboardRankShift(change *LeaderboardRankChange, rank int64) int64 {
+	switch {
+	case rank == 0:
+		// Not ranked at all.
+	case change.PreviousRank == 0:
+		// A new record pushes down everyone ranked after it.
+		if rank > change.Rank {
+			return rank - 1
+		}
+	case change.Rank == 0:
+		// A deleted record moves up everyone ranked after it.
+		if rank >= change.PreviousRank {
+			return rank + 1
+		}
+	case change.Rank < change.PreviousRank:
+		// An improved record pushes down everyone it overtook.
+		if rank > change.Rank && rank <= change.PreviousRank {
+			return rank - 1
+		}
+	case change.Rank > change.PreviousRank:
+		// A worse record moves up everyone that overtook it.
+		if rank >= change.PreviousRank && rank < change.Rank {
+			return rank + 1
+		}
+	}
+	return rank
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderboardFeedEvents(t *testing.T) {
+	tracker := StartLocalTracker(logger, cfg, NewLocalSessionRegistry(&testMetrics{}), nil, &testMetrics{}, protojsonMarshaler, NewLocalClusterTransport("node"))
+	defer tracker.Stop()
+
+	received := make(map[uuid.UUID][]*leaderboardFeedEvent)
+	router := &testMessageRouter{sendToPresence: func(presences []*PresenceID, envelope *rtapi.Envelope) {
+		var event *leaderboardFeedEvent
+		if err := json.Unmarshal([]byte(envelope.GetStreamData().Data), &event); err != nil {
+			t.Fatalf("error unmarshalling event: %v", err)
+		}
+		for _, presence := range presences {
+			received[presence.SessionID] = append(received[presence.SessionID], event)
+		}
+	}}
+
+	rankCache := &LocalLeaderboardRankCache{
+		logger:       logger,
+		blacklistIds: make(map[string]struct{}, 0),
+		blacklistAll: false,
+		cache:        make(map[LeaderboardWithExpiry]*RankCache, 0),
+		rankChangeCh: make(chan *LeaderboardRankChange, 1),
+		stopCh:       make(chan struct{}),
+	}
+	go rankCache.processRankChanges()
+	defer rankCache.Stop()
+	config := NewConfig(logger)
+	config.Leaderboard.SubscriptionTopCount = 1
+	feed := NewLeaderboardFeed(logger, config, rankCache, tracker, router)
+
+	// Changes are delivered asynchronously, wait for each one so ranks are read before the next change.
+	delivered := make(chan struct{})
+	rankCache.SetRankChangeListener(func(change *LeaderboardRankChange) {
+		feed.RankChange(change)
+		delivered <- struct{}{}
+	})
+
+	u1 := uuid.Must(uuid.NewV4())
+	u2 := uuid.Must(uuid.NewV4())
+	u3 := uuid.Must(uuid.NewV4())
+	subscribe := func(userID uuid.UUID, stream PresenceStream) uuid.UUID {
+		sessionID := uuid.Must(uuid.NewV4())
+		if success, _ := tracker.Track(context.Background(), sessionID, stream, userID, PresenceMeta{Hidden: true}, true); !success {
+			t.Fatal("expected subscription to be tracked")
+		}
+		return sessionID
+	}
+	s1 := subscribe(u1, leaderboardFeedStream("lid", uuid.Nil))
+	s2 := subscribe(u2, leaderboardFeedStream("lid", uuid.Nil))
+	s3 := subscribe(u3, leaderboardFeedStream("lid", uuid.Nil))
+	follower := subscribe(u1, leaderboardFeedStream("lid", u3))
+
+	rankCache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u1, 10, 0, 0)
+	<-delivered
+	rankCache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u2, 20, 0, 0)
+	<-delivered
+	rankCache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u3, 5, 0, 0)
+	<-delivered
+	rankCache.Delete("lid", 0, u2)
+	<-delivered
+
+	rankEvents := func(sessionID uuid.UUID) [][2]int64 {
+		ranks := make([][2]int64, 0)
+		for _, event := range received[sessionID] {
+			if event.Type == "rank" {
+				ranks = append(ranks, [2]int64{event.Rank, event.PreviousRank})
+			}
+		}
+		return ranks
+	}
+	assert.Equal(t, [][2]int64{{1, 0}, {2, 1}, {1, 2}}, rankEvents(s1))
+	assert.Equal(t, [][2]int64{{1, 0}, {0, 1}}, rankEvents(s2))
+	assert.Equal(t, [][2]int64{{3, 0}, {2, 3}}, rankEvents(s3))
+
+	// Only changes inside the top ranks are reported as top events, to every subscriber.
+	top := make([]string, 0)
+	for _, event := range received[s3] {
+		if event.Type == "top" {
+			top = append(top, event.Record.OwnerID)
+		}
+	}
+	assert.Equal(t, []string{u1.String(), u2.String(), u2.String()}, top)
+
+	// Followers only see the followed user's record.
+	if assert.Len(t, received[follower], 1) {
+		event := received[follower][0]
+		assert.Equal(t, "friend", event.Type)
+		assert.Equal(t, &leaderboardFeedRecord{OwnerID: u3.String(), Score: 5, Rank: 3}, event.Record)
+	}
+}
+
+func TestLeaderboardRankShift(t *testing.T) {
+	tests := []struct {
+		name   string
+		change *LeaderboardRankChange
+		rank   int64
+		want   int64
+	}{
+		{"new above", &LeaderboardRankChange{Rank: 2}, 3, 2},
+		{"new below", &LeaderboardRankChange{Rank: 2}, 1, 1},
+		{"deleted above", &LeaderboardRankChange{PreviousRank: 2}, 2, 3},
+		{"deleted below", &LeaderboardRankChange{PreviousRank: 2}, 1, 1},
+		{"improved overtaken", &LeaderboardRankChange{Rank: 1, PreviousRank: 3}, 3, 2},
+		{"improved not overtaken", &LeaderboardRankChange{Rank: 2, PreviousRank: 3}, 1, 1},
+		{"worsened overtaken", &LeaderboardRankChange{Rank: 3, PreviousRank: 1}, 1, 2},
+		{"worsened not overtaken", &LeaderboardRankChange{Rank: 3, PreviousRank: 2}, 4, 4},
+		{"unranked", &LeaderboardRankChange{Rank: 1}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, leaderboardRankShift(tt.change, tt.rank))
+		})
+	}
+}
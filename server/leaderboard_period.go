@@ -0,0 +1,159 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Past periods further back than this are not looked up by how many periods ago they ended, each lookup walks the reset
+// schedule back to the period. They can still be listed page by page, or read directly by their expiry time.
+const LeaderboardPeriodMaxAgo = 1000
+
+var (
+	ErrLeaderboardNoResetSchedule = errors.New("leaderboard has no reset schedule")
+	ErrLeaderboardInvalidPeriod   = errors.New("leaderboard period invalid")
+	ErrLeaderboardPeriodNotFound  = errors.New("leaderboard period not found")
+)
+
+type LeaderboardPeriod struct {
+	// Expiry time identifying the records of the period, to list them with LeaderboardRecordsList.
+	ExpiryTime int64
+	// The best records of the period when it ended.
+	Records []*api.LeaderboardRecord
+}
+
+type leaderboardPeriodListCursor struct {
+	LeaderboardId string
+	// Expiry time of the last period listed, the next page starts with the period before it.
+	ExpiryTime int64
+}
+
+// LeaderboardPeriodExpiry finds the expiry time identifying the records of the period a number of periods before the
+// current one, 1 being the period that ended most recently, up to LeaderboardPeriodMaxAgo.
+func LeaderboardPeriodExpiry(leaderboard *Leaderboard, periodsAgo int, now time.Time) (int64, error) {
+	if leaderboard.ResetSchedule == nil {
+		return 0, ErrLeaderboardNoResetSchedule
+	}
+	if periodsAgo < 1 || periodsAgo > LeaderboardPeriodMaxAgo {
+		return 0, ErrLeaderboardInvalidPeriod
+	}
+
+	expiries := leaderboardPeriodExpiries(leaderboard, leaderboard.ResetSchedule.Next(now.UTC()), periodsAgo)
+	if len(expiries) < periodsAgo {
+		// The leaderboard did not exist yet.
+		return 0, ErrLeaderboardPeriodNotFound
+	}
+	return expiries[periodsAgo-1], nil
+}
+
+func LeaderboardPeriodsList(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, leaderboardId string, limit, topCount int, cursor string) ([]*LeaderboardPeriod, string, error) {
+	leaderboard := leaderboardCache.Get(leaderboardId)
+	if leaderboard == nil {
+		return nil, "", ErrLeaderboardNotFound
+	}
+	if leaderboard.ResetSchedule == nil {
+		return nil, "", ErrLeaderboardNoResetSchedule
+	}
+
+	before := leaderboard.ResetSchedule.Next(time.Now().UTC())
+	if cursor != "" {
+		cb, err := base64.URLEncoding.DecodeString(cursor)
+		if err != nil {
+			return nil, "", ErrLeaderboardInvalidCursor
+		}
+		incomingCursor := &leaderboardPeriodListCursor{}
+		if err := gob.NewDecoder(bytes.NewReader(cb)).Decode(incomingCursor); err != nil {
+			return nil, "", ErrLeaderboardInvalidCursor
+		}
+		if incomingCursor.LeaderboardId != leaderboardId {
+			return nil, "", ErrLeaderboardInvalidCursor
+		}
+		before = time.Unix(incomingCursor.ExpiryTime, 0).UTC()
+	}
+
+	// Look for one more period than needed to know if there is a next page.
+	expiries := leaderboardPeriodExpiries(leaderboard, before, limit+1)
+	var newCursor string
+	if len(expiries) > limit {
+		expiries = expiries[:limit]
+		cursorBuf := new(bytes.Buffer)
+		if err := gob.NewEncoder(cursorBuf).Encode(&leaderboardPeriodListCursor{LeaderboardId: leaderboardId, ExpiryTime: expiries[limit-1]}); err != nil {
+			logger.Error("Error creating leaderboard periods list cursor", zap.Error(err))
+			return nil, "", err
+		}
+		newCursor = base64.URLEncoding.EncodeToString(cursorBuf.Bytes())
+	}
+
+	periods := make([]*LeaderboardPeriod, 0, len(expiries))
+	for _, expiry := range expiries {
+		period := &LeaderboardPeriod{ExpiryTime: expiry}
+		if topCount > 0 {
+			list, err := LeaderboardRecordsList(ctx, logger, db, leaderboardCache, rankCache, leaderboardId, &wrapperspb.Int32Value{Value: int32(topCount)}, "", nil, nil, expiry)
+			if err != nil {
+				return nil, "", err
+			}
+			period.Records = list.Records
+		}
+		periods = append(periods, period)
+	}
+
+	return periods, newCursor, nil
+}
+
+// Expiry times of up to count periods of a leaderboard ending before the given time, newest first. Periods that ended
+// before the leaderboard was created are not included.
+func leaderboardPeriodExpiries(leaderboard *Leaderboard, before time.Time, count int) []int64 {
+	created := time.Unix(leaderboard.CreateTime, 0).UTC()
+	resets := leaderboard.ResetSchedule.NextN(before, 2)
+	if len(resets) < 2 || !before.After(created) || count < 1 {
+		return []int64{}
+	}
+
+	// Schedules may not be regular, widen the search until enough resets are found or it reaches the creation time.
+	// The count is not trusted for preallocation, the slice only grows with the resets actually found.
+	var expiries []int64
+	for window := resets[1].Sub(resets[0]) * time.Duration(count+1); ; window *= 2 {
+		start := before.Add(-window)
+		if window <= 0 || window >= before.Sub(created) {
+			start = created
+		}
+		expiries = expiries[:0]
+		for t := leaderboard.ResetSchedule.Next(start); !t.IsZero() && t.Before(before); t = leaderboard.ResetSchedule.Next(t) {
+			expiries = append(expiries, t.Unix())
+		}
+		if len(expiries) >= count || start.Equal(created) {
+			break
+		}
+	}
+
+	for i, j := 0, len(expiries)-1; i < j; i, j = i+1, j-1 {
+		expiries[i], expiries[j] = expiries[j], expiries[i]
+	}
+	if len(expiries) > count {
+		expiries = expiries[:count]
+	}
+	return expiries
+}
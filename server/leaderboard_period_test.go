@@ -0,0 +1,108 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama/v3/apigrpc"
+	"github.com/heroiclabs/nakama/v3/internal/cronexpr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestLeaderboardPeriodExpiry(t *testing.T) {
+	now := time.Date(2022, 11, 10, 12, 0, 0, 0, time.UTC)
+	leaderboard := &Leaderboard{
+		Id:            "weekly",
+		ResetSchedule: cronexpr.MustParse("0 0 * * 1"),
+		CreateTime:    time.Date(2022, 10, 20, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+
+	// The current period ends on Monday the 14th, the one before it ended on Monday the 7th.
+	expiry, err := LeaderboardPeriodExpiry(leaderboard, 1, now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 11, 7, 0, 0, 0, 0, time.UTC).Unix(), expiry)
+
+	expiry, err = LeaderboardPeriodExpiry(leaderboard, 3, now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 10, 24, 0, 0, 0, 0, time.UTC).Unix(), expiry)
+
+	// The leaderboard did not exist before then.
+	_, err = LeaderboardPeriodExpiry(leaderboard, 4, now)
+	assert.Equal(t, ErrLeaderboardPeriodNotFound, err)
+
+	_, err = LeaderboardPeriodExpiry(leaderboard, 0, now)
+	assert.Equal(t, ErrLeaderboardInvalidPeriod, err)
+
+	// Lookups far into the past are rejected rather than walking the schedule back.
+	_, err = LeaderboardPeriodExpiry(leaderboard, 1<<62, now)
+	assert.Equal(t, ErrLeaderboardInvalidPeriod, err)
+
+	_, err = LeaderboardPeriodExpiry(&Leaderboard{Id: "static"}, 1, now)
+	assert.Equal(t, ErrLeaderboardNoResetSchedule, err)
+}
+
+func TestLeaderboardPeriodExpiries(t *testing.T) {
+	leaderboard := &Leaderboard{
+		Id:            "monthly",
+		ResetSchedule: cronexpr.MustParse("0 0 1 * *"),
+		CreateTime:    time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+	before := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	// Irregular periods are all found, newest first.
+	expiries := leaderboardPeriodExpiries(leaderboard, before, 3)
+	assert.Equal(t, []int64{
+		time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	}, expiries)
+
+	// Listing stops at the period the leaderboard was created in.
+	expiries = leaderboardPeriodExpiries(leaderboard, before, 100)
+	assert.Len(t, expiries, 14)
+	assert.Equal(t, time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC).Unix(), expiries[len(expiries)-1])
+
+	assert.Empty(t, leaderboardPeriodExpiries(leaderboard, time.Unix(leaderboard.CreateTime, 0), 10))
+}
+
+func TestLeaderboardPeriodApiInvalidArguments(t *testing.T) {
+	runtime, _, err := runtimeWithModules(t, map[string]string{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	apiserver, _ := NewAPIServer(t, runtime)
+	defer apiserver.Stop()
+	conn, client, _, ctx := NewAuthenticatedAPIClient(t, uuid.Must(uuid.NewV4()).String())
+	defer conn.Close()
+
+	_, err = client.ListLeaderboardPeriods(ctx, &apigrpc.ListLeaderboardPeriodsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.ListLeaderboardPeriods(ctx, &apigrpc.ListLeaderboardPeriodsRequest{LeaderboardId: "leaderboard", Limit: &wrapperspb.Int32Value{Value: 101}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.ListLeaderboardPeriods(ctx, &apigrpc.ListLeaderboardPeriodsRequest{LeaderboardId: "leaderboard", TopCount: &wrapperspb.Int32Value{Value: -1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	for _, periodsAgo := range []int32{-1, LeaderboardPeriodMaxAgo + 1} {
+		_, err = client.ListLeaderboardRecordsWithOptions(ctx, &apigrpc.ListLeaderboardRecordsWithOptionsRequest{LeaderboardId: "leaderboard", PeriodsAgo: periodsAgo})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), periodsAgo)
+	}
+}
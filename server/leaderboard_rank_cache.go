@@ -17,22 +17,43 @@ package server
 import (
 	"context"
 	"database/sql"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/heroiclabs/nakama-common/api"
 	"github.com/heroiclabs/nakama/v3/internal/skiplist"
+	"github.com/jackc/pgtype"
 	"go.uber.org/zap"
 )
 
 type LeaderboardRankCache interface {
 	Get(leaderboardId string, expiryUnix int64, ownerID uuid.UUID) int64
 	Fill(leaderboardId string, expiryUnix int64, records []*api.LeaderboardRecord)
-	Insert(leaderboardId string, expiryUnix int64, sortOrder int, ownerID uuid.UUID, score, subscore int64) int64
+	Insert(leaderboardId string, expiryUnix int64, sort LeaderboardSort, ownerID uuid.UUID, score, subscore, updateTime int64) int64
 	Delete(leaderboardId string, expiryUnix int64, ownerID uuid.UUID) bool
 	DeleteLeaderboard(leaderboardId string, expiryUnix int64) bool
 	TrimExpired(nowUnix int64) bool
+	SetRankChangeListener(func(change *LeaderboardRankChange))
+	Approximate(leaderboardId string) bool
+	UpdateApproximate(leaderboardId string, expiryUnix int64, sort LeaderboardSort, previousScore, score *int64) int64
+	Count(leaderboardId string, expiryUnix int64) (int64, bool)
+	Stop()
+}
+
+// A record inserted into or deleted from the rank cache, reported after the cache is updated.
+type LeaderboardRankChange struct {
+	LeaderboardId string
+	Expiry        int64
+	OwnerID       uuid.UUID
+	Score         int64
+	Subscore      int64
+	// The owner's new rank, or 0 if the record was deleted.
+	Rank int64
+	// The owner's rank before the change, or 0 if they had no record.
+	PreviousRank int64
 }
 
 type LeaderboardWithExpiry struct {
@@ -86,30 +107,94 @@ func (r *RankDesc) Less(other interface{}) bool {
 	return ro.OwnerId.String() < r.OwnerId.String()
 }
 
+// Rank data for leaderboards with a non-uniform sort, update times are in microseconds.
+type RankSorted struct {
+	OwnerId    uuid.UUID
+	Score      int64
+	Subscore   int64
+	UpdateTime int64
+	Sort       LeaderboardSort
+}
+
+func (r *RankSorted) Less(other interface{}) bool {
+	ro := other.(*RankSorted)
+	if r.Score != ro.Score {
+		return (r.Score < ro.Score) == (r.Sort.SortOrder == LeaderboardSortOrderAscending)
+	}
+	if r.Subscore != ro.Subscore {
+		return (r.Subscore < ro.Subscore) == (r.Sort.SubscoreSortOrder == LeaderboardSortOrderAscending)
+	}
+	if r.Sort.TieBreak == LeaderboardTieBreakTime && r.UpdateTime != ro.UpdateTime {
+		// Earliest first.
+		return r.UpdateTime < ro.UpdateTime
+	}
+	if r.Sort.SortOrder == LeaderboardSortOrderAscending {
+		return r.OwnerId.String() < ro.OwnerId.String()
+	}
+	return ro.OwnerId.String() < r.OwnerId.String()
+}
+
 type RankCache struct {
 	sync.RWMutex
 	owners map[uuid.UUID]skiplist.Interface
 	cache  *skiplist.SkipList
+	// Latest update time of the cached records in microseconds, used to tell if a snapshot of them is stale.
+	maxUpdateTime int64
 }
 
 type LocalLeaderboardRankCache struct {
 	sync.RWMutex
-	blacklistAll bool
-	blacklistIds map[string]struct{}
-	cache        map[LeaderboardWithExpiry]*RankCache
+	logger             *zap.Logger
+	blacklistAll       bool
+	blacklistIds       map[string]struct{}
+	cache              map[LeaderboardWithExpiry]*RankCache
+	rankChangeListener atomic.Value
+	rankChangeCh       chan *LeaderboardRankChange
+
+	approximateAll bool
+	approximateIds map[string]struct{}
+	sketches       map[LeaderboardWithExpiry]*rankSketch
+
+	snapshotPath     string
+	snapshotInterval time.Duration
+	initDone         chan struct{}
+	stopCh           chan struct{}
+	stopOnce         sync.Once
+	snapshotWg       sync.WaitGroup
 }
 
 var _ LeaderboardRankCache = &LocalLeaderboardRankCache{}
 
-func NewLocalLeaderboardRankCache(ctx context.Context, startupLogger *zap.Logger, db *sql.DB, config *LeaderboardConfig, leaderboardCache LeaderboardCache) LeaderboardRankCache {
+func NewLocalLeaderboardRankCache(ctx context.Context, logger, startupLogger *zap.Logger, db *sql.DB, config Config, leaderboardCache LeaderboardCache) LeaderboardRankCache {
+	leaderboardConfig := config.GetLeaderboard()
 	cache := &LocalLeaderboardRankCache{
-		blacklistIds: make(map[string]struct{}, len(config.BlacklistRankCache)),
-		blacklistAll: len(config.BlacklistRankCache) == 1 && config.BlacklistRankCache[0] == "*",
+		logger:       logger,
+		blacklistIds: make(map[string]struct{}, len(leaderboardConfig.BlacklistRankCache)),
+		blacklistAll: len(leaderboardConfig.BlacklistRankCache) == 1 && leaderboardConfig.BlacklistRankCache[0] == "*",
 		cache:        make(map[LeaderboardWithExpiry]*RankCache, 0),
+		rankChangeCh: make(chan *LeaderboardRankChange, leaderboardConfig.SubscriptionQueueSize),
+
+		approximateAll: len(leaderboardConfig.ApproximateRankCache) == 1 && leaderboardConfig.ApproximateRankCache[0] == "*",
+		approximateIds: make(map[string]struct{}, len(leaderboardConfig.ApproximateRankCache)),
+		sketches:       make(map[LeaderboardWithExpiry]*rankSketch),
+
+		snapshotPath:     filepath.Join(config.GetDataDir(), leaderboardRankCacheSnapshotFilename),
+		snapshotInterval: time.Duration(leaderboardConfig.RankCacheSnapshotIntervalSec) * time.Second,
+		initDone:         make(chan struct{}),
+		stopCh:           make(chan struct{}),
 	}
 
+	for _, id := range leaderboardConfig.BlacklistRankCache {
+		cache.blacklistIds[id] = struct{}{}
+	}
+	for _, id := range leaderboardConfig.ApproximateRankCache {
+		cache.approximateIds[id] = struct{}{}
+	}
+
+	go cache.processRankChanges()
+
 	// If caching is disabled completely do not preload any records.
-	if cache.blacklistAll {
+	if cache.blacklistAll && !cache.approximateAll && len(cache.approximateIds) == 0 {
 		startupLogger.Info("Skipping leaderboard rank cache initialization")
 		return cache
 	}
@@ -118,20 +203,24 @@ func NewLocalLeaderboardRankCache(ctx context.Context, startupLogger *zap.Logger
 
 	nowTime := time.Now().UTC()
 
+	// Ranks are restored from the last snapshot where it is still up to date, rather than read from the database.
+	var snapshot map[LeaderboardWithExpiry]*leaderboardRankCacheSnapshotBoard
+	if cache.snapshotInterval > 0 {
+		var err error
+		if snapshot, err = readLeaderboardRankCacheSnapshot(cache.snapshotPath); err != nil {
+			startupLogger.Warn("Failed to read leaderboard rank cache snapshot, ranks will be read from the database", zap.String("path", cache.snapshotPath), zap.Error(err))
+		}
+	}
+
 	go func() {
+		defer close(cache.initDone)
+
+		restoredLeaderboards := make([]string, 0, len(snapshot))
 		skippedLeaderboards := make([]string, 0, 10)
+		approximateLeaderboards := make([]string, 0, 10)
 		leaderboards := leaderboardCache.GetAllLeaderboards()
 		cachedLeaderboards := make([]string, 0, len(leaderboards))
 		for _, leaderboard := range leaderboards {
-			if _, ok := cache.blacklistIds[leaderboard.Id]; ok {
-				startupLogger.Debug("Skip caching leaderboard ranks", zap.String("leaderboard_id", leaderboard.Id))
-				skippedLeaderboards = append(skippedLeaderboards, leaderboard.Id)
-				continue
-			}
-
-			cachedLeaderboards = append(cachedLeaderboards, leaderboard.Id)
-			startupLogger.Debug("Caching leaderboard ranks", zap.String("leaderboard_id", leaderboard.Id))
-
 			// Current expiry for this leaderboard.
 			// This matches calculateTournamentDeadlines
 			var expiryUnix int64
@@ -144,6 +233,21 @@ func NewLocalLeaderboardRankCache(ctx context.Context, startupLogger *zap.Logger
 				expiryUnix = leaderboard.EndTime
 			}
 
+			if _, ok := cache.blacklistIds[leaderboard.Id]; ok || cache.blacklistAll {
+				if cache.Approximate(leaderboard.Id) {
+					startupLogger.Debug("Counting leaderboard scores for approximate ranks", zap.String("leaderboard_id", leaderboard.Id))
+					cache.initApproximate(ctx, startupLogger, db, leaderboard, expiryUnix)
+					approximateLeaderboards = append(approximateLeaderboards, leaderboard.Id)
+					continue
+				}
+				startupLogger.Debug("Skip caching leaderboard ranks", zap.String("leaderboard_id", leaderboard.Id))
+				skippedLeaderboards = append(skippedLeaderboards, leaderboard.Id)
+				continue
+			}
+
+			cachedLeaderboards = append(cachedLeaderboards, leaderboard.Id)
+			startupLogger.Debug("Caching leaderboard ranks", zap.String("leaderboard_id", leaderboard.Id))
+
 			// Prepare structure to receive rank data.
 			key := LeaderboardWithExpiry{LeaderboardId: leaderboard.Id, Expiry: expiryUnix}
 			cache.Lock()
@@ -157,16 +261,29 @@ func NewLocalLeaderboardRankCache(ctx context.Context, startupLogger *zap.Logger
 			}
 			cache.Unlock()
 
+			if board, found := snapshot[key]; found {
+				fresh, err := board.fresh(ctx, db, leaderboard.Sort())
+				if err != nil {
+					startupLogger.Error("Failed to check leaderboard rank cache snapshot", zap.String("leaderboard_id", leaderboard.Id), zap.Error(err))
+				} else if fresh {
+					board.restore(rankCache, leaderboard.Sort())
+					restoredLeaderboards = append(restoredLeaderboards, leaderboard.Id)
+					continue
+				}
+			}
+
 			expiryTime := time.Unix(expiryUnix, 0).UTC()
 
 			// Look up all active records for this leaderboard.
 			var score int64
 			var subscore int64
+			var updateTime pgtype.Timestamptz
+			var maxUpdateTime pgtype.Timestamptz
 			var ownerIDStr string
 			for {
 				ranks := make(map[uuid.UUID]skiplist.Interface, 10_000)
 
-				query := "SELECT owner_id, score, subscore FROM leaderboard_record WHERE leaderboard_id = $1 AND expiry_time = $2"
+				query := "SELECT owner_id, score, subscore, update_time FROM leaderboard_record WHERE leaderboard_id = $1 AND expiry_time = $2"
 				params := []interface{}{leaderboard.Id, expiryTime}
 				if ownerIDStr != "" {
 					query += " AND (leaderboard_id, expiry_time, score, subscore, owner_id) > ($1, $2, $3, $4, $5)"
@@ -183,7 +300,7 @@ func NewLocalLeaderboardRankCache(ctx context.Context, startupLogger *zap.Logger
 
 				// Read score information.
 				for rows.Next() {
-					if err = rows.Scan(&ownerIDStr, &score, &subscore); err != nil {
+					if err = rows.Scan(&ownerIDStr, &score, &subscore, &updateTime); err != nil {
 						_ = rows.Close()
 						startupLogger.Error("Failed to scan leaderboard rank data", zap.String("leaderboard_id", leaderboard.Id), zap.Error(err))
 						break
@@ -196,21 +313,10 @@ func NewLocalLeaderboardRankCache(ctx context.Context, startupLogger *zap.Logger
 					}
 
 					// Prepare new rank data for this leaderboard entry.
-					var rankData skiplist.Interface
-					if leaderboard.SortOrder == LeaderboardSortOrderDescending {
-						rankData = &RankDesc{
-							OwnerId:  ownerID,
-							Score:    score,
-							Subscore: subscore,
-						}
-					} else {
-						rankData = &RankAsc{
-							OwnerId:  ownerID,
-							Score:    score,
-							Subscore: subscore,
-						}
+					ranks[ownerID] = leaderboard.Sort().rankData(ownerID, score, subscore, updateTime.Time.UnixMicro())
+					if maxUpdateTime.Status != pgtype.Present || updateTime.Time.After(maxUpdateTime.Time) {
+						maxUpdateTime = updateTime
 					}
-					ranks[ownerID] = rankData
 				}
 				_ = rows.Close()
 
@@ -228,6 +334,9 @@ func NewLocalLeaderboardRankCache(ctx context.Context, startupLogger *zap.Logger
 					rankCache.owners[ownerID] = rankData
 					rankCache.cache.Insert(rankData)
 				}
+				if u := maxUpdateTime.Time.UnixMicro(); maxUpdateTime.Status == pgtype.Present && u > rankCache.maxUpdateTime {
+					rankCache.maxUpdateTime = u
+				}
 				rankCache.Unlock()
 
 				// Stop pagination when reaching the last (incomplete) page.
@@ -237,9 +346,14 @@ func NewLocalLeaderboardRankCache(ctx context.Context, startupLogger *zap.Logger
 			}
 		}
 
-		startupLogger.Info("Leaderboard rank cache initialization completed successfully", zap.Strings("cached", cachedLeaderboards), zap.Strings("skipped", skippedLeaderboards))
+		startupLogger.Info("Leaderboard rank cache initialization completed successfully", zap.Strings("cached", cachedLeaderboards), zap.Strings("restored", restoredLeaderboards), zap.Strings("approximate", approximateLeaderboards), zap.Strings("skipped", skippedLeaderboards))
 	}()
 
+	if cache.snapshotInterval > 0 {
+		cache.snapshotWg.Add(1)
+		go cache.snapshotLoop()
+	}
+
 	return cache
 }
 
@@ -276,12 +390,9 @@ func (l *LocalLeaderboardRankCache) Get(leaderboardId string, expiryUnix int64,
 }
 
 func (l *LocalLeaderboardRankCache) Fill(leaderboardId string, expiryUnix int64, records []*api.LeaderboardRecord) {
-	if l.blacklistAll {
-		// If all rank caching is disabled.
-		return
-	}
-	if _, ok := l.blacklistIds[leaderboardId]; ok {
-		// If rank caching is disabled for this particular leaderboard.
+	if _, ok := l.blacklistIds[leaderboardId]; ok || l.blacklistAll {
+		// If rank caching is disabled ranks may still be estimated.
+		l.fillApproximate(leaderboardId, expiryUnix, records)
 		return
 	}
 
@@ -315,7 +426,8 @@ func (l *LocalLeaderboardRankCache) Fill(leaderboardId string, expiryUnix int64,
 	rankCache.RUnlock()
 }
 
-func (l *LocalLeaderboardRankCache) Insert(leaderboardId string, expiryUnix int64, sortOrder int, ownerID uuid.UUID, score, subscore int64) int64 {
+// Insert or update an owner's rank data, update times are in microseconds and only used by sorts that break ties by time.
+func (l *LocalLeaderboardRankCache) Insert(leaderboardId string, expiryUnix int64, sort LeaderboardSort, ownerID uuid.UUID, score, subscore, updateTime int64) int64 {
 	if l.blacklistAll {
 		// If all rank caching is disabled.
 		return 0
@@ -346,31 +458,35 @@ func (l *LocalLeaderboardRankCache) Insert(leaderboardId string, expiryUnix int6
 	}
 
 	// Prepare new rank data for this leaderboard entry.
-	var rankData skiplist.Interface
-	if sortOrder == LeaderboardSortOrderDescending {
-		rankData = &RankDesc{
-			OwnerId:  ownerID,
-			Score:    score,
-			Subscore: subscore,
-		}
-	} else {
-		rankData = &RankAsc{
-			OwnerId:  ownerID,
-			Score:    score,
-			Subscore: subscore,
-		}
-	}
+	rankData := sort.rankData(ownerID, score, subscore, updateTime)
 
 	// Check for and remove any previous rank entry, then insert the new rank data and get its rank.
+	var previousRank int
 	rankCache.Lock()
 	if oldRankData, ok := rankCache.owners[ownerID]; ok {
+		previousRank = rankCache.cache.GetRank(oldRankData)
 		rankCache.cache.Delete(oldRankData)
 	}
 	rankCache.owners[ownerID] = rankData
 	rankCache.cache.Insert(rankData)
 	rank := rankCache.cache.GetRank(rankData)
+	if updateTime > rankCache.maxUpdateTime {
+		rankCache.maxUpdateTime = updateTime
+	}
 	rankCache.Unlock()
 
+	if l.rankChangeListener.Load() != nil {
+		l.queueRankChange(&LeaderboardRankChange{
+			LeaderboardId: leaderboardId,
+			Expiry:        expiryUnix,
+			OwnerID:       ownerID,
+			Score:         score,
+			Subscore:      subscore,
+			Rank:          int64(rank),
+			PreviousRank:  int64(previousRank),
+		})
+	}
+
 	return int64(rank)
 }
 
@@ -402,14 +518,39 @@ func (l *LocalLeaderboardRankCache) Delete(leaderboardId string, expiryUnix int6
 		rankCache.Unlock()
 		return true
 	}
+	previousRank := rankCache.cache.GetRank(rankData)
 	delete(rankCache.owners, ownerID)
 	rankCache.cache.Delete(rankData)
 	rankCache.Unlock()
 
+	if l.rankChangeListener.Load() != nil {
+		change := &LeaderboardRankChange{
+			LeaderboardId: leaderboardId,
+			Expiry:        expiryUnix,
+			OwnerID:       ownerID,
+			PreviousRank:  int64(previousRank),
+		}
+		switch r := rankData.(type) {
+		case *RankDesc:
+			change.Score, change.Subscore = r.Score, r.Subscore
+		case *RankAsc:
+			change.Score, change.Subscore = r.Score, r.Subscore
+		case *RankSorted:
+			change.Score, change.Subscore = r.Score, r.Subscore
+		}
+		l.queueRankChange(change)
+	}
+
 	return true
 }
 
 func (l *LocalLeaderboardRankCache) DeleteLeaderboard(leaderboardId string, expiryUnix int64) bool {
+	if l.Approximate(leaderboardId) {
+		l.Lock()
+		delete(l.sketches, LeaderboardWithExpiry{LeaderboardId: leaderboardId, Expiry: expiryUnix})
+		l.Unlock()
+		return true
+	}
 	if l.blacklistAll {
 		// If all rank caching is disabled.
 		return false
@@ -430,13 +571,18 @@ func (l *LocalLeaderboardRankCache) DeleteLeaderboard(leaderboardId string, expi
 }
 
 func (l *LocalLeaderboardRankCache) TrimExpired(nowUnix int64) bool {
+	// Used for the timer.
+	l.Lock()
+	for k := range l.sketches {
+		if k.Expiry != 0 && k.Expiry <= nowUnix {
+			delete(l.sketches, k)
+		}
+	}
 	if l.blacklistAll {
 		// If all rank caching is disabled.
+		l.Unlock()
 		return false
 	}
-
-	// Used for the timer.
-	l.Lock()
 	for k := range l.cache {
 		if k.Expiry != 0 && k.Expiry <= nowUnix {
 			delete(l.cache, k)
@@ -446,3 +592,31 @@ func (l *LocalLeaderboardRankCache) TrimExpired(nowUnix int64) bool {
 
 	return true
 }
+
+// Set a function to receive rank changes. It is called from a single routine in the order changes were made, after
+// the writes that made them have returned, so it never holds up leaderboard writes.
+func (l *LocalLeaderboardRankCache) SetRankChangeListener(fn func(change *LeaderboardRankChange)) {
+	l.rankChangeListener.Store(fn)
+}
+
+func (l *LocalLeaderboardRankCache) queueRankChange(change *LeaderboardRankChange) {
+	select {
+	case l.rankChangeCh <- change:
+	default:
+		// Queue is full, drop the change rather than block the writer.
+		l.logger.Warn("Leaderboard rank change queue full, subscriber events may be lost")
+	}
+}
+
+func (l *LocalLeaderboardRankCache) processRankChanges() {
+	for {
+		select {
+		case <-l.stopCh:
+			return
+		case change := <-l.rankChangeCh:
+			if fn, ok := l.rankChangeListener.Load().(func(change *LeaderboardRankChange)); ok && fn != nil {
+				fn(change)
+			}
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgtype"
+	"go.uber.org/zap"
+)
+
+const (
+	leaderboardRankCacheSnapshotFilename = "leaderboard_rank_cache.snapshot"
+	leaderboardRankCacheSnapshotVersion  = 1
+)
+
+type leaderboardRankCacheSnapshot struct {
+	Version int
+	Boards  []*leaderboardRankCacheSnapshotBoard
+}
+
+// The ranked records of a leaderboard and expiry pair, stored in columns to keep snapshots compact.
+type leaderboardRankCacheSnapshotBoard struct {
+	LeaderboardId string
+	Expiry        int64
+	MaxUpdateTime int64
+	OwnerIds      []uuid.UUID
+	Scores        []int64
+	Subscores     []int64
+	// Only kept for leaderboards that break ties by time, empty otherwise.
+	UpdateTimes []int64
+}
+
+func newLeaderboardRankCacheSnapshotBoard(key LeaderboardWithExpiry, rankCache *RankCache) *leaderboardRankCacheSnapshotBoard {
+	rankCache.RLock()
+	defer rankCache.RUnlock()
+
+	board := &leaderboardRankCacheSnapshotBoard{
+		LeaderboardId: key.LeaderboardId,
+		Expiry:        key.Expiry,
+		MaxUpdateTime: rankCache.maxUpdateTime,
+		OwnerIds:      make([]uuid.UUID, 0, len(rankCache.owners)),
+		Scores:        make([]int64, 0, len(rankCache.owners)),
+		Subscores:     make([]int64, 0, len(rankCache.owners)),
+	}
+	for ownerID, rankData := range rankCache.owners {
+		board.OwnerIds = append(board.OwnerIds, ownerID)
+		switch r := rankData.(type) {
+		case *RankDesc:
+			board.Scores = append(board.Scores, r.Score)
+			board.Subscores = append(board.Subscores, r.Subscore)
+		case *RankAsc:
+			board.Scores = append(board.Scores, r.Score)
+			board.Subscores = append(board.Subscores, r.Subscore)
+		case *RankSorted:
+			board.Scores = append(board.Scores, r.Score)
+			board.Subscores = append(board.Subscores, r.Subscore)
+			if r.Sort.TieBreak == LeaderboardTieBreakTime {
+				board.UpdateTimes = append(board.UpdateTimes, r.UpdateTime)
+			}
+		}
+	}
+	return board
+}
+
+// Check the snapshot still holds every record currently in the database. Any record written since sets a later update
+// time, and any record deleted since lowers the record count.
+func (b *leaderboardRankCacheSnapshotBoard) fresh(ctx context.Context, db *sql.DB, sort LeaderboardSort) (bool, error) {
+	if sort.TieBreak == LeaderboardTieBreakTime && len(b.UpdateTimes) != len(b.OwnerIds) {
+		// Update times are needed to rank these records.
+		return false, nil
+	}
+
+	var count int
+	var maxUpdateTime pgtype.Timestamptz
+	query := "SELECT COUNT(*), MAX(update_time) FROM leaderboard_record WHERE leaderboard_id = $1 AND expiry_time = $2"
+	if err := db.QueryRowContext(ctx, query, b.LeaderboardId, time.Unix(b.Expiry, 0).UTC()).Scan(&count, &maxUpdateTime); err != nil {
+		return false, err
+	}
+	if count != len(b.OwnerIds) {
+		return false, nil
+	}
+	return count == 0 || (maxUpdateTime.Status == pgtype.Present && maxUpdateTime.Time.UnixMicro() == b.MaxUpdateTime), nil
+}
+
+// Insert the snapshot records into a rank cache, keeping any records already inserted since startup.
+func (b *leaderboardRankCacheSnapshotBoard) restore(rankCache *RankCache, sort LeaderboardSort) {
+	rankCache.Lock()
+	defer rankCache.Unlock()
+
+	for i, ownerID := range b.OwnerIds {
+		if _, alreadyInserted := rankCache.owners[ownerID]; alreadyInserted {
+			continue
+		}
+		var updateTime int64
+		if len(b.UpdateTimes) != 0 {
+			updateTime = b.UpdateTimes[i]
+		}
+		rankData := sort.rankData(ownerID, b.Scores[i], b.Subscores[i], updateTime)
+		rankCache.owners[ownerID] = rankData
+		rankCache.cache.Insert(rankData)
+	}
+	if b.MaxUpdateTime > rankCache.maxUpdateTime {
+		rankCache.maxUpdateTime = b.MaxUpdateTime
+	}
+}
+
+// Read the boards in a snapshot file, or none if there is no snapshot yet.
+func readLeaderboardRankCacheSnapshot(path string) (map[LeaderboardWithExpiry]*leaderboardRankCacheSnapshotBoard, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var snapshot leaderboardRankCacheSnapshot
+	if err := gob.NewDecoder(bufio.NewReader(f)).Decode(&snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.Version != leaderboardRankCacheSnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %v", snapshot.Version)
+	}
+
+	boards := make(map[LeaderboardWithExpiry]*leaderboardRankCacheSnapshotBoard, len(snapshot.Boards))
+	for _, board := range snapshot.Boards {
+		if len(board.Scores) != len(board.OwnerIds) || len(board.Subscores) != len(board.OwnerIds) {
+			return nil, fmt.Errorf("inconsistent snapshot of leaderboard %v", board.LeaderboardId)
+		}
+		boards[LeaderboardWithExpiry{LeaderboardId: board.LeaderboardId, Expiry: board.Expiry}] = board
+	}
+	return boards, nil
+}
+
+// Write a snapshot of all cached ranks, replacing the previous snapshot only once the new one is complete.
+func (l *LocalLeaderboardRankCache) writeSnapshot() error {
+	l.RLock()
+	rankCaches := make(map[LeaderboardWithExpiry]*RankCache, len(l.cache))
+	for key, rankCache := range l.cache {
+		rankCaches[key] = rankCache
+	}
+	l.RUnlock()
+
+	snapshot := &leaderboardRankCacheSnapshot{
+		Version: leaderboardRankCacheSnapshotVersion,
+		Boards:  make([]*leaderboardRankCacheSnapshotBoard, 0, len(rankCaches)),
+	}
+	for key, rankCache := range rankCaches {
+		snapshot.Boards = append(snapshot.Boards, newLeaderboardRankCacheSnapshotBoard(key, rankCache))
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(l.snapshotPath), "."+leaderboardRankCacheSnapshotFilename+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	w := bufio.NewWriter(f)
+	if err := gob.NewEncoder(w).Encode(snapshot); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), l.snapshotPath)
+}
+
+func (l *LocalLeaderboardRankCache) snapshotLoop() {
+	defer l.snapshotWg.Done()
+
+	// A snapshot taken before initialization completes would be missing records.
+	select {
+	case <-l.initDone:
+	case <-l.stopCh:
+		return
+	}
+
+	ticker := time.NewTicker(l.snapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := l.writeSnapshot(); err != nil {
+				l.logger.Error("Failed to write leaderboard rank cache snapshot", zap.String("path", l.snapshotPath), zap.Error(err))
+			}
+		case <-l.stopCh:
+			// Final snapshot at shutdown, so a restart restores the latest ranks.
+			if err := l.writeSnapshot(); err != nil {
+				l.logger.Error("Failed to write leaderboard rank cache snapshot", zap.String("path", l.snapshotPath), zap.Error(err))
+			}
+			return
+		}
+	}
+}
+
+// Stop writing snapshots, writing a last snapshot if they are enabled.
+func (l *LocalLeaderboardRankCache) Stop() {
+	l.stopOnce.Do(func() {
+		if l.stopCh != nil {
+			close(l.stopCh)
+		}
+	})
+	l.snapshotWg.Wait()
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama/v3/internal/skiplist"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalLeaderboardRankCache_Snapshot(t *testing.T) {
+	cache := &LocalLeaderboardRankCache{
+		blacklistIds: make(map[string]struct{}, 0),
+		blacklistAll: false,
+		cache:        make(map[LeaderboardWithExpiry]*RankCache, 0),
+		snapshotPath: filepath.Join(t.TempDir(), leaderboardRankCacheSnapshotFilename),
+	}
+
+	u1 := uuid.Must(uuid.NewV4())
+	u2 := uuid.Must(uuid.NewV4())
+	u3 := uuid.Must(uuid.NewV4())
+	timeSort, _ := ParseLeaderboardSort("desc,desc,time")
+
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u1, 11, 12, 100)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u2, 22, 23, 300)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u3, 33, 34, 200)
+	cache.Insert("timed", 10, timeSort, u1, 10, 10, 200)
+	cache.Insert("timed", 10, timeSort, u2, 10, 10, 100)
+
+	if err := cache.writeSnapshot(); err != nil {
+		t.Fatalf("error writing snapshot: %v", err)
+	}
+	boards, err := readLeaderboardRankCacheSnapshot(cache.snapshotPath)
+	if err != nil {
+		t.Fatalf("error reading snapshot: %v", err)
+	}
+	assert.Len(t, boards, 2)
+
+	board := boards[LeaderboardWithExpiry{LeaderboardId: "lid", Expiry: 0}]
+	assert.EqualValues(t, 300, board.MaxUpdateTime)
+	assert.ElementsMatch(t, []uuid.UUID{u1, u2, u3}, board.OwnerIds)
+	// Update times are only needed to break ties by time.
+	assert.Empty(t, board.UpdateTimes)
+	assert.Len(t, boards[LeaderboardWithExpiry{LeaderboardId: "timed", Expiry: 10}].UpdateTimes, 2)
+
+	restored := &LocalLeaderboardRankCache{
+		blacklistIds: make(map[string]struct{}, 0),
+		blacklistAll: false,
+		cache:        make(map[LeaderboardWithExpiry]*RankCache, 0),
+	}
+	for key, board := range boards {
+		sort := NewLeaderboardSort(LeaderboardSortOrderDescending)
+		if key.LeaderboardId == "timed" {
+			sort = timeSort
+		}
+		rankCache := &RankCache{owners: make(map[uuid.UUID]skiplist.Interface), cache: skiplist.New()}
+		board.restore(rankCache, sort)
+		restored.cache[key] = rankCache
+	}
+
+	for _, ownerID := range []uuid.UUID{u1, u2, u3} {
+		assert.Equal(t, cache.Get("lid", 0, ownerID), restored.Get("lid", 0, ownerID))
+	}
+	assert.EqualValues(t, 1, restored.Get("timed", 10, u2))
+	assert.EqualValues(t, 2, restored.Get("timed", 10, u1))
+}
+
+func TestReadLeaderboardRankCacheSnapshot_Missing(t *testing.T) {
+	boards, err := readLeaderboardRankCacheSnapshot(filepath.Join(t.TempDir(), leaderboardRankCacheSnapshotFilename))
+	assert.NoError(t, err)
+	assert.Nil(t, boards)
+}
@@ -16,6 +16,7 @@ package server
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/heroiclabs/nakama-common/api"
@@ -35,11 +36,11 @@ func TestLocalLeaderboardRankCache_Insert_Ascending(t *testing.T) {
 	u4 := uuid.Must(uuid.NewV4())
 	u5 := uuid.Must(uuid.NewV4())
 
-	cache.Insert("lid", 0, LeaderboardSortOrderAscending, u3, 33, 34)
-	cache.Insert("lid", 0, LeaderboardSortOrderAscending, u2, 22, 23)
-	cache.Insert("lid", 0, LeaderboardSortOrderAscending, u4, 44, 45)
-	cache.Insert("lid", 0, LeaderboardSortOrderAscending, u1, 11, 12)
-	cache.Insert("lid", 0, LeaderboardSortOrderAscending, u5, 55, 56)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderAscending), u3, 33, 34, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderAscending), u2, 22, 23, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderAscending), u4, 44, 45, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderAscending), u1, 11, 12, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderAscending), u5, 55, 56, 0)
 
 	assert.EqualValues(t, 1, cache.Get("lid", 0, u1))
 	assert.EqualValues(t, 2, cache.Get("lid", 0, u2))
@@ -61,11 +62,11 @@ func TestLocalLeaderboardRankCache_Insert_Descending(t *testing.T) {
 	u4 := uuid.Must(uuid.NewV4())
 	u5 := uuid.Must(uuid.NewV4())
 
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u3, 33, 34)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u2, 22, 23)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u4, 44, 45)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u1, 11, 12)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u5, 55, 56)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u3, 33, 34, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u2, 22, 23, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u4, 44, 45, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u1, 11, 12, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u5, 55, 56, 0)
 
 	assert.EqualValues(t, 1, cache.Get("lid", 0, u5))
 	assert.EqualValues(t, 2, cache.Get("lid", 0, u4))
@@ -87,12 +88,12 @@ func TestLocalLeaderboardRankCache_Insert_Existing(t *testing.T) {
 	u4 := uuid.Must(uuid.NewV4())
 	u5 := uuid.Must(uuid.NewV4())
 
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u3, 33, 34)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u2, 22, 23)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u4, 44, 45)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u1, 11, 12)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u5, 55, 56)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u2, 55, 57)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u3, 33, 34, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u2, 22, 23, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u4, 44, 45, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u1, 11, 12, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u5, 55, 56, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u2, 55, 57, 0)
 
 	assert.EqualValues(t, 1, cache.Get("lid", 0, u2))
 	assert.EqualValues(t, 2, cache.Get("lid", 0, u5))
@@ -114,11 +115,11 @@ func TestLocalLeaderboardRankCache_TrimExpired(t *testing.T) {
 	u4 := uuid.Must(uuid.NewV4())
 	u5 := uuid.Must(uuid.NewV4())
 
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u3, 33, 34)
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u2, 22, 23)
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u4, 44, 45)
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u1, 11, 12)
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u5, 55, 56)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u3, 33, 34, 0)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u2, 22, 23, 0)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u4, 44, 45, 0)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u1, 11, 12, 0)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u5, 55, 56, 0)
 
 	assert.EqualValues(t, 1, cache.Get("lid", 1, u5))
 	assert.EqualValues(t, 2, cache.Get("lid", 1, u4))
@@ -148,11 +149,11 @@ func TestLocalLeaderboardRankCache_ExpirySeparation(t *testing.T) {
 	u4 := uuid.Must(uuid.NewV4())
 	u5 := uuid.Must(uuid.NewV4())
 
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u3, 33, 34)
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u2, 22, 23)
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u4, 44, 45)
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u1, 11, 12)
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u5, 55, 56)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u3, 33, 34, 0)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u2, 22, 23, 0)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u4, 44, 45, 0)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u1, 11, 12, 0)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u5, 55, 56, 0)
 
 	assert.EqualValues(t, 1, cache.Get("lid", 1, u5))
 	assert.EqualValues(t, 2, cache.Get("lid", 1, u4))
@@ -180,11 +181,11 @@ func TestLocalLeaderboardRankCache_LeaderboardSeparation(t *testing.T) {
 	u4 := uuid.Must(uuid.NewV4())
 	u5 := uuid.Must(uuid.NewV4())
 
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u3, 33, 34)
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u2, 22, 23)
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u4, 44, 45)
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u1, 11, 12)
-	cache.Insert("lid", 1, LeaderboardSortOrderDescending, u5, 55, 56)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u3, 33, 34, 0)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u2, 22, 23, 0)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u4, 44, 45, 0)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u1, 11, 12, 0)
+	cache.Insert("lid", 1, NewLeaderboardSort(LeaderboardSortOrderDescending), u5, 55, 56, 0)
 
 	assert.EqualValues(t, 1, cache.Get("lid", 1, u5))
 	assert.EqualValues(t, 2, cache.Get("lid", 1, u4))
@@ -212,11 +213,11 @@ func TestLocalLeaderboardRankCache_Delete(t *testing.T) {
 	u4 := uuid.Must(uuid.NewV4())
 	u5 := uuid.Must(uuid.NewV4())
 
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u3, 33, 34)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u2, 22, 23)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u4, 44, 45)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u1, 11, 12)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u5, 55, 56)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u3, 33, 34, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u2, 22, 23, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u4, 44, 45, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u1, 11, 12, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u5, 55, 56, 0)
 
 	assert.EqualValues(t, 1, cache.Get("lid", 0, u5))
 	assert.EqualValues(t, 2, cache.Get("lid", 0, u4))
@@ -246,11 +247,11 @@ func TestLocalLeaderboardRankCache_DeleteLeaderboard(t *testing.T) {
 	u4 := uuid.Must(uuid.NewV4())
 	u5 := uuid.Must(uuid.NewV4())
 
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u3, 33, 34)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u2, 22, 23)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u4, 44, 45)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u1, 11, 12)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u5, 55, 56)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u3, 33, 34, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u2, 22, 23, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u4, 44, 45, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u1, 11, 12, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u5, 55, 56, 0)
 
 	assert.EqualValues(t, 1, cache.Get("lid", 0, u5))
 	assert.EqualValues(t, 2, cache.Get("lid", 0, u4))
@@ -280,11 +281,11 @@ func TestLocalLeaderboardRankCache_Fill(t *testing.T) {
 	u4 := uuid.Must(uuid.NewV4())
 	u5 := uuid.Must(uuid.NewV4())
 
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u3, 33, 34)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u2, 22, 23)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u4, 44, 45)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u1, 11, 12)
-	cache.Insert("lid", 0, LeaderboardSortOrderDescending, u5, 55, 56)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u3, 33, 34, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u2, 22, 23, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u4, 44, 45, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u1, 11, 12, 0)
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u5, 55, 56, 0)
 
 	assert.EqualValues(t, 1, cache.Get("lid", 0, u5))
 	assert.EqualValues(t, 2, cache.Get("lid", 0, u4))
@@ -308,3 +309,51 @@ func TestLocalLeaderboardRankCache_Fill(t *testing.T) {
 	assert.EqualValues(t, 4, records[3].Rank)
 	assert.EqualValues(t, 2, records[4].Rank)
 }
+
+func TestLocalLeaderboardRankCache_RankChangeListener(t *testing.T) {
+	cache := &LocalLeaderboardRankCache{
+		logger:       logger,
+		blacklistIds: make(map[string]struct{}, 0),
+		blacklistAll: false,
+		cache:        make(map[LeaderboardWithExpiry]*RankCache, 0),
+		rankChangeCh: make(chan *LeaderboardRankChange, 1),
+		stopCh:       make(chan struct{}),
+	}
+	go cache.processRankChanges()
+	defer cache.Stop()
+
+	received := make(chan *LeaderboardRankChange, 3)
+	release := make(chan struct{})
+	cache.SetRankChangeListener(func(change *LeaderboardRankChange) {
+		received <- change
+		<-release
+	})
+
+	u1 := uuid.Must(uuid.NewV4())
+	u2 := uuid.Must(uuid.NewV4())
+	u3 := uuid.Must(uuid.NewV4())
+
+	// A blocked listener does not hold up writes, changes queue up until the queue is full and are dropped after that.
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u1, 10, 0, 0)
+	first := <-received
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u2, 20, 0, 0)
+	cache.Delete("lid", 0, u1)
+	assert.EqualValues(t, 1, cache.Get("lid", 0, u2))
+	cache.Insert("lid", 0, NewLeaderboardSort(LeaderboardSortOrderDescending), u3, 30, 0, 0)
+	close(release)
+
+	assert.Equal(t, u1, first.OwnerID)
+	assert.EqualValues(t, 1, first.Rank)
+	select {
+	case second := <-received:
+		assert.Equal(t, u2, second.OwnerID)
+		assert.EqualValues(t, 1, second.Rank)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected queued rank change")
+	}
+	select {
+	case change := <-received:
+		t.Fatalf("expected rank changes to be dropped, got %v", change)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"go.uber.org/zap"
+)
+
+// Responses listing records around an owner carry the number of ranked records in this metadata, and whether ranks
+// are approximate, reachable as "Grpc-Metadata-Leaderboard-Rank-Count" headers over HTTP.
+const (
+	LeaderboardRankCountMetadataKey       = "leaderboard-rank-count"
+	LeaderboardRankApproximateMetadataKey = "leaderboard-rank-approximate"
+)
+
+// Leaderboards excluded from the rank cache may keep approximate ranks instead, estimated from a histogram of their
+// scores rather than a skiplist of every record. Scores below 128 are counted exactly, larger scores are counted in
+// buckets that split each power of two in 64, so a bucket is never wider than about 1.6% of the scores in it.
+const (
+	rankSketchExactBuckets = 128
+	rankSketchMantissaBits = 6
+	rankSketchBuckets      = rankSketchExactBuckets + (64-8+1)<<rankSketchMantissaBits
+)
+
+// A histogram of the scores of a leaderboard, with a Fenwick tree over the buckets to count the scores before any of them.
+type rankSketch struct {
+	sync.RWMutex
+	ascending bool
+	counts    []int64
+	tree      []int64
+	total     int64
+	// Lowest and highest scores ever counted, to narrow the first and last buckets.
+	min int64
+	max int64
+}
+
+func newRankSketch(sort LeaderboardSort) *rankSketch {
+	return &rankSketch{
+		ascending: sort.SortOrder == LeaderboardSortOrderAscending,
+		counts:    make([]int64, rankSketchBuckets),
+		tree:      make([]int64, rankSketchBuckets+1),
+		min:       math.MaxInt64,
+		max:       -1,
+	}
+}
+
+func rankSketchBucket(score int64) int {
+	if score < rankSketchExactBuckets {
+		if score < 0 {
+			return 0
+		}
+		return int(score)
+	}
+	e := bits.Len64(uint64(score))
+	m := int(uint64(score)>>(e-rankSketchMantissaBits-1)) - 1<<rankSketchMantissaBits
+	return rankSketchExactBuckets + (e-8)<<rankSketchMantissaBits + m
+}
+
+// The lowest and highest scores counted in a bucket.
+func rankSketchBounds(bucket int) (int64, int64) {
+	if bucket < rankSketchExactBuckets {
+		return int64(bucket), int64(bucket)
+	}
+	k := bucket - rankSketchExactBuckets
+	shift := k>>rankSketchMantissaBits + 8 - rankSketchMantissaBits - 1
+	lo := uint64(k&(1<<rankSketchMantissaBits-1)+1<<rankSketchMantissaBits) << shift
+	return int64(lo), int64(lo + 1<<shift - 1)
+}
+
+// Add to the number of records with a score, removals from an empty bucket are ignored. Callers must hold the lock.
+func (s *rankSketch) add(score, delta int64) {
+	bucket := rankSketchBucket(score)
+	if s.counts[bucket]+delta < 0 {
+		delta = -s.counts[bucket]
+	}
+	if delta == 0 {
+		return
+	}
+	if delta > 0 {
+		if score < s.min {
+			s.min = score
+		}
+		if score > s.max {
+			s.max = score
+		}
+	}
+	s.counts[bucket] += delta
+	s.total += delta
+	for i := bucket + 1; i < len(s.tree); i += i & -i {
+		s.tree[i] += delta
+	}
+}
+
+// The number of records in the buckets before a bucket. Callers must hold the lock.
+func (s *rankSketch) before(bucket int) int64 {
+	var count int64
+	for i := bucket; i > 0; i -= i & -i {
+		count += s.tree[i]
+	}
+	return count
+}
+
+// Estimate the rank of a score, assuming records are spread evenly within its bucket. Subscores are not taken into
+// account. Callers must hold the lock.
+func (s *rankSketch) rank(score int64) int64 {
+	if s.total == 0 {
+		return 0
+	}
+	if score < 0 {
+		score = 0
+	}
+	bucket := rankSketchBucket(score)
+	lo, hi := rankSketchBounds(bucket)
+	if s.min > lo && s.min <= score {
+		lo = s.min
+	}
+	if s.max < hi && s.max >= score {
+		hi = s.max
+	}
+	width := float64(hi-lo) + 1
+
+	var ahead float64
+	if s.ascending {
+		ahead = float64(s.before(bucket)) + float64(s.counts[bucket])*float64(score-lo)/width
+	} else {
+		ahead = float64(s.total-s.before(bucket+1)) + float64(s.counts[bucket])*float64(hi-score)/width
+	}
+
+	rank := int64(ahead) + 1
+	if rank > s.total {
+		rank = s.total
+	}
+	return rank
+}
+
+// Approximate reports if ranks for a leaderboard excluded from the rank cache are estimated from its scores instead.
+func (l *LocalLeaderboardRankCache) Approximate(leaderboardId string) bool {
+	if _, ok := l.blacklistIds[leaderboardId]; !ok && !l.blacklistAll {
+		return false
+	}
+	if l.approximateAll {
+		return true
+	}
+	_, ok := l.approximateIds[leaderboardId]
+	return ok
+}
+
+// UpdateApproximate replaces an owner's previous score with their new score for a leaderboard with approximate ranks,
+// and returns the estimated rank of the new score. The previous score is nil for new records, the new score is nil
+// for deleted records.
+func (l *LocalLeaderboardRankCache) UpdateApproximate(leaderboardId string, expiryUnix int64, sort LeaderboardSort, previousScore, score *int64) int64 {
+	if !l.Approximate(leaderboardId) {
+		return 0
+	}
+
+	key := LeaderboardWithExpiry{LeaderboardId: leaderboardId, Expiry: expiryUnix}
+	l.RLock()
+	sketch, ok := l.sketches[key]
+	l.RUnlock()
+	if !ok {
+		if score == nil {
+			return 0
+		}
+		newSketch := newRankSketch(sort)
+		l.Lock()
+		// Last check if the histogram was created by another writer just after last read.
+		if sketch, ok = l.sketches[key]; !ok {
+			sketch = newSketch
+			l.sketches[key] = sketch
+		}
+		l.Unlock()
+	}
+
+	sketch.Lock()
+	defer sketch.Unlock()
+	if previousScore != nil {
+		sketch.add(*previousScore, -1)
+	}
+	if score == nil {
+		return 0
+	}
+	sketch.add(*score, 1)
+	return sketch.rank(*score)
+}
+
+// Count returns the number of ranked records for a leaderboard, and if their ranks are approximate.
+func (l *LocalLeaderboardRankCache) Count(leaderboardId string, expiryUnix int64) (int64, bool) {
+	key := LeaderboardWithExpiry{LeaderboardId: leaderboardId, Expiry: expiryUnix}
+	if l.Approximate(leaderboardId) {
+		l.RLock()
+		sketch, ok := l.sketches[key]
+		l.RUnlock()
+		if !ok {
+			return 0, true
+		}
+		sketch.RLock()
+		defer sketch.RUnlock()
+		return sketch.total, true
+	}
+	if _, ok := l.blacklistIds[leaderboardId]; ok || l.blacklistAll {
+		return 0, false
+	}
+
+	l.RLock()
+	rankCache, ok := l.cache[key]
+	l.RUnlock()
+	if !ok {
+		return 0, false
+	}
+	rankCache.RLock()
+	defer rankCache.RUnlock()
+	return int64(len(rankCache.owners)), false
+}
+
+func (l *LocalLeaderboardRankCache) fillApproximate(leaderboardId string, expiryUnix int64, records []*api.LeaderboardRecord) {
+	if len(records) == 0 || !l.Approximate(leaderboardId) {
+		return
+	}
+
+	key := LeaderboardWithExpiry{LeaderboardId: leaderboardId, Expiry: expiryUnix}
+	l.RLock()
+	sketch, ok := l.sketches[key]
+	l.RUnlock()
+	if !ok {
+		return
+	}
+
+	sketch.RLock()
+	for _, record := range records {
+		record.Rank = sketch.rank(record.Score)
+	}
+	sketch.RUnlock()
+}
+
+// Count the scores of a leaderboard's current records into its histogram.
+func (l *LocalLeaderboardRankCache) initApproximate(ctx context.Context, logger *zap.Logger, db *sql.DB, leaderboard *Leaderboard, expiryUnix int64) {
+	key := LeaderboardWithExpiry{LeaderboardId: leaderboard.Id, Expiry: expiryUnix}
+	l.Lock()
+	sketch, found := l.sketches[key]
+	if !found {
+		sketch = newRankSketch(leaderboard.Sort())
+		l.sketches[key] = sketch
+	}
+	l.Unlock()
+
+	query := "SELECT score, COUNT(*) FROM leaderboard_record WHERE leaderboard_id = $1 AND expiry_time = $2 GROUP BY score"
+	rows, err := db.QueryContext(ctx, query, leaderboard.Id, time.Unix(expiryUnix, 0).UTC())
+	if err != nil {
+		logger.Error("Failed to read leaderboard scores for approximate ranks", zap.String("leaderboard_id", leaderboard.Id), zap.Error(err))
+		return
+	}
+	defer rows.Close()
+
+	var score, count int64
+	for rows.Next() {
+		if err := rows.Scan(&score, &count); err != nil {
+			logger.Error("Failed to scan leaderboard scores for approximate ranks", zap.String("leaderboard_id", leaderboard.Id), zap.Error(err))
+			return
+		}
+		sketch.Lock()
+		sketch.add(score, count)
+		sketch.Unlock()
+	}
+}
+
+// Read the score of an owner's record before it is written, needed by validation rules. Nil if there is no record.
+func leaderboardRecordScore(ctx context.Context, db *sql.DB, leaderboardId, ownerID string, expiryTime time.Time) (*int64, error) {
+	var score int64
+	query := "SELECT score FROM leaderboard_record WHERE leaderboard_id = $1 AND owner_id = $2 AND expiry_time = $3"
+	if err := db.QueryRowContext(ctx, query, leaderboardId, ownerID, expiryTime).Scan(&score); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &score, nil
+}
+
+var errLeaderboardRecordRaced = errors.New("leaderboard record created concurrently")
+
+// Lock an owner's record until the end of the transaction and read its score, nil if there is no record. Approximate
+// ranks replace the previous score of a record with the score written, so both must come from the transaction writing
+// it, or concurrent writes would leave scores in the histogram that no record has anymore.
+func leaderboardRecordScoreForUpdate(ctx context.Context, tx *sql.Tx, leaderboardId, ownerID string, expiryTime time.Time) (*int64, error) {
+	var score int64
+	query := "SELECT score FROM leaderboard_record WHERE leaderboard_id = $1 AND owner_id = $2 AND expiry_time = $3 FOR UPDATE"
+	if err := tx.QueryRowContext(ctx, query, leaderboardId, ownerID, expiryTime).Scan(&score); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &score, nil
+}
+
+// Write a record of a leaderboard with approximate ranks in a transaction. Records that did not exist when read cannot
+// be locked, so the transaction is run again if another writer created the record before it was written.
+func leaderboardApproximateWrite(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	for attempt := 1; ; attempt++ {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		err = ExecuteInTx(ctx, tx, func() error { return fn(tx) })
+		// The record exists on the next attempt, unless it is also deleted concurrently.
+		if err != errLeaderboardRecordRaced || attempt == 3 {
+			return err
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankSketchBuckets(t *testing.T) {
+	previous := -1
+	for _, score := range []int64{0, 1, 127, 128, 129, 255, 256, 1000, 123456789, math.MaxInt64} {
+		bucket := rankSketchBucket(score)
+		assert.LessOrEqual(t, previous, bucket)
+		assert.Less(t, bucket, rankSketchBuckets)
+		previous = bucket
+
+		lo, hi := rankSketchBounds(bucket)
+		assert.LessOrEqual(t, lo, score)
+		assert.GreaterOrEqual(t, hi, score)
+		assert.LessOrEqual(t, float64(hi-lo), float64(lo)/64)
+	}
+
+	// Buckets cover every score without gaps.
+	for bucket := 1; bucket < rankSketchBuckets; bucket++ {
+		_, previousHi := rankSketchBounds(bucket - 1)
+		lo, _ := rankSketchBounds(bucket)
+		assert.Equal(t, previousHi+1, lo)
+	}
+}
+
+func TestRankSketchRank(t *testing.T) {
+	desc := newRankSketch(LeaderboardSort{SortOrder: LeaderboardSortOrderDescending})
+	asc := newRankSketch(LeaderboardSort{SortOrder: LeaderboardSortOrderAscending})
+	for score := int64(1); score <= 100_000; score++ {
+		desc.add(score*10, 1)
+		asc.add(score*10, 1)
+	}
+
+	for _, score := range []int64{10, 500, 12_340, 500_000, 1_000_000} {
+		exact := 100_000 - score/10 + 1
+		assert.InDelta(t, exact, desc.rank(score), 0.02*float64(exact)+1)
+		exact = score / 10
+		assert.InDelta(t, exact, asc.rank(score), 0.02*float64(exact)+1)
+	}
+	assert.Equal(t, int64(1), desc.rank(1_000_000))
+	assert.Equal(t, int64(100_000), desc.rank(10))
+
+	// Removals from empty buckets are ignored.
+	desc.add(5, -1)
+	assert.Equal(t, int64(100_000), desc.total)
+	desc.add(1_000_000, -1)
+	assert.Equal(t, int64(99_999), desc.total)
+}
+
+func TestLeaderboardRankCacheApproximate(t *testing.T) {
+	cache := &LocalLeaderboardRankCache{
+		blacklistIds:   map[string]struct{}{"approximate": {}, "skipped": {}},
+		approximateIds: map[string]struct{}{"approximate": {}},
+		cache:          make(map[LeaderboardWithExpiry]*RankCache),
+		sketches:       make(map[LeaderboardWithExpiry]*rankSketch),
+	}
+	sort := LeaderboardSort{SortOrder: LeaderboardSortOrderDescending}
+
+	assert.True(t, cache.Approximate("approximate"))
+	assert.False(t, cache.Approximate("skipped"))
+	assert.False(t, cache.Approximate("cached"))
+	assert.Equal(t, int64(0), cache.UpdateApproximate("skipped", 0, sort, nil, &[]int64{10}[0]))
+
+	scores := []int64{50, 40, 30, 20, 10}
+	for i := range scores {
+		cache.UpdateApproximate("approximate", 0, sort, nil, &scores[i])
+	}
+	// An owner improving from 10 to 45 moves up to second.
+	previous, score := int64(10), int64(45)
+	assert.Equal(t, int64(2), cache.UpdateApproximate("approximate", 0, sort, &previous, &score))
+
+	records := []*api.LeaderboardRecord{{Score: 50}, {Score: 20}}
+	cache.Fill("approximate", 0, records)
+	assert.Equal(t, int64(1), records[0].Rank)
+	assert.Equal(t, int64(5), records[1].Rank)
+
+	count, approximate := cache.Count("approximate", 0)
+	assert.Equal(t, int64(5), count)
+	assert.True(t, approximate)
+
+	cache.UpdateApproximate("approximate", 0, sort, &score, nil)
+	count, _ = cache.Count("approximate", 0)
+	assert.Equal(t, int64(4), count)
+
+	assert.True(t, cache.DeleteLeaderboard("approximate", 0))
+	count, _ = cache.Count("approximate", 0)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestLeaderboardRecordWriteApproximateConcurrent(t *testing.T) {
+	db := NewDB(t)
+	cache := NewLocalLeaderboardCache(logger, logger, db)
+	leaderboard, err := cache.Create(context.Background(), GenerateString(), false, NewLeaderboardSort(LeaderboardSortOrderDescending), LeaderboardOperatorIncrement, "", "")
+	if err != nil {
+		t.Fatalf("error creating leaderboard: %v", err)
+	}
+	defer cache.Delete(context.Background(), leaderboard.Id)
+	rankCache := &LocalLeaderboardRankCache{
+		blacklistIds:   map[string]struct{}{leaderboard.Id: {}},
+		approximateIds: map[string]struct{}{leaderboard.Id: {}},
+		cache:          make(map[LeaderboardWithExpiry]*RankCache),
+		sketches:       make(map[LeaderboardWithExpiry]*rankSketch),
+	}
+
+	userID := uuid.Must(uuid.NewV4())
+	InsertUser(t, db, userID)
+
+	// Each write replaces the score the previous one left, so the owner is counted once however writes interleave.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := LeaderboardRecordWrite(context.Background(), logger, db, cache, rankCache, &testMetrics{}, uuid.Nil, leaderboard.Id, userID.String(), userID.String(), 1, 0, "", api.Operator_NO_OVERRIDE)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	count, approximate := rankCache.Count(leaderboard.Id, 0)
+	assert.True(t, approximate)
+	assert.Equal(t, int64(1), count)
+	records := []*api.LeaderboardRecord{{Score: 10}}
+	rankCache.Fill(leaderboard.Id, 0, records)
+	assert.Equal(t, int64(1), records[0].Rank)
+}
@@ -22,6 +22,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/heroiclabs/nakama/v3/internal/cronexpr"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -31,6 +32,11 @@ type LeaderboardSchedulerCallback struct {
 	leaderboard *Leaderboard
 	ts          int64
 	t           time.Time
+	// Set for tournament start callbacks.
+	start bool
+	// Set for tournament join callbacks.
+	userID   string
+	username string
 }
 
 type LeaderboardScheduler interface {
@@ -39,6 +45,7 @@ type LeaderboardScheduler interface {
 	Resume()
 	Stop()
 	Update()
+	Join(tournamentId, userID, username string)
 }
 
 type LocalLeaderboardScheduler struct {
@@ -48,15 +55,20 @@ type LocalLeaderboardScheduler struct {
 	config    Config
 	cache     LeaderboardCache
 	rankCache LeaderboardRankCache
+	router    MessageRouter
 
 	fnLeaderboardReset RuntimeLeaderboardResetFunction
 	fnTournamentReset  RuntimeTournamentResetFunction
+	fnTournamentStart  RuntimeTournamentStartFunction
+	fnTournamentJoin   RuntimeTournamentJoinFunction
 	fnTournamentEnd    RuntimeTournamentEndFunction
 
-	endActiveTimer *time.Timer
-	expiryTimer    *time.Timer
-	lastEnd        int64
-	lastExpiry     int64
+	startActiveTimer *time.Timer
+	endActiveTimer   *time.Timer
+	expiryTimer      *time.Timer
+	lastStart        int64
+	lastEnd          int64
+	lastExpiry       int64
 
 	started bool
 	queue   chan *LeaderboardSchedulerCallback
@@ -66,7 +78,7 @@ type LocalLeaderboardScheduler struct {
 	ctxCancelFn context.CancelFunc
 }
 
-func NewLocalLeaderboardScheduler(logger *zap.Logger, db *sql.DB, config Config, cache LeaderboardCache, rankCache LeaderboardRankCache) LeaderboardScheduler {
+func NewLocalLeaderboardScheduler(logger *zap.Logger, db *sql.DB, config Config, cache LeaderboardCache, rankCache LeaderboardRankCache, router MessageRouter) LeaderboardScheduler {
 	ctx, ctxCancelFn := context.WithCancel(context.Background())
 	return &LocalLeaderboardScheduler{
 		logger:    logger,
@@ -74,9 +86,12 @@ func NewLocalLeaderboardScheduler(logger *zap.Logger, db *sql.DB, config Config,
 		config:    config,
 		cache:     cache,
 		rankCache: rankCache,
+		router:    router,
 
+		// startActiveTimer only initialized when needed.
 		// endActiveTimer only initialized when needed.
 		// expiryTimer only initialized when needed.
+		// lastStart only initialized when needed.
 		// lastEnd only initialized when needed.
 		// lastExpiry only initialized when needed.
 
@@ -95,6 +110,8 @@ func (ls *LocalLeaderboardScheduler) Start(runtime *Runtime) {
 	// Capture callback references, if any are registered.
 	ls.fnLeaderboardReset = runtime.LeaderboardReset()
 	ls.fnTournamentReset = runtime.TournamentReset()
+	ls.fnTournamentStart = runtime.TournamentStart()
+	ls.fnTournamentJoin = runtime.TournamentJoin()
 	ls.fnTournamentEnd = runtime.TournamentEnd()
 
 	// Start the required number of callback workers.
@@ -103,6 +120,11 @@ func (ls *LocalLeaderboardScheduler) Start(runtime *Runtime) {
 	}
 
 	ls.Update()
+
+	// Timers only fire for periods ending from now on, catch up on rewards for periods that ended while stopped.
+	go func() {
+		_ = tournamentRewardsPayoutMissed(ls.ctx, ls.logger, ls.db, ls.cache, ls.router, time.Now().UTC())
+	}()
 }
 
 func (ls *LocalLeaderboardScheduler) Pause() {
@@ -114,6 +136,14 @@ func (ls *LocalLeaderboardScheduler) Pause() {
 	}
 
 	ls.Lock()
+	if ls.startActiveTimer != nil {
+		if !ls.startActiveTimer.Stop() {
+			select {
+			case <-ls.startActiveTimer.C:
+			default:
+			}
+		}
+	}
 	if ls.endActiveTimer != nil {
 		if !ls.endActiveTimer.Stop() {
 			select {
@@ -147,6 +177,14 @@ func (ls *LocalLeaderboardScheduler) Resume() {
 func (ls *LocalLeaderboardScheduler) Stop() {
 	ls.Lock()
 	ls.ctxCancelFn()
+	if ls.startActiveTimer != nil {
+		if !ls.startActiveTimer.Stop() {
+			select {
+			case <-ls.startActiveTimer.C:
+			default:
+			}
+		}
+	}
 	if ls.endActiveTimer != nil {
 		if !ls.endActiveTimer.Stop() {
 			select {
@@ -183,9 +221,11 @@ func (ls *LocalLeaderboardScheduler) Update() {
 	// Grab the set of known leaderboards.
 	leaderboards := ls.cache.GetAllLeaderboards()
 
+	earliestStartActive := int64(-1)
 	earliestEndActive := int64(-1)
 	earliestExpiry := int64(-1)
 
+	startActiveLeaderboardIds := make([]string, 0, 1)
 	endActiveLeaderboardIds := make([]string, 0, 1)
 	expiryLeaderboardIds := make([]string, 0, 1)
 
@@ -199,6 +239,16 @@ func (ls *LocalLeaderboardScheduler) Update() {
 				continue
 			}
 
+			// Check tournament start.
+			if startActive := tournamentNextStart(l.StartTime, l.EndTime, int64(l.Duration), l.ResetSchedule, now); startActive > 0 {
+				if earliestStartActive == -1 || startActive < earliestStartActive {
+					earliestStartActive = startActive
+					startActiveLeaderboardIds = []string{l.Id}
+				} else if startActive == earliestStartActive {
+					startActiveLeaderboardIds = append(startActiveLeaderboardIds, l.Id)
+				}
+			}
+
 			// Check tournament end.
 			if endActive > 0 && nowUnix < endActive {
 				if earliestEndActive == -1 || endActive < earliestEndActive {
@@ -234,6 +284,11 @@ func (ls *LocalLeaderboardScheduler) Update() {
 		}
 	}
 
+	startActiveDuration := time.Duration(-1)
+	if earliestStartActive > -1 {
+		startActiveDuration = time.Unix(earliestStartActive, 0).UTC().Sub(now)
+	}
+
 	endActiveDuration := time.Duration(-1)
 	if earliestEndActive > -1 {
 		endActiveDuration = time.Unix(earliestEndActive, 0).UTC().Sub(now)
@@ -244,8 +299,16 @@ func (ls *LocalLeaderboardScheduler) Update() {
 		expiryDuration = time.Unix(earliestExpiry, 0).UTC().Sub(now)
 	}
 
-	// Replace IDs earmarked for end and expiry, and restart timers as needed.
+	// Replace IDs earmarked for start, end and expiry, and restart timers as needed.
 	ls.Lock()
+	if ls.startActiveTimer != nil {
+		if !ls.startActiveTimer.Stop() {
+			select {
+			case <-ls.startActiveTimer.C:
+			default:
+			}
+		}
+	}
 	if ls.endActiveTimer != nil {
 		if !ls.endActiveTimer.Stop() {
 			select {
@@ -262,6 +325,12 @@ func (ls *LocalLeaderboardScheduler) Update() {
 			}
 		}
 	}
+	if startActiveDuration > -1 {
+		ls.logger.Debug("Setting timer to run start active function", zap.Duration("start_active", startActiveDuration), zap.Strings("ids", startActiveLeaderboardIds))
+		ls.startActiveTimer = time.AfterFunc(startActiveDuration, func() {
+			ls.queueStartActiveElapse(time.Unix(earliestStartActive, 0).UTC(), startActiveLeaderboardIds)
+		})
+	}
 	if endActiveDuration > -1 {
 		ls.logger.Debug("Setting timer to run end active function", zap.Duration("end_active", endActiveDuration), zap.Strings("ids", endActiveLeaderboardIds))
 		ls.endActiveTimer = time.AfterFunc(endActiveDuration, func() {
@@ -276,26 +345,60 @@ func (ls *LocalLeaderboardScheduler) Update() {
 	}
 	ls.Unlock()
 
-	ls.logger.Info("Leaderboard scheduler update", zap.Duration("end_active", endActiveDuration), zap.Int("end_active_count", len(endActiveLeaderboardIds)), zap.Duration("expiry", expiryDuration), zap.Int("expiry_count", len(expiryLeaderboardIds)))
+	ls.logger.Info("Leaderboard scheduler update", zap.Duration("start_active", startActiveDuration), zap.Int("start_active_count", len(startActiveLeaderboardIds)), zap.Duration("end_active", endActiveDuration), zap.Int("end_active_count", len(endActiveLeaderboardIds)), zap.Duration("expiry", expiryDuration), zap.Int("expiry_count", len(expiryLeaderboardIds)))
 }
 
-func (ls *LocalLeaderboardScheduler) queueEndActiveElapse(t time.Time, ids []string) {
+func (ls *LocalLeaderboardScheduler) queueStartActiveElapse(t time.Time, ids []string) {
 	if ls.active.Load() != 1 {
 		// Not active.
 		return
 	}
 
 	ts := t.Unix()
-	tMinusOne := time.Unix(ts-1, 0).UTC()
 
 	// Immediately schedule the next invocation to avoid any gaps caused by time spent processing below.
 	ls.Update()
 
-	// Skip processing if there is no tournament end callback registered.
-	if ls.fnTournamentEnd == nil {
+	// Skip processing if there is no tournament start callback registered.
+	if ls.fnTournamentStart == nil {
+		return
+	}
+
+	ls.Lock()
+	if ls.lastStart != 0 && ls.lastStart >= ts {
+		// Avoid running duplicate or delayed scheduling.
+		ls.Unlock()
+		return
+	}
+	ls.lastStart = ts
+	ls.Unlock()
+
+	ls.logger.Info("Leaderboard scheduler start active", zap.Int("count", len(ids)))
+
+	go func() {
+		// Process the current set of tournament starts.
+		for _, id := range ids {
+			currentId := id
+			// Will block if the queue is full.
+			ls.queue <- &LeaderboardSchedulerCallback{id: currentId, ts: ts, t: t, start: true}
+		}
+	}()
+}
+
+func (ls *LocalLeaderboardScheduler) queueEndActiveElapse(t time.Time, ids []string) {
+	if ls.active.Load() != 1 {
+		// Not active.
 		return
 	}
 
+	ts := t.Unix()
+	tMinusOne := time.Unix(ts-1, 0).UTC()
+
+	// Immediately schedule the next invocation to avoid any gaps caused by time spent processing below.
+	ls.Update()
+
+	// Tournament ends are processed even if there is no callback registered, to pay out any tournament rewards.
+
 	ls.Lock()
 	if ls.lastEnd != 0 && ls.lastEnd >= ts {
 		// Avoid running duplicate or delayed scheduling.
@@ -362,13 +465,46 @@ func (ls *LocalLeaderboardScheduler) queueExpiryElapse(t time.Time, ids []string
 	}()
 }
 
+func (ls *LocalLeaderboardScheduler) Join(tournamentId, userID, username string) {
+	// Skip processing if there is no tournament join callback registered.
+	if ls.fnTournamentJoin == nil {
+		return
+	}
+
+	go func() {
+		// Will block if the queue is full.
+		ls.queue <- &LeaderboardSchedulerCallback{id: tournamentId, t: time.Now().UTC(), userID: userID, username: username}
+	}()
+}
+
 func (ls *LocalLeaderboardScheduler) invokeCallback() {
 	for {
 		select {
 		case <-ls.ctx.Done():
 			return
 		case callback := <-ls.queue:
-			if callback.leaderboard != nil {
+			switch {
+			case callback.start:
+				tournament, err := ls.getTournament(callback)
+				if err != nil {
+					continue
+				}
+
+				// fnTournamentStart cannot be nil here, if it was the callback would not be queued at all.
+				if err := ls.fnTournamentStart(ls.ctx, tournament, int64(tournament.StartActive), int64(tournament.EndActive)); err != nil {
+					ls.logger.Warn("Failed to invoke tournament start callback", zap.Error(err))
+				}
+			case callback.userID != "":
+				tournament, err := ls.getTournament(callback)
+				if err != nil {
+					continue
+				}
+
+				// fnTournamentJoin cannot be nil here, if it was the callback would not be queued at all.
+				if err := ls.fnTournamentJoin(ls.ctx, tournament, callback.userID, callback.username); err != nil {
+					ls.logger.Warn("Failed to invoke tournament join callback", zap.Error(err))
+				}
+			case callback.leaderboard != nil:
 				if callback.leaderboard.IsTournament() {
 					// Tournament, fetch most up to date info for size etc.
 					// Some processing is needed even if there is no runtime callback registered for tournament reset.
@@ -411,27 +547,56 @@ WHERE id = $1`
 						ls.logger.Warn("Failed to invoke leaderboard reset callback", zap.Error(err))
 					}
 				}
-			} else {
-				query := `SELECT
-id, sort_order, operator, reset_schedule, metadata, create_time,
-category, description, duration, end_time, max_size, max_num_score, title, size, start_time
-FROM leaderboard
-WHERE id = $1`
-				row := ls.db.QueryRowContext(ls.ctx, query, callback.id)
-				tournament, err := parseTournament(row, callback.t)
+			default:
+				tournament, err := ls.getTournament(callback)
 				if err != nil {
-					if err != sql.ErrNoRows {
-						// Do not log if tournament was deleted before it reached the scheduler here.
-						ls.logger.Error("Error retrieving tournament to invoke end callback", zap.Error(err), zap.String("id", callback.id))
-					}
 					continue
 				}
 
-				// fnTournamentEnd cannot be nil here, if it was the callback would not be queued at all.
-				if err := ls.fnTournamentEnd(ls.ctx, tournament, int64(tournament.EndActive), int64(tournament.NextReset)); err != nil {
-					ls.logger.Warn("Failed to invoke tournament end callback", zap.Error(err))
+				// Pay out rewards for the period that just ended before handing over to the runtime.
+				if leaderboard := ls.cache.Get(callback.id); leaderboard != nil {
+					_, _, expiry := calculateTournamentDeadlines(leaderboard.StartTime, leaderboard.EndTime, int64(leaderboard.Duration), leaderboard.ResetSchedule, callback.t)
+					if err := tournamentRewardsPayout(ls.ctx, ls.logger, ls.db, ls.router, leaderboard, expiry); err != nil {
+						ls.logger.Error("Failed to pay out tournament rewards", zap.Error(err), zap.String("id", callback.id))
+					}
+				}
+
+				if ls.fnTournamentEnd != nil {
+					if err := ls.fnTournamentEnd(ls.ctx, tournament, int64(tournament.EndActive), int64(tournament.NextReset)); err != nil {
+						ls.logger.Warn("Failed to invoke tournament end callback", zap.Error(err))
+					}
 				}
 			}
 		}
 	}
 }
+
+// Fetch the most up to date tournament info for a start, join, or end callback.
+func (ls *LocalLeaderboardScheduler) getTournament(callback *LeaderboardSchedulerCallback) (*api.Tournament, error) {
+	query := `SELECT
+id, sort_order, operator, reset_schedule, metadata, create_time,
+category, description, duration, end_time, max_size, max_num_score, title, size, start_time
+FROM leaderboard
+WHERE id = $1`
+	row := ls.db.QueryRowContext(ls.ctx, query, callback.id)
+	tournament, err := parseTournament(row, callback.t)
+	if err != nil && err != sql.ErrNoRows {
+		// Do not log if tournament was deleted before it reached the scheduler here.
+		ls.logger.Error("Error retrieving tournament to invoke callback", zap.Error(err), zap.String("id", callback.id))
+	}
+	return tournament, err
+}
+
+// The next time a tournament becomes active after the given time, or 0 if it does not start again.
+func tournamentNextStart(startTime, endTime, duration int64, resetSchedule *cronexpr.Expression, t time.Time) int64 {
+	tUnix := t.Unix()
+	startActive, _, expiry := calculateTournamentDeadlines(startTime, endTime, duration, resetSchedule, t)
+	if startActive <= tUnix && resetSchedule != nil {
+		// Already active, the next active period starts at the following reset.
+		startActive, _, _ = calculateTournamentDeadlines(startTime, endTime, duration, resetSchedule, time.Unix(expiry, 0).UTC())
+	}
+	if startActive <= tUnix || (endTime > 0 && startActive >= endTime) {
+		return 0
+	}
+	return startActive
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama/v3/apigrpc"
+)
+
+var ErrLeaderboardInvalidScope = errors.New("leaderboard scope invalid")
+
+// A subset of owners that leaderboard records are ranked among, instead of all owners.
+type LeaderboardScope struct {
+	// Rank among the friends of this user, and the user themselves.
+	FriendsOf uuid.UUID
+	// Rank among the members of this group.
+	GroupID uuid.UUID
+	// Rank among the owners in the same tournament bucket as this user.
+	BucketOf uuid.UUID
+}
+
+// Build the scope a client asked records to be ranked in, nil to rank among all owners. Listings of bucketed
+// tournaments use the caller's bucket when no scope is given.
+func NewLeaderboardScope(callerID uuid.UUID, scope apigrpc.ListLeaderboardRecordsWithOptionsRequest_Scope, groupID string) (*LeaderboardScope, error) {
+	switch scope {
+	case apigrpc.ListLeaderboardRecordsWithOptionsRequest_ALL:
+		return nil, nil
+	case apigrpc.ListLeaderboardRecordsWithOptionsRequest_FRIENDS:
+		if callerID == uuid.Nil {
+			return nil, ErrLeaderboardInvalidScope
+		}
+		return &LeaderboardScope{FriendsOf: callerID}, nil
+	case apigrpc.ListLeaderboardRecordsWithOptionsRequest_BUCKET:
+		if callerID == uuid.Nil {
+			return nil, ErrLeaderboardInvalidScope
+		}
+		return &LeaderboardScope{BucketOf: callerID}, nil
+	case apigrpc.ListLeaderboardRecordsWithOptionsRequest_GROUP:
+		groupUUID, err := uuid.FromString(groupID)
+		if err != nil || groupUUID == uuid.Nil {
+			return nil, ErrLeaderboardInvalidScope
+		}
+		return &LeaderboardScope{GroupID: groupUUID}, nil
+	default:
+		return nil, ErrLeaderboardInvalidScope
+	}
+}
+
+// Identifies the scope in list cursors, so cursors are only valid for the scope they were issued for.
+func (s *LeaderboardScope) String() string {
+	switch {
+	case s == nil:
+		return ""
+	case s.FriendsOf != uuid.Nil:
+		return "friends:" + s.FriendsOf.String()
+	case s.BucketOf != uuid.Nil:
+		return "bucket:" + s.BucketOf.String()
+	default:
+		return "group:" + s.GroupID.String()
+	}
+}
+
+// Build a query condition restricting records to owners in the scope, with its single parameter at the given position.
+// The query must have the leaderboard ID and expiry time as its first two parameters.
+func (s *LeaderboardScope) filter(param int) (string, interface{}) {
+	p := "$" + strconv.Itoa(param)
+	if s.FriendsOf != uuid.Nil {
+		// Accepted friends only, plus the user themselves.
+		return " AND (owner_id = " + p + " OR owner_id IN (SELECT destination_id FROM user_edge WHERE source_id = " + p + " AND state = 0))", s.FriendsOf
+	}
+	if s.BucketOf != uuid.Nil {
+		// Nothing is listed if the user has not joined.
+		return " AND bucket = (SELECT bucket FROM leaderboard_record WHERE leaderboard_id = $1 AND expiry_time = $2 AND owner_id = " + p + ")", s.BucketOf
+	}
+	// Superadmins, admins, and members, but not join requests or banned users.
+	return " AND owner_id IN (SELECT destination_id FROM group_edge WHERE source_id = " + p + " AND state >= 0 AND state <= 2)", s.GroupID
+}
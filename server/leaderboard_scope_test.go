@@ -0,0 +1,120 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama/v3/apigrpc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewLeaderboardScope(t *testing.T) {
+	callerID := uuid.Must(uuid.NewV4())
+	groupID := uuid.Must(uuid.NewV4())
+
+	scope, err := NewLeaderboardScope(callerID, apigrpc.ListLeaderboardRecordsWithOptionsRequest_ALL, "")
+	assert.NoError(t, err)
+	assert.Nil(t, scope)
+	assert.Equal(t, "", scope.String())
+
+	scope, err = NewLeaderboardScope(callerID, apigrpc.ListLeaderboardRecordsWithOptionsRequest_FRIENDS, "")
+	assert.NoError(t, err)
+	assert.Equal(t, &LeaderboardScope{FriendsOf: callerID}, scope)
+	assert.Equal(t, "friends:"+callerID.String(), scope.String())
+	filter, param := scope.filter(4)
+	assert.Equal(t, " AND (owner_id = $4 OR owner_id IN (SELECT destination_id FROM user_edge WHERE source_id = $4 AND state = 0))", filter)
+	assert.Equal(t, callerID, param)
+
+	scope, err = NewLeaderboardScope(callerID, apigrpc.ListLeaderboardRecordsWithOptionsRequest_GROUP, groupID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, &LeaderboardScope{GroupID: groupID}, scope)
+	assert.Equal(t, "group:"+groupID.String(), scope.String())
+	_, param = scope.filter(7)
+	assert.Equal(t, groupID, param)
+
+	scope, err = NewLeaderboardScope(callerID, apigrpc.ListLeaderboardRecordsWithOptionsRequest_BUCKET, "")
+	assert.NoError(t, err)
+	assert.Equal(t, &LeaderboardScope{BucketOf: callerID}, scope)
+	assert.Equal(t, "bucket:"+callerID.String(), scope.String())
+	filter, param = scope.filter(5)
+	assert.Equal(t, " AND bucket = (SELECT bucket FROM leaderboard_record WHERE leaderboard_id = $1 AND expiry_time = $2 AND owner_id = $5)", filter)
+	assert.Equal(t, callerID, param)
+
+	for _, id := range []string{"", "invalid", uuid.Nil.String()} {
+		_, err = NewLeaderboardScope(callerID, apigrpc.ListLeaderboardRecordsWithOptionsRequest_GROUP, id)
+		assert.Equal(t, ErrLeaderboardInvalidScope, err, id)
+	}
+	_, err = NewLeaderboardScope(callerID, apigrpc.ListLeaderboardRecordsWithOptionsRequest_Scope(99), "")
+	assert.Equal(t, ErrLeaderboardInvalidScope, err)
+	// Friends and bucket scopes need a caller.
+	_, err = NewLeaderboardScope(uuid.Nil, apigrpc.ListLeaderboardRecordsWithOptionsRequest_FRIENDS, "")
+	assert.Equal(t, ErrLeaderboardInvalidScope, err)
+	_, err = NewLeaderboardScope(uuid.Nil, apigrpc.ListLeaderboardRecordsWithOptionsRequest_BUCKET, "")
+	assert.Equal(t, ErrLeaderboardInvalidScope, err)
+}
+
+func TestLeaderboardRecordsListCursorScope(t *testing.T) {
+	scope := (&LeaderboardScope{FriendsOf: uuid.Must(uuid.NewV4())}).String()
+	cursor, err := marshalLeaderboardRecordsListCursor(&leaderboardRecordListCursor{IsNext: true, LeaderboardId: "lid", Rank: 10, Scope: scope})
+	if err != nil {
+		t.Fatalf("error marshalling cursor: %v", err)
+	}
+
+	incomingCursor, err := unmarshalLeaderboardRecordsListCursor("lid", 0, scope, cursor)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, incomingCursor.Rank)
+
+	// Ranks in the cursor do not carry over to other scopes.
+	_, err = unmarshalLeaderboardRecordsListCursor("lid", 0, "", cursor)
+	assert.Equal(t, ErrLeaderboardInvalidCursor, err)
+}
+
+func TestTournamentBucketBand(t *testing.T) {
+	assert.Nil(t, tournamentBucketBand(nil, 100))
+
+	for rating, band := range map[int64]int64{0: 0, 99: 0, 100: 1, 1550: 15, -1: -1, -100: -1, -101: -2} {
+		r := rating
+		assert.Equal(t, band, *tournamentBucketBand(&r, 100), rating)
+	}
+}
+
+func TestLeaderboardScopeApiInvalidGroup(t *testing.T) {
+	runtime, _, err := runtimeWithModules(t, map[string]string{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	apiserver, _ := NewAPIServer(t, runtime)
+	defer apiserver.Stop()
+	conn, client, _, ctx := NewAuthenticatedAPIClient(t, uuid.Must(uuid.NewV4()).String())
+	defer conn.Close()
+
+	_, err = client.ListLeaderboardRecordsWithOptions(ctx, &apigrpc.ListLeaderboardRecordsWithOptionsRequest{
+		LeaderboardId: "leaderboard",
+		Scope:         apigrpc.ListLeaderboardRecordsWithOptionsRequest_GROUP,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.ListTournamentRecordsWithOptions(ctx, &apigrpc.ListTournamentRecordsWithOptionsRequest{
+		TournamentId: "tournament",
+		Scope:        apigrpc.ListLeaderboardRecordsWithOptionsRequest_GROUP,
+		GroupId:      "invalid",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
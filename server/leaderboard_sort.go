@@ -0,0 +1,197 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama/v3/internal/skiplist"
+)
+
+const (
+	// Records with the same score and subscore are ordered by owner ID.
+	LeaderboardTieBreakOwner = iota
+	// Records with the same score and subscore are ordered by earliest update, then by owner ID.
+	LeaderboardTieBreakTime
+)
+
+var ErrLeaderboardInvalidSort = errors.New("leaderboard sort invalid")
+
+// How records in a leaderboard are ranked. Scores and subscores each have their own sort order, and records with the
+// same score and subscore are ordered by the tie-breaker. Owner IDs are always the final tie-breaker, in score order.
+type LeaderboardSort struct {
+	SortOrder         int
+	SubscoreSortOrder int
+	TieBreak          int
+}
+
+// The sort all leaderboards used before sort specifications, scores and subscores in the same order with ties broken
+// by owner ID.
+func NewLeaderboardSort(sortOrder int) LeaderboardSort {
+	return LeaderboardSort{SortOrder: sortOrder, SubscoreSortOrder: sortOrder, TieBreak: LeaderboardTieBreakOwner}
+}
+
+// Sort specifications are the score sort order "asc" or "desc", optionally followed by a comma and the subscore sort
+// order, then optionally by a comma and "time" to break ties by earliest submission. For example "desc,asc,time".
+func ParseLeaderboardSort(spec string) (LeaderboardSort, error) {
+	parts := strings.Split(spec, ",")
+	if len(parts) > 3 {
+		return LeaderboardSort{}, ErrLeaderboardInvalidSort
+	}
+
+	sortOrders := make([]int, 0, 2)
+	tieBreak := LeaderboardTieBreakOwner
+	for i, part := range parts {
+		switch part {
+		case "asc":
+			sortOrders = append(sortOrders, LeaderboardSortOrderAscending)
+		case "desc":
+			sortOrders = append(sortOrders, LeaderboardSortOrderDescending)
+		case "time":
+			if i == 0 || i != len(parts)-1 {
+				return LeaderboardSort{}, ErrLeaderboardInvalidSort
+			}
+			tieBreak = LeaderboardTieBreakTime
+		default:
+			return LeaderboardSort{}, ErrLeaderboardInvalidSort
+		}
+	}
+	if len(sortOrders) > 2 {
+		return LeaderboardSort{}, ErrLeaderboardInvalidSort
+	}
+
+	sort := NewLeaderboardSort(sortOrders[0])
+	if len(sortOrders) == 2 {
+		sort.SubscoreSortOrder = sortOrders[1]
+	}
+	sort.TieBreak = tieBreak
+	return sort, nil
+}
+
+func (s LeaderboardSort) String() string {
+	spec := leaderboardSortOrderName(s.SortOrder)
+	if s.SubscoreSortOrder != s.SortOrder || s.TieBreak != LeaderboardTieBreakOwner {
+		spec += "," + leaderboardSortOrderName(s.SubscoreSortOrder)
+	}
+	if s.TieBreak == LeaderboardTieBreakTime {
+		spec += ",time"
+	}
+	return spec
+}
+
+// Uniform sorts order score, subscore, and owner ID in the same direction, so listings can use row comparisons.
+func (s LeaderboardSort) IsUniform() bool {
+	return s.SubscoreSortOrder == s.SortOrder && s.TieBreak == LeaderboardTieBreakOwner
+}
+
+// The ORDER BY clause listing records from best to worst, or from worst to best if reversed.
+func (s LeaderboardSort) orderBy(reverse bool) string {
+	scoreDirection := leaderboardSortDirection(s.SortOrder, reverse)
+	orderBy := " ORDER BY score " + scoreDirection + ", subscore " + leaderboardSortDirection(s.SubscoreSortOrder, reverse)
+	if s.TieBreak == LeaderboardTieBreakTime {
+		orderBy += ", update_time " + leaderboardSortDirection(LeaderboardSortOrderAscending, reverse)
+	}
+	return orderBy + ", owner_id " + scoreDirection
+}
+
+// Append a condition selecting records listed after the given record, in the listing order of orderBy, and its
+// parameters. Listing queries must have the leaderboard ID and expiry time as their first two parameters.
+func (s LeaderboardSort) after(query string, params []interface{}, reverse bool, score, subscore int64, updateTime int64, ownerID string) (string, []interface{}) {
+	p := func(value interface{}) string {
+		params = append(params, value)
+		return "$" + strconv.Itoa(len(params))
+	}
+	op := func(sortOrder int) string {
+		if (sortOrder == LeaderboardSortOrderAscending) != reverse {
+			return " > "
+		}
+		return " < "
+	}
+
+	if s.IsUniform() {
+		return query + " AND (leaderboard_id, expiry_time, score, subscore, owner_id)" + op(s.SortOrder) + "($1, $2, " + p(score) + ", " + p(subscore) + ", " + p(ownerID) + ")", params
+	}
+
+	// Expand the comparison field by field, each in its own direction.
+	scoreParam, subscoreParam := p(score), p(subscore)
+	condition := "owner_id" + op(s.SortOrder) + p(ownerID)
+	if s.TieBreak == LeaderboardTieBreakTime {
+		updateTimeParam := p(time.UnixMicro(updateTime).UTC())
+		condition = "update_time" + op(LeaderboardSortOrderAscending) + updateTimeParam + " OR (update_time = " + updateTimeParam + " AND " + condition + ")"
+	}
+	condition = "subscore" + op(s.SubscoreSortOrder) + subscoreParam + " OR (subscore = " + subscoreParam + " AND (" + condition + "))"
+	condition = "score" + op(s.SortOrder) + scoreParam + " OR (score = " + scoreParam + " AND (" + condition + "))"
+	return query + " AND (" + condition + ")", params
+}
+
+// The SQL to keep the best of the stored and submitted score and subscore, and the condition for the update to apply,
+// for records submitted with the best operator. Scores and subscores are each kept if better in their own sort order.
+func (s LeaderboardSort) best(scoreParam, subscoreParam string) (string, string) {
+	pick := func(sortOrder int, column, param string) (string, string) {
+		if sortOrder == LeaderboardSortOrderAscending {
+			// Lower is better.
+			return column + " = LEAST(leaderboard_record." + column + ", " + param + ")", "leaderboard_record." + column + " > " + param
+		}
+		// Higher is better.
+		return column + " = GREATEST(leaderboard_record." + column + ", " + param + ")", "leaderboard_record." + column + " < " + param
+	}
+	scoreSQL, scoreFilter := pick(s.SortOrder, "score", scoreParam)
+	subscoreSQL, subscoreFilter := pick(s.SubscoreSortOrder, "subscore", subscoreParam)
+	return scoreSQL + ", " + subscoreSQL, scoreFilter + " OR " + subscoreFilter
+}
+
+// Rank cache data for a record, update times are in microseconds.
+func (s LeaderboardSort) rankData(ownerID uuid.UUID, score, subscore, updateTime int64) skiplist.Interface {
+	switch {
+	case !s.IsUniform():
+		return &RankSorted{
+			OwnerId:    ownerID,
+			Score:      score,
+			Subscore:   subscore,
+			UpdateTime: updateTime,
+			Sort:       s,
+		}
+	case s.SortOrder == LeaderboardSortOrderDescending:
+		return &RankDesc{
+			OwnerId:  ownerID,
+			Score:    score,
+			Subscore: subscore,
+		}
+	default:
+		return &RankAsc{
+			OwnerId:  ownerID,
+			Score:    score,
+			Subscore: subscore,
+		}
+	}
+}
+
+func leaderboardSortOrderName(sortOrder int) string {
+	if sortOrder == LeaderboardSortOrderAscending {
+		return "asc"
+	}
+	return "desc"
+}
+
+func leaderboardSortDirection(sortOrder int, reverse bool) string {
+	if (sortOrder == LeaderboardSortOrderAscending) != reverse {
+		return "ASC"
+	}
+	return "DESC"
+}
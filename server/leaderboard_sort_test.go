@@ -0,0 +1,98 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLeaderboardSort(t *testing.T) {
+	tests := []struct {
+		spec string
+		want LeaderboardSort
+	}{
+		{"desc", NewLeaderboardSort(LeaderboardSortOrderDescending)},
+		{"asc", NewLeaderboardSort(LeaderboardSortOrderAscending)},
+		{"desc,asc", LeaderboardSort{SortOrder: LeaderboardSortOrderDescending, SubscoreSortOrder: LeaderboardSortOrderAscending}},
+		{"asc,asc,time", LeaderboardSort{SortOrder: LeaderboardSortOrderAscending, SubscoreSortOrder: LeaderboardSortOrderAscending, TieBreak: LeaderboardTieBreakTime}},
+		{"desc,asc,time", LeaderboardSort{SortOrder: LeaderboardSortOrderDescending, SubscoreSortOrder: LeaderboardSortOrderAscending, TieBreak: LeaderboardTieBreakTime}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			sort, err := ParseLeaderboardSort(tt.spec)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, sort)
+			assert.Equal(t, tt.spec, sort.String())
+		})
+	}
+
+	// Subscore sort orders default to the score sort order.
+	sort, err := ParseLeaderboardSort("desc,time")
+	assert.NoError(t, err)
+	assert.Equal(t, LeaderboardSort{SortOrder: LeaderboardSortOrderDescending, SubscoreSortOrder: LeaderboardSortOrderDescending, TieBreak: LeaderboardTieBreakTime}, sort)
+
+	for _, spec := range []string{"", "up", "time", "desc,time,asc", "desc,asc,desc", "desc,asc,time,time"} {
+		_, err := ParseLeaderboardSort(spec)
+		assert.Equal(t, ErrLeaderboardInvalidSort, err, spec)
+	}
+}
+
+func TestLeaderboardSortQueries(t *testing.T) {
+	uniform := NewLeaderboardSort(LeaderboardSortOrderDescending)
+	assert.Equal(t, " ORDER BY score DESC, subscore DESC, owner_id DESC", uniform.orderBy(false))
+	assert.Equal(t, " ORDER BY score ASC, subscore ASC, owner_id ASC", uniform.orderBy(true))
+	query, params := uniform.after("", []interface{}{"lid", 0, 10}, false, 5, 6, 7, "owner")
+	assert.Equal(t, " AND (leaderboard_id, expiry_time, score, subscore, owner_id) < ($1, $2, $4, $5, $6)", query)
+	assert.Equal(t, []interface{}{"lid", 0, 10, int64(5), int64(6), "owner"}, params)
+
+	sort := LeaderboardSort{SortOrder: LeaderboardSortOrderDescending, SubscoreSortOrder: LeaderboardSortOrderAscending, TieBreak: LeaderboardTieBreakTime}
+	assert.Equal(t, " ORDER BY score DESC, subscore ASC, update_time ASC, owner_id DESC", sort.orderBy(false))
+	assert.Equal(t, " ORDER BY score ASC, subscore DESC, update_time DESC, owner_id ASC", sort.orderBy(true))
+	query, params = sort.after("", []interface{}{"lid", 0}, true, 5, 6, 7, "owner")
+	assert.Equal(t, " AND (score > $3 OR (score = $3 AND (subscore < $4 OR (subscore = $4 AND (update_time < $6 OR (update_time = $6 AND owner_id > $5))))))", query)
+	assert.Len(t, params, 6)
+
+	opSQL, filter := sort.best("$4", "$5")
+	assert.Equal(t, "score = GREATEST(leaderboard_record.score, $4), subscore = LEAST(leaderboard_record.subscore, $5)", opSQL)
+	assert.Equal(t, "leaderboard_record.score < $4 OR leaderboard_record.subscore > $5", filter)
+}
+
+func TestLocalLeaderboardRankCache_Insert_Sorted(t *testing.T) {
+	cache := &LocalLeaderboardRankCache{
+		blacklistIds: make(map[string]struct{}, 0),
+		blacklistAll: false,
+		cache:        make(map[LeaderboardWithExpiry]*RankCache, 0),
+	}
+	sort, _ := ParseLeaderboardSort("desc,asc,time")
+
+	u1 := uuid.Must(uuid.NewV4())
+	u2 := uuid.Must(uuid.NewV4())
+	u3 := uuid.Must(uuid.NewV4())
+	u4 := uuid.Must(uuid.NewV4())
+
+	// Most laps first, then fastest time, then earliest submission.
+	cache.Insert("lid", 0, sort, u1, 10, 300, 4)
+	cache.Insert("lid", 0, sort, u2, 10, 200, 3)
+	cache.Insert("lid", 0, sort, u3, 12, 500, 2)
+	cache.Insert("lid", 0, sort, u4, 10, 200, 1)
+
+	assert.EqualValues(t, 1, cache.Get("lid", 0, u3))
+	assert.EqualValues(t, 2, cache.Get("lid", 0, u4))
+	assert.EqualValues(t, 3, cache.Get("lid", 0, u2))
+	assert.EqualValues(t, 4, cache.Get("lid", 0, u1))
+}
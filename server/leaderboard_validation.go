@@ -0,0 +1,173 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Reasons a client score submission is rejected by leaderboard validation rules.
+const (
+	LeaderboardRejectMinScore  = "min_score"
+	LeaderboardRejectMaxScore  = "max_score"
+	LeaderboardRejectMaxDelta  = "max_score_delta"
+	LeaderboardRejectRateLimit = "max_submissions"
+)
+
+var (
+	ErrLeaderboardRecordRejected    = errors.New("leaderboard record rejected by validation rules")
+	ErrLeaderboardValidationInvalid = errors.New("leaderboard validation rules invalid")
+)
+
+// LeaderboardValidation limits the scores clients may submit to a leaderboard or tournament. Writes from the server
+// runtime are not validated. Zero values disable each limit.
+type LeaderboardValidation struct {
+	// Bounds on each submitted score.
+	MinScore *int64 `json:"min_score,omitempty"`
+	MaxScore *int64 `json:"max_score,omitempty"`
+	// Largest change to the owner's score a single submission may make.
+	MaxScoreDelta int64 `json:"max_score_delta,omitempty"`
+	// Largest number of submissions each owner may make in each window of the given number of seconds.
+	MaxSubmissions      int `json:"max_submissions,omitempty"`
+	SubmissionWindowSec int `json:"submission_window_sec,omitempty"`
+}
+
+// LeaderboardValidationSet replaces the validation rules of a leaderboard or tournament, or removes them if nil.
+func LeaderboardValidationSet(ctx context.Context, cache LeaderboardCache, id string, validation *LeaderboardValidation) error {
+	if validation != nil {
+		if validation.MinScore != nil && validation.MaxScore != nil && *validation.MinScore > *validation.MaxScore {
+			return ErrLeaderboardValidationInvalid
+		}
+		if validation.MaxScoreDelta < 0 || validation.MaxSubmissions < 0 || validation.SubmissionWindowSec < 0 {
+			return ErrLeaderboardValidationInvalid
+		}
+		if validation.MaxSubmissions > 0 && validation.SubmissionWindowSec == 0 {
+			return ErrLeaderboardValidationInvalid
+		}
+	}
+
+	return cache.SetValidation(ctx, id, validation)
+}
+
+// LeaderboardRejectionsList returns the number of client score submissions rejected for each reason.
+func LeaderboardRejectionsList(ctx context.Context, db *sql.DB, id string) (map[string]int64, error) {
+	rows, err := db.QueryContext(ctx, "SELECT reason, count FROM leaderboard_rejection WHERE leaderboard_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rejections := make(map[string]int64)
+	for rows.Next() {
+		var reason string
+		var count int64
+		if err := rows.Scan(&reason, &count); err != nil {
+			return nil, err
+		}
+		rejections[reason] = count
+	}
+	return rejections, rows.Err()
+}
+
+// Check a client score submission against the leaderboard's validation rules. The previous score is only needed if
+// the rules limit the score or the score delta, and is nil if the owner has no record yet. Rejections are counted in the database
+// for the console, and in metrics.
+func leaderboardValidateWrite(ctx context.Context, logger *zap.Logger, db *sql.DB, metrics Metrics, leaderboard *Leaderboard, ownerID string, operator int, score int64, previousScore *int64, now time.Time) error {
+	validation := leaderboard.Validation
+
+	reason := leaderboardValidationReason(validation, operator, score, previousScore)
+	if reason == "" && validation.MaxSubmissions > 0 {
+		// Submissions are counted in fixed windows, shared by all cluster nodes.
+		window := int64(validation.SubmissionWindowSec)
+		windowStart := time.Unix(now.Unix()-now.Unix()%window, 0).UTC()
+		query := `INSERT INTO leaderboard_submission (leaderboard_id, owner_id, window_start, count) VALUES ($1, $2, $3, 1)
+ON CONFLICT (leaderboard_id, owner_id) DO UPDATE SET
+count = CASE WHEN leaderboard_submission.window_start = $3 THEN leaderboard_submission.count + 1 ELSE 1 END, window_start = $3
+RETURNING count`
+		var count int
+		if err := db.QueryRowContext(ctx, query, leaderboard.Id, ownerID, windowStart).Scan(&count); err != nil {
+			logger.Error("Error counting leaderboard submissions", zap.Error(err))
+			return err
+		}
+		if count > validation.MaxSubmissions {
+			reason = LeaderboardRejectRateLimit
+		}
+	}
+	if reason == "" {
+		return nil
+	}
+
+	metrics.LeaderboardWriteRejectCount(map[string]string{"leaderboard_id": leaderboard.Id, "reason": reason}, 1)
+	query := `INSERT INTO leaderboard_rejection (leaderboard_id, reason, count) VALUES ($1, $2, 1)
+ON CONFLICT (leaderboard_id, reason) DO UPDATE SET count = leaderboard_rejection.count + 1, update_time = now()`
+	if _, err := db.ExecContext(ctx, query, leaderboard.Id, reason); err != nil {
+		logger.Error("Error counting leaderboard rejection", zap.Error(err))
+	}
+	logger.Debug("Rejected leaderboard record write", zap.String("leaderboard_id", leaderboard.Id), zap.String("owner_id", ownerID), zap.Int64("score", score), zap.String("reason", reason))
+	return ErrLeaderboardRecordRejected
+}
+
+// Whether checking submissions against the validation rules needs the owner's previous score.
+func (v *LeaderboardValidation) needsPreviousScore() bool {
+	// Increments and decrements are bounded by the score they result in.
+	return v.MinScore != nil || v.MaxScore != nil || v.MaxScoreDelta > 0
+}
+
+// The reason a submitted score breaks the score limits of the validation rules, empty if it does not. Increments and
+// decrements change the score by the submitted amount and the limits apply to the resulting score, other operators
+// change it by the difference from the previous score and the limits apply to the submitted score.
+func leaderboardValidationReason(validation *LeaderboardValidation, operator int, score int64, previousScore *int64) string {
+	result := score
+	switch operator {
+	case LeaderboardOperatorIncrement:
+		if previousScore != nil {
+			result = *previousScore + score
+		}
+	case LeaderboardOperatorDecrement:
+		// Decrements never take scores below 0, and new records start at 0.
+		result = 0
+		if previousScore != nil && *previousScore > score {
+			result = *previousScore - score
+		}
+	}
+
+	switch {
+	case validation.MinScore != nil && result < *validation.MinScore:
+		return LeaderboardRejectMinScore
+	case validation.MaxScore != nil && result > *validation.MaxScore:
+		return LeaderboardRejectMaxScore
+	case validation.MaxScoreDelta > 0:
+		delta := score
+		if operator != LeaderboardOperatorIncrement && operator != LeaderboardOperatorDecrement {
+			if previousScore == nil {
+				// The first submission is only bounded by the score limits.
+				return ""
+			}
+			delta = score - *previousScore
+		}
+		if delta < 0 {
+			delta = -delta
+		}
+		if delta > validation.MaxScoreDelta {
+			return LeaderboardRejectMaxDelta
+		}
+	}
+	return ""
+}
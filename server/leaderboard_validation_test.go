@@ -0,0 +1,146 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderboardValidationReason(t *testing.T) {
+	min, max := int64(0), int64(1000)
+	validation := &LeaderboardValidation{MinScore: &min, MaxScore: &max, MaxScoreDelta: 100}
+	previous := int64(500)
+
+	assert.Equal(t, "", leaderboardValidationReason(validation, LeaderboardOperatorBest, 550, &previous))
+	assert.Equal(t, LeaderboardRejectMinScore, leaderboardValidationReason(validation, LeaderboardOperatorBest, -1, &previous))
+	assert.Equal(t, LeaderboardRejectMaxScore, leaderboardValidationReason(validation, LeaderboardOperatorSet, 1001, &previous))
+	assert.Equal(t, LeaderboardRejectMaxDelta, leaderboardValidationReason(validation, LeaderboardOperatorSet, 601, &previous))
+	assert.Equal(t, LeaderboardRejectMaxDelta, leaderboardValidationReason(validation, LeaderboardOperatorSet, 399, &previous))
+
+	// The first submission is only bounded by the score limits.
+	assert.Equal(t, "", leaderboardValidationReason(validation, LeaderboardOperatorBest, 900, nil))
+
+	// Increments change the score by the submitted amount, regardless of the previous score.
+	assert.Equal(t, "", leaderboardValidationReason(validation, LeaderboardOperatorIncrement, 100, nil))
+	assert.Equal(t, LeaderboardRejectMaxDelta, leaderboardValidationReason(validation, LeaderboardOperatorIncrement, 101, &previous))
+
+	// The score limits apply to the score increments and decrements result in.
+	high, low := int64(950), int64(50)
+	assert.Equal(t, LeaderboardRejectMaxScore, leaderboardValidationReason(validation, LeaderboardOperatorIncrement, 60, &high))
+	assert.Equal(t, "", leaderboardValidationReason(validation, LeaderboardOperatorIncrement, 50, &high))
+	assert.Equal(t, "", leaderboardValidationReason(validation, LeaderboardOperatorDecrement, 60, &low))
+	atLeast := int64(10)
+	floor := &LeaderboardValidation{MinScore: &atLeast}
+	assert.Equal(t, LeaderboardRejectMinScore, leaderboardValidationReason(floor, LeaderboardOperatorDecrement, 45, &low))
+	assert.Equal(t, "", leaderboardValidationReason(floor, LeaderboardOperatorDecrement, 40, &low))
+	assert.Equal(t, LeaderboardRejectMinScore, leaderboardValidationReason(floor, LeaderboardOperatorIncrement, 5, nil))
+
+	// Without rules every score is accepted.
+	assert.Equal(t, "", leaderboardValidationReason(&LeaderboardValidation{}, LeaderboardOperatorSet, -1<<40, &previous))
+}
+
+func TestLeaderboardValidationSetInvalid(t *testing.T) {
+	min, max := int64(10), int64(0)
+	for _, validation := range []*LeaderboardValidation{
+		{MinScore: &min, MaxScore: &max},
+		{MaxScoreDelta: -1},
+		{MaxSubmissions: 5},
+	} {
+		assert.Equal(t, ErrLeaderboardValidationInvalid, LeaderboardValidationSet(context.Background(), nil, "id", validation))
+	}
+}
+
+func TestLeaderboardValidateWriteSubmissionWindow(t *testing.T) {
+	db := NewDB(t)
+	cache := NewLocalLeaderboardCache(logger, logger, db)
+	leaderboard, err := cache.Create(context.Background(), GenerateString(), false, NewLeaderboardSort(LeaderboardSortOrderDescending), LeaderboardOperatorBest, "", "")
+	if err != nil {
+		t.Fatalf("error creating leaderboard: %v", err)
+	}
+	defer cache.Delete(context.Background(), leaderboard.Id)
+
+	if err := LeaderboardValidationSet(context.Background(), cache, leaderboard.Id, &LeaderboardValidation{MaxSubmissions: 2, SubmissionWindowSec: 60}); err != nil {
+		t.Fatalf("error setting validation: %v", err)
+	}
+	leaderboard = cache.Get(leaderboard.Id)
+
+	owner := uuid.Must(uuid.NewV4()).String()
+	other := uuid.Must(uuid.NewV4()).String()
+	window := time.Unix(time.Now().Unix()/60*60, 0).UTC()
+	write := func(ownerID string, now time.Time) error {
+		return leaderboardValidateWrite(context.Background(), logger, db, &testMetrics{}, leaderboard, ownerID, LeaderboardOperatorBest, 10, nil, now)
+	}
+
+	// Submissions are counted per owner within each window.
+	assert.NoError(t, write(owner, window))
+	assert.NoError(t, write(owner, window.Add(30*time.Second)))
+	assert.Equal(t, ErrLeaderboardRecordRejected, write(owner, window.Add(59*time.Second)))
+	assert.NoError(t, write(other, window.Add(59*time.Second)))
+
+	// The count starts over in the next window, rather than adding to the previous one.
+	assert.NoError(t, write(owner, window.Add(60*time.Second)))
+	assert.NoError(t, write(owner, window.Add(90*time.Second)))
+	assert.Equal(t, ErrLeaderboardRecordRejected, write(owner, window.Add(119*time.Second)))
+
+	rejections, err := LeaderboardRejectionsList(context.Background(), db, leaderboard.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{LeaderboardRejectRateLimit: 2}, rejections)
+}
+
+func TestLeaderboardRecordWriteValidation(t *testing.T) {
+	db := NewDB(t)
+	cache := NewLocalLeaderboardCache(logger, logger, db)
+	rankCache := &LocalLeaderboardRankCache{
+		blacklistIds: make(map[string]struct{}, 0),
+		blacklistAll: false,
+		cache:        make(map[LeaderboardWithExpiry]*RankCache, 0),
+	}
+	leaderboard, err := cache.Create(context.Background(), GenerateString(), false, NewLeaderboardSort(LeaderboardSortOrderDescending), LeaderboardOperatorBest, "", "")
+	if err != nil {
+		t.Fatalf("error creating leaderboard: %v", err)
+	}
+	defer cache.Delete(context.Background(), leaderboard.Id)
+
+	max := int64(100)
+	if err := LeaderboardValidationSet(context.Background(), cache, leaderboard.Id, &LeaderboardValidation{MaxScore: &max, MaxScoreDelta: 50}); err != nil {
+		t.Fatalf("error setting validation: %v", err)
+	}
+
+	userID := uuid.Must(uuid.NewV4())
+	InsertUser(t, db, userID)
+	write := func(caller uuid.UUID, score int64) error {
+		_, err := LeaderboardRecordWrite(context.Background(), logger, db, cache, rankCache, &testMetrics{}, caller, leaderboard.Id, userID.String(), userID.String(), score, 0, "", api.Operator_NO_OVERRIDE)
+		return err
+	}
+
+	assert.NoError(t, write(userID, 40))
+	assert.Equal(t, ErrLeaderboardRecordRejected, write(userID, 101))
+	// The delta is measured from the stored score.
+	assert.Equal(t, ErrLeaderboardRecordRejected, write(userID, 91))
+	assert.NoError(t, write(userID, 90))
+
+	// Writes from the server runtime are not validated.
+	assert.NoError(t, write(uuid.Nil, 1000))
+
+	rejections, err := LeaderboardRejectionsList(context.Background(), db, leaderboard.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{LeaderboardRejectMaxScore: 1, LeaderboardRejectMaxDelta: 1}, rejections)
+}
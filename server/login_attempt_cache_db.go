@@ -0,0 +1,241 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+)
+
+const clusterMessageLoginAttemptCacheInvalidate = "login_attempt_cache_invalidate"
+
+// NewLoginAttemptCache selects the login attempt cache implementation matching the session configuration.
+func NewLoginAttemptCache(logger *zap.Logger, db *sql.DB, transport ClusterTransport, config Config) LoginAttemptCache {
+	if config.GetSession().CacheBackend == SessionCacheBackendDatabase {
+		return NewDbLoginAttemptCache(logger, db, transport, time.Duration(config.GetSession().CacheTtlMs)*time.Millisecond)
+	}
+	return NewLocalLoginAttemptCache()
+}
+
+// A lockout state recently read from the database, zero locked until time if not locked out.
+type dbLoginAttemptCacheEntry struct {
+	lockedUntil time.Time
+	checkTime   time.Time
+}
+
+// A lockout is identified by its type and the account or IP it applies to.
+type dbLoginAttemptCacheKey struct {
+	LockoutType LockoutType
+	Key         string
+}
+
+// DbLoginAttemptCache records failed attempts and lockouts in the database, so a lockout applies on all nodes
+// regardless of which node received the failed attempts. Lockout states read from the database are kept locally for
+// a short TTL, and dropped when a lockout is applied or reset on any node. Changes are also sent over the cluster
+// transport, for other nodes to drop their copies immediately.
+type DbLoginAttemptCache struct {
+	sync.Mutex
+	logger    *zap.Logger
+	db        *sql.DB
+	transport ClusterTransport
+	ttl       time.Duration
+	checked   map[dbLoginAttemptCacheKey]*dbLoginAttemptCacheEntry
+
+	ctx         context.Context
+	ctxCancelFn context.CancelFunc
+}
+
+func NewDbLoginAttemptCache(logger *zap.Logger, db *sql.DB, transport ClusterTransport, ttl time.Duration) LoginAttemptCache {
+	ctx, ctxCancelFn := context.WithCancel(context.Background())
+
+	c := &DbLoginAttemptCache{
+		logger:    logger,
+		db:        db,
+		transport: transport,
+		ttl:       ttl,
+		checked:   make(map[dbLoginAttemptCacheKey]*dbLoginAttemptCacheEntry),
+
+		ctx:         ctx,
+		ctxCancelFn: ctxCancelFn,
+	}
+
+	transport.Handle(clusterMessageLoginAttemptCacheInvalidate, c.handleInvalidate)
+
+	go func() {
+		ticker := time.NewTicker(10 * time.Minute)
+		for {
+			select {
+			case <-c.ctx.Done():
+				ticker.Stop()
+				return
+			case t := <-ticker.C:
+				now := t.UTC()
+				// Attempts older than the longest lockout period can no longer contribute to a lockout.
+				if _, err := c.db.ExecContext(c.ctx, "DELETE FROM login_attempt WHERE create_time < $1", now.Add(-lockoutPeriodIp)); err != nil {
+					c.logger.Error("Error removing expired login attempts", zap.Error(err))
+				}
+				if _, err := c.db.ExecContext(c.ctx, "DELETE FROM login_lockout WHERE locked_until < $1", now); err != nil {
+					c.logger.Error("Error removing expired login lockouts", zap.Error(err))
+				}
+				expired := t.Add(-c.ttl)
+				c.Lock()
+				for key, entry := range c.checked {
+					if !entry.checkTime.After(expired) {
+						delete(c.checked, key)
+					}
+				}
+				c.Unlock()
+			}
+		}
+	}()
+
+	return c
+}
+
+func (c *DbLoginAttemptCache) Stop() {
+	c.ctxCancelFn()
+}
+
+func (c *DbLoginAttemptCache) Allow(account, ip string) bool {
+	now := time.Now().UTC()
+	for _, key := range []dbLoginAttemptCacheKey{{LockoutType: LockoutTypeAccount, Key: account}, {LockoutType: LockoutTypeIp, Key: ip}} {
+		lockedUntil, err := c.lockedUntil(key, now)
+		if err != nil {
+			// Do not lock everyone out if the database is unavailable, authentication will fail regardless.
+			c.logger.Error("Error checking login lockout", zap.Error(err))
+			return true
+		}
+		if lockedUntil.After(now) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *DbLoginAttemptCache) Reset(account string) {
+	key := dbLoginAttemptCacheKey{LockoutType: LockoutTypeAccount, Key: account}
+	c.remove([]dbLoginAttemptCacheKey{key})
+
+	if _, err := c.db.ExecContext(c.ctx, "DELETE FROM login_attempt WHERE lockout_type = $1 AND key = $2", LockoutTypeAccount, account); err != nil {
+		c.logger.Error("Error resetting login attempts", zap.Error(err))
+	}
+	if _, err := c.db.ExecContext(c.ctx, "DELETE FROM login_lockout WHERE lockout_type = $1 AND key = $2", LockoutTypeAccount, account); err != nil {
+		c.logger.Error("Error resetting login lockout", zap.Error(err))
+	}
+	c.invalidate([]dbLoginAttemptCacheKey{key})
+}
+
+func (c *DbLoginAttemptCache) Add(account, ip string) (LockoutType, time.Time) {
+	now := time.Now().UTC()
+	var lockoutType LockoutType
+	var lockedUntil time.Time
+	if account != "" {
+		if c.add(LockoutTypeAccount, account, now, maxAttemptsAccount, lockoutPeriodAccount) {
+			lockedUntil = now.Add(lockoutPeriodAccount)
+			lockoutType = LockoutTypeAccount
+		}
+	}
+	// IP lockouts are not applied, matching the local login attempt cache.
+	return lockoutType, lockedUntil
+}
+
+// Record a failed attempt and apply a lockout if the maximum number of attempts was reached within the period.
+func (c *DbLoginAttemptCache) add(lockoutType LockoutType, key string, now time.Time, maxAttempts int, lockoutPeriod time.Duration) bool {
+	if _, err := c.db.ExecContext(c.ctx, "INSERT INTO login_attempt (id, lockout_type, key, create_time) VALUES ($1, $2, $3, $4)", uuid.Must(uuid.NewV4()), lockoutType, key, now); err != nil {
+		c.logger.Error("Error recording login attempt", zap.Error(err))
+		return false
+	}
+
+	var attempts int
+	if err := c.db.QueryRowContext(c.ctx, "SELECT count(*) FROM login_attempt WHERE lockout_type = $1 AND key = $2 AND create_time > $3", lockoutType, key, now.Add(-lockoutPeriod)).Scan(&attempts); err != nil {
+		c.logger.Error("Error counting login attempts", zap.Error(err))
+		return false
+	}
+	if attempts < maxAttempts {
+		return false
+	}
+
+	lockedUntil := now.Add(lockoutPeriod)
+	query := `
+INSERT INTO login_lockout (lockout_type, key, locked_until) VALUES ($1, $2, $3)
+ON CONFLICT (lockout_type, key) DO UPDATE SET locked_until = $3`
+	if _, err := c.db.ExecContext(c.ctx, query, lockoutType, key, lockedUntil); err != nil {
+		c.logger.Error("Error recording login lockout", zap.Error(err))
+	}
+
+	cacheKey := dbLoginAttemptCacheKey{LockoutType: lockoutType, Key: key}
+	c.set(cacheKey, lockedUntil, now)
+	c.invalidate([]dbLoginAttemptCacheKey{cacheKey})
+	return true
+}
+
+// Read the time a lockout applies until, from the local copy if it was read from the database within the TTL.
+func (c *DbLoginAttemptCache) lockedUntil(key dbLoginAttemptCacheKey, now time.Time) (time.Time, error) {
+	c.Lock()
+	entry, found := c.checked[key]
+	c.Unlock()
+	if found && now.Sub(entry.checkTime) < c.ttl {
+		return entry.lockedUntil, nil
+	}
+
+	var lockedUntil time.Time
+	if err := c.db.QueryRowContext(c.ctx, "SELECT locked_until FROM login_lockout WHERE lockout_type = $1 AND key = $2", key.LockoutType, key.Key).Scan(&lockedUntil); err != nil && err != sql.ErrNoRows {
+		return time.Time{}, err
+	}
+	c.set(key, lockedUntil, now)
+	return lockedUntil, nil
+}
+
+func (c *DbLoginAttemptCache) set(key dbLoginAttemptCacheKey, lockedUntil, now time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.Lock()
+	c.checked[key] = &dbLoginAttemptCacheEntry{lockedUntil: lockedUntil, checkTime: now}
+	c.Unlock()
+}
+
+func (c *DbLoginAttemptCache) remove(keys []dbLoginAttemptCacheKey) {
+	c.Lock()
+	for _, key := range keys {
+		delete(c.checked, key)
+	}
+	c.Unlock()
+}
+
+// Other nodes may have these lockout states cached, make them read the database again on next use rather than
+// waiting for the TTL.
+func (c *DbLoginAttemptCache) invalidate(keys []dbLoginAttemptCacheKey) {
+	payload, err := gobEncode(keys)
+	if err != nil {
+		c.logger.Error("Error encoding login attempt cache invalidation", zap.Error(err))
+		return
+	}
+	c.transport.Broadcast(clusterMessageLoginAttemptCacheInvalidate, payload)
+}
+
+func (c *DbLoginAttemptCache) handleInvalidate(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var keys []dbLoginAttemptCacheKey
+	if err := gobDecode(payload, &keys); err != nil {
+		return nil, err
+	}
+	c.remove(keys)
+	return nil, nil
+}
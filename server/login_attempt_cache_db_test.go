@@ -0,0 +1,123 @@
+// Copyright 2019 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDbLoginAttemptCacheLockoutAcrossNodes(t *testing.T) {
+	db := NewDB(t)
+	defer db.Close()
+
+	a := NewDbLoginAttemptCache(logger, db, NewLocalClusterTransport("node-a"), 0)
+	b := NewDbLoginAttemptCache(logger, db, NewLocalClusterTransport("node-b"), 0)
+	defer a.Stop()
+	defer b.Stop()
+
+	account := GenerateString()
+	ip := "127.0.0.1"
+
+	// Failed attempts received by either node count towards the same lockout.
+	for i := 0; i < maxAttemptsAccount-1; i++ {
+		node := a
+		if i%2 == 1 {
+			node = b
+		}
+		lockoutType, _ := node.Add(account, ip)
+		assert.Equal(t, LockoutTypeNone, lockoutType)
+	}
+	assert.True(t, a.Allow(account, ip))
+	assert.True(t, b.Allow(account, ip))
+
+	lockoutType, lockedUntil := a.Add(account, ip)
+	assert.Equal(t, LockoutTypeAccount, lockoutType)
+	assert.False(t, lockedUntil.IsZero())
+	assert.False(t, a.Allow(account, ip))
+	assert.False(t, b.Allow(account, ip))
+	// Other accounts are not affected.
+	assert.True(t, b.Allow(GenerateString(), ip))
+
+	// A successful login on any node clears the lockout everywhere.
+	b.Reset(account)
+	assert.True(t, a.Allow(account, ip))
+	assert.True(t, b.Allow(account, ip))
+}
+
+func TestDbLoginAttemptCacheInvalidate(t *testing.T) {
+	db := NewDB(t)
+	defer db.Close()
+
+	hub := NewLoopbackClusterHub()
+	transportA, transportB := hub.NewTransport("node-a"), hub.NewTransport("node-b")
+	a := NewDbLoginAttemptCache(logger, db, transportA, time.Minute)
+	b := NewDbLoginAttemptCache(logger, db, transportB, time.Minute)
+	defer a.Stop()
+	defer b.Stop()
+	for _, transport := range []*LoopbackClusterTransport{transportA, transportB} {
+		if err := transport.Start(); err != nil {
+			t.Fatalf("error starting transport: %v", err)
+		}
+		defer transport.Stop()
+	}
+
+	account := GenerateString()
+	ip := "127.0.0.1"
+
+	// Both nodes cache the account as not locked out.
+	assert.True(t, a.Allow(account, ip))
+	assert.True(t, b.Allow(account, ip))
+
+	// Clustered nodes drop their cached lockout states without waiting for the TTL.
+	for i := 0; i < maxAttemptsAccount; i++ {
+		a.Add(account, ip)
+	}
+	assert.False(t, a.Allow(account, ip))
+	assert.Eventually(t, func() bool {
+		return !b.Allow(account, ip)
+	}, time.Second, 10*time.Millisecond)
+
+	b.Reset(account)
+	assert.True(t, b.Allow(account, ip))
+	assert.Eventually(t, func() bool {
+		return a.Allow(account, ip)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDbLoginAttemptCacheLocalTtl(t *testing.T) {
+	db := NewDB(t)
+	defer db.Close()
+
+	ttl := 200 * time.Millisecond
+	a := NewDbLoginAttemptCache(logger, db, NewLocalClusterTransport("node-a"), ttl)
+	b := NewDbLoginAttemptCache(logger, db, NewLocalClusterTransport("node-b"), ttl)
+	defer a.Stop()
+	defer b.Stop()
+
+	account := GenerateString()
+	ip := "127.0.0.1"
+	assert.True(t, b.Allow(account, ip))
+
+	// Without a cluster connection, other nodes apply the lockout once their cached state expires.
+	for i := 0; i < maxAttemptsAccount; i++ {
+		a.Add(account, ip)
+	}
+	assert.False(t, a.Allow(account, ip))
+	time.Sleep(ttl)
+	assert.False(t, b.Allow(account, ip))
+}
@@ -49,11 +49,17 @@ type fatalable interface {
 // In addition to the MatchRegistry, a RuntimeMatchCreateFunction paired to work with it is returned.
 // This RuntimeMatchCreateFunction may be needed for later operations (such as CreateMatch)
 func createTestMatchRegistry(t fatalable, logger *zap.Logger) (*LocalMatchRegistry, RuntimeMatchCreateFunction, error) {
+	return createTestMatchRegistryForNode(t, logger, "node", &testTracker{}, NewLocalClusterTransport("node"))
+}
+
+// createTestMatchRegistryForNode is like createTestMatchRegistry, but allows the registry to be part of a cluster.
+func createTestMatchRegistryForNode(t fatalable, logger *zap.Logger, node string, tracker Tracker, transport ClusterTransport) (*LocalMatchRegistry, RuntimeMatchCreateFunction, error) {
 	cfg := NewConfig(logger)
+	cfg.Name = node
 	cfg.GetMatch().LabelUpdateIntervalMs = int(time.Hour / time.Millisecond)
 	messageRouter := &testMessageRouter{}
-	matchRegistry := NewLocalMatchRegistry(logger, logger, cfg, &testSessionRegistry{}, &testTracker{},
-		messageRouter, &testMetrics{}, "node")
+	matchRegistry := NewLocalMatchRegistry(logger, logger, cfg, &testSessionRegistry{}, tracker,
+		messageRouter, &testMetrics{}, node, transport)
 	mp := NewMatchProvider()
 
 	mp.RegisterCreateFn("go",
@@ -64,7 +70,7 @@ func createTestMatchRegistry(t fatalable, logger *zap.Logger) (*LocalMatchRegist
 				return nil, err
 			}
 
-			rmc, err := NewRuntimeGoMatchCore(logger, "module", matchRegistry, messageRouter, id, "node",
+			rmc, err := NewRuntimeGoMatchCore(logger, "module", matchRegistry, messageRouter, id, node,
 				stopped, nil, map[string]string{}, nil, match)
 			if err != nil {
 				return nil, err
@@ -179,11 +185,14 @@ func (s *testMetrics) GaugeAuthoritativeMatches(value float64)
 func (s *testMetrics) CountDroppedEvents(delta int64)                                       {}
 func (s *testMetrics) CountWebsocketOpened(delta int64)                                     {}
 func (s *testMetrics) CountWebsocketClosed(delta int64)                                     {}
+func (s *testMetrics) CountUdpOpened(delta int64)                                           {}
+func (s *testMetrics) CountUdpClosed(delta int64)                                           {}
 func (s *testMetrics) GaugeSessions(value float64)                                          {}
 func (s *testMetrics) GaugePresences(value float64)                                         {}
 func (s *testMetrics) Matchmaker(tickets, activeTickets float64, processTime time.Duration) {}
 func (s *testMetrics) PresenceEvent(dequeueElapsed, processElapsed time.Duration)           {}
 func (s *testMetrics) StorageWriteRejectCount(tags map[string]string, delta int64)          {}
+func (s *testMetrics) LeaderboardWriteRejectCount(tags map[string]string, delta int64)      {}
 func (s *testMetrics) CustomCounter(name string, tags map[string]string, delta int64)       {}
 func (s *testMetrics) CustomGauge(name string, tags map[string]string, value float64)       {}
 func (s *testMetrics) CustomTimer(name string, tags map[string]string, value time.Duration) {}
@@ -96,6 +96,10 @@ type MatchRegistry interface {
 	RemoveMatch(id uuid.UUID, stream PresenceStream)
 	// Update the label entry for a given match.
 	UpdateMatchLabel(id uuid.UUID, tickRate int, handlerName, label string, createTime int64) error
+	// Set the function that publishes matchmaker backfill requests for matches.
+	SetBackfillListener(f func(matchID, query string, count, size int, label string) error)
+	// Publish a matchmaker backfill request for open slots in a match, or cancel it if the count is 0.
+	Backfill(id uuid.UUID, query string, count int) error
 	// List (and optionally filter) currently running matches.
 	// This can list across both authoritative and relayed matches.
 	ListMatches(ctx context.Context, limit int, authoritative *wrapperspb.BoolValue, label *wrapperspb.StringValue, minSize *wrapperspb.Int32Value, maxSize *wrapperspb.Int32Value, query *wrapperspb.StringValue, node *wrapperspb.StringValue) ([]*api.Match, []string, error)
@@ -131,6 +135,7 @@ type LocalMatchRegistry struct {
 	router          MessageRouter
 	metrics         Metrics
 	node            string
+	transport       ClusterTransport
 
 	ctx         context.Context
 	ctxCancelFn context.CancelFunc
@@ -142,11 +147,21 @@ type LocalMatchRegistry struct {
 	pendingUpdatesMutex *sync.Mutex
 	pendingUpdates      map[string]*MatchIndexEntry
 
+	// Labels of local matches, and the IDs of matches known to be hosted by each peer node.
+	clusterMutex     sync.Mutex
+	clusterLabels    map[string]*matchClusterLabel
+	clusterPending   map[string]*matchClusterLabel
+	clusterPeers     map[string]struct{}
+	clusterSyncNodes map[string]struct{}
+	clusterRemote    map[string]map[string]struct{}
+
 	stopped   *atomic.Bool
 	stoppedCh chan struct{}
+
+	backfillListener func(matchID, query string, count, size int, label string) error
 }
 
-func NewLocalMatchRegistry(logger, startupLogger *zap.Logger, config Config, sessionRegistry SessionRegistry, tracker Tracker, router MessageRouter, metrics Metrics, node string) MatchRegistry {
+func NewLocalMatchRegistry(logger, startupLogger *zap.Logger, config Config, sessionRegistry SessionRegistry, tracker Tracker, router MessageRouter, metrics Metrics, node string, transport ClusterTransport) MatchRegistry {
 
 	cfg := BlugeInMemoryConfig()
 	indexWriter, err := bluge.OpenWriter(cfg)
@@ -164,6 +179,7 @@ func NewLocalMatchRegistry(logger, startupLogger *zap.Logger, config Config, ses
 		router:          router,
 		metrics:         metrics,
 		node:            node,
+		transport:       transport,
 
 		ctx:         ctx,
 		ctxCancelFn: ctxCancelFn,
@@ -175,10 +191,25 @@ func NewLocalMatchRegistry(logger, startupLogger *zap.Logger, config Config, ses
 		pendingUpdatesMutex: &sync.Mutex{},
 		pendingUpdates:      make(map[string]*MatchIndexEntry, 10),
 
+		clusterLabels:    make(map[string]*matchClusterLabel, 10),
+		clusterPending:   make(map[string]*matchClusterLabel, 10),
+		clusterPeers:     make(map[string]struct{}),
+		clusterSyncNodes: make(map[string]struct{}),
+		clusterRemote:    make(map[string]map[string]struct{}),
+
 		stopped:   atomic.NewBool(false),
 		stoppedCh: make(chan struct{}, 2),
 	}
 
+	transport.Handle(clusterMessageMatchLabels, r.handleClusterLabels)
+	transport.Handle(clusterMessageMatchGet, r.handleClusterGet)
+	transport.Handle(clusterMessageMatchJoinAttempt, r.handleClusterJoinAttempt)
+	transport.Handle(clusterMessageMatchData, r.handleClusterData)
+	transport.Handle(clusterMessageMatchSignal, r.handleClusterSignal)
+	transport.Handle(clusterMessageMatchGetState, r.handleClusterGetState)
+	transport.Handle(clusterMessageMatchKick, r.handleClusterKick)
+	transport.AddPeerListener(r.onClusterPeer)
+
 	go func() {
 		ticker := time.NewTicker(time.Duration(config.GetMatch().LabelUpdateIntervalMs) * time.Millisecond)
 		batch := bluge.NewBatch()
@@ -188,6 +219,7 @@ func NewLocalMatchRegistry(logger, startupLogger *zap.Logger, config Config, ses
 				ticker.Stop()
 				return
 			case <-ticker.C:
+				r.processClusterLabels()
 				r.processLabelUpdates(batch)
 			}
 		}
@@ -298,7 +330,7 @@ func (r *LocalMatchRegistry) GetMatch(ctx context.Context, id string) (*api.Matc
 
 	// Authoritative match.
 	if idComponents[1] != r.node {
-		return nil, "", nil
+		return r.getRemoteMatch(ctx, id, idComponents[1])
 	}
 
 	mh, ok := r.matches.Load(matchID)
@@ -327,6 +359,12 @@ func (r *LocalMatchRegistry) RemoveMatch(id uuid.UUID, stream PresenceStream) {
 	r.pendingUpdatesMutex.Lock()
 	r.pendingUpdates[idStr] = nil
 	r.pendingUpdatesMutex.Unlock()
+	r.replicateLabel(idStr, nil)
+
+	if r.backfillListener != nil {
+		// Ended matches no longer take matchmaker tickets.
+		_ = r.backfillListener(idStr, "", 0, 0, "")
+	}
 
 	// If there are no more matches in this registry and a shutdown was initiated then signal
 	// that the process is complete.
@@ -340,6 +378,27 @@ func (r *LocalMatchRegistry) RemoveMatch(id uuid.UUID, stream PresenceStream) {
 	}
 }
 
+func (r *LocalMatchRegistry) SetBackfillListener(f func(matchID, query string, count, size int, label string) error) {
+	r.backfillListener = f
+}
+
+func (r *LocalMatchRegistry) Backfill(id uuid.UUID, query string, count int) error {
+	if r.backfillListener == nil {
+		return runtime.ErrMatchmakerNotAvailable
+	}
+	if count < 0 {
+		return ErrMatchmakerBackfillInvalid
+	}
+	// Tickets are checked against the match as it is when the request is made.
+	var size int
+	var label string
+	if mh, ok := r.matches.Load(id); ok {
+		size = mh.PresenceList.Size()
+		label = mh.Label()
+	}
+	return r.backfillListener(fmt.Sprintf("%v.%v", id.String(), r.node), query, count, size, label)
+}
+
 func (r *LocalMatchRegistry) UpdateMatchLabel(id uuid.UUID, tickRate int, handlerName, label string, createTime int64) error {
 	if len(label) > MatchLabelMaxBytes {
 		return runtime.ErrMatchLabelTooLong
@@ -361,6 +420,7 @@ func (r *LocalMatchRegistry) UpdateMatchLabel(id uuid.UUID, tickRate int, handle
 	r.pendingUpdatesMutex.Lock()
 	r.pendingUpdates[idStr] = entry
 	r.pendingUpdatesMutex.Unlock()
+	r.replicateLabel(idStr, entry)
 
 	return nil
 }
@@ -392,7 +452,7 @@ func (r *LocalMatchRegistry) ListMatches(ctx context.Context, limit int, authori
 		// If there are filters other than query, we don't know which matches will work so get more than the limit.
 		count := limit
 		if minSize != nil || maxSize != nil {
-			count = int(r.matchCount.Load())
+			count = r.indexedCount()
 		}
 		if count == 0 {
 			return make([]*api.Match, 0), make([]string, 0), nil
@@ -444,7 +504,7 @@ func (r *LocalMatchRegistry) ListMatches(ctx context.Context, limit int, authori
 		// If there are filters other than label, we don't know which matches will work so get more than the limit.
 		count := limit
 		if minSize != nil || maxSize != nil {
-			count = int(r.matchCount.Load())
+			count = r.indexedCount()
 		}
 		if count == 0 {
 			return make([]*api.Match, 0), make([]string, 0), nil
@@ -476,7 +536,7 @@ func (r *LocalMatchRegistry) ListMatches(ctx context.Context, limit int, authori
 		// if authoritative matches may be included in the results.
 		count := limit
 		if minSize != nil || maxSize != nil {
-			count = int(r.matchCount.Load())
+			count = r.indexedCount()
 		}
 		if count == 0 && authoritative != nil && authoritative.Value {
 			return make([]*api.Match, 0), make([]string, 0), nil
@@ -531,13 +591,22 @@ func (r *LocalMatchRegistry) ListMatches(ctx context.Context, limit int, authori
 	if labelResults != nil {
 		for _, hit := range labelResults.Hits {
 			matchIDComponents := strings.SplitN(hit.ID, ".", 2)
+			if len(matchIDComponents) != 2 {
+				continue
+			}
 			id := uuid.FromStringOrNil(matchIDComponents[0])
 
-			mh, ok := r.matches.Load(id)
-			if !ok {
-				continue
+			var size int32
+			if matchIDComponents[1] == r.node {
+				mh, ok := r.matches.Load(id)
+				if !ok {
+					continue
+				}
+				size = int32(mh.PresenceList.Size())
+			} else {
+				// Matches hosted on other nodes are sized by their presences replicated to the local tracker.
+				size = int32(r.tracker.CountByStream(PresenceStream{Mode: StreamModeMatchAuthoritative, Subject: id, Label: matchIDComponents[1]}))
 			}
-			size := int32(mh.PresenceList.Size())
 
 			if minSize != nil && minSize.Value > size {
 				// Not eligible based on minimum size.
@@ -695,7 +764,18 @@ func (r *LocalMatchRegistry) Count() int {
 
 func (r *LocalMatchRegistry) JoinAttempt(ctx context.Context, id uuid.UUID, node string, userID, sessionID uuid.UUID, username string, sessionExpiry int64, vars map[string]string, clientIP, clientPort, fromNode string, metadata map[string]string) (bool, bool, bool, string, string, []*MatchPresence) {
 	if node != r.node {
-		return false, false, false, "", "", nil
+		return r.remoteJoinAttempt(ctx, &matchClusterJoinAttempt{
+			ID:            id,
+			UserID:        userID,
+			SessionID:     sessionID,
+			Username:      username,
+			SessionExpiry: sessionExpiry,
+			Vars:          vars,
+			ClientIP:      clientIP,
+			ClientPort:    clientPort,
+			FromNode:      fromNode,
+			Metadata:      metadata,
+		}, node)
 	}
 
 	mh, ok := r.matches.Load(id)
@@ -749,16 +829,37 @@ func (r *LocalMatchRegistry) Leave(id uuid.UUID, presences []*MatchPresence) {
 }
 
 func (r *LocalMatchRegistry) Kick(stream PresenceStream, presences []*MatchPresence) {
+	var remotePresences map[string][]*MatchPresence
 	for _, presence := range presences {
 		if presence.Node != r.node {
+			if remotePresences == nil {
+				remotePresences = make(map[string][]*MatchPresence, 1)
+			}
+			remotePresences[presence.Node] = append(remotePresences[presence.Node], presence)
 			continue
 		}
 		r.tracker.Untrack(presence.SessionID, stream, presence.UserID)
 	}
+
+	// Participants connected to other nodes must be untracked by the node they're connected to.
+	for node, nodePresences := range remotePresences {
+		r.remoteKick(stream, nodePresences, node)
+	}
 }
 
 func (r *LocalMatchRegistry) SendData(id uuid.UUID, node string, userID, sessionID uuid.UUID, username, fromNode string, opCode int64, data []byte, reliable bool, receiveTime int64) {
 	if node != r.node {
+		r.remoteSendData(&matchClusterData{
+			ID:          id,
+			UserID:      userID,
+			SessionID:   sessionID,
+			Username:    username,
+			FromNode:    fromNode,
+			OpCode:      opCode,
+			Data:        data,
+			Reliable:    reliable,
+			ReceiveTime: receiveTime,
+		}, node)
 		return
 	}
 
@@ -771,7 +872,7 @@ func (r *LocalMatchRegistry) SendData(id uuid.UUID, node string, userID, session
 		UserID:      userID,
 		SessionID:   sessionID,
 		Username:    username,
-		Node:        node,
+		Node:        fromNode,
 		OpCode:      opCode,
 		Data:        data,
 		Reliable:    reliable,
@@ -797,7 +898,7 @@ func (r *LocalMatchRegistry) Signal(ctx context.Context, id, data string) (strin
 
 	// Authoritative match.
 	if idComponents[1] != r.node {
-		return "", runtime.ErrMatchNotFound
+		return r.remoteSignal(ctx, id, data, idComponents[1])
 	}
 
 	mh, ok := r.matches.Load(matchID)
@@ -835,7 +936,7 @@ func (r *LocalMatchRegistry) Signal(ctx context.Context, id, data string) (strin
 
 func (r *LocalMatchRegistry) GetState(ctx context.Context, id uuid.UUID, node string) ([]*rtapi.UserPresence, int64, string, error) {
 	if node != r.node {
-		return nil, 0, "", nil
+		return r.remoteGetState(ctx, id, node)
 	}
 
 	mh, ok := r.matches.Load(id)
@@ -0,0 +1,469 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	clusterMessageMatchLabels      = "match_labels"
+	clusterMessageMatchGet         = "match_get"
+	clusterMessageMatchJoinAttempt = "match_join_attempt"
+	clusterMessageMatchData        = "match_data"
+	clusterMessageMatchSignal      = "match_signal"
+	clusterMessageMatchGetState    = "match_get_state"
+	clusterMessageMatchKick        = "match_kick"
+)
+
+// matchClusterLabel is the replicated form of a match index entry, or its removal.
+type matchClusterLabel struct {
+	ID          string
+	Removed     bool
+	LabelString string
+	TickRate    int
+	HandlerName string
+	CreateTime  int64
+}
+
+type matchClusterLabels struct {
+	Labels []*matchClusterLabel
+	// True if this is the complete set of matches hosted by the sender, replacing any previously known.
+	Full bool
+}
+
+type matchClusterJoinAttempt struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	SessionID     uuid.UUID
+	Username      string
+	SessionExpiry int64
+	Vars          map[string]string
+	ClientIP      string
+	ClientPort    string
+	FromNode      string
+	Metadata      map[string]string
+}
+
+type matchClusterJoinAttemptResult struct {
+	Found     bool
+	Allow     bool
+	IsNew     bool
+	Reason    string
+	Label     string
+	Presences []*MatchPresence
+}
+
+type matchClusterData struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	SessionID   uuid.UUID
+	Username    string
+	FromNode    string
+	OpCode      int64
+	Data        []byte
+	Reliable    bool
+	ReceiveTime int64
+}
+
+type matchClusterSignal struct {
+	ID   string
+	Data string
+}
+
+type matchClusterPresence struct {
+	UserID    string
+	SessionID string
+	Username  string
+}
+
+type matchClusterGetStateResult struct {
+	Presences []*matchClusterPresence
+	Tick      int64
+	State     string
+}
+
+type matchClusterKick struct {
+	Stream    PresenceStream
+	Presences []*MatchPresence
+}
+
+// Queue a label change to a locally hosted match for replication to other nodes. A nil entry marks a removal.
+func (r *LocalMatchRegistry) replicateLabel(id string, entry *MatchIndexEntry) {
+	label := &matchClusterLabel{ID: id, Removed: entry == nil}
+	if entry != nil {
+		label.LabelString = entry.LabelString
+		label.TickRate = entry.TickRate
+		label.HandlerName = entry.HandlerName
+		label.CreateTime = entry.CreateTime
+	}
+
+	r.clusterMutex.Lock()
+	if entry == nil {
+		delete(r.clusterLabels, id)
+	} else {
+		r.clusterLabels[id] = label
+	}
+	r.clusterPending[id] = label
+	r.clusterMutex.Unlock()
+}
+
+// Send any label changes and full label snapshots to peers, runs on the same interval as local index updates.
+func (r *LocalMatchRegistry) processClusterLabels() {
+	r.clusterMutex.Lock()
+	if len(r.clusterPending) == 0 && len(r.clusterSyncNodes) == 0 {
+		r.clusterMutex.Unlock()
+		return
+	}
+	pending := make([]*matchClusterLabel, 0, len(r.clusterPending))
+	for _, label := range r.clusterPending {
+		pending = append(pending, label)
+	}
+	r.clusterPending = make(map[string]*matchClusterLabel, len(pending))
+	var full []*matchClusterLabel
+	syncNodes := r.clusterSyncNodes
+	if len(syncNodes) != 0 {
+		full = make([]*matchClusterLabel, 0, len(r.clusterLabels))
+		for _, label := range r.clusterLabels {
+			full = append(full, label)
+		}
+		r.clusterSyncNodes = make(map[string]struct{})
+	}
+	r.clusterMutex.Unlock()
+
+	for node := range syncNodes {
+		r.sendClusterLabels(node, &matchClusterLabels{Labels: full, Full: true})
+	}
+	if len(pending) == 0 {
+		return
+	}
+	for _, node := range r.transport.Peers() {
+		if _, found := syncNodes[node]; found {
+			// The full set already reflects all pending changes.
+			continue
+		}
+		r.sendClusterLabels(node, &matchClusterLabels{Labels: pending})
+	}
+}
+
+func (r *LocalMatchRegistry) sendClusterLabels(node string, labels *matchClusterLabels) {
+	payload, err := gobEncode(labels)
+	if err != nil {
+		r.logger.Error("Failed to encode match labels", zap.Error(err))
+		return
+	}
+	if err := r.transport.Send(node, clusterMessageMatchLabels, payload); err != nil {
+		r.logger.Debug("Failed to send match labels", zap.String("node", node), zap.Error(err))
+	}
+}
+
+func (r *LocalMatchRegistry) onClusterPeer(node string, connected bool) {
+	r.clusterMutex.Lock()
+	defer r.clusterMutex.Unlock()
+
+	if connected {
+		r.clusterPeers[node] = struct{}{}
+		r.clusterSyncNodes[node] = struct{}{}
+		return
+	}
+
+	// Matches hosted on the disconnected node are no longer reachable.
+	delete(r.clusterPeers, node)
+	ids := r.clusterRemote[node]
+	delete(r.clusterRemote, node)
+	r.pendingUpdatesMutex.Lock()
+	for id := range ids {
+		r.pendingUpdates[id] = nil
+	}
+	r.pendingUpdatesMutex.Unlock()
+}
+
+func (r *LocalMatchRegistry) handleClusterLabels(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var labels matchClusterLabels
+	if err := gobDecode(payload, &labels); err != nil {
+		return nil, err
+	}
+
+	r.clusterMutex.Lock()
+	defer r.clusterMutex.Unlock()
+	if _, found := r.clusterPeers[node]; !found {
+		// Arrived after the peer disconnected, it will send a full set again if it reconnects.
+		return nil, nil
+	}
+
+	ids, found := r.clusterRemote[node]
+	if !found {
+		ids = make(map[string]struct{}, len(labels.Labels))
+		r.clusterRemote[node] = ids
+	}
+
+	r.pendingUpdatesMutex.Lock()
+	defer r.pendingUpdatesMutex.Unlock()
+
+	if labels.Full {
+		keep := make(map[string]struct{}, len(labels.Labels))
+		for _, label := range labels.Labels {
+			keep[label.ID] = struct{}{}
+		}
+		for id := range ids {
+			if _, found := keep[id]; !found {
+				delete(ids, id)
+				r.pendingUpdates[id] = nil
+			}
+		}
+	}
+
+	for _, label := range labels.Labels {
+		if !strings.HasSuffix(label.ID, "."+node) {
+			// Nodes may only replicate the matches they host.
+			continue
+		}
+		if label.Removed {
+			delete(ids, label.ID)
+			r.pendingUpdates[label.ID] = nil
+			continue
+		}
+		var labelJSON map[string]interface{}
+		// Doesn't matter if this is not JSON.
+		_ = json.Unmarshal([]byte(label.LabelString), &labelJSON)
+		ids[label.ID] = struct{}{}
+		r.pendingUpdates[label.ID] = &MatchIndexEntry{
+			Node:        node,
+			Label:       labelJSON,
+			LabelString: label.LabelString,
+			TickRate:    label.TickRate,
+			HandlerName: label.HandlerName,
+			CreateTime:  label.CreateTime,
+		}
+	}
+
+	return nil, nil
+}
+
+// Total number of authoritative matches known to this node, hosted either locally or on any peer.
+func (r *LocalMatchRegistry) indexedCount() int {
+	count := int(r.matchCount.Load())
+	r.clusterMutex.Lock()
+	for _, ids := range r.clusterRemote {
+		count += len(ids)
+	}
+	r.clusterMutex.Unlock()
+	return count
+}
+
+func (r *LocalMatchRegistry) getRemoteMatch(ctx context.Context, id, node string) (*api.Match, string, error) {
+	reply, err := r.transport.Request(ctx, node, clusterMessageMatchGet, []byte(id))
+	if err != nil {
+		if err == ErrClusterNodeNotFound {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	if len(reply) == 0 {
+		return nil, "", nil
+	}
+	match := &api.Match{}
+	if err := proto.Unmarshal(reply, match); err != nil {
+		return nil, "", err
+	}
+	return match, node, nil
+}
+
+func (r *LocalMatchRegistry) handleClusterGet(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	match, _, err := r.GetMatch(ctx, string(payload))
+	if err != nil || match == nil {
+		return nil, err
+	}
+	return proto.Marshal(match)
+}
+
+func (r *LocalMatchRegistry) remoteJoinAttempt(ctx context.Context, attempt *matchClusterJoinAttempt, node string) (bool, bool, bool, string, string, []*MatchPresence) {
+	payload, err := gobEncode(attempt)
+	if err != nil {
+		r.logger.Error("Failed to encode match join attempt", zap.Error(err))
+		return true, false, false, "", "", nil
+	}
+	reply, err := r.transport.Request(ctx, node, clusterMessageMatchJoinAttempt, payload)
+	if err != nil {
+		if err == ErrClusterNodeNotFound {
+			return false, false, false, "", "", nil
+		}
+		// The join attempt could not be completed, join is assumed to be rejected.
+		r.logger.Warn("Failed to route match join attempt", zap.String("node", node), zap.Error(err))
+		return true, false, false, "", "", nil
+	}
+	var result matchClusterJoinAttemptResult
+	if err := gobDecode(reply, &result); err != nil {
+		r.logger.Error("Failed to decode match join attempt result", zap.Error(err))
+		return true, false, false, "", "", nil
+	}
+	return result.Found, result.Allow, result.IsNew, result.Reason, result.Label, result.Presences
+}
+
+func (r *LocalMatchRegistry) handleClusterJoinAttempt(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var attempt matchClusterJoinAttempt
+	if err := gobDecode(payload, &attempt); err != nil {
+		return nil, err
+	}
+	found, allow, isNew, reason, label, presences := r.JoinAttempt(ctx, attempt.ID, r.node, attempt.UserID, attempt.SessionID, attempt.Username, attempt.SessionExpiry, attempt.Vars, attempt.ClientIP, attempt.ClientPort, attempt.FromNode, attempt.Metadata)
+	return gobEncode(&matchClusterJoinAttemptResult{
+		Found:     found,
+		Allow:     allow,
+		IsNew:     isNew,
+		Reason:    reason,
+		Label:     label,
+		Presences: presences,
+	})
+}
+
+func (r *LocalMatchRegistry) remoteSendData(data *matchClusterData, node string) {
+	payload, err := gobEncode(data)
+	if err != nil {
+		r.logger.Error("Failed to encode match data", zap.Error(err))
+		return
+	}
+	if err := r.transport.Send(node, clusterMessageMatchData, payload); err != nil {
+		r.logger.Debug("Failed to route match data", zap.String("node", node), zap.Error(err))
+	}
+}
+
+func (r *LocalMatchRegistry) handleClusterData(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var data matchClusterData
+	if err := gobDecode(payload, &data); err != nil {
+		return nil, err
+	}
+	r.SendData(data.ID, r.node, data.UserID, data.SessionID, data.Username, data.FromNode, data.OpCode, data.Data, data.Reliable, data.ReceiveTime)
+	return nil, nil
+}
+
+func (r *LocalMatchRegistry) remoteSignal(ctx context.Context, id, data, node string) (string, error) {
+	payload, err := gobEncode(&matchClusterSignal{ID: id, Data: data})
+	if err != nil {
+		return "", err
+	}
+	reply, err := r.transport.Request(ctx, node, clusterMessageMatchSignal, payload)
+	if err != nil {
+		return "", matchClusterError(err)
+	}
+	return string(reply), nil
+}
+
+func (r *LocalMatchRegistry) handleClusterSignal(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var signal matchClusterSignal
+	if err := gobDecode(payload, &signal); err != nil {
+		return nil, err
+	}
+	result, err := r.Signal(ctx, signal.ID, signal.Data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(result), nil
+}
+
+func (r *LocalMatchRegistry) remoteGetState(ctx context.Context, id uuid.UUID, node string) ([]*rtapi.UserPresence, int64, string, error) {
+	reply, err := r.transport.Request(ctx, node, clusterMessageMatchGetState, id.Bytes())
+	if err != nil {
+		if err == ErrClusterNodeNotFound {
+			return nil, 0, "", nil
+		}
+		return nil, 0, "", matchClusterError(err)
+	}
+	var result matchClusterGetStateResult
+	if err := gobDecode(reply, &result); err != nil {
+		return nil, 0, "", err
+	}
+	presences := make([]*rtapi.UserPresence, 0, len(result.Presences))
+	for _, presence := range result.Presences {
+		presences = append(presences, &rtapi.UserPresence{
+			UserId:    presence.UserID,
+			SessionId: presence.SessionID,
+			Username:  presence.Username,
+		})
+	}
+	return presences, result.Tick, result.State, nil
+}
+
+func (r *LocalMatchRegistry) handleClusterGetState(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	id, err := uuid.FromBytes(payload)
+	if err != nil {
+		return nil, err
+	}
+	presences, tick, state, err := r.GetState(ctx, id, r.node)
+	if err != nil {
+		return nil, err
+	}
+	result := &matchClusterGetStateResult{
+		Presences: make([]*matchClusterPresence, 0, len(presences)),
+		Tick:      tick,
+		State:     state,
+	}
+	for _, presence := range presences {
+		result.Presences = append(result.Presences, &matchClusterPresence{
+			UserID:    presence.UserId,
+			SessionID: presence.SessionId,
+			Username:  presence.Username,
+		})
+	}
+	return gobEncode(result)
+}
+
+func (r *LocalMatchRegistry) remoteKick(stream PresenceStream, presences []*MatchPresence, node string) {
+	payload, err := gobEncode(&matchClusterKick{Stream: stream, Presences: presences})
+	if err != nil {
+		r.logger.Error("Failed to encode match kick", zap.Error(err))
+		return
+	}
+	if err := r.transport.Send(node, clusterMessageMatchKick, payload); err != nil {
+		r.logger.Debug("Failed to route match kick", zap.String("node", node), zap.Error(err))
+	}
+}
+
+func (r *LocalMatchRegistry) handleClusterKick(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var kick matchClusterKick
+	if err := gobDecode(payload, &kick); err != nil {
+		return nil, err
+	}
+	if kick.Stream.Label != node {
+		// Only the node hosting a match may kick its participants.
+		return nil, nil
+	}
+	r.Kick(kick.Stream, kick.Presences)
+	return nil, nil
+}
+
+// Errors returned by other nodes only carry their message, map them back to the expected runtime errors.
+func matchClusterError(err error) error {
+	switch err {
+	case ErrClusterNodeNotFound:
+		return runtime.ErrMatchNotFound
+	case ErrClusterRequestTimeout:
+		return runtime.ErrMatchBusy
+	}
+	for _, e := range []error{runtime.ErrMatchNotFound, runtime.ErrMatchBusy, runtime.ErrMatchIdInvalid, runtime.ErrMatchStateFailed} {
+		if err.Error() == e.Error() {
+			return e
+		}
+	}
+	return err
+}
@@ -21,10 +21,12 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/blugelabs/bluge"
 	"github.com/gofrs/uuid"
 	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -472,3 +474,79 @@ func matchUUIDFromString(matchIDString string) (uuid.UUID, error) {
 	}
 	return uuid.FromString(matchIDComponents[0])
 }
+
+// should route match operations to the node hosting the match, and list matches from all nodes
+func TestMatchRegistryClusterRouting(t *testing.T) {
+	consoleLogger := loggerForTest(t)
+	hub := NewLoopbackClusterHub()
+	transportA := hub.NewTransport("a")
+	transportB := hub.NewTransport("b")
+	matchRegistryA, _, err := createTestMatchRegistryForNode(t, consoleLogger, "a", &testTracker{}, transportA)
+	if err != nil {
+		t.Fatalf("error creating test match registry: %v", err)
+	}
+	defer matchRegistryA.Stop(0)
+	matchRegistryB, runtimeMatchCreateFunc, err := createTestMatchRegistryForNode(t, consoleLogger, "b", &testTracker{}, transportB)
+	if err != nil {
+		t.Fatalf("error creating test match registry: %v", err)
+	}
+	defer matchRegistryB.Stop(0)
+	_ = transportA.Start()
+	defer transportA.Stop()
+	_ = transportB.Start()
+	defer transportB.Stop()
+
+	res, err := matchRegistryB.CreateMatch(context.Background(),
+		runtimeMatchCreateFunc, "match", map[string]interface{}{
+			"label": "label",
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matchID, err := matchUUIDFromString(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Labels of matches on node b become visible in listings on node a.
+	matchRegistryB.processLabelUpdates(bluge.NewBatch())
+	matchRegistryB.processClusterLabels()
+	assert.Eventually(t, func() bool {
+		matchRegistryA.processLabelUpdates(bluge.NewBatch())
+		matches, nodes, err := matchRegistryA.ListMatches(context.Background(), 2, wrapperspb.Bool(true),
+			wrapperspb.String("label"), nil, nil, nil, nil)
+		return err == nil && len(matches) == 1 && matches[0].MatchId == res && nodes[0] == "b"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	match, node, err := matchRegistryA.GetMatch(context.Background(), res)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", node)
+	assert.Equal(t, "label", match.GetLabel().GetValue())
+
+	result, err := matchRegistryA.Signal(context.Background(), res, "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "signal received: hello", result)
+
+	_, err = matchRegistryA.Signal(context.Background(), uuid.Must(uuid.NewV4()).String()+".b", "hello")
+	assert.Equal(t, runtime.ErrMatchNotFound, err)
+
+	userID, _ := uuid.NewV4()
+	sessionID, _ := uuid.NewV4()
+	found, accepted, isNew, _, label, _ := matchRegistryA.JoinAttempt(context.Background(), matchID, "b", userID,
+		sessionID, "username", 0, map[string]string{}, "clientIP", "clientPort",
+		"a", map[string]string{})
+	assert.True(t, found)
+	assert.True(t, accepted)
+	assert.True(t, isNew)
+	assert.Equal(t, "label", label)
+
+	// Removing the match on node b removes it from listings on node a.
+	matchRegistryB.RemoveMatch(matchID, PresenceStream{Mode: StreamModeMatchAuthoritative, Subject: matchID, Label: "b"})
+	matchRegistryB.processClusterLabels()
+	assert.Eventually(t, func() bool {
+		matchRegistryA.processLabelUpdates(bluge.NewBatch())
+		matches, _, err := matchRegistryA.ListMatches(context.Background(), 2, wrapperspb.Bool(true),
+			wrapperspb.String("label"), nil, nil, nil, nil)
+		return err == nil && len(matches) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -16,6 +16,7 @@ package server
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"math"
 	"sort"
@@ -107,6 +108,9 @@ type MatchmakerIndex struct {
 	StringProperties  map[string]string   `json:"-"`
 	NumericProperties map[string]float64  `json:"-"`
 	ParsedQuery       bluge.Query         `json:"-"`
+	// Team format of the ticket and the roles of its users by session ID, if the ticket requests teams.
+	Teams     *MatchmakerTeams  `json:"-"`
+	TeamRoles map[string]string `json:"-"`
 }
 
 type MatchmakerExtract struct {
@@ -182,6 +186,10 @@ type Matchmaker interface {
 	RemovePartyAll(partyID string) error
 	RemoveAll(node string)
 	Remove(tickets []string)
+	Backfill(matchID, query string, count, size int, label string) error
+	Tickets(query string, limit int) []*MatchmakerTicket
+	QueryStats() []*MatchmakerQueryStats
+	RecentMatches() []*MatchmakerRecentMatch
 }
 
 type LocalMatchmaker struct {
@@ -189,6 +197,7 @@ type LocalMatchmaker struct {
 	logger  *zap.Logger
 	node    string
 	config  Config
+	db      *sql.DB
 	router  MessageRouter
 	metrics Metrics
 	runtime *Runtime
@@ -208,9 +217,23 @@ type LocalMatchmaker struct {
 	activeIndexes    map[string]*MatchmakerIndex
 	revCache         map[string]map[string]bool
 	revThresholdFn   func() *time.Timer
+	backfills        map[string]*MatchmakerBackfill
+
+	recentMutex   sync.Mutex
+	recentMatches []*MatchmakerRecentMatch
+
+	// Only set if tickets are shared between cluster nodes.
+	transport           ClusterTransport
+	clusterMutex        sync.Mutex
+	clusterOps          []*matchmakerClusterOp
+	clusterPeers        map[string]struct{}
+	clusterSyncNodes    map[string]bool
+	clusterHandoffNodes map[string]struct{}
+	clusterTombstones   map[string]time.Time
+	clusterCh           chan struct{}
 }
 
-func NewLocalMatchmaker(logger, startupLogger *zap.Logger, config Config, router MessageRouter, metrics Metrics, runtime *Runtime) Matchmaker {
+func NewLocalMatchmaker(logger, startupLogger *zap.Logger, config Config, db *sql.DB, router MessageRouter, metrics Metrics, runtime *Runtime, transport ClusterTransport) Matchmaker {
 	cfg := BlugeInMemoryConfig()
 	indexWriter, err := bluge.OpenWriter(cfg)
 	if err != nil {
@@ -223,6 +246,7 @@ func NewLocalMatchmaker(logger, startupLogger *zap.Logger, config Config, router
 		logger:  logger,
 		node:    config.GetName(),
 		config:  config,
+		db:      db,
 		router:  router,
 		metrics: metrics,
 		runtime: runtime,
@@ -240,6 +264,7 @@ func NewLocalMatchmaker(logger, startupLogger *zap.Logger, config Config, router
 		indexes:        make(map[string]*MatchmakerIndex),
 		activeIndexes:  make(map[string]*MatchmakerIndex),
 		revCache:       make(map[string]map[string]bool),
+		backfills:      make(map[string]*MatchmakerBackfill),
 	}
 
 	if revThreshold := m.config.GetMatchmaker().RevThreshold; revThreshold > 0 && m.config.GetMatchmaker().RevPrecision {
@@ -248,6 +273,10 @@ func NewLocalMatchmaker(logger, startupLogger *zap.Logger, config Config, router
 		}
 	}
 
+	if config.GetMatchmaker().Cluster {
+		m.startCluster(transport)
+	}
+
 	go func() {
 		ticker := time.NewTicker(time.Duration(config.GetMatchmaker().IntervalSec) * time.Second)
 		for {
@@ -281,6 +310,11 @@ func (m *LocalMatchmaker) OnMatchedEntries(fn func(entries [][]*MatchmakerEntry)
 }
 
 func (m *LocalMatchmaker) Process() {
+	if !m.clusterLeader() {
+		// Another node processes the tickets shared by all nodes.
+		return
+	}
+
 	matchedEntries := make([][]*MatchmakerEntry, 0, 5)
 
 	startTime := time.Now()
@@ -294,12 +328,24 @@ func (m *LocalMatchmaker) Process() {
 		m.metrics.Matchmaker(float64(indexCount), float64(activeIndexCount), time.Now().Sub(startTime))
 	}()
 
-	// No active matchmaking tickets, the pool may be non-empty but there are no new tickets to check/query with.
-	if activeIndexCount == 0 {
+	// No active matchmaking tickets or backfill requests, the pool may be non-empty but there are no new tickets to check/query with.
+	if activeIndexCount == 0 && len(m.backfills) == 0 {
 		m.Unlock()
 		return
 	}
 
+	// Running matches with open slots take tickets from the pool before new matches are formed.
+	var backfillMatches []*matchmakerBackfillMatch
+	var matchedTickets []string
+	var backfillFills map[string]int
+	if m.active.Load() == 1 {
+		backfillMatches, matchedTickets, backfillFills = m.processBackfillsLocked()
+	}
+
+	// With an override function, suitable matches are only candidates until the function decides which to form.
+	overrideFn := m.runtime.MatchmakerOverride()
+	var candidateMatches [][]*MatchmakerEntry
+
 	var threshold bool
 	var timer *time.Timer
 	if m.revThresholdFn != nil {
@@ -411,6 +457,11 @@ func (m *LocalMatchmaker) Process() {
 				}
 			}
 
+			if !index.Teams.compatible(hitIndex.Teams) {
+				// Tickets only match with tickets requesting the same teams.
+				continue
+			}
+
 			if index.MaxCount < hitIndex.MaxCount && hitIndex.Intervals <= m.config.GetMatchmaker().MaxIntervals {
 				// This match would be less than the search hit's preferred max, and they can still wait. Let them wait more.
 				continue
@@ -439,7 +490,7 @@ func (m *LocalMatchmaker) Process() {
 			var foundCombo []*MatchmakerEntry
 			var mutualMatchConflict bool
 			for entryComboIdx, entryCombo := range entryCombos {
-				if len(entryCombo)+len(entries)+index.Count <= index.MaxCount {
+				if len(entryCombo)+len(entries)+index.Count <= index.MaxCount && m.teamRolesFitLocked(index, entryCombo, hitIndex) {
 					// There is room in this combo for these entries. Check if there are session ID conflicts with current combo.
 					for _, entry := range entryCombo {
 						if _, found := hitIndex.SessionIDs[entry.Presence.SessionId]; found {
@@ -487,6 +538,9 @@ func (m *LocalMatchmaker) Process() {
 				}
 			}
 			if foundCombo == nil {
+				if !m.teamRolesFitLocked(index, nil, hitIndex) {
+					continue
+				}
 				entryCombo := make([]*MatchmakerEntry, len(entries))
 				copy(entryCombo, entries)
 				entryCombos = append(entryCombos, entryCombo)
@@ -557,6 +611,29 @@ func (m *LocalMatchmaker) Process() {
 					continue
 				}
 
+				// Team tickets must also be able to form balanced teams that respect parties and roles.
+				var ticketTeams map[string]int
+				if index.Teams != nil {
+					groupIndexes := []*MatchmakerIndex{index}
+					ticketTeams = map[string]int{index.Ticket: 0}
+					for _, e := range foundCombo {
+						if _, found := ticketTeams[e.Ticket]; found {
+							continue
+						}
+						if foundIndex, ok := m.indexes[e.Ticket]; ok {
+							ticketTeams[e.Ticket] = 0
+							groupIndexes = append(groupIndexes, foundIndex)
+						}
+					}
+					assignment := matchmakerAssignTeams(index.Teams, groupIndexes)
+					if assignment == nil {
+						continue
+					}
+					for i, groupIndex := range groupIndexes {
+						ticketTeams[groupIndex.Ticket] = assignment[i]
+					}
+				}
+
 				// Found a suitable match.
 				entries, ok := m.entries[ticket]
 				if !ok {
@@ -565,10 +642,19 @@ func (m *LocalMatchmaker) Process() {
 					break
 				}
 				currentMatchedEntries := append(foundCombo, entries...)
+				if ticketTeams != nil {
+					currentMatchedEntries = matchmakerTeamEntries(currentMatchedEntries, m.indexes, ticketTeams)
+				}
 
 				// Remove the found combos from currently tracked list.
 				entryCombos = append(entryCombos[:foundComboIdx], entryCombos[foundComboIdx+1:]...)
 
+				if overrideFn != nil {
+					// Tickets stay in the pool, so candidates may overlap.
+					candidateMatches = append(candidateMatches, currentMatchedEntries)
+					break
+				}
+
 				matchedEntries = append(matchedEntries, currentMatchedEntries)
 
 				// Remove all entries/indexes that have just matched. It must be done here so any following process iterations
@@ -578,6 +664,7 @@ func (m *LocalMatchmaker) Process() {
 					if _, ok := ticketsToDelete[entry.Ticket]; !ok {
 						m.batch.Delete(bluge.Identifier(entry.Ticket))
 						ticketsToDelete[entry.Ticket] = struct{}{}
+						matchedTickets = append(matchedTickets, entry.Ticket)
 					}
 					delete(m.entries, entry.Ticket)
 					delete(m.indexes, entry.Ticket)
@@ -610,8 +697,29 @@ func (m *LocalMatchmaker) Process() {
 		}
 	}
 
+	if len(candidateMatches) != 0 {
+		// Do not hold the lock while the override function runs, the tickets are checked again before matches are formed.
+		m.Unlock()
+		overrideMatches, err := overrideFn(m.ctx, candidateMatches)
+		m.Lock()
+		if err != nil {
+			m.logger.Error("error running matchmaker override function", zap.Error(err))
+		} else {
+			overrideMatches, overrideTickets := m.finalizeOverrideLocked(overrideMatches)
+			matchedEntries = append(matchedEntries, overrideMatches...)
+			matchedTickets = append(matchedTickets, overrideTickets...)
+		}
+	}
+
+	m.replicateLocked(&matchmakerClusterOp{Removes: matchedTickets, BackfillFills: backfillFills})
+
 	m.Unlock()
 
+	for _, backfillMatch := range backfillMatches {
+		m.sendMatched(backfillMatch.entries, backfillMatch.matchID, true)
+		m.recordMatch(backfillMatch.entries, backfillMatch.matchID, true)
+	}
+
 	if matchedEntriesCount := len(matchedEntries); matchedEntriesCount > 0 {
 		wg := &sync.WaitGroup{}
 		wg.Add(matchedEntriesCount)
@@ -639,37 +747,12 @@ func (m *LocalMatchmaker) Process() {
 					tokenOrMatchID, _ = token.SignedString([]byte(m.config.GetSession().EncryptionKey))
 				}
 
-				users := make([]*rtapi.MatchmakerMatched_MatchmakerUser, 0, len(entries))
-				for _, entry := range entries {
-					users = append(users, &rtapi.MatchmakerMatched_MatchmakerUser{
-						Presence: &rtapi.UserPresence{
-							UserId:    entry.Presence.UserId,
-							SessionId: entry.Presence.SessionId,
-							Username:  entry.Presence.Username,
-						},
-						StringProperties:  entry.StringProperties,
-						NumericProperties: entry.NumericProperties,
-						PartyId:           entry.PartyId,
-					})
-				}
-				outgoing := &rtapi.Envelope{Message: &rtapi.Envelope_MatchmakerMatched{MatchmakerMatched: &rtapi.MatchmakerMatched{
-					// Ticket is set individually below for each recipient.
-					// Id set below to account for token or match ID case.
-					Users: users,
-					// Self is set individually below for each recipient.
-				}}}
+				m.sendMatched(entries, tokenOrMatchID, isMatchID)
 				if isMatchID {
-					outgoing.GetMatchmakerMatched().Id = &rtapi.MatchmakerMatched_MatchId{MatchId: tokenOrMatchID}
+					m.recordMatch(entries, tokenOrMatchID, false)
 				} else {
-					outgoing.GetMatchmakerMatched().Id = &rtapi.MatchmakerMatched_Token{Token: tokenOrMatchID}
-				}
-
-				for i, entry := range entries {
-					// Set per-recipient fields.
-					outgoing.GetMatchmakerMatched().Self = users[i]
-					outgoing.GetMatchmakerMatched().Ticket = entry.Ticket
-					// Route outgoing message.
-					m.router.SendToPresenceIDs(m.logger, []*PresenceID{{Node: entry.Presence.Node, SessionID: entry.Presence.SessionID}}, outgoing, true)
+					// Tokens are not kept, they only allow the matched users to join the new match.
+					m.recordMatch(entries, "", false)
 				}
 				wg.Done()
 			}(entries)
@@ -681,6 +764,42 @@ func (m *LocalMatchmaker) Process() {
 	}
 }
 
+// Notify all matched users, with a token to create a new match or the ID of the match to join.
+func (m *LocalMatchmaker) sendMatched(entries []*MatchmakerEntry, tokenOrMatchID string, isMatchID bool) {
+	users := make([]*rtapi.MatchmakerMatched_MatchmakerUser, 0, len(entries))
+	for _, entry := range entries {
+		users = append(users, &rtapi.MatchmakerMatched_MatchmakerUser{
+			Presence: &rtapi.UserPresence{
+				UserId:    entry.Presence.UserId,
+				SessionId: entry.Presence.SessionId,
+				Username:  entry.Presence.Username,
+			},
+			StringProperties:  entry.StringProperties,
+			NumericProperties: entry.NumericProperties,
+			PartyId:           entry.PartyId,
+		})
+	}
+	outgoing := &rtapi.Envelope{Message: &rtapi.Envelope_MatchmakerMatched{MatchmakerMatched: &rtapi.MatchmakerMatched{
+		// Ticket is set individually below for each recipient.
+		// Id set below to account for token or match ID case.
+		Users: users,
+		// Self is set individually below for each recipient.
+	}}}
+	if isMatchID {
+		outgoing.GetMatchmakerMatched().Id = &rtapi.MatchmakerMatched_MatchId{MatchId: tokenOrMatchID}
+	} else {
+		outgoing.GetMatchmakerMatched().Id = &rtapi.MatchmakerMatched_Token{Token: tokenOrMatchID}
+	}
+
+	for i, entry := range entries {
+		// Set per-recipient fields.
+		outgoing.GetMatchmakerMatched().Self = users[i]
+		outgoing.GetMatchmakerMatched().Ticket = entry.Ticket
+		// Route outgoing message.
+		m.router.SendToPresenceIDs(m.logger, []*PresenceID{{Node: entry.Presence.Node, SessionID: entry.Presence.SessionID}}, outgoing, true)
+	}
+}
+
 func (m *LocalMatchmaker) Add(ctx context.Context, presences []*MatchmakerPresence, sessionID, partyId, query string, minCount, maxCount, countMultiple int, stringProperties map[string]string, numericProperties map[string]float64) (string, int64, error) {
 	// Check if the matchmaker has been stopped.
 	if m.stopped.Load() {
@@ -697,6 +816,26 @@ func (m *LocalMatchmaker) Add(ctx context.Context, presences []*MatchmakerPresen
 		}
 	}
 
+	teams, teamRoles, err := parseMatchmakerTeams(presences, minCount, maxCount, stringProperties, numericProperties)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// Rated tickets match on the skill rating of their users in the requested rating queue.
+	if ratingQueueProperty := m.config.GetMatchmaker().RatingQueueProperty; ratingQueueProperty != "" {
+		if queue := stringProperties[ratingQueueProperty]; queue != "" {
+			userIDs := make([]uuid.UUID, 0, len(presences))
+			for _, presence := range presences {
+				userIDs = append(userIDs, uuid.FromStringOrNil(presence.UserId))
+			}
+			ratings, err := RatingsGet(ctx, m.logger, m.db, queue, userIDs)
+			if err != nil {
+				return "", 0, err
+			}
+			numericProperties = ratingMatchmakerProperties(ratings, numericProperties)
+		}
+	}
+
 	// Merge incoming properties.
 	properties := make(map[string]interface{}, len(stringProperties)+len(numericProperties))
 	for k, v := range stringProperties {
@@ -735,6 +874,8 @@ func (m *LocalMatchmaker) Add(ctx context.Context, presences []*MatchmakerPresen
 		StringProperties:  stringProperties,
 		NumericProperties: numericProperties,
 		ParsedQuery:       parsedQuery,
+		Teams:             teams,
+		TeamRoles:         teamRoles,
 	}
 
 	m.Lock()
@@ -801,6 +942,8 @@ func (m *LocalMatchmaker) Add(ctx context.Context, presences []*MatchmakerPresen
 	m.indexes[ticket] = index
 	m.activeIndexes[ticket] = index
 
+	m.replicateLocked(&matchmakerClusterOp{Adds: []*MatchmakerExtract{newMatchmakerExtract(index, entries)}})
+
 	m.Unlock()
 	return ticket, createdAt, nil
 }
@@ -813,6 +956,17 @@ func (m *LocalMatchmaker) Insert(extracts []*MatchmakerExtract) error {
 		return nil
 	}
 
+	batch, indexes, entries := m.prepareInsert(extracts)
+
+	m.Lock()
+	err := m.insertLocked(batch, indexes, entries)
+	m.Unlock()
+
+	return err
+}
+
+// Parse and index extracted tickets, without changing any matchmaker state yet.
+func (m *LocalMatchmaker) prepareInsert(extracts []*MatchmakerExtract) (*index.Batch, map[string]*MatchmakerIndex, map[string][]*MatchmakerEntry) {
 	batch := bluge.NewBatch()
 	indexes := make(map[string]*MatchmakerIndex, len(extracts))
 	entries := make(map[string][]*MatchmakerEntry, len(extracts))
@@ -838,6 +992,12 @@ func (m *LocalMatchmaker) Insert(extracts []*MatchmakerExtract) error {
 			properties[k] = v
 		}
 
+		teams, teamRoles, err := parseMatchmakerTeams(extract.Presences, extract.MinCount, extract.MaxCount, extract.StringProperties, extract.NumericProperties)
+		if err != nil {
+			m.logger.Error("error parsing matchmaker teams", zap.Error(err), zap.String("ticket", extract.Ticket))
+			continue
+		}
+
 		sessionIDs := make(map[string]struct{}, len(extract.Presences))
 		for _, presence := range extract.Presences {
 			if _, found := sessionIDs[presence.SessionId]; found {
@@ -865,6 +1025,8 @@ func (m *LocalMatchmaker) Insert(extracts []*MatchmakerExtract) error {
 			StringProperties:  extract.StringProperties,
 			NumericProperties: extract.NumericProperties,
 			ParsedQuery:       parsedQuery,
+			Teams:             teams,
+			TeamRoles:         teamRoles,
 		}
 
 		matchmakerIndexDoc, err := MapMatchmakerIndex(extract.Ticket, index)
@@ -890,10 +1052,11 @@ func (m *LocalMatchmaker) Insert(extracts []*MatchmakerExtract) error {
 		indexes[extract.Ticket] = index
 	}
 
-	m.Lock()
+	return batch, indexes, entries
+}
 
+func (m *LocalMatchmaker) insertLocked(batch *index.Batch, indexes map[string]*MatchmakerIndex, entries map[string][]*MatchmakerEntry) error {
 	if err := m.indexWriter.Batch(batch); err != nil {
-		m.Unlock()
 		m.logger.Error("error indexing matchmaker entries", zap.Error(err))
 		return runtime.ErrMatchmakerIndex
 	}
@@ -921,8 +1084,6 @@ func (m *LocalMatchmaker) Insert(extracts []*MatchmakerExtract) error {
 		}
 	}
 
-	m.Unlock()
-
 	return nil
 }
 
@@ -944,27 +1105,7 @@ func (m *LocalMatchmaker) Extract() []*MatchmakerExtract {
 			continue
 		}
 
-		extract := &MatchmakerExtract{
-			Presences:         make([]*MatchmakerPresence, 0, len(entries)),
-			SessionID:         index.SessionID,
-			PartyId:           index.PartyId,
-			Query:             index.Query,
-			MinCount:          index.MinCount,
-			MaxCount:          index.MaxCount,
-			CountMultiple:     index.CountMultiple,
-			StringProperties:  index.StringProperties,
-			NumericProperties: index.NumericProperties,
-			Ticket:            ticket,
-			Count:             index.Count,
-			Intervals:         index.Intervals,
-			CreatedAt:         index.CreatedAt,
-			Node:              index.Node,
-		}
-		for _, entry := range entries {
-			extract.Presences = append(extract.Presences, entry.Presence)
-		}
-
-		extracts = append(extracts, extract)
+		extracts = append(extracts, newMatchmakerExtract(index, entries))
 	}
 
 	m.Unlock()
@@ -972,6 +1113,29 @@ func (m *LocalMatchmaker) Extract() []*MatchmakerExtract {
 	return extracts
 }
 
+func newMatchmakerExtract(index *MatchmakerIndex, entries []*MatchmakerEntry) *MatchmakerExtract {
+	extract := &MatchmakerExtract{
+		Presences:         make([]*MatchmakerPresence, 0, len(entries)),
+		SessionID:         index.SessionID,
+		PartyId:           index.PartyId,
+		Query:             index.Query,
+		MinCount:          index.MinCount,
+		MaxCount:          index.MaxCount,
+		CountMultiple:     index.CountMultiple,
+		StringProperties:  index.StringProperties,
+		NumericProperties: index.NumericProperties,
+		Ticket:            index.Ticket,
+		Count:             index.Count,
+		Intervals:         index.Intervals,
+		CreatedAt:         index.CreatedAt,
+		Node:              index.Node,
+	}
+	for _, entry := range entries {
+		extract.Presences = append(extract.Presences, entry.Presence)
+	}
+	return extract
+}
+
 func (m *LocalMatchmaker) RemoveSession(sessionID, ticket string) error {
 	m.Lock()
 
@@ -1012,6 +1176,8 @@ func (m *LocalMatchmaker) RemoveSession(sessionID, ticket string) error {
 	delete(m.activeIndexes, ticket)
 	delete(m.revCache, ticket)
 
+	m.replicateLocked(&matchmakerClusterOp{Removes: []string{ticket}})
+
 	if err := m.indexWriter.Delete(bluge.Identifier(ticket)); err != nil {
 		m.Unlock()
 		m.logger.Error("error deleting matchmaker entries", zap.Error(err))
@@ -1035,6 +1201,12 @@ func (m *LocalMatchmaker) RemoveSessionAll(sessionID string) error {
 	}
 	delete(m.sessionTickets, sessionID)
 
+	tickets := make([]string, 0, len(sessionTickets))
+	for ticket := range sessionTickets {
+		tickets = append(tickets, ticket)
+	}
+	m.replicateLocked(&matchmakerClusterOp{Removes: tickets})
+
 	for ticket := range sessionTickets {
 		batch.Delete(bluge.Identifier(ticket))
 
@@ -1127,6 +1299,8 @@ func (m *LocalMatchmaker) RemoveParty(partyID, ticket string) error {
 	delete(m.activeIndexes, ticket)
 	delete(m.revCache, ticket)
 
+	m.replicateLocked(&matchmakerClusterOp{Removes: []string{ticket}})
+
 	if err := m.indexWriter.Delete(bluge.Identifier(ticket)); err != nil {
 		m.Unlock()
 		m.logger.Error("error deleting matchmaker entries", zap.Error(err))
@@ -1150,6 +1324,12 @@ func (m *LocalMatchmaker) RemovePartyAll(partyID string) error {
 	}
 	delete(m.partyTickets, partyID)
 
+	tickets := make([]string, 0, len(partyTickets))
+	for ticket := range partyTickets {
+		tickets = append(tickets, ticket)
+	}
+	m.replicateLocked(&matchmakerClusterOp{Removes: tickets})
+
 	for ticket := range partyTickets {
 		batch.Delete(bluge.Identifier(ticket))
 
@@ -1195,6 +1375,12 @@ func (m *LocalMatchmaker) RemoveAll(node string) {
 
 	m.Lock()
 
+	for matchID, backfill := range m.backfills {
+		if backfill.Node == node {
+			delete(m.backfills, matchID)
+		}
+	}
+
 	for ticket, index := range m.indexes {
 		if index.Node != node {
 			continue
@@ -1247,6 +1433,19 @@ func (m *LocalMatchmaker) Remove(tickets []string) {
 
 	m.Lock()
 
+	removed := m.removeLocked(tickets, batch)
+	m.replicateLocked(&matchmakerClusterOp{Removes: removed})
+
+	err := m.indexWriter.Batch(batch)
+	m.Unlock()
+	if err != nil {
+		m.logger.Error("error deleting matchmaker entries batch", zap.Error(err))
+	}
+}
+
+// Remove any of the given tickets, adding their index deletions to the batch. Returns the tickets that were found.
+func (m *LocalMatchmaker) removeLocked(tickets []string, batch *index.Batch) []string {
+	removed := make([]string, 0, len(tickets))
 	for _, ticket := range tickets {
 		index, found := m.indexes[ticket]
 		if !found {
@@ -1254,6 +1453,7 @@ func (m *LocalMatchmaker) Remove(tickets []string) {
 		}
 
 		batch.Delete(bluge.Identifier(ticket))
+		removed = append(removed, ticket)
 
 		delete(m.indexes, ticket)
 
@@ -1288,11 +1488,7 @@ func (m *LocalMatchmaker) Remove(tickets []string) {
 		}
 	}
 
-	err := m.indexWriter.Batch(batch)
-	m.Unlock()
-	if err != nil {
-		m.logger.Error("error deleting matchmaker entries batch", zap.Error(err))
-	}
+	return removed
 }
 
 func MapMatchmakerIndex(id string, in *MatchmakerIndex) (*bluge.Document, error) {
@@ -0,0 +1,271 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/blugelabs/bluge"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"go.uber.org/zap"
+)
+
+// MatchmakerBackfill is a request from a running authoritative match for tickets to fill its open slots. Tickets are
+// selected with the backfill query, and must accept the match in turn: their own query is checked against the fields
+// of the match label as if they were ticket properties, and their count constraints against the size the match reaches
+// once every open slot is filled.
+type MatchmakerBackfill struct {
+	MatchID string
+	Node    string
+	Query   string
+	Count   int
+	// Number of users in the match, including those delivered by this request so far.
+	Size      int
+	Label     string
+	CreatedAt int64
+
+	parsedQuery bluge.Query
+}
+
+var ErrMatchmakerBackfillInvalid = errors.New("matchmaker backfill count invalid")
+
+type matchmakerBackfillMatch struct {
+	matchID string
+	entries []*MatchmakerEntry
+}
+
+// Backfill publishes a request for count tickets to join an existing match of the given size and label, replacing any
+// previous request for the match. A count of 0 cancels the request. Each delivered ticket reduces the count by its
+// number of users.
+func (m *LocalMatchmaker) Backfill(matchID, query string, count, size int, label string) error {
+	if m.stopped.Load() {
+		return runtime.ErrMatchmakerNotAvailable
+	}
+
+	if count <= 0 {
+		m.Lock()
+		if _, found := m.backfills[matchID]; found {
+			delete(m.backfills, matchID)
+			m.replicateLocked(&matchmakerClusterOp{BackfillRemoves: []string{matchID}})
+		}
+		m.Unlock()
+		return nil
+	}
+
+	parsedQuery, err := parseMatchmakerBackfillQuery(query)
+	if err != nil {
+		return err
+	}
+	backfill := &MatchmakerBackfill{
+		MatchID:     matchID,
+		Node:        m.node,
+		Query:       query,
+		Count:       count,
+		Size:        size,
+		Label:       label,
+		CreatedAt:   time.Now().UTC().UnixNano(),
+		parsedQuery: parsedQuery,
+	}
+
+	m.Lock()
+	if existing, found := m.backfills[matchID]; found {
+		// Updated requests keep their place in the queue.
+		backfill.CreatedAt = existing.CreatedAt
+	}
+	m.backfills[matchID] = backfill
+	m.replicateLocked(&matchmakerClusterOp{Backfills: []*MatchmakerBackfill{backfill}})
+	m.Unlock()
+	return nil
+}
+
+func parseMatchmakerBackfillQuery(query string) (bluge.Query, error) {
+	parsedQuery, err := ParseQueryString(query)
+	if err != nil {
+		return nil, runtime.ErrMatchmakerQueryInvalid
+	}
+	if parsedQuery, ok := parsedQuery.(ValidatableQuery); ok {
+		if parsedQuery.Validate() != nil {
+			return nil, runtime.ErrMatchmakerQueryInvalid
+		}
+	}
+	return parsedQuery, nil
+}
+
+// Fill open backfill requests from the ticket pool, oldest requests first and with the longest waiting tickets
+// first. Matched tickets are removed from the pool, and the number of slots filled for each match is returned for
+// replication. Must be called while holding the matchmaker lock.
+func (m *LocalMatchmaker) processBackfillsLocked() ([]*matchmakerBackfillMatch, []string, map[string]int) {
+	if len(m.backfills) == 0 || len(m.indexes) == 0 {
+		return nil, nil, nil
+	}
+
+	backfills := make([]*MatchmakerBackfill, 0, len(m.backfills))
+	for _, backfill := range m.backfills {
+		backfills = append(backfills, backfill)
+	}
+	sort.Slice(backfills, func(i, j int) bool {
+		return backfills[i].CreatedAt < backfills[j].CreatedAt
+	})
+
+	indexReader, err := m.indexWriter.Reader()
+	if err != nil {
+		m.logger.Error("error accessing index reader", zap.Error(err))
+		return nil, nil, nil
+	}
+	defer indexReader.Close()
+
+	var matches []*matchmakerBackfillMatch
+	var matchedTickets []string
+	var fills map[string]int
+	batch := bluge.NewBatch()
+	for _, backfill := range backfills {
+		searchRequest := bluge.NewTopNSearch(len(m.indexes), backfill.parsedQuery)
+		searchRequest.SortBy([]string{"created_at"})
+		result, err := indexReader.Search(m.ctx, searchRequest)
+		if err != nil {
+			m.logger.Error("error searching index", zap.Error(err))
+			continue
+		}
+		blugeMatches, err := IterateBlugeMatches(result, map[string]struct{}{}, m.logger)
+		if err != nil {
+			m.logger.Error("error iterating search results", zap.Error(err))
+			continue
+		}
+		matchWriter, matchReader, err := newMatchmakerBackfillMatchIndex(backfill)
+		if err != nil {
+			m.logger.Error("error indexing backfill match", zap.Error(err))
+			continue
+		}
+
+		// The size the match reaches once every open slot is filled, which tickets' count constraints must allow.
+		size := backfill.Size + backfill.Count
+		remaining := backfill.Count
+		sessionIDs := make(map[string]struct{}, remaining)
+		tickets := make([]string, 0, remaining)
+		var entries []*MatchmakerEntry
+		for _, hit := range blugeMatches.Hits {
+			// Tickets matched by an earlier backfill are still in the reader, but no longer in the pool.
+			index, found := m.indexes[hit.ID]
+			if !found || index.Count > remaining {
+				continue
+			}
+			if index.MinCount > size || index.MaxCount < size || size%index.CountMultiple != 0 {
+				continue
+			}
+			if accepted, err := m.validateBackfillMatch(matchReader, index.ParsedQuery); err != nil {
+				m.logger.Error("error validating backfill match", zap.Error(err))
+				continue
+			} else if !accepted {
+				continue
+			}
+			var sessionIdConflict bool
+			for sessionID := range index.SessionIDs {
+				if _, found := sessionIDs[sessionID]; found {
+					sessionIdConflict = true
+					break
+				}
+			}
+			if sessionIdConflict {
+				continue
+			}
+
+			for sessionID := range index.SessionIDs {
+				sessionIDs[sessionID] = struct{}{}
+			}
+			tickets = append(tickets, hit.ID)
+			entries = append(entries, m.entries[hit.ID]...)
+			remaining -= index.Count
+			if remaining == 0 {
+				break
+			}
+		}
+		_ = matchReader.Close()
+		_ = matchWriter.Close()
+		if len(tickets) == 0 {
+			continue
+		}
+
+		matchedTickets = append(matchedTickets, m.removeLocked(tickets, batch)...)
+		matches = append(matches, &matchmakerBackfillMatch{matchID: backfill.MatchID, entries: entries})
+		if fills == nil {
+			fills = make(map[string]int, len(backfills))
+		}
+		fills[backfill.MatchID] = backfill.Count - remaining
+		m.fillBackfillLocked(backfill.MatchID, backfill.Count-remaining)
+	}
+
+	if err := m.indexWriter.Batch(batch); err != nil {
+		m.logger.Error("error deleting matchmaker process entries batch", zap.Error(err))
+	}
+	return matches, matchedTickets, fills
+}
+
+// Reduce the open slots of a backfill request, removing it once all are filled. Must be called while holding the
+// matchmaker lock.
+func (m *LocalMatchmaker) fillBackfillLocked(matchID string, count int) {
+	backfill, found := m.backfills[matchID]
+	if !found {
+		return
+	}
+	if backfill.Count <= count {
+		delete(m.backfills, matchID)
+		return
+	}
+	// Replace rather than modify, the request may be queued for replication.
+	filled := *backfill
+	filled.Count -= count
+	filled.Size += count
+	m.backfills[matchID] = &filled
+}
+
+// Index the match as a single document with its label fields as properties, the same way ticket properties are
+// indexed, so ticket queries can be checked against it.
+func newMatchmakerBackfillMatchIndex(backfill *MatchmakerBackfill) (*bluge.Writer, *bluge.Reader, error) {
+	writer, err := bluge.OpenWriter(BlugeInMemoryConfig())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc := bluge.NewDocument(backfill.MatchID)
+	var label map[string]interface{}
+	if err := json.Unmarshal([]byte(backfill.Label), &label); err == nil && label != nil {
+		// Labels that are not JSON objects have no properties, only tickets with queries matching anything accept them.
+		BlugeWalkDocument(label, []string{"properties"}, doc)
+	}
+	if err := writer.Update(doc.ID(), doc); err != nil {
+		_ = writer.Close()
+		return nil, nil, err
+	}
+
+	reader, err := writer.Reader()
+	if err != nil {
+		_ = writer.Close()
+		return nil, nil, err
+	}
+	return writer, reader, nil
+}
+
+// Check a ticket query against the match indexed by newMatchmakerBackfillMatchIndex, the reverse of the backfill query.
+func (m *LocalMatchmaker) validateBackfillMatch(matchReader *bluge.Reader, ticketQuery bluge.Query) (bool, error) {
+	req := bluge.NewTopNSearch(0, ticketQuery).WithStandardAggregations()
+	dmi, err := matchReader.Search(m.ctx, req)
+	if err != nil {
+		return false, err
+	}
+	return dmi.Aggregations().Count() == 1, nil
+}
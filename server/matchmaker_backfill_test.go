@@ -0,0 +1,177 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+)
+
+func addTestBackfillTicket(t *testing.T, matchMaker *LocalMatchmaker, mode string) string {
+	return addTestBackfillTicketWithQuery(t, matchMaker, mode, "*", 4, 4)
+}
+
+func addTestBackfillTicketWithQuery(t *testing.T, matchMaker *LocalMatchmaker, mode, query string, minCount, maxCount int) string {
+	sessionID := uuid.Must(uuid.NewV4())
+	ticket, _, err := matchMaker.Add(context.Background(), []*MatchmakerPresence{
+		{
+			UserId:    sessionID.String(),
+			SessionId: sessionID.String(),
+			Username:  sessionID.String(),
+			Node:      matchMaker.node,
+			SessionID: sessionID,
+		},
+	}, sessionID.String(), "", query, minCount, maxCount, 1, map[string]string{"mode": mode}, map[string]float64{})
+	if err != nil {
+		t.Fatalf("error matchmaker add: %v", err)
+	}
+	return ticket
+}
+
+func testMatchmakerBackfill(matchMaker *LocalMatchmaker, matchID string) *MatchmakerBackfill {
+	matchMaker.Lock()
+	defer matchMaker.Unlock()
+	return matchMaker.backfills[matchID]
+}
+
+func TestMatchmakerBackfill(t *testing.T) {
+	cfg := NewConfig(logger)
+	// Processing is triggered manually.
+	cfg.Matchmaker.IntervalSec = 3600
+
+	var mu sync.Mutex
+	matched := make(map[string]string)
+	messageRouter := &testMessageRouter{
+		sendToPresence: func(presences []*PresenceID, envelope *rtapi.Envelope) {
+			if m := envelope.GetMatchmakerMatched(); m != nil {
+				mu.Lock()
+				matched[m.Ticket] = m.GetMatchId()
+				mu.Unlock()
+			}
+		},
+	}
+	matchMaker := NewLocalMatchmaker(logger, logger, cfg, nil, messageRouter, &testMetrics{}, &Runtime{}, nil).(*LocalMatchmaker)
+	defer matchMaker.Stop()
+
+	ffa1 := addTestBackfillTicket(t, matchMaker, "ffa")
+	ffa2 := addTestBackfillTicket(t, matchMaker, "ffa")
+	ffa3 := addTestBackfillTicket(t, matchMaker, "ffa")
+	duel := addTestBackfillTicket(t, matchMaker, "duel")
+
+	assert.Equal(t, runtime.ErrMatchmakerQueryInvalid, matchMaker.Backfill("match", "+properties.count:>", 1, 0, ""))
+
+	matchID := uuid.Must(uuid.NewV4()).String() + ".node"
+	if err := matchMaker.Backfill(matchID, "+properties.mode:ffa", 2, 2, ""); err != nil {
+		t.Fatalf("error matchmaker backfill: %v", err)
+	}
+	matchMaker.Process()
+
+	// The longest waiting tickets matching the backfill query join the existing match.
+	assert.Equal(t, map[string]string{ffa1: matchID, ffa2: matchID}, matched)
+	assert.Nil(t, testMatchmakerBackfill(matchMaker, matchID))
+	assert.Equal(t, 2, testMatchmakerTicketCount(matchMaker))
+
+	// Requests stay open until all their slots are filled.
+	if err := matchMaker.Backfill(matchID, "+properties.mode:ffa", 3, 1, ""); err != nil {
+		t.Fatalf("error matchmaker backfill: %v", err)
+	}
+	matchMaker.Process()
+	assert.Equal(t, matchID, matched[ffa3])
+	assert.NotContains(t, matched, duel)
+	if backfill := testMatchmakerBackfill(matchMaker, matchID); assert.NotNil(t, backfill) {
+		assert.Equal(t, 2, backfill.Count)
+		assert.Equal(t, 2, backfill.Size)
+	}
+
+	if err := matchMaker.Backfill(matchID, "", 0, 0, ""); err != nil {
+		t.Fatalf("error matchmaker backfill: %v", err)
+	}
+	assert.Nil(t, testMatchmakerBackfill(matchMaker, matchID))
+}
+
+func TestMatchmakerBackfillTicketConstraints(t *testing.T) {
+	cfg := NewConfig(logger)
+	// Processing is triggered manually.
+	cfg.Matchmaker.IntervalSec = 3600
+
+	var mu sync.Mutex
+	matched := make(map[string]string)
+	messageRouter := &testMessageRouter{
+		sendToPresence: func(presences []*PresenceID, envelope *rtapi.Envelope) {
+			if m := envelope.GetMatchmakerMatched(); m != nil {
+				mu.Lock()
+				matched[m.Ticket] = m.GetMatchId()
+				mu.Unlock()
+			}
+		},
+	}
+	matchMaker := NewLocalMatchmaker(logger, logger, cfg, nil, messageRouter, &testMetrics{}, &Runtime{}, nil).(*LocalMatchmaker)
+	defer matchMaker.Stop()
+
+	otherRegion := addTestBackfillTicketWithQuery(t, matchMaker, "ffa", "+properties.region:eu", 4, 4)
+	tooSmall := addTestBackfillTicketWithQuery(t, matchMaker, "ffa", "*", 2, 2)
+	accepted := addTestBackfillTicketWithQuery(t, matchMaker, "ffa", "+properties.region:us +properties.level:>=5", 3, 4)
+
+	// Every ticket matches the backfill query, but only one accepts a match of 4 users labelled with its region.
+	matchID := uuid.Must(uuid.NewV4()).String() + ".node"
+	if err := matchMaker.Backfill(matchID, "+properties.mode:ffa", 2, 2, `{"region":"us","level":10}`); err != nil {
+		t.Fatalf("error matchmaker backfill: %v", err)
+	}
+	matchMaker.Process()
+
+	assert.Equal(t, map[string]string{accepted: matchID}, matched)
+	assert.NotContains(t, matched, otherRegion)
+	assert.NotContains(t, matched, tooSmall)
+	if backfill := testMatchmakerBackfill(matchMaker, matchID); assert.NotNil(t, backfill) {
+		assert.Equal(t, 1, backfill.Count)
+		assert.Equal(t, 3, backfill.Size)
+	}
+}
+
+func TestMatchmakerClusterBackfill(t *testing.T) {
+	hub := NewLoopbackClusterHub()
+	matchedA, matchedB := atomic.NewInt32(0), atomic.NewInt32(0)
+	matchMakerA := createTestClusterMatchmaker(t, hub, "a", matchedA)
+	matchMakerB := createTestClusterMatchmaker(t, hub, "b", matchedB)
+
+	// Backfill requests from a match on another node are filled by the leader.
+	addTestBackfillTicket(t, matchMakerA, "ffa")
+	matchID := uuid.Must(uuid.NewV4()).String() + ".b"
+	if err := matchMakerB.Backfill(matchID, "+properties.mode:ffa", 3, 1, ""); err != nil {
+		t.Fatalf("error matchmaker backfill: %v", err)
+	}
+	assert.Eventually(t, func() bool { return testMatchmakerBackfill(matchMakerA, matchID) != nil }, 5*time.Second, 10*time.Millisecond)
+
+	matchMakerA.Process()
+	assert.Equal(t, int32(1), matchedA.Load())
+	assert.Eventually(t, func() bool {
+		backfill := testMatchmakerBackfill(matchMakerB, matchID)
+		return backfill != nil && backfill.Count == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Cancelled requests are removed from every node.
+	if err := matchMakerB.Backfill(matchID, "", 0, 0, ""); err != nil {
+		t.Fatalf("error matchmaker backfill: %v", err)
+	}
+	assert.Eventually(t, func() bool { return testMatchmakerBackfill(matchMakerA, matchID) == nil }, 5*time.Second, 10*time.Millisecond)
+}
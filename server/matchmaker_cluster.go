@@ -0,0 +1,425 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/blugelabs/bluge"
+	"go.uber.org/zap"
+)
+
+const (
+	clusterMessageMatchmakerDelta   = "matchmaker_delta"
+	clusterMessageMatchmakerSync    = "matchmaker_sync"
+	clusterMessageMatchmakerHandoff = "matchmaker_handoff"
+
+	// How long removed tickets are remembered, to ignore snapshots and handoffs that were sent before the removal.
+	matchmakerClusterTombstoneTTL = time.Minute
+)
+
+// matchmakerClusterOp is a change to the shared ticket pool. Nodes only add their own tickets, but any node
+// may remove tickets: their owner when they are cancelled, or the leader when they are matched.
+// Backfill requests are likewise only set and removed by the node hosting their match, while the leader reports
+// the slots it filled.
+type matchmakerClusterOp struct {
+	Adds    []*MatchmakerExtract
+	Removes []string
+
+	Backfills       []*MatchmakerBackfill
+	BackfillRemoves []string
+	BackfillFills   map[string]int
+}
+
+type matchmakerClusterDelta struct {
+	Ops []*matchmakerClusterOp
+}
+
+// matchmakerClusterSync is a full snapshot of the tickets owned by a node, as returned by Extract(), and of the
+// backfill requests of its matches.
+type matchmakerClusterSync struct {
+	Extracts  []*MatchmakerExtract
+	Backfills []*MatchmakerBackfill
+	// Set on snapshots sent to a newly connected peer, which answers with the tickets it owns.
+	Request bool
+}
+
+// matchmakerClusterHandoff carries the whole ticket pool held by a previous leader, including the interval counts
+// it tracked, so tickets do not restart waiting for their preferred max count when another node takes over.
+type matchmakerClusterHandoff struct {
+	Extracts []*MatchmakerExtract
+}
+
+// Every node holds a copy of the tickets from all nodes, but only the leader runs Process() over them.
+// The leader is the connected node with the lowest name, this requires no coordination beyond peer discovery.
+func (m *LocalMatchmaker) startCluster(transport ClusterTransport) {
+	m.transport = transport
+	m.clusterOps = make([]*matchmakerClusterOp, 0, 16)
+	m.clusterPeers = make(map[string]struct{})
+	m.clusterSyncNodes = make(map[string]bool)
+	m.clusterHandoffNodes = make(map[string]struct{})
+	m.clusterTombstones = make(map[string]time.Time)
+	m.clusterCh = make(chan struct{}, 1)
+
+	transport.Handle(clusterMessageMatchmakerDelta, m.handleClusterDelta)
+	transport.Handle(clusterMessageMatchmakerSync, m.handleClusterSync)
+	transport.Handle(clusterMessageMatchmakerHandoff, m.handleClusterHandoff)
+	transport.AddPeerListener(m.onClusterPeer)
+
+	go func() {
+		for {
+			select {
+			case <-m.ctx.Done():
+				return
+			case <-m.clusterCh:
+				m.flushCluster()
+			}
+		}
+	}()
+}
+
+func (m *LocalMatchmaker) clusterLeader() bool {
+	if m.transport == nil {
+		return true
+	}
+	m.clusterMutex.Lock()
+	leader := m.clusterLeaderLocked()
+	m.clusterMutex.Unlock()
+	return leader
+}
+
+func (m *LocalMatchmaker) clusterLeaderLocked() bool {
+	for node := range m.clusterPeers {
+		if node < m.node {
+			return false
+		}
+	}
+	return true
+}
+
+// Queue a change to the ticket pool for replication, and remember removed tickets. Must be called while holding the
+// matchmaker lock. Flushes take snapshots under the same lock, so a snapshot reflects every delta sent with it, and
+// peers can apply both without losing removals: re-added tickets are ignored, removed tickets are tombstoned.
+func (m *LocalMatchmaker) replicateLocked(op *matchmakerClusterOp) {
+	if m.transport == nil || (len(op.Adds) == 0 && len(op.Removes) == 0 && len(op.Backfills) == 0 && len(op.BackfillRemoves) == 0 && len(op.BackfillFills) == 0) {
+		return
+	}
+	m.tombstoneLocked(op.Removes)
+	m.clusterMutex.Lock()
+	m.clusterOps = append(m.clusterOps, op)
+	m.clusterMutex.Unlock()
+	m.signalCluster()
+}
+
+// Ticket IDs are never reused, so a removed ticket must never be added again. Must be called while holding
+// the matchmaker lock.
+func (m *LocalMatchmaker) tombstoneLocked(tickets []string) {
+	now := time.Now()
+	for _, ticket := range tickets {
+		m.clusterTombstones[ticket] = now
+	}
+}
+
+// Filter out extracts of removed tickets, and of tickets already present. Must be called while holding the matchmaker lock.
+func (m *LocalMatchmaker) newExtractsLocked(extracts []*MatchmakerExtract) []*MatchmakerExtract {
+	adds := make([]*MatchmakerExtract, 0, len(extracts))
+	for _, extract := range extracts {
+		if _, found := m.indexes[extract.Ticket]; found {
+			continue
+		}
+		if _, found := m.clusterTombstones[extract.Ticket]; found {
+			continue
+		}
+		adds = append(adds, extract)
+	}
+	return adds
+}
+
+func (m *LocalMatchmaker) signalCluster() {
+	select {
+	case m.clusterCh <- struct{}{}:
+	default:
+		// Already signalled, the pending flush picks up this change as well.
+	}
+}
+
+func (m *LocalMatchmaker) onClusterPeer(node string, connected bool) {
+	if connected {
+		m.clusterMutex.Lock()
+		wasLeader := m.clusterLeaderLocked()
+		m.clusterPeers[node] = struct{}{}
+		m.clusterSyncNodes[node] = true
+		if wasLeader && !m.clusterLeaderLocked() {
+			// The new peer takes over processing.
+			m.clusterHandoffNodes[node] = struct{}{}
+		}
+		m.clusterMutex.Unlock()
+		m.signalCluster()
+		return
+	}
+
+	// The peer is gone, and its sessions along with it. If it was the leader any interval counts it held are lost,
+	// the next leader continues from the interval counts tickets had when they were replicated.
+	m.clusterMutex.Lock()
+	delete(m.clusterPeers, node)
+	delete(m.clusterHandoffNodes, node)
+	m.clusterMutex.Unlock()
+	m.RemoveAll(node)
+}
+
+// A disconnected peer's sessions are gone, so its tickets are removed and must not be added back by a delta,
+// snapshot or handoff still being handled, or they could be matched with nobody left to receive the match.
+func (m *LocalMatchmaker) clusterPeerLocked(node string) bool {
+	m.clusterMutex.Lock()
+	_, found := m.clusterPeers[node]
+	m.clusterMutex.Unlock()
+	return found
+}
+
+// Send all queued changes to peers, full snapshots to any peers that need one, and the ticket pool to a new leader.
+func (m *LocalMatchmaker) flushCluster() {
+	m.Lock()
+	m.clusterMutex.Lock()
+	ops := m.clusterOps
+	m.clusterOps = make([]*matchmakerClusterOp, 0, 16)
+	syncNodes := m.clusterSyncNodes
+	m.clusterSyncNodes = make(map[string]bool)
+	handoffNodes := m.clusterHandoffNodes
+	m.clusterHandoffNodes = make(map[string]struct{})
+	m.clusterMutex.Unlock()
+
+	expiry := time.Now().Add(-matchmakerClusterTombstoneTTL)
+	for ticket, removedAt := range m.clusterTombstones {
+		if removedAt.Before(expiry) {
+			delete(m.clusterTombstones, ticket)
+		}
+	}
+
+	var snapshot []*MatchmakerExtract
+	var backfills []*MatchmakerBackfill
+	if len(syncNodes) != 0 {
+		snapshot = make([]*MatchmakerExtract, 0, len(m.indexes))
+		for ticket, index := range m.indexes {
+			if entries, found := m.entries[ticket]; found && index.Node == m.node {
+				snapshot = append(snapshot, newMatchmakerExtract(index, entries))
+			}
+		}
+		for _, backfill := range m.backfills {
+			if backfill.Node == m.node {
+				backfills = append(backfills, backfill)
+			}
+		}
+	}
+	var pool []*MatchmakerExtract
+	if len(handoffNodes) != 0 {
+		pool = make([]*MatchmakerExtract, 0, len(m.indexes))
+		for ticket, index := range m.indexes {
+			if entries, found := m.entries[ticket]; found {
+				pool = append(pool, newMatchmakerExtract(index, entries))
+			}
+		}
+	}
+	m.Unlock()
+
+	for node, request := range syncNodes {
+		m.sendCluster(node, clusterMessageMatchmakerSync, &matchmakerClusterSync{Extracts: snapshot, Backfills: backfills, Request: request})
+	}
+
+	// Snapshots only cover tickets owned by this node, removals of other nodes' tickets must be sent regardless.
+	// Receivers ignore additions of tickets they already hold.
+	if len(ops) != 0 {
+		for _, node := range m.transport.Peers() {
+			m.sendCluster(node, clusterMessageMatchmakerDelta, &matchmakerClusterDelta{Ops: ops})
+		}
+	}
+
+	for node := range handoffNodes {
+		m.sendCluster(node, clusterMessageMatchmakerHandoff, &matchmakerClusterHandoff{Extracts: pool})
+	}
+}
+
+func (m *LocalMatchmaker) sendCluster(node, msgType string, msg interface{}) {
+	payload, err := gobEncode(msg)
+	if err != nil {
+		m.logger.Error("Failed to encode matchmaker cluster message", zap.String("type", msgType), zap.Error(err))
+		return
+	}
+	if err := m.transport.Send(node, msgType, payload); err != nil {
+		// The peer removes this node's tickets when the connection breaks, and both sides exchange snapshots once
+		// it reconnects, which also sends a new handoff if this node stopped being the leader.
+		m.logger.Debug("Failed to send matchmaker cluster message", zap.String("type", msgType), zap.String("node", node), zap.Error(err))
+	}
+}
+
+func (m *LocalMatchmaker) handleClusterDelta(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var delta matchmakerClusterDelta
+	if err := gobDecode(payload, &delta); err != nil {
+		return nil, err
+	}
+
+	batch := bluge.NewBatch()
+	m.Lock()
+	defer m.Unlock()
+	if !m.clusterPeerLocked(node) {
+		return nil, nil
+	}
+	for _, op := range delta.Ops {
+		if len(op.Adds) != 0 {
+			owned := make([]*MatchmakerExtract, 0, len(op.Adds))
+			for _, extract := range op.Adds {
+				// Nodes may only add their own tickets.
+				if extract.Node == node {
+					owned = append(owned, extract)
+				}
+			}
+			if err := m.insertLocked(m.prepareInsert(m.newExtractsLocked(owned))); err != nil {
+				return nil, err
+			}
+		}
+		if len(op.Removes) != 0 {
+			// Removals are applied in order with additions, one op at a time.
+			m.tombstoneLocked(op.Removes)
+			m.removeLocked(op.Removes, batch)
+			if err := m.indexWriter.Batch(batch); err != nil {
+				m.logger.Error("error deleting matchmaker entries batch", zap.Error(err))
+			}
+			batch.Reset()
+		}
+		for _, backfill := range op.Backfills {
+			if backfill.Node == node {
+				m.setBackfillLocked(backfill)
+			}
+		}
+		for _, matchID := range op.BackfillRemoves {
+			if backfill, found := m.backfills[matchID]; found && backfill.Node == node {
+				delete(m.backfills, matchID)
+			}
+		}
+		for matchID, count := range op.BackfillFills {
+			m.fillBackfillLocked(matchID, count)
+		}
+	}
+	return nil, nil
+}
+
+// Store a backfill request received from another node. Must be called while holding the matchmaker lock.
+func (m *LocalMatchmaker) setBackfillLocked(backfill *MatchmakerBackfill) {
+	parsedQuery, err := parseMatchmakerBackfillQuery(backfill.Query)
+	if err != nil {
+		// Validated by the node that published it.
+		m.logger.Warn("matchmaker received invalid backfill query", zap.String("match_id", backfill.MatchID))
+		return
+	}
+	backfill.parsedQuery = parsedQuery
+	m.backfills[backfill.MatchID] = backfill
+}
+
+func (m *LocalMatchmaker) handleClusterSync(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var sync matchmakerClusterSync
+	if err := gobDecode(payload, &sync); err != nil {
+		return nil, err
+	}
+
+	m.Lock()
+	if !m.clusterPeerLocked(node) {
+		m.Unlock()
+		return nil, nil
+	}
+
+	// Replace everything known about the node's tickets with the snapshot, keeping local state such as
+	// interval counts for tickets that are already present.
+	keep := make(map[string]struct{}, len(sync.Extracts))
+	owned := make([]*MatchmakerExtract, 0, len(sync.Extracts))
+	for _, extract := range sync.Extracts {
+		if extract.Node == node {
+			keep[extract.Ticket] = struct{}{}
+			owned = append(owned, extract)
+		}
+	}
+	var stale []string
+	for ticket, index := range m.indexes {
+		if _, found := keep[ticket]; !found && index.Node == node {
+			stale = append(stale, ticket)
+		}
+	}
+
+	for matchID, backfill := range m.backfills {
+		if backfill.Node == node {
+			delete(m.backfills, matchID)
+		}
+	}
+	for _, backfill := range sync.Backfills {
+		if backfill.Node == node {
+			m.setBackfillLocked(backfill)
+		}
+	}
+
+	batch := bluge.NewBatch()
+	m.tombstoneLocked(stale)
+	m.removeLocked(stale, batch)
+	if err := m.indexWriter.Batch(batch); err != nil {
+		m.logger.Error("error deleting matchmaker entries batch", zap.Error(err))
+	}
+	err := m.insertLocked(m.prepareInsert(m.newExtractsLocked(owned)))
+	m.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if sync.Request {
+		// The flush goroutine sends the answer, in order with the deltas it sends to the same peer.
+		m.clusterMutex.Lock()
+		if _, found := m.clusterSyncNodes[node]; !found {
+			m.clusterSyncNodes[node] = false
+		}
+		m.clusterMutex.Unlock()
+		m.signalCluster()
+	}
+	return nil, nil
+}
+
+func (m *LocalMatchmaker) handleClusterHandoff(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var handoff matchmakerClusterHandoff
+	if err := gobDecode(payload, &handoff); err != nil {
+		return nil, err
+	}
+
+	maxIntervals := m.config.GetMatchmaker().MaxIntervals
+	m.Lock()
+	defer m.Unlock()
+	if !m.clusterPeerLocked(node) {
+		return nil, nil
+	}
+	// The pool may include tickets not yet received from their owners, but not tickets from nodes no longer connected.
+	// This node's own tickets are already known, or were removed.
+	adds := make([]*MatchmakerExtract, 0, len(handoff.Extracts))
+	for _, extract := range handoff.Extracts {
+		index, found := m.indexes[extract.Ticket]
+		if !found {
+			if extract.Node != m.node && m.clusterPeerLocked(extract.Node) {
+				adds = append(adds, extract)
+			}
+			continue
+		}
+		if extract.Intervals > index.Intervals {
+			index.Intervals = extract.Intervals
+			if index.Intervals >= maxIntervals {
+				delete(m.activeIndexes, extract.Ticket)
+			}
+		}
+	}
+	return nil, m.insertLocked(m.prepareInsert(m.newExtractsLocked(adds)))
+}
@@ -0,0 +1,194 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sort"
+	"time"
+)
+
+// A ticket in the matchmaker pool, as listed in the console.
+type MatchmakerTicket struct {
+	Ticket            string                `json:"ticket"`
+	Query             string                `json:"query"`
+	SessionId         string                `json:"session_id,omitempty"`
+	PartyId           string                `json:"party_id,omitempty"`
+	Node              string                `json:"node"`
+	Presences         []*MatchmakerPresence `json:"presences"`
+	MinCount          int                   `json:"min_count"`
+	MaxCount          int                   `json:"max_count"`
+	CountMultiple     int                   `json:"count_multiple"`
+	StringProperties  map[string]string     `json:"string_properties"`
+	NumericProperties map[string]float64    `json:"numeric_properties"`
+	CreateTime        int64                 `json:"create_time"`
+	AgeSec            int64                 `json:"age_sec"`
+	// Processing intervals the ticket has taken part in, and whether it still searches for matches itself.
+	Intervals int  `json:"intervals"`
+	Active    bool `json:"active"`
+}
+
+// Statistics of the tickets in the matchmaker pool that share a query.
+type MatchmakerQueryStats struct {
+	Query         string  `json:"query"`
+	Tickets       int     `json:"tickets"`
+	ActiveTickets int     `json:"active_tickets"`
+	Players       int     `json:"players"`
+	MaxAgeSec     int64   `json:"max_age_sec"`
+	AvgAgeSec     float64 `json:"avg_age_sec"`
+}
+
+// A match recently formed by the matchmaker on this node.
+type MatchmakerRecentMatch struct {
+	// The authoritative match the users were sent to, empty if they received a token for a new relayed match.
+	MatchId    string                `json:"match_id,omitempty"`
+	Backfill   bool                  `json:"backfill"`
+	Tickets    []string              `json:"tickets"`
+	Presences  []*MatchmakerPresence `json:"presences"`
+	CreateTime int64                 `json:"create_time"`
+}
+
+// Tickets lists the tickets in the pool, optionally only those with the given query, longest waiting first.
+func (m *LocalMatchmaker) Tickets(query string, limit int) []*MatchmakerTicket {
+	now := time.Now().UTC().UnixNano()
+
+	m.Lock()
+	indexes := make([]*MatchmakerIndex, 0, len(m.indexes))
+	for _, index := range m.indexes {
+		if query == "" || index.Query == query {
+			indexes = append(indexes, index)
+		}
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		if indexes[i].CreatedAt == indexes[j].CreatedAt {
+			return indexes[i].Ticket < indexes[j].Ticket
+		}
+		return indexes[i].CreatedAt < indexes[j].CreatedAt
+	})
+	if limit > 0 && len(indexes) > limit {
+		indexes = indexes[:limit]
+	}
+
+	tickets := make([]*MatchmakerTicket, 0, len(indexes))
+	for _, index := range indexes {
+		entries := m.entries[index.Ticket]
+		presences := make([]*MatchmakerPresence, 0, len(entries))
+		for _, entry := range entries {
+			presences = append(presences, entry.Presence)
+		}
+		_, active := m.activeIndexes[index.Ticket]
+		tickets = append(tickets, &MatchmakerTicket{
+			Ticket:            index.Ticket,
+			Query:             index.Query,
+			SessionId:         index.SessionID,
+			PartyId:           index.PartyId,
+			Node:              index.Node,
+			Presences:         presences,
+			MinCount:          index.MinCount,
+			MaxCount:          index.MaxCount,
+			CountMultiple:     index.CountMultiple,
+			StringProperties:  index.StringProperties,
+			NumericProperties: index.NumericProperties,
+			CreateTime:        index.CreatedAt / int64(time.Second),
+			AgeSec:            (now - index.CreatedAt) / int64(time.Second),
+			Intervals:         index.Intervals,
+			Active:            active,
+		})
+	}
+	m.Unlock()
+
+	return tickets
+}
+
+// QueryStats summarises the tickets in the pool for each query, the queries with most tickets first.
+func (m *LocalMatchmaker) QueryStats() []*MatchmakerQueryStats {
+	now := time.Now().UTC().UnixNano()
+
+	m.Lock()
+	statsByQuery := make(map[string]*MatchmakerQueryStats)
+	for _, index := range m.indexes {
+		stats, found := statsByQuery[index.Query]
+		if !found {
+			stats = &MatchmakerQueryStats{Query: index.Query}
+			statsByQuery[index.Query] = stats
+		}
+		ageSec := (now - index.CreatedAt) / int64(time.Second)
+		stats.Tickets++
+		if _, active := m.activeIndexes[index.Ticket]; active {
+			stats.ActiveTickets++
+		}
+		stats.Players += index.Count
+		if ageSec > stats.MaxAgeSec {
+			stats.MaxAgeSec = ageSec
+		}
+		// Summed here, averaged below.
+		stats.AvgAgeSec += float64(ageSec)
+	}
+	m.Unlock()
+
+	queryStats := make([]*MatchmakerQueryStats, 0, len(statsByQuery))
+	for _, stats := range statsByQuery {
+		stats.AvgAgeSec /= float64(stats.Tickets)
+		queryStats = append(queryStats, stats)
+	}
+	sort.Slice(queryStats, func(i, j int) bool {
+		if queryStats[i].Tickets == queryStats[j].Tickets {
+			return queryStats[i].Query < queryStats[j].Query
+		}
+		return queryStats[i].Tickets > queryStats[j].Tickets
+	})
+
+	return queryStats
+}
+
+// RecentMatches lists the matches most recently formed by this node, newest first.
+func (m *LocalMatchmaker) RecentMatches() []*MatchmakerRecentMatch {
+	m.recentMutex.Lock()
+	recentMatches := make([]*MatchmakerRecentMatch, 0, len(m.recentMatches))
+	for i := len(m.recentMatches) - 1; i >= 0; i-- {
+		recentMatches = append(recentMatches, m.recentMatches[i])
+	}
+	m.recentMutex.Unlock()
+
+	return recentMatches
+}
+
+func (m *LocalMatchmaker) recordMatch(entries []*MatchmakerEntry, matchID string, backfill bool) {
+	size := m.config.GetMatchmaker().RecentMatches
+	if size == 0 {
+		return
+	}
+
+	recentMatch := &MatchmakerRecentMatch{
+		MatchId:    matchID,
+		Backfill:   backfill,
+		Presences:  make([]*MatchmakerPresence, 0, len(entries)),
+		CreateTime: time.Now().UTC().Unix(),
+	}
+	tickets := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		if _, found := tickets[entry.Ticket]; !found {
+			tickets[entry.Ticket] = struct{}{}
+			recentMatch.Tickets = append(recentMatch.Tickets, entry.Ticket)
+		}
+		recentMatch.Presences = append(recentMatch.Presences, entry.Presence)
+	}
+
+	m.recentMutex.Lock()
+	m.recentMatches = append(m.recentMatches, recentMatch)
+	if len(m.recentMatches) > size {
+		m.recentMatches = m.recentMatches[len(m.recentMatches)-size:]
+	}
+	m.recentMutex.Unlock()
+}
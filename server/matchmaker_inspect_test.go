@@ -0,0 +1,77 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchmakerInspect(t *testing.T) {
+	cfg := NewConfig(logger)
+	// Processing is triggered manually.
+	cfg.Matchmaker.IntervalSec = 3600
+	cfg.Matchmaker.RecentMatches = 1
+	matchMaker := NewLocalMatchmaker(logger, logger, cfg, nil, &testMessageRouter{}, &testMetrics{}, &Runtime{}, nil).(*LocalMatchmaker)
+	defer matchMaker.Stop()
+
+	add := func(query string, presences []*MatchmakerPresence) string {
+		ticket, _, err := matchMaker.Add(context.Background(), presences, presences[0].SessionId, "", query, 2, 2, 1, map[string]string{"mode": "a"}, map[string]float64{})
+		if err != nil {
+			t.Fatalf("error matchmaker add: %v", err)
+		}
+		return ticket
+	}
+	first := add("+properties.mode:a", newTestTeamPresences(1))
+	second := add("+properties.mode:b", newTestTeamPresences(2))
+	third := add("+properties.mode:a", newTestTeamPresences(1))
+
+	tickets := matchMaker.Tickets("", 0)
+	assert.Len(t, tickets, 3)
+	assert.Equal(t, first, tickets[0].Ticket)
+	assert.Equal(t, "a", tickets[0].StringProperties["mode"])
+	assert.True(t, tickets[0].Active)
+	assert.Len(t, tickets[1].Presences, 2)
+
+	tickets = matchMaker.Tickets("+properties.mode:a", 1)
+	assert.Len(t, tickets, 1)
+	assert.Equal(t, first, tickets[0].Ticket)
+
+	stats := matchMaker.QueryStats()
+	assert.Len(t, stats, 2)
+	assert.Equal(t, "+properties.mode:a", stats[0].Query)
+	assert.Equal(t, 2, stats[0].Tickets)
+	assert.Equal(t, 2, stats[0].Players)
+	assert.Equal(t, 1, stats[1].Tickets)
+	assert.Equal(t, 2, stats[1].Players)
+
+	matchMaker.Process()
+
+	// The pair with the same query matched, the remaining ticket is no longer active as its min and max counts are equal.
+	recentMatches := matchMaker.RecentMatches()
+	assert.Len(t, recentMatches, 1)
+	assert.ElementsMatch(t, []string{first, third}, recentMatches[0].Tickets)
+	assert.Equal(t, "", recentMatches[0].MatchId)
+	tickets = matchMaker.Tickets("", 0)
+	assert.Len(t, tickets, 1)
+	assert.Equal(t, second, tickets[0].Ticket)
+	assert.False(t, tickets[0].Active)
+
+	matchMaker.Remove([]string{second})
+	assert.Len(t, matchMaker.Tickets("", 0), 0)
+	assert.Len(t, matchMaker.QueryStats(), 0)
+}
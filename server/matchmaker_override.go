@@ -0,0 +1,135 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+var ErrMatchmakerOverrideEntryNotFound = errors.New("matchmaker override entry not found in the candidate matches")
+
+// Identifies a candidate entry returned by a Lua or JavaScript matchmaker override function.
+type matchmakerOverrideKey struct {
+	Ticket    string
+	SessionID string
+}
+
+// Map the entries returned by a Lua or JavaScript matchmaker override function back to the candidate entries. A
+// returned match is resolved against the first candidate that contains all of its entries, so properties assigned
+// per candidate such as teams are kept, and against any candidate otherwise.
+func matchmakerOverrideEntries(candidateMatches [][]*MatchmakerEntry, overrideKeys [][]matchmakerOverrideKey) ([][]*MatchmakerEntry, error) {
+	candidateLookups := make([]map[matchmakerOverrideKey]*MatchmakerEntry, 0, len(candidateMatches))
+	for _, entries := range candidateMatches {
+		lookup := make(map[matchmakerOverrideKey]*MatchmakerEntry, len(entries))
+		for _, entry := range entries {
+			lookup[matchmakerOverrideKey{Ticket: entry.Ticket, SessionID: entry.Presence.SessionId}] = entry
+		}
+		candidateLookups = append(candidateLookups, lookup)
+	}
+
+	matches := make([][]*MatchmakerEntry, 0, len(overrideKeys))
+	for _, keys := range overrideKeys {
+		entries := make([]*MatchmakerEntry, len(keys))
+		var resolved bool
+		for _, lookup := range candidateLookups {
+			resolved = true
+			for i, key := range keys {
+				entry, found := lookup[key]
+				if !found {
+					resolved = false
+					break
+				}
+				entries[i] = entry
+			}
+			if resolved {
+				break
+			}
+		}
+		if !resolved {
+			for i, key := range keys {
+				for _, lookup := range candidateLookups {
+					if entry, found := lookup[key]; found {
+						entries[i] = entry
+						break
+					}
+				}
+				if entries[i] == nil {
+					return nil, ErrMatchmakerOverrideEntryNotFound
+				}
+			}
+		}
+		matches = append(matches, entries)
+	}
+
+	return matches, nil
+}
+
+// Form the matches returned by a matchmaker override function, in order. A match is skipped if any of its tickets is
+// no longer in the pool or was used by an earlier match, if it includes only some of a ticket's entries, or if its size
+// does not satisfy the count constraints of its tickets.
+func (m *LocalMatchmaker) finalizeOverrideLocked(overrideMatches [][]*MatchmakerEntry) ([][]*MatchmakerEntry, []string) {
+	matches := make([][]*MatchmakerEntry, 0, len(overrideMatches))
+	var matchedTickets []string
+	for _, entries := range overrideMatches {
+		l := len(entries)
+		if l == 0 {
+			continue
+		}
+
+		tickets := make([]string, 0, l)
+		ticketCounts := make(map[string]int, l)
+		sessionIDs := make(map[string]struct{}, l)
+		valid := true
+		for _, entry := range entries {
+			if _, found := sessionIDs[entry.Presence.SessionId]; found {
+				// The same session cannot be matched twice.
+				valid = false
+				break
+			}
+			sessionIDs[entry.Presence.SessionId] = struct{}{}
+			if _, found := ticketCounts[entry.Ticket]; !found {
+				tickets = append(tickets, entry.Ticket)
+			}
+			ticketCounts[entry.Ticket]++
+		}
+		for ticket, count := range ticketCounts {
+			if !valid {
+				break
+			}
+			index, found := m.indexes[ticket]
+			if !found || len(m.entries[ticket]) != count || index.MinCount > l || index.MaxCount < l || l%index.CountMultiple != 0 {
+				valid = false
+			}
+		}
+		if !valid {
+			m.logger.Debug("matchmaker override match skipped", zap.Strings("tickets", tickets))
+			continue
+		}
+
+		matches = append(matches, entries)
+		matchedTickets = append(matchedTickets, m.removeLocked(tickets, m.batch)...)
+	}
+
+	if len(matchedTickets) != 0 {
+		if err := m.indexWriter.Batch(m.batch); err != nil {
+			m.logger.Error("error deleting matchmaker override entries batch", zap.Error(err))
+		}
+		m.batch.Reset()
+	}
+
+	return matches, matchedTickets
+}
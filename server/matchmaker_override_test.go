@@ -0,0 +1,96 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchmakerOverrideEntries(t *testing.T) {
+	a := &MatchmakerEntry{Ticket: "a", Presence: &MatchmakerPresence{SessionId: "a"}}
+	b1 := &MatchmakerEntry{Ticket: "b", Presence: &MatchmakerPresence{SessionId: "b"}, StringProperties: map[string]string{"team": "1"}}
+	b2 := &MatchmakerEntry{Ticket: "b", Presence: &MatchmakerPresence{SessionId: "b"}, StringProperties: map[string]string{"team": "2"}}
+	c := &MatchmakerEntry{Ticket: "c", Presence: &MatchmakerPresence{SessionId: "c"}}
+	candidateMatches := [][]*MatchmakerEntry{{a, b1}, {c, b2}}
+
+	// Entries are taken from the candidate holding the whole match, or from any candidate otherwise.
+	matches, err := matchmakerOverrideEntries(candidateMatches, [][]matchmakerOverrideKey{
+		{{Ticket: "b", SessionID: "b"}, {Ticket: "c", SessionID: "c"}},
+		{{Ticket: "a", SessionID: "a"}, {Ticket: "c", SessionID: "c"}},
+	})
+	if err != nil {
+		t.Fatalf("error resolving override entries: %v", err)
+	}
+	assert.Equal(t, [][]*MatchmakerEntry{{b2, c}, {a, c}}, matches)
+
+	_, err = matchmakerOverrideEntries(candidateMatches, [][]matchmakerOverrideKey{{{Ticket: "a", SessionID: "b"}}})
+	assert.Equal(t, ErrMatchmakerOverrideEntryNotFound, err)
+}
+
+func TestMatchmakerOverride(t *testing.T) {
+	cfg := NewConfig(logger)
+	// Processing is triggered manually.
+	cfg.Matchmaker.IntervalSec = 3600
+
+	var mu sync.Mutex
+	matched := make(map[string]struct{})
+	messageRouter := &testMessageRouter{
+		sendToPresence: func(presences []*PresenceID, envelope *rtapi.Envelope) {
+			if m := envelope.GetMatchmakerMatched(); m != nil {
+				mu.Lock()
+				matched[m.Self.Presence.SessionId] = struct{}{}
+				mu.Unlock()
+			}
+		},
+	}
+
+	presences := newTestTeamPresences(4)
+	var candidateCount int
+	runtime := &Runtime{matchmakerOverrideFunction: func(ctx context.Context, candidateMatches [][]*MatchmakerEntry) ([][]*MatchmakerEntry, error) {
+		candidateCount = len(candidateMatches)
+		entries := make(map[string]*MatchmakerEntry)
+		for _, candidate := range candidateMatches {
+			for _, entry := range candidate {
+				entries[entry.Presence.SessionId] = entry
+			}
+		}
+		// Veto the first player, and pair the others. Matches reusing a ticket or below the min count are not formed.
+		return [][]*MatchmakerEntry{
+			{entries[presences[1].SessionId], entries[presences[2].SessionId]},
+			{entries[presences[2].SessionId], entries[presences[3].SessionId]},
+			{entries[presences[3].SessionId]},
+		}, nil
+	}}
+	matchMaker := NewLocalMatchmaker(logger, logger, cfg, nil, messageRouter, &testMetrics{}, runtime, nil).(*LocalMatchmaker)
+	defer matchMaker.Stop()
+
+	for _, presence := range presences {
+		_, _, err := matchMaker.Add(context.Background(), []*MatchmakerPresence{presence}, presence.SessionId, "", "*", 2, 2, 1, map[string]string{}, map[string]float64{})
+		if err != nil {
+			t.Fatalf("error matchmaker add: %v", err)
+		}
+	}
+	matchMaker.Process()
+
+	// Every ticket found a suitable candidate match.
+	assert.Equal(t, 4, candidateCount)
+	assert.Equal(t, map[string]struct{}{presences[1].SessionId: {}, presences[2].SessionId: {}}, matched)
+	assert.Equal(t, 2, testMatchmakerTicketCount(matchMaker))
+}
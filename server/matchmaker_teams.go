@@ -0,0 +1,290 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Tickets request team matchmaking with these properties. The numeric "team_count" property is the number of teams
+// to form, and the optional string "team_roles" property lists the roles of each team, as in "tank:1,healer:1,dps:3".
+// With roles, the string "role" property of a ticket lists the role of each of its users in order, separated by commas.
+//
+// Matched users find their team number, from 1, and their role in the "team" and "role" string properties of the
+// matchmaker matched message and hook. Parties are always placed in a single team.
+const (
+	MatchmakerTeamCountProperty = "team_count"
+	MatchmakerTeamRolesProperty = "team_roles"
+	MatchmakerRoleProperty      = "role"
+	MatchmakerTeamProperty      = "team"
+
+	// Bound on the team assignments considered for each matched group, the best balanced one found is used.
+	matchmakerTeamsMaxSteps = 100_000
+)
+
+var ErrMatchmakerTeamsInvalid = errors.New("matchmaker team properties invalid")
+
+// MatchmakerTeams is the team format of a ticket, only tickets with the same format match together.
+type MatchmakerTeams struct {
+	Count int
+	Roles map[string]int
+	// Users in each team, only set if the format has roles.
+	Size   int
+	format string
+}
+
+func (t *MatchmakerTeams) compatible(other *MatchmakerTeams) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return t.Count == other.Count && t.format == other.format
+}
+
+// Parse the team format of a ticket and the roles of its users by session ID. Both are nil if the ticket does not
+// request teams.
+func parseMatchmakerTeams(presences []*MatchmakerPresence, minCount, maxCount int, stringProperties map[string]string, numericProperties map[string]float64) (*MatchmakerTeams, map[string]string, error) {
+	teamCount, found := numericProperties[MatchmakerTeamCountProperty]
+	if !found {
+		return nil, nil, nil
+	}
+	if teamCount < 2 || teamCount != math.Trunc(teamCount) {
+		return nil, nil, ErrMatchmakerTeamsInvalid
+	}
+	teams := &MatchmakerTeams{Count: int(teamCount)}
+
+	format := stringProperties[MatchmakerTeamRolesProperty]
+	if format == "" {
+		// Teams of equal size, without roles.
+		if maxCount%teams.Count != 0 || len(presences) > maxCount/teams.Count {
+			return nil, nil, ErrMatchmakerTeamsInvalid
+		}
+		return teams, nil, nil
+	}
+
+	teams.Roles = make(map[string]int)
+	for _, part := range strings.Split(format, ",") {
+		role, countStr, found := strings.Cut(strings.TrimSpace(part), ":")
+		if !found || role == "" {
+			return nil, nil, ErrMatchmakerTeamsInvalid
+		}
+		count, err := strconv.Atoi(countStr)
+		if err != nil || count < 1 {
+			return nil, nil, ErrMatchmakerTeamsInvalid
+		}
+		if _, found := teams.Roles[role]; found {
+			return nil, nil, ErrMatchmakerTeamsInvalid
+		}
+		teams.Roles[role] = count
+		teams.Size += count
+	}
+	teams.format = format
+	if minCount != teams.Size*teams.Count || maxCount != teams.Size*teams.Count {
+		// Role composition requires full teams.
+		return nil, nil, ErrMatchmakerTeamsInvalid
+	}
+
+	userRoles := strings.Split(stringProperties[MatchmakerRoleProperty], ",")
+	if len(userRoles) != len(presences) {
+		return nil, nil, ErrMatchmakerTeamsInvalid
+	}
+	roles := make(map[string]string, len(presences))
+	roleCounts := make(map[string]int, len(teams.Roles))
+	for i, presence := range presences {
+		role := strings.TrimSpace(userRoles[i])
+		roleCounts[role]++
+		// A party must fit the roles of a single team.
+		if roleCounts[role] > teams.Roles[role] {
+			return nil, nil, ErrMatchmakerTeamsInvalid
+		}
+		roles[presence.SessionId] = role
+	}
+	return teams, roles, nil
+}
+
+// Check the roles of a group of tickets can still fill the teams of their format. Must be called while holding the
+// matchmaker lock.
+func (m *LocalMatchmaker) teamRolesFitLocked(index *MatchmakerIndex, combo []*MatchmakerEntry, hitIndex *MatchmakerIndex) bool {
+	if index.Teams == nil || index.Teams.Roles == nil {
+		return true
+	}
+	counts := make(map[string]int, len(index.Teams.Roles))
+	for _, role := range index.TeamRoles {
+		counts[role]++
+	}
+	for _, role := range hitIndex.TeamRoles {
+		counts[role]++
+	}
+	for _, entry := range combo {
+		if entryIndex, found := m.indexes[entry.Ticket]; found {
+			counts[entryIndex.TeamRoles[entry.Presence.SessionId]]++
+		}
+	}
+	for role, count := range counts {
+		if count > index.Teams.Roles[role]*index.Teams.Count {
+			return false
+		}
+	}
+	return true
+}
+
+// Assign each ticket of a matched group to a team, keeping parties together and filling the roles of every team.
+// Teams are balanced on the total "rating" property of their users, where tickets have one. Returns the team index
+// of each ticket, or nil if the tickets cannot form the teams.
+func matchmakerAssignTeams(teams *MatchmakerTeams, indexes []*MatchmakerIndex) []int {
+	total := 0
+	for _, index := range indexes {
+		total += index.Count
+	}
+	size := teams.Size
+	if teams.Roles == nil {
+		if total%teams.Count != 0 {
+			return nil
+		}
+		size = total / teams.Count
+	} else if total != size*teams.Count {
+		return nil
+	}
+
+	// Place larger parties first, they are the hardest to fit.
+	order := make([]int, len(indexes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return indexes[order[i]].Count > indexes[order[j]].Count
+	})
+	ratings := make([]float64, len(indexes))
+	ticketRoles := make([]map[string]int, len(indexes))
+	for i, index := range indexes {
+		// Party tickets carry the mean rating of their users.
+		ratings[i] = index.NumericProperties[ratingPropertyName] * float64(index.Count)
+		ticketRoles[i] = make(map[string]int, len(index.TeamRoles))
+		for _, role := range index.TeamRoles {
+			ticketRoles[i][role]++
+		}
+	}
+
+	sizes := make([]int, teams.Count)
+	teamRatings := make([]float64, teams.Count)
+	teamRoles := make([]map[string]int, teams.Count)
+	for i := range teamRoles {
+		teamRoles[i] = make(map[string]int, len(teams.Roles))
+	}
+	assignment := make([]int, len(indexes))
+	var best []int
+	bestSpread := math.Inf(1)
+	steps := 0
+
+	var assign func(n int) bool
+	assign = func(n int) bool {
+		steps++
+		if n == len(order) {
+			low, high := teamRatings[0], teamRatings[0]
+			for _, rating := range teamRatings[1:] {
+				low, high = math.Min(low, rating), math.Max(high, rating)
+			}
+			if spread := high - low; spread < bestSpread {
+				bestSpread = spread
+				best = append(best[:0], assignment...)
+			}
+			// Stop early once teams are perfectly balanced.
+			return bestSpread == 0
+		}
+
+		i := order[n]
+		triedEmpty := false
+		for team := 0; team < teams.Count && steps < matchmakerTeamsMaxSteps; team++ {
+			if sizes[team] == 0 {
+				// Empty teams are interchangeable.
+				if triedEmpty {
+					continue
+				}
+				triedEmpty = true
+			}
+			if sizes[team]+indexes[i].Count > size {
+				continue
+			}
+			fits := true
+			for role, count := range ticketRoles[i] {
+				if teamRoles[team][role]+count > teams.Roles[role] {
+					fits = false
+					break
+				}
+			}
+			if !fits {
+				continue
+			}
+
+			sizes[team] += indexes[i].Count
+			teamRatings[team] += ratings[i]
+			for role, count := range ticketRoles[i] {
+				teamRoles[team][role] += count
+			}
+			assignment[i] = team
+			done := assign(n + 1)
+			sizes[team] -= indexes[i].Count
+			teamRatings[team] -= ratings[i]
+			for role, count := range ticketRoles[i] {
+				teamRoles[team][role] -= count
+			}
+			if done {
+				return true
+			}
+		}
+		return false
+	}
+	assign(0)
+
+	return best
+}
+
+// Copy matched entries with the team and role of each user added to their properties.
+func matchmakerTeamEntries(entries []*MatchmakerEntry, indexes map[string]*MatchmakerIndex, ticketTeams map[string]int) []*MatchmakerEntry {
+	results := make([]*MatchmakerEntry, 0, len(entries))
+	for _, entry := range entries {
+		team := strconv.Itoa(ticketTeams[entry.Ticket] + 1)
+		var role string
+		if index, found := indexes[entry.Ticket]; found {
+			role = index.TeamRoles[entry.Presence.SessionId]
+		}
+
+		stringProperties := make(map[string]string, len(entry.StringProperties)+2)
+		for k, v := range entry.StringProperties {
+			stringProperties[k] = v
+		}
+		properties := make(map[string]interface{}, len(entry.Properties)+2)
+		for k, v := range entry.Properties {
+			properties[k] = v
+		}
+		stringProperties[MatchmakerTeamProperty], properties[MatchmakerTeamProperty] = team, team
+		if role != "" {
+			stringProperties[MatchmakerRoleProperty], properties[MatchmakerRoleProperty] = role, role
+		}
+
+		results = append(results, &MatchmakerEntry{
+			Ticket:            entry.Ticket,
+			Presence:          entry.Presence,
+			Properties:        properties,
+			PartyId:           entry.PartyId,
+			StringProperties:  stringProperties,
+			NumericProperties: entry.NumericProperties,
+		})
+	}
+	return results
+}
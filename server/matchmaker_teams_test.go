@@ -0,0 +1,170 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTeamPresences(count int) []*MatchmakerPresence {
+	presences := make([]*MatchmakerPresence, 0, count)
+	for i := 0; i < count; i++ {
+		sessionID := uuid.Must(uuid.NewV4())
+		presences = append(presences, &MatchmakerPresence{
+			UserId:    sessionID.String(),
+			SessionId: sessionID.String(),
+			Username:  sessionID.String(),
+			Node:      "node",
+			SessionID: sessionID,
+		})
+	}
+	return presences
+}
+
+func TestParseMatchmakerTeams(t *testing.T) {
+	teamProperties := func(roles, role string) (map[string]string, map[string]float64) {
+		return map[string]string{MatchmakerTeamRolesProperty: roles, MatchmakerRoleProperty: role}, map[string]float64{MatchmakerTeamCountProperty: 2}
+	}
+
+	stringProperties, numericProperties := teamProperties("tank:1,healer:1,dps:3", "tank,dps")
+	presences := newTestTeamPresences(2)
+	teams, roles, err := parseMatchmakerTeams(presences, 10, 10, stringProperties, numericProperties)
+	if assert.NoError(t, err) {
+		assert.Equal(t, &MatchmakerTeams{Count: 2, Roles: map[string]int{"tank": 1, "healer": 1, "dps": 3}, Size: 5, format: "tank:1,healer:1,dps:3"}, teams)
+		assert.Equal(t, map[string]string{presences[0].SessionId: "tank", presences[1].SessionId: "dps"}, roles)
+	}
+
+	// Tickets without a team count do not request teams.
+	teams, roles, err = parseMatchmakerTeams(presences, 2, 4, map[string]string{}, map[string]float64{})
+	assert.NoError(t, err)
+	assert.Nil(t, teams)
+	assert.Nil(t, roles)
+
+	for _, invalid := range []struct {
+		roles, role        string
+		minCount, maxCount int
+	}{
+		// Roles must add up to full teams.
+		{"tank:1,healer:1,dps:3", "tank,dps", 8, 10},
+		// A party must fit the roles of one team.
+		{"tank:1,healer:1,dps:3", "tank,tank", 10, 10},
+		// Every user needs a known role.
+		{"tank:1,healer:1,dps:3", "tank", 10, 10},
+		{"tank:1,healer:1,dps:3", "tank,mage", 10, 10},
+		{"tank:0,dps:5", "dps,dps", 10, 10},
+		{"tank", "tank,dps", 10, 10},
+	} {
+		stringProperties, numericProperties := teamProperties(invalid.roles, invalid.role)
+		_, _, err := parseMatchmakerTeams(presences, invalid.minCount, invalid.maxCount, stringProperties, numericProperties)
+		assert.Equal(t, ErrMatchmakerTeamsInvalid, err, invalid)
+	}
+}
+
+func TestMatchmakerAssignTeams(t *testing.T) {
+	teams := &MatchmakerTeams{Count: 2, Roles: map[string]int{"tank": 1, "healer": 1, "dps": 3}, Size: 5}
+	newIndex := func(rating float64, roles ...string) *MatchmakerIndex {
+		teamRoles := make(map[string]string, len(roles))
+		for _, role := range roles {
+			teamRoles[uuid.Must(uuid.NewV4()).String()] = role
+		}
+		return &MatchmakerIndex{Count: len(roles), TeamRoles: teamRoles, NumericProperties: map[string]float64{"rating": rating}}
+	}
+	indexes := []*MatchmakerIndex{
+		// A party with a tank and a healer.
+		newIndex(1800, "tank", "healer"),
+		newIndex(1500, "tank"),
+		newIndex(1500, "healer"),
+		newIndex(1200, "dps"),
+		newIndex(1200, "dps"),
+		newIndex(1500, "dps"),
+		newIndex(1500, "dps"),
+		newIndex(1800, "dps"),
+		newIndex(1800, "dps"),
+	}
+
+	assignment := matchmakerAssignTeams(teams, indexes)
+	if assert.Len(t, assignment, len(indexes)) {
+		ratings := make([]float64, 2)
+		roles := []map[string]int{{}, {}}
+		for i, index := range indexes {
+			ratings[assignment[i]] += index.NumericProperties["rating"] * float64(index.Count)
+			for _, role := range index.TeamRoles {
+				roles[assignment[i]][role]++
+			}
+		}
+		for _, teamRoles := range roles {
+			assert.Equal(t, teams.Roles, teamRoles)
+		}
+		// The higher rated party is balanced by lower rated dps.
+		assert.Equal(t, ratings[0], ratings[1])
+	}
+
+	// Three tanks cannot fill two teams with one tank each.
+	indexes[5] = newIndex(1500, "tank")
+	assert.Nil(t, matchmakerAssignTeams(teams, indexes))
+}
+
+func TestMatchmakerTeams(t *testing.T) {
+	cfg := NewConfig(logger)
+	// Processing is triggered manually.
+	cfg.Matchmaker.IntervalSec = 3600
+
+	var mu sync.Mutex
+	matched := make(map[string]*rtapi.MatchmakerMatched_MatchmakerUser)
+	messageRouter := &testMessageRouter{
+		sendToPresence: func(presences []*PresenceID, envelope *rtapi.Envelope) {
+			if m := envelope.GetMatchmakerMatched(); m != nil {
+				mu.Lock()
+				matched[m.Self.Presence.SessionId] = m.Self
+				mu.Unlock()
+			}
+		},
+	}
+	matchMaker := NewLocalMatchmaker(logger, logger, cfg, nil, messageRouter, &testMetrics{}, &Runtime{}, nil).(*LocalMatchmaker)
+	defer matchMaker.Stop()
+
+	// Three tanks are queued, but only two can play.
+	for _, role := range []string{"tank", "tank", "tank", "healer", "healer", "dps", "dps", "dps", "dps", "dps", "dps"} {
+		presences := newTestTeamPresences(1)
+		_, _, err := matchMaker.Add(context.Background(), presences, presences[0].SessionId, "", "*", 10, 10, 1,
+			map[string]string{MatchmakerTeamRolesProperty: "tank:1,healer:1,dps:3", MatchmakerRoleProperty: role},
+			map[string]float64{MatchmakerTeamCountProperty: 2})
+		if err != nil {
+			t.Fatalf("error matchmaker add: %v", err)
+		}
+	}
+	matchMaker.Process()
+
+	assert.Len(t, matched, 10)
+	roles := map[string]map[string]int{}
+	for _, user := range matched {
+		team := user.StringProperties[MatchmakerTeamProperty]
+		if roles[team] == nil {
+			roles[team] = map[string]int{}
+		}
+		roles[team][user.StringProperties[MatchmakerRoleProperty]]++
+	}
+	assert.Equal(t, map[string]map[string]int{
+		"1": {"tank": 1, "healer": 1, "dps": 3},
+		"2": {"tank": 1, "healer": 1, "dps": 3},
+	}, roles)
+	assert.Equal(t, 1, testMatchmakerTicketCount(matchMaker))
+}
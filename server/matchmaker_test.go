@@ -27,6 +27,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/heroiclabs/nakama-common/rtapi"
 	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -1635,7 +1636,7 @@ func createTestMatchmaker(t fatalable, logger *zap.Logger, tickerActive bool, me
 
 	runtime, _, err := NewRuntime(context.Background(), logger, logger, nil, jsonpbMarshaler, jsonpbUnmarshaler, cfg,
 		nil, nil, nil, nil, sessionRegistry, nil, nil,
-		nil, tracker, metrics, nil, messageRouter)
+		nil, tracker, metrics, nil, messageRouter, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2445,3 +2446,105 @@ func BenchmarkMatchmakerProcessTickets10_000_min2_max2(b *testing.B) {
 /*func BenchmarkMatchmakerProcessTickets100_000(b *testing.B) {
 	benchmarkMatchmakerProcessTickets(100_000, 4, 4, b)
 }*/
+
+func createTestClusterMatchmaker(t *testing.T, hub *LoopbackClusterHub, node string, matched *atomic.Int32) *LocalMatchmaker {
+	cfg := NewConfig(logger)
+	cfg.Name = node
+	// Processing is triggered manually.
+	cfg.Matchmaker.IntervalSec = 3600
+	cfg.Matchmaker.Cluster = true
+
+	messageRouter := &testMessageRouter{
+		sendToPresence: func(presences []*PresenceID, envelope *rtapi.Envelope) {
+			if envelope.GetMatchmakerMatched() != nil {
+				matched.Inc()
+			}
+		},
+	}
+
+	transport := hub.NewTransport(node)
+	matchMaker := NewLocalMatchmaker(logger, logger, cfg, nil, messageRouter, &testMetrics{}, &Runtime{}, transport).(*LocalMatchmaker)
+	if err := transport.Start(); err != nil {
+		t.Fatalf("error starting transport: %v", err)
+	}
+	t.Cleanup(func() {
+		transport.Stop()
+		matchMaker.Stop()
+	})
+	return matchMaker
+}
+
+func addTestClusterTicket(t *testing.T, matchMaker *LocalMatchmaker) string {
+	sessionID := uuid.Must(uuid.NewV4())
+	ticket, _, err := matchMaker.Add(context.Background(), []*MatchmakerPresence{
+		{
+			UserId:    sessionID.String(),
+			SessionId: sessionID.String(),
+			Username:  sessionID.String(),
+			Node:      matchMaker.node,
+			SessionID: sessionID,
+		},
+	}, sessionID.String(), "", "*", 2, 2, 1, map[string]string{}, map[string]float64{})
+	if err != nil {
+		t.Fatalf("error matchmaker add: %v", err)
+	}
+	return ticket
+}
+
+func testMatchmakerTicketCount(matchMaker *LocalMatchmaker) int {
+	matchMaker.Lock()
+	defer matchMaker.Unlock()
+	return len(matchMaker.indexes)
+}
+
+func TestMatchmakerClusterProcess(t *testing.T) {
+	hub := NewLoopbackClusterHub()
+	matchedA, matchedB := atomic.NewInt32(0), atomic.NewInt32(0)
+	matchMakerA := createTestClusterMatchmaker(t, hub, "a", matchedA)
+	matchMakerB := createTestClusterMatchmaker(t, hub, "b", matchedB)
+
+	// Tickets created on different nodes end up in the same pool.
+	addTestClusterTicket(t, matchMakerA)
+	addTestClusterTicket(t, matchMakerB)
+	assert.Eventually(t, func() bool { return testMatchmakerTicketCount(matchMakerA) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return testMatchmakerTicketCount(matchMakerB) == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	// Only the leader processes the pool.
+	matchMakerB.Process()
+	assert.Equal(t, int32(0), matchedB.Load())
+	assert.Equal(t, 2, testMatchmakerTicketCount(matchMakerB))
+
+	matchMakerA.Process()
+	assert.Equal(t, int32(2), matchedA.Load())
+	assert.Equal(t, 0, testMatchmakerTicketCount(matchMakerA))
+	// Matched tickets are removed from every node.
+	assert.Eventually(t, func() bool { return testMatchmakerTicketCount(matchMakerB) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestMatchmakerClusterHandoff(t *testing.T) {
+	hub := NewLoopbackClusterHub()
+	matchedB, matchedC := atomic.NewInt32(0), atomic.NewInt32(0)
+	matchMakerB := createTestClusterMatchmaker(t, hub, "b", matchedB)
+	matchMakerC := createTestClusterMatchmaker(t, hub, "c", matchedC)
+
+	ticket := addTestClusterTicket(t, matchMakerC)
+	assert.Eventually(t, func() bool { return testMatchmakerTicketCount(matchMakerB) == 1 }, 5*time.Second, 10*time.Millisecond)
+	matchMakerB.Process()
+
+	// A node with a lower name takes over as leader, receiving existing tickets and their interval counts.
+	matchedA := atomic.NewInt32(0)
+	matchMakerA := createTestClusterMatchmaker(t, hub, "a", matchedA)
+	assert.Eventually(t, func() bool {
+		matchMakerA.Lock()
+		defer matchMakerA.Unlock()
+		index, found := matchMakerA.indexes[ticket]
+		return found && index.Intervals == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, matchMakerB.clusterLeader())
+	assert.True(t, matchMakerA.clusterLeader())
+
+	// Tickets are dropped when their owner leaves the cluster.
+	matchMakerC.transport.Stop()
+	assert.Eventually(t, func() bool { return testMatchmakerTicketCount(matchMakerA) == 0 }, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return testMatchmakerTicketCount(matchMakerB) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
@@ -15,6 +15,9 @@
 package server
 
 import (
+	"context"
+
+	"github.com/gofrs/uuid"
 	"github.com/heroiclabs/nakama-common/rtapi"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -108,3 +111,169 @@ func (r *LocalMessageRouter) SendDeferred(logger *zap.Logger, messages []*Deferr
 		r.SendToPresenceIDs(logger, message.PresenceIDs, message.Envelope, message.Reliable)
 	}
 }
+
+const (
+	clusterMessageRouterPresences = "router_presences"
+	clusterMessageRouterStream    = "router_stream"
+)
+
+// clusterRouterMessage carries an envelope to be delivered to sessions connected to another node.
+type clusterRouterMessage struct {
+	SessionIDs []uuid.UUID
+	Stream     *PresenceStream
+	Envelope   []byte
+	Reliable   bool
+}
+
+// ClusterMessageRouter delivers messages to local sessions directly, and forwards messages
+// for sessions connected to other nodes over the cluster transport.
+type ClusterMessageRouter struct {
+	logger    *zap.Logger
+	local     *LocalMessageRouter
+	tracker   Tracker
+	transport ClusterTransport
+	node      string
+}
+
+func NewClusterMessageRouter(logger *zap.Logger, sessionRegistry SessionRegistry, tracker Tracker, transport ClusterTransport, protojsonMarshaler *protojson.MarshalOptions) MessageRouter {
+	r := &ClusterMessageRouter{
+		logger: logger,
+		local: &LocalMessageRouter{
+			protojsonMarshaler: protojsonMarshaler,
+			sessionRegistry:    sessionRegistry,
+			tracker:            tracker,
+		},
+		tracker:   tracker,
+		transport: transport,
+		node:      transport.Node(),
+	}
+
+	transport.Handle(clusterMessageRouterPresences, r.handlePresences)
+	transport.Handle(clusterMessageRouterStream, r.handleStream)
+
+	return r
+}
+
+func (r *ClusterMessageRouter) SendToPresenceIDs(logger *zap.Logger, presenceIDs []*PresenceID, envelope *rtapi.Envelope, reliable bool) {
+	if len(presenceIDs) == 0 {
+		return
+	}
+
+	localPresenceIDs := make([]*PresenceID, 0, len(presenceIDs))
+	var remoteSessionIDs map[string][]uuid.UUID
+	for _, presenceID := range presenceIDs {
+		if presenceID.Node == r.node || presenceID.Node == "" {
+			localPresenceIDs = append(localPresenceIDs, presenceID)
+			continue
+		}
+		if remoteSessionIDs == nil {
+			remoteSessionIDs = make(map[string][]uuid.UUID, 1)
+		}
+		remoteSessionIDs[presenceID.Node] = append(remoteSessionIDs[presenceID.Node], presenceID.SessionID)
+	}
+
+	r.local.SendToPresenceIDs(logger, localPresenceIDs, envelope, reliable)
+
+	if len(remoteSessionIDs) == 0 {
+		return
+	}
+
+	payloadEnvelope, err := proto.Marshal(envelope)
+	if err != nil {
+		logger.Error("Could not marshal message", zap.Error(err))
+		return
+	}
+	for node, sessionIDs := range remoteSessionIDs {
+		payload, err := gobEncode(&clusterRouterMessage{SessionIDs: sessionIDs, Envelope: payloadEnvelope, Reliable: reliable})
+		if err != nil {
+			logger.Error("Could not encode cluster message", zap.Error(err))
+			continue
+		}
+		if err := r.transport.Send(node, clusterMessageRouterPresences, payload); err != nil {
+			logger.Error("Failed to route message to node", zap.String("node", node), zap.Error(err))
+		}
+	}
+}
+
+func (r *ClusterMessageRouter) SendToStream(logger *zap.Logger, stream PresenceStream, envelope *rtapi.Envelope, reliable bool) {
+	r.sendToLocalStream(logger, stream, envelope, reliable)
+
+	// Only forward to nodes the tracker knows have presences for this stream.
+	nodes := r.tracker.ListNodesForStream(stream)
+	delete(nodes, r.node)
+	if len(nodes) == 0 {
+		return
+	}
+
+	payloadEnvelope, err := proto.Marshal(envelope)
+	if err != nil {
+		logger.Error("Could not marshal message", zap.Error(err))
+		return
+	}
+	payload, err := gobEncode(&clusterRouterMessage{Stream: &stream, Envelope: payloadEnvelope, Reliable: reliable})
+	if err != nil {
+		logger.Error("Could not encode cluster message", zap.Error(err))
+		return
+	}
+	for node := range nodes {
+		if err := r.transport.Send(node, clusterMessageRouterStream, payload); err != nil {
+			logger.Error("Failed to route message to node", zap.String("node", node), zap.Error(err))
+		}
+	}
+}
+
+func (r *ClusterMessageRouter) SendDeferred(logger *zap.Logger, messages []*DeferredMessage) {
+	for _, message := range messages {
+		r.SendToPresenceIDs(logger, message.PresenceIDs, message.Envelope, message.Reliable)
+	}
+}
+
+func (r *ClusterMessageRouter) sendToLocalStream(logger *zap.Logger, stream PresenceStream, envelope *rtapi.Envelope, reliable bool) {
+	sessionIDs := r.tracker.ListLocalSessionIDByStream(stream)
+	presenceIDs := make([]*PresenceID, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		presenceIDs = append(presenceIDs, &PresenceID{Node: r.node, SessionID: sessionID})
+	}
+	r.local.SendToPresenceIDs(logger, presenceIDs, envelope, reliable)
+}
+
+func (r *ClusterMessageRouter) decode(payload []byte) (*clusterRouterMessage, *rtapi.Envelope, error) {
+	msg := &clusterRouterMessage{}
+	if err := gobDecode(payload, msg); err != nil {
+		return nil, nil, err
+	}
+	envelope := &rtapi.Envelope{}
+	if err := proto.Unmarshal(msg.Envelope, envelope); err != nil {
+		return nil, nil, err
+	}
+	return msg, envelope, nil
+}
+
+func (r *ClusterMessageRouter) handlePresences(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	msg, envelope, err := r.decode(payload)
+	if err != nil {
+		r.logger.Error("Could not decode routed message", zap.String("node", node), zap.Error(err))
+		return nil, err
+	}
+
+	presenceIDs := make([]*PresenceID, 0, len(msg.SessionIDs))
+	for _, sessionID := range msg.SessionIDs {
+		presenceIDs = append(presenceIDs, &PresenceID{Node: r.node, SessionID: sessionID})
+	}
+	r.local.SendToPresenceIDs(r.logger, presenceIDs, envelope, msg.Reliable)
+	return nil, nil
+}
+
+func (r *ClusterMessageRouter) handleStream(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	msg, envelope, err := r.decode(payload)
+	if err != nil {
+		r.logger.Error("Could not decode routed message", zap.String("node", node), zap.Error(err))
+		return nil, err
+	}
+	if msg.Stream == nil {
+		return nil, nil
+	}
+
+	r.sendToLocalStream(r.logger, *msg.Stream, envelope, msg.Reliable)
+	return nil, nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// clusterTestSession records every message delivered to it.
+type clusterTestSession struct {
+	sync.Mutex
+	DummySession
+	id       uuid.UUID
+	received []*rtapi.Envelope
+}
+
+func newClusterTestSession() *clusterTestSession {
+	return &clusterTestSession{id: uuid.Must(uuid.NewV4())}
+}
+
+func (s *clusterTestSession) ID() uuid.UUID {
+	return s.id
+}
+
+func (s *clusterTestSession) SendBytes(payload []byte, reliable bool) error {
+	envelope := &rtapi.Envelope{}
+	if err := protojsonUnmarshaler.Unmarshal(payload, envelope); err != nil {
+		return err
+	}
+	s.Lock()
+	s.received = append(s.received, envelope)
+	s.Unlock()
+	return nil
+}
+
+func (s *clusterTestSession) Received() []*rtapi.Envelope {
+	s.Lock()
+	defer s.Unlock()
+	return s.received
+}
+
+type clusterTestNode struct {
+	transport       *LoopbackClusterTransport
+	sessionRegistry SessionRegistry
+	tracker         Tracker
+	router          MessageRouter
+}
+
+func newClusterTestNode(t *testing.T, hub *LoopbackClusterHub, name string) *clusterTestNode {
+	nodeCfg := NewConfig(logger)
+	nodeCfg.Name = name
+	sessionRegistry := NewLocalSessionRegistry(&testMetrics{})
+	transport := hub.NewTransport(name)
+	tracker := StartLocalTracker(logger, nodeCfg, sessionRegistry, nil, &testMetrics{}, protojsonMarshaler, transport)
+	router := NewClusterMessageRouter(logger, sessionRegistry, tracker, transport, protojsonMarshaler)
+	if err := transport.Start(); err != nil {
+		t.Fatalf("error starting transport: %v", err)
+	}
+	t.Cleanup(func() {
+		transport.Stop()
+		tracker.Stop()
+	})
+	return &clusterTestNode{
+		transport:       transport,
+		sessionRegistry: sessionRegistry,
+		tracker:         tracker,
+		router:          router,
+	}
+}
+
+func (n *clusterTestNode) addSession(t *testing.T, stream *PresenceStream) *clusterTestSession {
+	session := newClusterTestSession()
+	n.sessionRegistry.Add(session)
+	if stream != nil {
+		if ok, _ := n.tracker.Track(context.Background(), session.ID(), *stream, uuid.Must(uuid.NewV4()), PresenceMeta{Hidden: true}, true); !ok {
+			t.Fatal("error tracking session")
+		}
+	}
+	return session
+}
+
+func TestClusterMessageRouterSendToPresenceIDs(t *testing.T) {
+	hub := NewLoopbackClusterHub()
+	nodeA := newClusterTestNode(t, hub, "a")
+	nodeB := newClusterTestNode(t, hub, "b")
+
+	sessionA := nodeA.addSession(t, nil)
+	sessionB := nodeB.addSession(t, nil)
+
+	envelope := &rtapi.Envelope{Message: &rtapi.Envelope_Pong{Pong: &rtapi.Pong{}}}
+	nodeA.router.SendToPresenceIDs(zap.NewNop(), []*PresenceID{
+		{Node: "a", SessionID: sessionA.ID()},
+		{Node: "b", SessionID: sessionB.ID()},
+	}, envelope, true)
+
+	assert.Len(t, sessionA.Received(), 1)
+	assert.Eventually(t, func() bool { return len(sessionB.Received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClusterMessageRouterSendToStream(t *testing.T) {
+	hub := NewLoopbackClusterHub()
+	nodeA := newClusterTestNode(t, hub, "a")
+	nodeB := newClusterTestNode(t, hub, "b")
+	nodeC := newClusterTestNode(t, hub, "c")
+
+	stream := PresenceStream{Mode: StreamModeChannel, Label: "room"}
+	sessionA := nodeA.addSession(t, &stream)
+	sessionB := nodeB.addSession(t, &stream)
+	// Connected, but not part of the stream.
+	sessionC := nodeC.addSession(t, nil)
+	// Streams are only forwarded to nodes known to have presences on them.
+	assert.Eventually(t, func() bool { return len(nodeA.tracker.ListNodesForStream(stream)) == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	envelope := &rtapi.Envelope{Message: &rtapi.Envelope_Pong{Pong: &rtapi.Pong{}}}
+	nodeA.router.SendToStream(zap.NewNop(), stream, envelope, true)
+
+	assert.Len(t, sessionA.Received(), 1)
+	assert.Eventually(t, func() bool { return len(sessionB.Received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Never(t, func() bool { return len(sessionC.Received()) != 0 }, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestClusterMessageRouterSendDeferred(t *testing.T) {
+	hub := NewLoopbackClusterHub()
+	nodeA := newClusterTestNode(t, hub, "a")
+	nodeB := newClusterTestNode(t, hub, "b")
+
+	sessionB := nodeB.addSession(t, nil)
+
+	envelope := &rtapi.Envelope{Message: &rtapi.Envelope_Pong{Pong: &rtapi.Pong{}}}
+	nodeA.router.SendDeferred(zap.NewNop(), []*DeferredMessage{
+		{PresenceIDs: []*PresenceID{{Node: "b", SessionID: sessionB.ID()}}, Envelope: envelope, Reliable: true},
+		{PresenceIDs: []*PresenceID{{Node: "b", SessionID: sessionB.ID()}}, Envelope: envelope, Reliable: false},
+	})
+
+	assert.Eventually(t, func() bool { return len(sessionB.Received()) == 2 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLoopbackClusterTransportRequest(t *testing.T) {
+	hub := NewLoopbackClusterHub()
+	nodeA := hub.NewTransport("a")
+	nodeB := hub.NewTransport("b")
+
+	nodeB.Handle("echo", func(ctx context.Context, node string, payload []byte) ([]byte, error) {
+		return append([]byte(node+":"), payload...), nil
+	})
+	nodeB.Handle("fail", func(ctx context.Context, node string, payload []byte) ([]byte, error) {
+		return nil, runtime.ErrMatchNotFound
+	})
+
+	var disconnected []string
+	nodeA.AddPeerListener(func(node string, connected bool) {
+		if !connected {
+			disconnected = append(disconnected, node)
+		}
+	})
+
+	_ = nodeA.Start()
+	_ = nodeB.Start()
+
+	assert.Equal(t, []string{"b"}, nodeA.Peers())
+
+	reply, err := nodeA.Request(context.Background(), "b", "echo", []byte("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a:hello", string(reply))
+
+	_, err = nodeA.Request(context.Background(), "b", "fail", nil)
+	assert.EqualError(t, err, runtime.ErrMatchNotFound.Error())
+
+	nodeB.Stop()
+	assert.Equal(t, []string{"b"}, disconnected)
+	_, err = nodeA.Request(context.Background(), "b", "echo", nil)
+	assert.Equal(t, ErrClusterNodeNotFound, err)
+}
@@ -53,6 +53,8 @@ type Metrics interface {
 	CountDroppedEvents(delta int64)
 	CountWebsocketOpened(delta int64)
 	CountWebsocketClosed(delta int64)
+	CountUdpOpened(delta int64)
+	CountUdpClosed(delta int64)
 	GaugeSessions(value float64)
 	GaugePresences(value float64)
 
@@ -61,6 +63,7 @@ type Metrics interface {
 	PresenceEvent(dequeueElapsed, processElapsed time.Duration)
 
 	StorageWriteRejectCount(tags map[string]string, delta int64)
+	LeaderboardWriteRejectCount(tags map[string]string, delta int64)
 
 	CustomCounter(name string, tags map[string]string, delta int64)
 	CustomGauge(name string, tags map[string]string, value float64)
@@ -407,6 +410,16 @@ func (m *LocalMetrics) CountWebsocketClosed(delta int64) {
 	m.PrometheusScope.Counter("socket_ws_closed").Inc(delta)
 }
 
+// Increment the number of opened UDP sessions.
+func (m *LocalMetrics) CountUdpOpened(delta int64) {
+	m.PrometheusScope.Counter("socket_udp_opened").Inc(delta)
+}
+
+// Increment the number of closed UDP sessions.
+func (m *LocalMetrics) CountUdpClosed(delta int64) {
+	m.PrometheusScope.Counter("socket_udp_closed").Inc(delta)
+}
+
 // Set the absolute value of currently active sessions.
 func (m *LocalMetrics) GaugeSessions(value float64) {
 	m.PrometheusScope.Gauge("sessions").Update(value)
@@ -439,6 +452,15 @@ func (m *LocalMetrics) StorageWriteRejectCount(tags map[string]string, delta int
 	scope.Counter("storage_write_reject_count").Inc(delta)
 }
 
+// Count client leaderboard and tournament score submissions rejected by validation rules.
+func (m *LocalMetrics) LeaderboardWriteRejectCount(tags map[string]string, delta int64) {
+	scope := m.PrometheusScope
+	if len(tags) != 0 {
+		scope = scope.Tagged(tags)
+	}
+	scope.Counter("leaderboard_write_reject_count").Inc(delta)
+}
+
 // CustomCounter adds the given delta to a counter with the specified name and tags.
 func (m *LocalMetrics) CustomCounter(name string, tags map[string]string, delta int64) {
 	scope := m.prometheusCustomScope
@@ -0,0 +1,171 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"go.uber.org/zap"
+)
+
+const leaderboardFeedMaxFollow = 100
+
+type leaderboardFeedSubscription struct {
+	LeaderboardID string   `json:"leaderboard_id"`
+	Follow        []string `json:"follow"`
+}
+
+func (p *Pipeline) leaderboardSubscribe(logger *zap.Logger, session Session, envelope *rtapi.Envelope) (bool, *rtapi.Envelope) {
+	streams, ok := p.leaderboardSubscriptionStreams(session, envelope)
+	if !ok {
+		return false, nil
+	}
+
+	if len(streams) > 1 {
+		// Only friends may be followed.
+		friends, err := leaderboardFeedFriends(session.Context(), p.db, session.UserID(), streams[1:])
+		if err != nil {
+			logger.Error("Error checking leaderboard subscription friends", zap.Error(err))
+			session.Send(&rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Error{Error: &rtapi.Error{
+				Code:    int32(rtapi.Error_RUNTIME_EXCEPTION),
+				Message: "Error checking leaderboard subscription friends",
+			}}}, true)
+			return false, nil
+		}
+		if friends != len(streams)-1 {
+			session.Send(&rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Error{Error: &rtapi.Error{
+				Code:    int32(rtapi.Error_BAD_INPUT),
+				Message: "Leaderboard subscriptions may only follow friends",
+			}}}, true)
+			return false, nil
+		}
+	}
+
+	ops := make([]*TrackerOp, 0, len(streams))
+	for _, stream := range streams {
+		ops = append(ops, &TrackerOp{
+			Stream: stream,
+			Meta: PresenceMeta{
+				Format:   session.Format(),
+				Username: session.Username(),
+				Hidden:   true,
+			},
+		})
+	}
+	if success := p.tracker.TrackMulti(session.Context(), session.ID(), ops, session.UserID(), false); !success {
+		session.Send(&rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Error{Error: &rtapi.Error{
+			Code:    int32(rtapi.Error_RUNTIME_EXCEPTION),
+			Message: "Error tracking leaderboard subscription",
+		}}}, true)
+		return false, nil
+	}
+
+	out := &rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Rpc{Rpc: &api.Rpc{Id: LeaderboardSubscribeRpcID}}}
+	session.Send(out, true)
+
+	return true, out
+}
+
+func (p *Pipeline) leaderboardUnsubscribe(logger *zap.Logger, session Session, envelope *rtapi.Envelope) (bool, *rtapi.Envelope) {
+	streams, ok := p.leaderboardSubscriptionStreams(session, envelope)
+	if !ok {
+		return false, nil
+	}
+
+	untrack := make([]*PresenceStream, 0, len(streams))
+	for i := range streams {
+		untrack = append(untrack, &streams[i])
+	}
+	p.tracker.UntrackMulti(session.ID(), untrack, session.UserID())
+
+	out := &rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Rpc{Rpc: &api.Rpc{Id: LeaderboardUnsubscribeRpcID}}}
+	session.Send(out, true)
+
+	return true, out
+}
+
+// Parse a subscription payload into the leaderboard stream, followed by the streams of any followed users.
+func (p *Pipeline) leaderboardSubscriptionStreams(session Session, envelope *rtapi.Envelope) ([]PresenceStream, bool) {
+	var subscription leaderboardFeedSubscription
+	if err := json.Unmarshal([]byte(envelope.GetRpc().Payload), &subscription); err != nil {
+		session.Send(&rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Error{Error: &rtapi.Error{
+			Code:    int32(rtapi.Error_BAD_INPUT),
+			Message: "Invalid leaderboard subscription payload",
+		}}}, true)
+		return nil, false
+	}
+
+	if subscription.LeaderboardID == "" {
+		session.Send(&rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Error{Error: &rtapi.Error{
+			Code:    int32(rtapi.Error_BAD_INPUT),
+			Message: "Leaderboard subscription leaderboard ID must be set",
+		}}}, true)
+		return nil, false
+	}
+
+	if len(subscription.Follow) > leaderboardFeedMaxFollow {
+		session.Send(&rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Error{Error: &rtapi.Error{
+			Code:    int32(rtapi.Error_BAD_INPUT),
+			Message: "Leaderboard subscriptions may follow at most " + strconv.Itoa(leaderboardFeedMaxFollow) + " users",
+		}}}, true)
+		return nil, false
+	}
+
+	streams := make([]PresenceStream, 0, len(subscription.Follow)+1)
+	streams = append(streams, leaderboardFeedStream(subscription.LeaderboardID, uuid.Nil))
+	seen := make(map[uuid.UUID]struct{}, len(subscription.Follow))
+	for _, follow := range subscription.Follow {
+		userID, err := uuid.FromString(follow)
+		if err != nil || userID == uuid.Nil {
+			session.Send(&rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Error{Error: &rtapi.Error{
+				Code:    int32(rtapi.Error_BAD_INPUT),
+				Message: "Leaderboard subscription followed user IDs must be valid",
+			}}}, true)
+			return nil, false
+		}
+		if _, found := seen[userID]; found {
+			continue
+		}
+		seen[userID] = struct{}{}
+		streams = append(streams, leaderboardFeedStream(subscription.LeaderboardID, userID))
+	}
+
+	return streams, true
+}
+
+// Count how many of the users followed on the given streams are friends of the user.
+func leaderboardFeedFriends(ctx context.Context, db *sql.DB, userID uuid.UUID, streams []PresenceStream) (int, error) {
+	statements := make([]string, 0, len(streams))
+	params := make([]interface{}, 0, len(streams)+1)
+	params = append(params, userID)
+	for i, stream := range streams {
+		statements = append(statements, "$"+strconv.Itoa(i+2))
+		params = append(params, stream.Subject)
+	}
+
+	var count int
+	query := "SELECT count(*) FROM user_edge WHERE source_id = $1 AND state = 0 AND destination_id IN (" + strings.Join(statements, ", ") + ")"
+	if err := db.QueryRowContext(ctx, query, params...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
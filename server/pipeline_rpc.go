@@ -22,6 +22,14 @@ import (
 	"go.uber.org/zap"
 )
 
+// RPC IDs handled by the server itself on realtime sockets, unless runtime modules register RPC functions with them.
+var pipelineBuiltinRpcIDs = map[string]struct{}{
+	StorageSubscribeRpcID:       {},
+	StorageUnsubscribeRpcID:     {},
+	LeaderboardSubscribeRpcID:   {},
+	LeaderboardUnsubscribeRpcID: {},
+}
+
 func (p *Pipeline) rpc(logger *zap.Logger, session Session, envelope *rtapi.Envelope) (bool, *rtapi.Envelope) {
 	rpcMessage := envelope.GetRpc()
 	if rpcMessage.Id == "" {
@@ -36,6 +44,17 @@ func (p *Pipeline) rpc(logger *zap.Logger, session Session, envelope *rtapi.Enve
 
 	fn := p.runtime.Rpc(id)
 	if fn == nil {
+		// Built-in socket RPCs, shadowed by any runtime functions registered with the same IDs.
+		switch id {
+		case StorageSubscribeRpcID:
+			return p.storageSubscribe(logger, session, envelope)
+		case StorageUnsubscribeRpcID:
+			return p.storageUnsubscribe(logger, session, envelope)
+		case LeaderboardSubscribeRpcID:
+			return p.leaderboardSubscribe(logger, session, envelope)
+		case LeaderboardUnsubscribeRpcID:
+			return p.leaderboardUnsubscribe(logger, session, envelope)
+		}
 		session.Send(&rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Error{Error: &rtapi.Error{
 			Code:    int32(rtapi.Error_RUNTIME_FUNCTION_NOT_FOUND),
 			Message: "RPC function not found",
@@ -0,0 +1,103 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"go.uber.org/zap"
+)
+
+type storageFeedSubscription struct {
+	Collection string `json:"collection"`
+	Key        string `json:"key"`
+	UserID     string `json:"user_id"`
+}
+
+func (p *Pipeline) storageSubscribe(logger *zap.Logger, session Session, envelope *rtapi.Envelope) (bool, *rtapi.Envelope) {
+	stream, ok := p.storageSubscriptionStream(session, envelope)
+	if !ok {
+		return false, nil
+	}
+
+	// Permissions are checked when changes are delivered, subscribers may gain or lose access as objects change.
+	success, _ := p.tracker.Track(session.Context(), session.ID(), stream, session.UserID(), PresenceMeta{
+		Format:   session.Format(),
+		Username: session.Username(),
+		Hidden:   true,
+	}, false)
+	if !success {
+		session.Send(&rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Error{Error: &rtapi.Error{
+			Code:    int32(rtapi.Error_RUNTIME_EXCEPTION),
+			Message: "Error tracking storage subscription",
+		}}}, true)
+		return false, nil
+	}
+
+	out := &rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Rpc{Rpc: &api.Rpc{Id: StorageSubscribeRpcID}}}
+	session.Send(out, true)
+
+	return true, out
+}
+
+func (p *Pipeline) storageUnsubscribe(logger *zap.Logger, session Session, envelope *rtapi.Envelope) (bool, *rtapi.Envelope) {
+	stream, ok := p.storageSubscriptionStream(session, envelope)
+	if !ok {
+		return false, nil
+	}
+
+	p.tracker.Untrack(session.ID(), stream, session.UserID())
+
+	out := &rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Rpc{Rpc: &api.Rpc{Id: StorageUnsubscribeRpcID}}}
+	session.Send(out, true)
+
+	return true, out
+}
+
+func (p *Pipeline) storageSubscriptionStream(session Session, envelope *rtapi.Envelope) (PresenceStream, bool) {
+	var subscription storageFeedSubscription
+	if err := json.Unmarshal([]byte(envelope.GetRpc().Payload), &subscription); err != nil {
+		session.Send(&rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Error{Error: &rtapi.Error{
+			Code:    int32(rtapi.Error_BAD_INPUT),
+			Message: "Invalid storage subscription payload",
+		}}}, true)
+		return PresenceStream{}, false
+	}
+
+	if subscription.Collection == "" {
+		session.Send(&rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Error{Error: &rtapi.Error{
+			Code:    int32(rtapi.Error_BAD_INPUT),
+			Message: "Storage subscription collection must be set",
+		}}}, true)
+		return PresenceStream{}, false
+	}
+
+	ownerID := uuid.Nil
+	if subscription.UserID != "" {
+		var err error
+		if ownerID, err = uuid.FromString(subscription.UserID); err != nil || subscription.Key == "" {
+			session.Send(&rtapi.Envelope{Cid: envelope.Cid, Message: &rtapi.Envelope_Error{Error: &rtapi.Error{
+				Code:    int32(rtapi.Error_BAD_INPUT),
+				Message: "Storage subscription user ID must be valid, and is only allowed with a key",
+			}}}, true)
+			return PresenceStream{}, false
+		}
+	}
+
+	return storageFeedStream(subscription.Collection, subscription.Key, ownerID), true
+}
@@ -204,16 +204,23 @@ type (
 	RuntimeBeforeGetSubscriptionFunction                   func(ctx context.Context, logger *zap.Logger, userID, username string, vars map[string]string, expiry int64, clientIP, clientPort string, in *api.GetSubscriptionRequest) (*api.GetSubscriptionRequest, error, codes.Code)
 	RuntimeAfterGetSubscriptionFunction                    func(ctx context.Context, logger *zap.Logger, userID, username string, vars map[string]string, expiry int64, clientIP, clientPort string, out *api.ValidatedSubscription, in *api.GetSubscriptionRequest) error
 
-	RuntimeMatchmakerMatchedFunction func(ctx context.Context, entries []*MatchmakerEntry) (string, bool, error)
+	RuntimeMatchmakerMatchedFunction  func(ctx context.Context, entries []*MatchmakerEntry) (string, bool, error)
+	RuntimeMatchmakerOverrideFunction func(ctx context.Context, candidateMatches [][]*MatchmakerEntry) ([][]*MatchmakerEntry, error)
 
 	RuntimeMatchCreateFunction       func(ctx context.Context, logger *zap.Logger, id uuid.UUID, node string, stopped *atomic.Bool, name string) (RuntimeMatchCore, error)
 	RuntimeMatchDeferMessageFunction func(msg *DeferredMessage) error
 
+	RuntimeTournamentStartFunction func(ctx context.Context, tournament *api.Tournament, start, end int64) error
+	RuntimeTournamentJoinFunction  func(ctx context.Context, tournament *api.Tournament, userID, username string) error
 	RuntimeTournamentEndFunction   func(ctx context.Context, tournament *api.Tournament, end, reset int64) error
 	RuntimeTournamentResetFunction func(ctx context.Context, tournament *api.Tournament, end, reset int64) error
 
 	RuntimeLeaderboardResetFunction func(ctx context.Context, leaderboard *api.Leaderboard, reset int64) error
 
+	RuntimeStorageIndexFilterFunction func(ctx context.Context, write *StorageOpWrite) (bool, error)
+
+	RuntimeStorageExpireFunction func(ctx context.Context, objects []*api.StorageObject) error
+
 	RuntimeEventFunction func(ctx context.Context, logger runtime.Logger, evt *api.Event)
 
 	RuntimeEventCustomFunction       func(ctx context.Context, evt *api.Event)
@@ -235,6 +242,11 @@ const (
 	RuntimeExecutionModeTournamentEnd
 	RuntimeExecutionModeTournamentReset
 	RuntimeExecutionModeLeaderboardReset
+	RuntimeExecutionModeStorageIndexFilter
+	RuntimeExecutionModeStorageExpire
+	RuntimeExecutionModeTournamentStart
+	RuntimeExecutionModeTournamentJoin
+	RuntimeExecutionModeMatchmakerOverride
 )
 
 func (e RuntimeExecutionMode) String() string {
@@ -261,6 +273,16 @@ func (e RuntimeExecutionMode) String() string {
 		return "tournament_reset"
 	case RuntimeExecutionModeLeaderboardReset:
 		return "leaderboard_reset"
+	case RuntimeExecutionModeStorageIndexFilter:
+		return "storage_index_filter"
+	case RuntimeExecutionModeStorageExpire:
+		return "storage_expire"
+	case RuntimeExecutionModeTournamentStart:
+		return "tournament_start"
+	case RuntimeExecutionModeTournamentJoin:
+		return "tournament_join"
+	case RuntimeExecutionModeMatchmakerOverride:
+		return "matchmaker_override"
 	}
 
 	return ""
@@ -472,13 +494,20 @@ type Runtime struct {
 	beforeReqFunctions *RuntimeBeforeReqFunctions
 	afterReqFunctions  *RuntimeAfterReqFunctions
 
-	matchmakerMatchedFunction RuntimeMatchmakerMatchedFunction
+	matchmakerMatchedFunction  RuntimeMatchmakerMatchedFunction
+	matchmakerOverrideFunction RuntimeMatchmakerOverrideFunction
 
+	tournamentStartFunction RuntimeTournamentStartFunction
+	tournamentJoinFunction  RuntimeTournamentJoinFunction
 	tournamentEndFunction   RuntimeTournamentEndFunction
 	tournamentResetFunction RuntimeTournamentResetFunction
 
 	leaderboardResetFunction RuntimeLeaderboardResetFunction
 
+	storageIndexFilterFunctions map[string]RuntimeStorageIndexFilterFunction
+
+	storageExpireFunction RuntimeStorageExpireFunction
+
 	eventFunctions *RuntimeEventFunctions
 
 	consoleInfo *RuntimeInfo
@@ -582,7 +611,7 @@ func CheckRuntime(logger *zap.Logger, config Config) error {
 	return nil
 }
 
-func NewRuntime(ctx context.Context, logger, startupLogger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, leaderboardRankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter) (*Runtime, *RuntimeInfo, error) {
+func NewRuntime(ctx context.Context, logger, startupLogger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, leaderboardRankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, storageIndex StorageIndex, storageFeed StorageFeed) (*Runtime, *RuntimeInfo, error) {
 	runtimeConfig := config.GetRuntime()
 	startupLogger.Info("Initialising runtime", zap.String("path", runtimeConfig.Path))
 
@@ -597,19 +626,19 @@ func NewRuntime(ctx context.Context, logger, startupLogger *zap.Logger, db *sql.
 
 	matchProvider := NewMatchProvider()
 
-	goModules, goRPCFunctions, goBeforeRtFunctions, goAfterRtFunctions, goBeforeReqFunctions, goAfterReqFunctions, goMatchmakerMatchedFunction, goTournamentEndFunction, goTournamentResetFunction, goLeaderboardResetFunction, allEventFunctions, goMatchNamesListFn, err := NewRuntimeProviderGo(ctx, logger, startupLogger, db, protojsonMarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, runtimeConfig.Path, paths, eventQueue, matchProvider)
+	goModules, goRPCFunctions, goBeforeRtFunctions, goAfterRtFunctions, goBeforeReqFunctions, goAfterReqFunctions, goMatchmakerMatchedFunction, goTournamentStartFunction, goTournamentJoinFunction, goTournamentEndFunction, goTournamentResetFunction, goLeaderboardResetFunction, goStorageIndexFilterFunctions, goStorageExpireFunction, goMatchmakerOverrideFunction, allEventFunctions, goMatchNamesListFn, err := NewRuntimeProviderGo(ctx, logger, startupLogger, db, protojsonMarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, storageIndex, storageFeed, runtimeConfig.Path, paths, eventQueue, matchProvider)
 	if err != nil {
 		startupLogger.Error("Error initialising Go runtime provider", zap.Error(err))
 		return nil, nil, err
 	}
 
-	luaModules, luaRPCFunctions, luaBeforeRtFunctions, luaAfterRtFunctions, luaBeforeReqFunctions, luaAfterReqFunctions, luaMatchmakerMatchedFunction, luaTournamentEndFunction, luaTournamentResetFunction, luaLeaderboardResetFunction, err := NewRuntimeProviderLua(logger, startupLogger, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, allEventFunctions.eventFunction, runtimeConfig.Path, paths, matchProvider)
+	luaModules, luaRPCFunctions, luaBeforeRtFunctions, luaAfterRtFunctions, luaBeforeReqFunctions, luaAfterReqFunctions, luaMatchmakerMatchedFunction, luaTournamentStartFunction, luaTournamentJoinFunction, luaTournamentEndFunction, luaTournamentResetFunction, luaLeaderboardResetFunction, luaStorageIndexFilterFunctions, luaStorageExpireFunction, luaMatchmakerOverrideFunction, err := NewRuntimeProviderLua(logger, startupLogger, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, storageIndex, storageFeed, allEventFunctions.eventFunction, runtimeConfig.Path, paths, matchProvider)
 	if err != nil {
 		startupLogger.Error("Error initialising Lua runtime provider", zap.Error(err))
 		return nil, nil, err
 	}
 
-	jsModules, jsRPCFunctions, jsBeforeRtFunctions, jsAfterRtFunctions, jsBeforeReqFunctions, jsAfterReqFunctions, jsMatchmakerMatchedFunction, jsTournamentEndFunction, jsTournamentResetFunction, jsLeaderboardResetFunction, err := NewRuntimeProviderJS(logger, startupLogger, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, allEventFunctions.eventFunction, runtimeConfig.Path, runtimeConfig.JsEntrypoint, matchProvider)
+	jsModules, jsRPCFunctions, jsBeforeRtFunctions, jsAfterRtFunctions, jsBeforeReqFunctions, jsAfterReqFunctions, jsMatchmakerMatchedFunction, jsTournamentStartFunction, jsTournamentJoinFunction, jsTournamentEndFunction, jsTournamentResetFunction, jsLeaderboardResetFunction, jsStorageIndexFilterFunctions, jsStorageExpireFunction, jsMatchmakerOverrideFunction, err := NewRuntimeProviderJS(logger, startupLogger, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, storageIndex, storageFeed, allEventFunctions.eventFunction, runtimeConfig.Path, runtimeConfig.JsEntrypoint, matchProvider)
 	if err != nil {
 		startupLogger.Error("Error initialising JavaScript runtime provider", zap.Error(err))
 		return nil, nil, err
@@ -638,6 +667,15 @@ func NewRuntime(ctx context.Context, logger, startupLogger *zap.Logger, db *sql.
 		startupLogger.Info("Registered event function invocation", zap.String("id", "session_end"))
 	}
 
+	for _, rpcFunctions := range []map[string]RuntimeRpcFunction{jsRPCFunctions, luaRPCFunctions, goRPCFunctions} {
+		for id := range rpcFunctions {
+			if _, found := pipelineBuiltinRpcIDs[id]; found {
+				// Modules written before the built-in socket RPC existed keep working, their functions take precedence.
+				startupLogger.Warn("RPC function shadows a built-in socket RPC with the same ID", zap.String("id", id))
+			}
+		}
+	}
+
 	allRPCFunctions := make(map[string]RuntimeRpcFunction, len(goRPCFunctions)+len(luaRPCFunctions)+len(jsRPCFunctions))
 	jsRpcIDs := make(map[string]bool, len(jsRPCFunctions))
 	for id, fn := range jsRPCFunctions {
@@ -2379,6 +2417,45 @@ func NewRuntime(ctx context.Context, logger, startupLogger *zap.Logger, db *sql.
 		startupLogger.Info("Registered JavaScript runtime Matchmaker Matched function invocation")
 	}
 
+	var allMatchmakerOverrideFunction RuntimeMatchmakerOverrideFunction
+	switch {
+	case goMatchmakerOverrideFunction != nil:
+		allMatchmakerOverrideFunction = goMatchmakerOverrideFunction
+		startupLogger.Info("Registered Go runtime Matchmaker Override function invocation")
+	case luaMatchmakerOverrideFunction != nil:
+		allMatchmakerOverrideFunction = luaMatchmakerOverrideFunction
+		startupLogger.Info("Registered Lua runtime Matchmaker Override function invocation")
+	case jsMatchmakerOverrideFunction != nil:
+		allMatchmakerOverrideFunction = jsMatchmakerOverrideFunction
+		startupLogger.Info("Registered JavaScript runtime Matchmaker Override function invocation")
+	}
+
+	var allTournamentStartFunction RuntimeTournamentStartFunction
+	switch {
+	case goTournamentStartFunction != nil:
+		allTournamentStartFunction = goTournamentStartFunction
+		startupLogger.Info("Registered Go runtime Tournament Start function invocation")
+	case luaTournamentStartFunction != nil:
+		allTournamentStartFunction = luaTournamentStartFunction
+		startupLogger.Info("Registered Lua runtime Tournament Start function invocation")
+	case jsTournamentStartFunction != nil:
+		allTournamentStartFunction = jsTournamentStartFunction
+		startupLogger.Info("Registered JavaScript runtime Tournament Start function invocation")
+	}
+
+	var allTournamentJoinFunction RuntimeTournamentJoinFunction
+	switch {
+	case goTournamentJoinFunction != nil:
+		allTournamentJoinFunction = goTournamentJoinFunction
+		startupLogger.Info("Registered Go runtime Tournament Join function invocation")
+	case luaTournamentJoinFunction != nil:
+		allTournamentJoinFunction = luaTournamentJoinFunction
+		startupLogger.Info("Registered Lua runtime Tournament Join function invocation")
+	case jsTournamentJoinFunction != nil:
+		allTournamentJoinFunction = jsTournamentJoinFunction
+		startupLogger.Info("Registered JavaScript runtime Tournament Join function invocation")
+	}
+
 	var allTournamentEndFunction RuntimeTournamentEndFunction
 	switch {
 	case goTournamentEndFunction != nil:
@@ -2418,6 +2495,33 @@ func NewRuntime(ctx context.Context, logger, startupLogger *zap.Logger, db *sql.
 		startupLogger.Info("Registered JavaScript runtime Leaderboard Reset function invocation")
 	}
 
+	allStorageIndexFilterFunctions := make(map[string]RuntimeStorageIndexFilterFunction, len(jsStorageIndexFilterFunctions)+len(luaStorageIndexFilterFunctions)+len(goStorageIndexFilterFunctions))
+	for id, fn := range jsStorageIndexFilterFunctions {
+		allStorageIndexFilterFunctions[id] = fn
+		startupLogger.Info("Registered JavaScript runtime Storage Index Filter function invocation", zap.String("index", id))
+	}
+	for id, fn := range luaStorageIndexFilterFunctions {
+		allStorageIndexFilterFunctions[id] = fn
+		startupLogger.Info("Registered Lua runtime Storage Index Filter function invocation", zap.String("index", id))
+	}
+	for id, fn := range goStorageIndexFilterFunctions {
+		allStorageIndexFilterFunctions[id] = fn
+		startupLogger.Info("Registered Go runtime Storage Index Filter function invocation", zap.String("index", id))
+	}
+
+	var allStorageExpireFunction RuntimeStorageExpireFunction
+	switch {
+	case goStorageExpireFunction != nil:
+		allStorageExpireFunction = goStorageExpireFunction
+		startupLogger.Info("Registered Go runtime Storage Expire function invocation")
+	case luaStorageExpireFunction != nil:
+		allStorageExpireFunction = luaStorageExpireFunction
+		startupLogger.Info("Registered Lua runtime Storage Expire function invocation")
+	case jsStorageExpireFunction != nil:
+		allStorageExpireFunction = jsStorageExpireFunction
+		startupLogger.Info("Registered JavaScript runtime Storage Expire function invocation")
+	}
+
 	// Lua matches are not registered the same, list only Go ones.
 	goMatchNames := goMatchNamesListFn()
 	for _, name := range goMatchNames {
@@ -2431,17 +2535,22 @@ func NewRuntime(ctx context.Context, logger, startupLogger *zap.Logger, db *sql.
 	}
 
 	return &Runtime{
-		matchCreateFunction:       matchProvider.CreateMatch,
-		rpcFunctions:              allRPCFunctions,
-		beforeRtFunctions:         allBeforeRtFunctions,
-		afterRtFunctions:          allAfterRtFunctions,
-		beforeReqFunctions:        allBeforeReqFunctions,
-		afterReqFunctions:         allAfterReqFunctions,
-		matchmakerMatchedFunction: allMatchmakerMatchedFunction,
-		tournamentEndFunction:     allTournamentEndFunction,
-		tournamentResetFunction:   allTournamentResetFunction,
-		leaderboardResetFunction:  allLeaderboardResetFunction,
-		eventFunctions:            allEventFunctions,
+		matchCreateFunction:         matchProvider.CreateMatch,
+		rpcFunctions:                allRPCFunctions,
+		beforeRtFunctions:           allBeforeRtFunctions,
+		afterRtFunctions:            allAfterRtFunctions,
+		beforeReqFunctions:          allBeforeReqFunctions,
+		afterReqFunctions:           allAfterReqFunctions,
+		matchmakerMatchedFunction:   allMatchmakerMatchedFunction,
+		matchmakerOverrideFunction:  allMatchmakerOverrideFunction,
+		tournamentStartFunction:     allTournamentStartFunction,
+		tournamentJoinFunction:      allTournamentJoinFunction,
+		tournamentEndFunction:       allTournamentEndFunction,
+		tournamentResetFunction:     allTournamentResetFunction,
+		leaderboardResetFunction:    allLeaderboardResetFunction,
+		storageIndexFilterFunctions: allStorageIndexFilterFunctions,
+		storageExpireFunction:       allStorageExpireFunction,
+		eventFunctions:              allEventFunctions,
 	}, rInfo, nil
 }
 
@@ -3139,6 +3248,18 @@ func (r *Runtime) MatchmakerMatched() RuntimeMatchmakerMatchedFunction {
 	return r.matchmakerMatchedFunction
 }
 
+func (r *Runtime) MatchmakerOverride() RuntimeMatchmakerOverrideFunction {
+	return r.matchmakerOverrideFunction
+}
+
+func (r *Runtime) TournamentStart() RuntimeTournamentStartFunction {
+	return r.tournamentStartFunction
+}
+
+func (r *Runtime) TournamentJoin() RuntimeTournamentJoinFunction {
+	return r.tournamentJoinFunction
+}
+
 func (r *Runtime) TournamentEnd() RuntimeTournamentEndFunction {
 	return r.tournamentEndFunction
 }
@@ -3151,6 +3272,14 @@ func (r *Runtime) LeaderboardReset() RuntimeLeaderboardResetFunction {
 	return r.leaderboardResetFunction
 }
 
+func (r *Runtime) StorageIndexFilterFunctions() map[string]RuntimeStorageIndexFilterFunction {
+	return r.storageIndexFilterFunctions
+}
+
+func (r *Runtime) StorageExpire() RuntimeStorageExpireFunction {
+	return r.storageExpireFunction
+}
+
 func (r *Runtime) Event() RuntimeEventCustomFunction {
 	return r.eventFunctions.eventFunction
 }
@@ -37,6 +37,17 @@ import (
 
 // No need for a stateful RuntimeProviderGo here.
 
+// RuntimeGoInitializer implements runtime.Initializer for Go modules. Register functions added after the nakama-common
+// version the server is built with, such as RegisterTournamentStart, RegisterTournamentJoin, RegisterMatchmakerOverride,
+// RegisterStorageIndex, RegisterStorageIndexFilter and RegisterStorageExpire, are not part of that interface, and Go
+// modules call them through an interface assertion declaring the functions they use:
+//
+//	tournaments, ok := initializer.(interface {
+//		RegisterTournamentStart(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tournament *api.Tournament, start, end int64) error) error
+//	})
+//	if !ok {
+//		return errors.New("tournament start hooks are not supported by this server")
+//	}
 type RuntimeGoInitializer struct {
 	logger runtime.Logger
 	db     *sql.DB
@@ -44,15 +55,22 @@ type RuntimeGoInitializer struct {
 	env    map[string]string
 	nk     runtime.NakamaModule
 
-	rpc               map[string]RuntimeRpcFunction
-	beforeRt          map[string]RuntimeBeforeRtFunction
-	afterRt           map[string]RuntimeAfterRtFunction
-	beforeReq         *RuntimeBeforeReqFunctions
-	afterReq          *RuntimeAfterReqFunctions
-	matchmakerMatched RuntimeMatchmakerMatchedFunction
-	tournamentEnd     RuntimeTournamentEndFunction
-	tournamentReset   RuntimeTournamentResetFunction
-	leaderboardReset  RuntimeLeaderboardResetFunction
+	rpc                map[string]RuntimeRpcFunction
+	beforeRt           map[string]RuntimeBeforeRtFunction
+	afterRt            map[string]RuntimeAfterRtFunction
+	beforeReq          *RuntimeBeforeReqFunctions
+	afterReq           *RuntimeAfterReqFunctions
+	matchmakerMatched  RuntimeMatchmakerMatchedFunction
+	matchmakerOverride RuntimeMatchmakerOverrideFunction
+	tournamentStart    RuntimeTournamentStartFunction
+	tournamentJoin     RuntimeTournamentJoinFunction
+	tournamentEnd      RuntimeTournamentEndFunction
+	tournamentReset    RuntimeTournamentResetFunction
+	leaderboardReset   RuntimeLeaderboardResetFunction
+
+	storageIndex       StorageIndex
+	storageIndexFilter map[string]RuntimeStorageIndexFilterFunction
+	storageExpire      RuntimeStorageExpireFunction
 
 	eventFunctions        []RuntimeEventFunction
 	sessionStartFunctions []RuntimeEventFunction
@@ -2416,6 +2434,51 @@ func (ri *RuntimeGoInitializer) RegisterMatchmakerMatched(fn func(ctx context.Co
 	return nil
 }
 
+func (ri *RuntimeGoInitializer) RegisterMatchmakerOverride(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, candidateMatches [][]runtime.MatchmakerEntry) [][]runtime.MatchmakerEntry) error {
+	ri.matchmakerOverride = func(ctx context.Context, candidateMatches [][]*MatchmakerEntry) ([][]*MatchmakerEntry, error) {
+		ctx = NewRuntimeGoContext(ctx, ri.node, ri.env, RuntimeExecutionModeMatchmakerOverride, nil, nil, 0, "", "", nil, "", "", "", "")
+		runtimeCandidates := make([][]runtime.MatchmakerEntry, len(candidateMatches))
+		for i, candidate := range candidateMatches {
+			runtimeEntries := make([]runtime.MatchmakerEntry, len(candidate))
+			for j, entry := range candidate {
+				runtimeEntries[j] = runtime.MatchmakerEntry(entry)
+			}
+			runtimeCandidates[i] = runtimeEntries
+		}
+		runtimeMatches := fn(ctx, ri.logger.WithField("mode", RuntimeExecutionModeMatchmakerOverride.String()), ri.db, ri.nk, runtimeCandidates)
+		matches := make([][]*MatchmakerEntry, 0, len(runtimeMatches))
+		for _, runtimeEntries := range runtimeMatches {
+			entries := make([]*MatchmakerEntry, 0, len(runtimeEntries))
+			for _, runtimeEntry := range runtimeEntries {
+				entry, ok := runtimeEntry.(*MatchmakerEntry)
+				if !ok {
+					return nil, ErrMatchmakerOverrideEntryNotFound
+				}
+				entries = append(entries, entry)
+			}
+			matches = append(matches, entries)
+		}
+		return matches, nil
+	}
+	return nil
+}
+
+func (ri *RuntimeGoInitializer) RegisterTournamentStart(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tournament *api.Tournament, start, end int64) error) error {
+	ri.tournamentStart = func(ctx context.Context, tournament *api.Tournament, start, end int64) error {
+		ctx = NewRuntimeGoContext(ctx, ri.node, ri.env, RuntimeExecutionModeTournamentStart, nil, nil, 0, "", "", nil, "", "", "", "")
+		return fn(ctx, ri.logger.WithField("mode", RuntimeExecutionModeTournamentStart.String()), ri.db, ri.nk, tournament, start, end)
+	}
+	return nil
+}
+
+func (ri *RuntimeGoInitializer) RegisterTournamentJoin(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tournament *api.Tournament, userID, username string) error) error {
+	ri.tournamentJoin = func(ctx context.Context, tournament *api.Tournament, userID, username string) error {
+		ctx = NewRuntimeGoContext(ctx, ri.node, ri.env, RuntimeExecutionModeTournamentJoin, nil, nil, 0, userID, username, nil, "", "", "", "")
+		return fn(ctx, ri.logger.WithField("mode", RuntimeExecutionModeTournamentJoin.String()), ri.db, ri.nk, tournament, userID, username)
+	}
+	return nil
+}
+
 func (ri *RuntimeGoInitializer) RegisterTournamentEnd(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tournament *api.Tournament, end, reset int64) error) error {
 	ri.tournamentEnd = func(ctx context.Context, tournament *api.Tournament, end, reset int64) error {
 		ctx = NewRuntimeGoContext(ctx, ri.node, ri.env, RuntimeExecutionModeTournamentEnd, nil, nil, 0, "", "", nil, "", "", "", "")
@@ -2440,6 +2503,35 @@ func (ri *RuntimeGoInitializer) RegisterLeaderboardReset(fn func(ctx context.Con
 	return nil
 }
 
+func (ri *RuntimeGoInitializer) RegisterStorageIndex(name, collection, key string, fields []string, maxEntries int) error {
+	return ri.storageIndex.CreateIndex(context.Background(), name, collection, key, fields, maxEntries)
+}
+
+func (ri *RuntimeGoInitializer) RegisterStorageIndexFilter(indexName string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, write *runtime.StorageWrite) bool) error {
+	ri.storageIndexFilter[indexName] = func(ctx context.Context, write *StorageOpWrite) (bool, error) {
+		ctx = NewRuntimeGoContext(ctx, ri.node, ri.env, RuntimeExecutionModeStorageIndexFilter, nil, nil, 0, "", "", nil, "", "", "", "")
+		sw := &runtime.StorageWrite{
+			Collection:      write.Object.Collection,
+			Key:             write.Object.Key,
+			UserID:          write.OwnerID,
+			Value:           write.Object.Value,
+			Version:         write.Object.Version,
+			PermissionRead:  int(write.Object.GetPermissionRead().GetValue()),
+			PermissionWrite: int(write.Object.GetPermissionWrite().GetValue()),
+		}
+		return fn(ctx, ri.logger.WithField("mode", RuntimeExecutionModeStorageIndexFilter.String()), ri.db, ri.nk, sw), nil
+	}
+	return nil
+}
+
+func (ri *RuntimeGoInitializer) RegisterStorageExpire(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, objects []*api.StorageObject) error) error {
+	ri.storageExpire = func(ctx context.Context, objects []*api.StorageObject) error {
+		ctx = NewRuntimeGoContext(ctx, ri.node, ri.env, RuntimeExecutionModeStorageExpire, nil, nil, 0, "", "", nil, "", "", "", "")
+		return fn(ctx, ri.logger.WithField("mode", RuntimeExecutionModeStorageExpire.String()), ri.db, ri.nk, objects)
+	}
+	return nil
+}
+
 func (ri *RuntimeGoInitializer) RegisterMatch(name string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) (runtime.Match, error)) error {
 	ri.matchLock.Lock()
 	ri.match[name] = fn
@@ -2447,11 +2539,11 @@ func (ri *RuntimeGoInitializer) RegisterMatch(name string, fn func(ctx context.C
 	return nil
 }
 
-func NewRuntimeProviderGo(ctx context.Context, logger, startupLogger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, leaderboardRankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, rootPath string, paths []string, eventQueue *RuntimeEventQueue, matchProvider *MatchProvider) ([]string, map[string]RuntimeRpcFunction, map[string]RuntimeBeforeRtFunction, map[string]RuntimeAfterRtFunction, *RuntimeBeforeReqFunctions, *RuntimeAfterReqFunctions, RuntimeMatchmakerMatchedFunction, RuntimeTournamentEndFunction, RuntimeTournamentResetFunction, RuntimeLeaderboardResetFunction, *RuntimeEventFunctions, func() []string, error) {
+func NewRuntimeProviderGo(ctx context.Context, logger, startupLogger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, leaderboardRankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, storageIndex StorageIndex, storageFeed StorageFeed, rootPath string, paths []string, eventQueue *RuntimeEventQueue, matchProvider *MatchProvider) ([]string, map[string]RuntimeRpcFunction, map[string]RuntimeBeforeRtFunction, map[string]RuntimeAfterRtFunction, *RuntimeBeforeReqFunctions, *RuntimeAfterReqFunctions, RuntimeMatchmakerMatchedFunction, RuntimeTournamentStartFunction, RuntimeTournamentJoinFunction, RuntimeTournamentEndFunction, RuntimeTournamentResetFunction, RuntimeLeaderboardResetFunction, map[string]RuntimeStorageIndexFilterFunction, RuntimeStorageExpireFunction, RuntimeMatchmakerOverrideFunction, *RuntimeEventFunctions, func() []string, error) {
 	runtimeLogger := NewRuntimeGoLogger(logger)
 	node := config.GetName()
 	env := config.GetRuntime().Environment
-	nk := NewRuntimeGoNakamaModule(logger, db, protojsonMarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router)
+	nk := NewRuntimeGoNakamaModule(logger, db, protojsonMarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, storageIndex, storageFeed)
 
 	match := make(map[string]func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) (runtime.Match, error), 0)
 
@@ -2502,6 +2594,9 @@ func NewRuntimeProviderGo(ctx context.Context, logger, startupLogger *zap.Logger
 		beforeReq: &RuntimeBeforeReqFunctions{},
 		afterReq:  &RuntimeAfterReqFunctions{},
 
+		storageIndex:       storageIndex,
+		storageIndexFilter: make(map[string]RuntimeStorageIndexFilterFunction, 0),
+
 		eventFunctions:        make([]RuntimeEventFunction, 0),
 		sessionStartFunctions: make([]RuntimeEventFunction, 0),
 		sessionEndFunctions:   make([]RuntimeEventFunction, 0),
@@ -2526,13 +2621,13 @@ func NewRuntimeProviderGo(ctx context.Context, logger, startupLogger *zap.Logger
 		relPath, name, fn, err := openGoModule(startupLogger, rootPath, path)
 		if err != nil {
 			// Errors are already logged in the function above.
-			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 
 		// Run the initialisation.
 		if err = fn(ctx, runtimeLogger, db, nk, initializer); err != nil {
 			startupLogger.Fatal("Error returned by InitModule function in Go module", zap.String("name", name), zap.Error(err))
-			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, errors.New("error returned by InitModule function in Go module")
+			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, errors.New("error returned by InitModule function in Go module")
 		}
 		modulePaths = append(modulePaths, relPath)
 	}
@@ -2580,7 +2675,7 @@ func NewRuntimeProviderGo(ctx context.Context, logger, startupLogger *zap.Logger
 		}
 	}
 
-	return modulePaths, initializer.rpc, initializer.beforeRt, initializer.afterRt, initializer.beforeReq, initializer.afterReq, initializer.matchmakerMatched, initializer.tournamentEnd, initializer.tournamentReset, initializer.leaderboardReset, events, matchNamesListFn, nil
+	return modulePaths, initializer.rpc, initializer.beforeRt, initializer.afterRt, initializer.beforeReq, initializer.afterReq, initializer.matchmakerMatched, initializer.tournamentStart, initializer.tournamentJoin, initializer.tournamentEnd, initializer.tournamentReset, initializer.leaderboardReset, initializer.storageIndexFilter, initializer.storageExpire, initializer.matchmakerOverride, events, matchNamesListFn, nil
 }
 
 func CheckRuntimeProviderGo(logger *zap.Logger, rootPath string, paths []string) error {
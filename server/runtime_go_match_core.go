@@ -381,6 +381,20 @@ func (r *RuntimeGoMatchCore) MatchKick(presences []runtime.Presence) error {
 	return nil
 }
 
+// MatchmakerBackfill requests matchmaker tickets for count open slots in the match, selected with the query. Matched
+// users receive the match ID, and each delivered ticket reduces the open slots. A count of 0 cancels the request.
+// Tickets must also accept the match: their query is checked against the current match label fields, and their count
+// constraints against the match size once all open slots are filled.
+func (r *RuntimeGoMatchCore) MatchmakerBackfill(query string, count int) error {
+	if r.stopped.Load() {
+		return ErrMatchStopped
+	}
+	if err := r.matchRegistry.Backfill(r.id, query, count); err != nil {
+		return fmt.Errorf("error updating matchmaker backfill: %v", err.Error())
+	}
+	return nil
+}
+
 func (r *RuntimeGoMatchCore) MatchLabelUpdate(label string) error {
 	if r.stopped.Load() {
 		return ErrMatchStopped
@@ -40,6 +40,17 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+// RuntimeGoNakamaModule implements runtime.NakamaModule for Go modules. Functions added after the nakama-common
+// version the server is built with are not part of that interface, and only use nakama-common and standard library
+// types in their signatures, so Go modules can call them through an interface assertion declaring the functions they
+// use:
+//
+//	periods, ok := nk.(interface {
+//		LeaderboardPeriodsList(ctx context.Context, id string, limit, topCount int, cursor string) ([]int64, [][]*api.LeaderboardRecord, string, error)
+//	})
+//	if !ok {
+//		return errors.New("leaderboard periods are not supported by this server")
+//	}
 type RuntimeGoNakamaModule struct {
 	sync.RWMutex
 	logger               *zap.Logger
@@ -58,6 +69,8 @@ type RuntimeGoNakamaModule struct {
 	metrics              Metrics
 	streamManager        StreamManager
 	router               MessageRouter
+	storageIndex         StorageIndex
+	storageFeed          StorageFeed
 
 	eventFn RuntimeEventCustomFunction
 
@@ -66,7 +79,7 @@ type RuntimeGoNakamaModule struct {
 	matchCreateFn RuntimeMatchCreateFunction
 }
 
-func NewRuntimeGoNakamaModule(logger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, leaderboardRankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter) *RuntimeGoNakamaModule {
+func NewRuntimeGoNakamaModule(logger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, leaderboardRankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, storageIndex StorageIndex, storageFeed StorageFeed) *RuntimeGoNakamaModule {
 	return &RuntimeGoNakamaModule{
 		logger:               logger,
 		db:                   db,
@@ -84,6 +97,8 @@ func NewRuntimeGoNakamaModule(logger *zap.Logger, db *sql.DB, protojsonMarshaler
 		metrics:              metrics,
 		streamManager:        streamManager,
 		router:               router,
+		storageIndex:         storageIndex,
+		storageFeed:          storageFeed,
 
 		node: config.GetName(),
 	}
@@ -675,7 +690,7 @@ func (n *RuntimeGoNakamaModule) UsersUnbanId(ctx context.Context, userIDs []stri
 		ids = append(ids, id)
 	}
 
-	return UnbanUsers(ctx, n.logger, n.db, n.sessionCache, ids)
+	return UnbanUsers(ctx, n.logger, n.db, ids)
 }
 
 // @group authenticate
@@ -1541,6 +1556,81 @@ func (n *RuntimeGoNakamaModule) MatchSignal(ctx context.Context, id string, data
 	return n.matchRegistry.Signal(ctx, id, data)
 }
 
+// @group ratings
+// @summary Get the skill ratings of users in a rating queue. Users that have not played a rated match in the queue have the default rating. Not part of runtime.NakamaModule, Go modules call it through an interface assertion on their module.
+// @param ctx(type=context.Context) The context object represents information about the server and requester.
+// @param queue(type=string) The rating queue.
+// @param userIDs(type=[]string) The IDs of the users.
+// @return ratings([]map[string]interface{}) The ratings of the users with their userId, queue, rating, deviation, volatility, games and updateTime, in the same order as the user IDs.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeGoNakamaModule) RatingsGet(ctx context.Context, queue string, userIDs []string) ([]map[string]interface{}, error) {
+	if queue == "" {
+		return nil, errors.New("expects a queue string")
+	}
+
+	uids := make([]uuid.UUID, 0, len(userIDs))
+	for _, id := range userIDs {
+		uid, err := uuid.FromString(id)
+		if err != nil {
+			return nil, errors.New("expects user IDs to be valid UUIDs")
+		}
+		uids = append(uids, uid)
+	}
+
+	ratings, err := RatingsGet(ctx, n.logger, n.db, queue, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	return runtimeRatings(ratings), nil
+}
+
+// @group ratings
+// @summary Submit the result of a rated match, and update the skill ratings of all users who played it. Not part of runtime.NakamaModule, Go modules call it through an interface assertion on their module.
+// @param ctx(type=context.Context) The context object represents information about the server and requester.
+// @param queue(type=string) The rating queue.
+// @param teams(type=[][]string) The user IDs of each team that played the match.
+// @param placements(type=[]int) The placement of each team. Teams with a lower placement beat teams with a higher placement, and equal placements tie.
+// @return ratings([]map[string]interface{}) The new ratings of the users with their userId, queue, rating, deviation, volatility, games and updateTime, in the order they appear in the teams.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeGoNakamaModule) RatingsSubmitMatch(ctx context.Context, queue string, teams [][]string, placements []int) ([]map[string]interface{}, error) {
+	if queue == "" {
+		return nil, errors.New("expects a queue string")
+	}
+
+	if len(placements) != len(teams) {
+		return nil, errors.New("expects a placement for each team")
+	}
+
+	matchTeams := make([]*RatingMatchTeam, 0, len(teams))
+	for i, userIDs := range teams {
+		matchTeams = append(matchTeams, &RatingMatchTeam{UserIDs: userIDs, Placement: placements[i]})
+	}
+
+	ratings, err := RatingsSubmitMatch(ctx, n.logger, n.db, queue, matchTeams)
+	if err != nil {
+		return nil, err
+	}
+
+	return runtimeRatings(ratings), nil
+}
+
+func runtimeRatings(ratings []*Rating) []map[string]interface{} {
+	results := make([]map[string]interface{}, 0, len(ratings))
+	for _, rating := range ratings {
+		results = append(results, map[string]interface{}{
+			"userId":     rating.UserID,
+			"queue":      rating.Queue,
+			"rating":     rating.Rating,
+			"deviation":  rating.Deviation,
+			"volatility": rating.Volatility,
+			"games":      rating.Games,
+			"updateTime": rating.UpdateTime,
+		})
+	}
+	return results
+}
+
 // @group notifications
 // @summary Send one in-app notification to a user.
 // @param ctx(type=context.Context) The context object represents information about the server and requester.
@@ -1933,6 +2023,30 @@ func (n *RuntimeGoNakamaModule) StorageRead(ctx context.Context, reads []*runtim
 	return objects.Objects, nil
 }
 
+// Check the optional per write expiry times and patches of StorageWriteWithOptions and MultiUpdateWithOptions, which
+// must be nil or hold one entry for each write.
+func runtimeStorageWriteOptions(writes int, expireTimes []int64, patches []string) error {
+	if expireTimes != nil && len(expireTimes) != writes {
+		return errors.New("expects expire times to be nil or one for each write")
+	}
+	if patches != nil && len(patches) != writes {
+		return errors.New("expects patches to be nil or one for each write")
+	}
+	return nil
+}
+
+func runtimeStorageWriteOption(i int, expireTimes []int64, patches []string) (int64, string) {
+	var expireTime int64
+	if expireTimes != nil {
+		expireTime = expireTimes[i]
+	}
+	var patch string
+	if patches != nil {
+		patch = patches[i]
+	}
+	return expireTime, patch
+}
+
 // @group storage
 // @summary Write one or more objects by their collection/keyname and optional user.
 // @param ctx(type=context.Context) The context object represents information about the server and requester.
@@ -1940,14 +2054,29 @@ func (n *RuntimeGoNakamaModule) StorageRead(ctx context.Context, reads []*runtim
 // @return acks([]*api.StorageObjectAcks) A list of acks with the version of the written objects.
 // @return error(error) An optional error value if an error occurred.
 func (n *RuntimeGoNakamaModule) StorageWrite(ctx context.Context, writes []*runtime.StorageWrite) ([]*api.StorageObjectAck, error) {
+	return n.StorageWriteWithOptions(ctx, writes, nil, nil)
+}
+
+// @group storage
+// @summary Write one or more objects by their collection/keyname and optional user, with an optional expiry time or patch for each. Not part of runtime.NakamaModule, Go modules call it through an interface assertion on their module.
+// @param ctx(type=context.Context) The context object represents information about the server and requester.
+// @param objectIds(type=[]*runtime.StorageWrite) An array of object identifiers to be written.
+// @param expireTimes(type=[]int64) Unix time in seconds after which each object expires, or 0 to never expire. Nil for no expiry, or one entry for each write.
+// @param patches(type=[]string) Apply each value to the stored value as a "merge" (RFC 7386) or "json" (RFC 6902) patch, or replace it if empty. Nil to replace all values, or one entry for each write.
+// @return acks([]*api.StorageObjectAcks) A list of acks with the version of the written objects.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeGoNakamaModule) StorageWriteWithOptions(ctx context.Context, writes []*runtime.StorageWrite, expireTimes []int64, patches []string) ([]*api.StorageObjectAck, error) {
 	size := len(writes)
 	if size == 0 {
 		return make([]*api.StorageObjectAck, 0), nil
 	}
+	if err := runtimeStorageWriteOptions(size, expireTimes, patches); err != nil {
+		return nil, err
+	}
 
 	ops := make(StorageOpWrites, 0, size)
 
-	for _, write := range writes {
+	for i, write := range writes {
 		if write.Collection == "" {
 			return nil, errors.New("expects collection to be a non-empty string")
 		}
@@ -1959,8 +2088,16 @@ func (n *RuntimeGoNakamaModule) StorageWrite(ctx context.Context, writes []*runt
 				return nil, errors.New("expects an empty or valid user id")
 			}
 		}
-		if maybeJSON := []byte(write.Value); !json.Valid(maybeJSON) || bytes.TrimSpace(maybeJSON)[0] != byteBracket {
-			return nil, errors.New("value must be a JSON-encoded object")
+		expireTime, patchName := runtimeStorageWriteOption(i, expireTimes, patches)
+		patch, err := ParseStoragePatch(patchName)
+		if err != nil {
+			return nil, errors.New("expects patch to be empty, merge or json")
+		}
+		if !StoragePatchValueValid(patch, write.Value) {
+			return nil, errors.New("value must be a JSON-encoded object, or a JSON-encoded array for json patches")
+		}
+		if expireTime < 0 {
+			return nil, errors.New("expects expire time to be 0 or a unix timestamp")
 		}
 
 		op := &StorageOpWrite{
@@ -1972,6 +2109,8 @@ func (n *RuntimeGoNakamaModule) StorageWrite(ctx context.Context, writes []*runt
 				PermissionRead:  &wrapperspb.Int32Value{Value: int32(write.PermissionRead)},
 				PermissionWrite: &wrapperspb.Int32Value{Value: int32(write.PermissionWrite)},
 			},
+			ExpireTime: expireTime,
+			Patch:      patch,
 		}
 		if write.UserID == "" {
 			op.OwnerID = uuid.Nil.String()
@@ -1982,7 +2121,7 @@ func (n *RuntimeGoNakamaModule) StorageWrite(ctx context.Context, writes []*runt
 		ops = append(ops, op)
 	}
 
-	acks, _, err := StorageWriteObjects(ctx, n.logger, n.db, n.metrics, true, ops)
+	acks, _, err := StorageWriteObjects(ctx, n.logger, n.db, n.metrics, n.storageIndex, n.storageFeed, true, ops)
 	if err != nil {
 		return nil, err
 	}
@@ -2032,11 +2171,41 @@ func (n *RuntimeGoNakamaModule) StorageDelete(ctx context.Context, deletes []*ru
 		ops = append(ops, op)
 	}
 
-	_, err := StorageDeleteObjects(ctx, n.logger, n.db, true, ops)
+	_, err := StorageDeleteObjects(ctx, n.logger, n.db, n.storageIndex, n.storageFeed, true, ops)
 
 	return err
 }
 
+// @group storage
+// @summary List storage objects from a storage index, using a query over the indexed fields of their values. Not part of runtime.NakamaModule, Go modules call it through an interface assertion on their module.
+// @param ctx(type=context.Context) The context object represents information about the server and requester.
+// @param callerID(type=string) User ID of the caller, will apply permissions checks of the user. If empty defaults to system user and permission checks are bypassed.
+// @param indexName(type=string) Name of the index to list entries from.
+// @param query(type=string) Query to filter index entries, using the same syntax as match listing queries against fields prefixed with "value.".
+// @param limit(type=int) Maximum number of results to be returned.
+// @return objects(*api.StorageObjects) A list of storage objects.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeGoNakamaModule) StorageIndexList(ctx context.Context, callerID, indexName, query string, limit int) (*api.StorageObjects, error) {
+	cid := uuid.Nil
+	if callerID != "" {
+		id, err := uuid.FromString(callerID)
+		if err != nil {
+			return nil, errors.New("expects caller id to be empty or a valid user id")
+		}
+		cid = id
+	}
+
+	if indexName == "" {
+		return nil, errors.New("expects a non-empty indexName")
+	}
+
+	if limit < 1 || limit > 10000 {
+		return nil, errors.New("limit must be 1-10000")
+	}
+
+	return n.storageIndex.List(ctx, cid, indexName, query, limit)
+}
+
 // @group users
 // @summary Update account, storage, and wallet information simultaneously.
 // @param ctx(type=context.Context) The context object represents information about the server and requester.
@@ -2048,6 +2217,22 @@ func (n *RuntimeGoNakamaModule) StorageDelete(ctx context.Context, deletes []*ru
 // @return walletUpdateOps(*runtime.WalletUpdateResult) A list of wallet updates results.
 // @return error(error) An optional error value if an error occurred.
 func (n *RuntimeGoNakamaModule) MultiUpdate(ctx context.Context, accountUpdates []*runtime.AccountUpdate, storageWrites []*runtime.StorageWrite, walletUpdates []*runtime.WalletUpdate, updateLedger bool) ([]*api.StorageObjectAck, []*runtime.WalletUpdateResult, error) {
+	return n.MultiUpdateWithOptions(ctx, accountUpdates, storageWrites, nil, nil, walletUpdates, updateLedger)
+}
+
+// @group users
+// @summary Update account, storage, and wallet information simultaneously, with an optional expiry time or patch for each storage write. Not part of runtime.NakamaModule, Go modules call it through an interface assertion on their module.
+// @param ctx(type=context.Context) The context object represents information about the server and requester.
+// @param accountUpdates(type=[]*runtime.AccountUpdate) Array of account information to be updated.
+// @param storageWrites(type=[]*runtime.StorageWrite) Array of storage objects to be updated.
+// @param storageExpireTimes(type=[]int64) Unix time in seconds after which each storage object expires, or 0 to never expire. Nil for no expiry, or one entry for each storage write.
+// @param storagePatches(type=[]string) Apply each storage value as a "merge" (RFC 7386) or "json" (RFC 6902) patch, or replace it if empty. Nil to replace all values, or one entry for each storage write.
+// @param walletUpdates(type=[]*runtime.WalletUpdate) Array of wallet updates to be made.
+// @param updateLedger(type=bool, optional=true, default=false) Whether to record this wallet update in the ledger.
+// @return storageWriteOps([]*api.StorageObjectAck) A list of acks with the version of the written objects.
+// @return walletUpdateOps(*runtime.WalletUpdateResult) A list of wallet updates results.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeGoNakamaModule) MultiUpdateWithOptions(ctx context.Context, accountUpdates []*runtime.AccountUpdate, storageWrites []*runtime.StorageWrite, storageExpireTimes []int64, storagePatches []string, walletUpdates []*runtime.WalletUpdate, updateLedger bool) ([]*api.StorageObjectAck, []*runtime.WalletUpdateResult, error) {
 	// Process account update inputs.
 	accountUpdateOps := make([]*accountUpdate, 0, len(accountUpdates))
 	for _, update := range accountUpdates {
@@ -2099,8 +2284,11 @@ func (n *RuntimeGoNakamaModule) MultiUpdate(ctx context.Context, accountUpdates
 	}
 
 	// Process storage write inputs.
+	if err := runtimeStorageWriteOptions(len(storageWrites), storageExpireTimes, storagePatches); err != nil {
+		return nil, nil, err
+	}
 	storageWriteOps := make(StorageOpWrites, 0, len(storageWrites))
-	for _, write := range storageWrites {
+	for i, write := range storageWrites {
 		if write.Collection == "" {
 			return nil, nil, errors.New("expects collection to be a non-empty string")
 		}
@@ -2112,8 +2300,16 @@ func (n *RuntimeGoNakamaModule) MultiUpdate(ctx context.Context, accountUpdates
 				return nil, nil, errors.New("expects an empty or valid user id")
 			}
 		}
-		if maybeJSON := []byte(write.Value); !json.Valid(maybeJSON) || bytes.TrimSpace(maybeJSON)[0] != byteBracket {
-			return nil, nil, errors.New("value must be a JSON-encoded object")
+		expireTime, patchName := runtimeStorageWriteOption(i, storageExpireTimes, storagePatches)
+		patch, err := ParseStoragePatch(patchName)
+		if err != nil {
+			return nil, nil, errors.New("expects patch to be empty, merge or json")
+		}
+		if !StoragePatchValueValid(patch, write.Value) {
+			return nil, nil, errors.New("value must be a JSON-encoded object, or a JSON-encoded array for json patches")
+		}
+		if expireTime < 0 {
+			return nil, nil, errors.New("expects expire time to be 0 or a unix timestamp")
 		}
 
 		op := &StorageOpWrite{
@@ -2125,6 +2321,8 @@ func (n *RuntimeGoNakamaModule) MultiUpdate(ctx context.Context, accountUpdates
 				PermissionRead:  &wrapperspb.Int32Value{Value: int32(write.PermissionRead)},
 				PermissionWrite: &wrapperspb.Int32Value{Value: int32(write.PermissionWrite)},
 			},
+			ExpireTime: expireTime,
+			Patch:      patch,
 		}
 		if write.UserID == "" {
 			op.OwnerID = uuid.Nil.String()
@@ -2158,7 +2356,7 @@ func (n *RuntimeGoNakamaModule) MultiUpdate(ctx context.Context, accountUpdates
 		}
 	}
 
-	return MultiUpdate(ctx, n.logger, n.db, n.metrics, accountUpdateOps, storageWriteOps, walletUpdateOps, updateLedger)
+	return MultiUpdate(ctx, n.logger, n.db, n.metrics, n.storageIndex, n.storageFeed, accountUpdateOps, storageWriteOps, walletUpdateOps, updateLedger)
 }
 
 // @group leaderboards
@@ -2166,7 +2364,7 @@ func (n *RuntimeGoNakamaModule) MultiUpdate(ctx context.Context, accountUpdates
 // @param ctx(type=context.Context) The context object represents information about the server and requester.
 // @param leaderboardID(type=string) The unique identifier for the new leaderboard. This is used by clients to submit scores.
 // @param authoritative(type=bool, default=false) Mark the leaderboard as authoritative which ensures updates can only be made via the Go runtime. No client can submit a score directly.
-// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the leaderboard. Possible values are "asc" or "desc".
+// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the leaderboard. Possible values are "asc" or "desc", optionally followed by a comma and the subscore sort order, then a comma and "time" to break ties by earliest submission. For example "desc,asc,time".
 // @param operator(type=string, optional=true, default="best") The operator that determines how scores behave when submitted. Possible values are "best", "set", or "incr".
 // @param resetSchedule(type=string, optional=true) The cron format used to define the reset schedule for the leaderboard. This controls when a leaderboard is reset and can be used to power daily/weekly/monthly leaderboards.
 // @param metadata(type=map[string]interface{}, optional=true) The metadata you want associated to the leaderboard. Some good examples are weather conditions for a racing game.
@@ -2176,14 +2374,9 @@ func (n *RuntimeGoNakamaModule) LeaderboardCreate(ctx context.Context, id string
 		return errors.New("expects a leaderboard ID string")
 	}
 
-	sort := LeaderboardSortOrderDescending
-	switch sortOrder {
-	case "desc":
-		sort = LeaderboardSortOrderDescending
-	case "asc":
-		sort = LeaderboardSortOrderAscending
-	default:
-		return errors.New("expects sort order to be 'asc' or 'desc'")
+	sort, err := ParseLeaderboardSort(sortOrder)
+	if err != nil {
+		return errors.New("expects sort order to be 'asc' or 'desc', optionally followed by a subscore sort order and 'time'")
 	}
 
 	oper := LeaderboardOperatorBest
@@ -2215,7 +2408,7 @@ func (n *RuntimeGoNakamaModule) LeaderboardCreate(ctx context.Context, id string
 		metadataStr = string(metadataBytes)
 	}
 
-	_, err := n.leaderboardCache.Create(ctx, id, authoritative, sort, oper, resetSchedule, metadataStr)
+	_, err = n.leaderboardCache.Create(ctx, id, authoritative, sort, oper, resetSchedule, metadataStr)
 	if err != nil {
 		return err
 	}
@@ -2307,7 +2500,7 @@ func (n *RuntimeGoNakamaModule) LeaderboardRecordsList(ctx context.Context, id s
 		return nil, nil, "", "", errors.New("expects expiry to equal or greater than 0")
 	}
 
-	list, err := LeaderboardRecordsList(ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardRankCache, id, limitWrapper, cursor, ownerIDs, expiry)
+	list, err := LeaderboardRecordsList(ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardRankCache, id, limitWrapper, cursor, ownerIDs, nil, expiry)
 	if err != nil {
 		return nil, nil, "", "", err
 	}
@@ -2361,7 +2554,7 @@ func (n *RuntimeGoNakamaModule) LeaderboardRecordWrite(ctx context.Context, id,
 		operator = api.Operator(*overrideOperator)
 	}
 
-	return LeaderboardRecordWrite(ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardRankCache, uuid.Nil, id, ownerID, username, score, subscore, metadataStr, operator)
+	return LeaderboardRecordWrite(ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardRankCache, n.metrics, uuid.Nil, id, ownerID, username, score, subscore, metadataStr, operator)
 }
 
 // @group leaderboards
@@ -2413,6 +2606,94 @@ func (n *RuntimeGoNakamaModule) LeaderboardRecordsHaystack(ctx context.Context,
 	return LeaderboardRecordsHaystack(ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardRankCache, id, cursor, owner, limit, expiry)
 }
 
+// @group leaderboards
+// @summary List past periods of a leaderboard with a reset schedule, most recent first, with their final top records. Not part of runtime.NakamaModule, Go modules call it through an interface assertion on their module.
+// @param ctx(type=context.Context) The context object represents information about the server and requester.
+// @param id(type=string) The ID of the leaderboard to list periods for.
+// @param limit(type=int) Return only the required number of periods denoted by this limit value. Between 1-100.
+// @param topCount(type=int) The number of top records to return for each period. Between 0-100.
+// @param cursor(type=string, optional=true, default="") Pagination cursor from previous result. Don't set to start fetching from the beginning.
+// @return expiryTimes([]int64) The expiry time of each period, identifying its records in LeaderboardRecordsList.
+// @return records([][]*api.LeaderboardRecord) The top records of each period, in the same order as the expiry times.
+// @return nextCursor(string) An optional next page cursor that can be used to retrieve the next page of periods (if any).
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeGoNakamaModule) LeaderboardPeriodsList(ctx context.Context, id string, limit, topCount int, cursor string) ([]int64, [][]*api.LeaderboardRecord, string, error) {
+	if id == "" {
+		return nil, nil, "", errors.New("expects a leaderboard ID string")
+	}
+
+	if limit < 1 || limit > 100 {
+		return nil, nil, "", errors.New("limit must be 1-100")
+	}
+
+	if topCount < 0 || topCount > 100 {
+		return nil, nil, "", errors.New("top count must be 0-100")
+	}
+
+	periods, nextCursor, err := LeaderboardPeriodsList(ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardRankCache, id, limit, topCount, cursor)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	expiryTimes := make([]int64, 0, len(periods))
+	records := make([][]*api.LeaderboardRecord, 0, len(periods))
+	for _, period := range periods {
+		expiryTimes = append(expiryTimes, period.ExpiryTime)
+		records = append(records, period.Records)
+	}
+
+	return expiryTimes, records, nextCursor, nil
+}
+
+// @group leaderboards
+// @summary Find the expiry time of a past period of a leaderboard with a reset schedule, to list its records. Not part of runtime.NakamaModule, Go modules call it through an interface assertion on their module.
+// @param ctx(type=context.Context) The context object represents information about the server and requester.
+// @param id(type=string) The ID of the leaderboard.
+// @param periodsAgo(type=int) The number of periods before the current one, 1 being the period that ended most recently.
+// @return expiry(int64) The expiry time of the period, in seconds since epoch.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeGoNakamaModule) LeaderboardPeriodExpiry(ctx context.Context, id string, periodsAgo int) (int64, error) {
+	if id == "" {
+		return 0, errors.New("expects a leaderboard ID string")
+	}
+
+	leaderboard := n.leaderboardCache.Get(id)
+	if leaderboard == nil {
+		return 0, ErrLeaderboardNotFound
+	}
+
+	return LeaderboardPeriodExpiry(leaderboard, periodsAgo, time.Now())
+}
+
+// @group leaderboards
+// @summary Set the limits on scores clients may submit to a leaderboard or tournament. Scores written by the server are not validated. Not part of runtime.NakamaModule, Go modules call it through an interface assertion on their module.
+// @param ctx(type=context.Context) The context object represents information about the server and requester.
+// @param id(type=string) The ID of the leaderboard or tournament.
+// @param minScore(type=*int64) The lowest score a client may submit, or nil for no limit.
+// @param maxScore(type=*int64) The highest score a client may submit, or nil for no limit.
+// @param maxScoreDelta(type=int64) The largest change to the owner's score a single submission may make, or 0 for no limit.
+// @param maxSubmissions(type=int) The largest number of submissions each owner may make in each submission window, or 0 for no limit.
+// @param submissionWindowSec(type=int) The length of the submission window in seconds. Required with maxSubmissions.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeGoNakamaModule) LeaderboardValidationSet(ctx context.Context, id string, minScore, maxScore *int64, maxScoreDelta int64, maxSubmissions, submissionWindowSec int) error {
+	if id == "" {
+		return errors.New("expects a leaderboard ID string")
+	}
+
+	var validation *LeaderboardValidation
+	if minScore != nil || maxScore != nil || maxScoreDelta != 0 || maxSubmissions != 0 || submissionWindowSec != 0 {
+		validation = &LeaderboardValidation{
+			MinScore:            minScore,
+			MaxScore:            maxScore,
+			MaxScoreDelta:       maxScoreDelta,
+			MaxSubmissions:      maxSubmissions,
+			SubmissionWindowSec: submissionWindowSec,
+		}
+	}
+
+	return LeaderboardValidationSet(ctx, n.leaderboardCache, id, validation)
+}
+
 // @group leaderboards
 // @summary Fetch one or more leaderboards by ID.
 // @param ids(type=[]string) The table array of leaderboard ids.
@@ -2427,7 +2708,7 @@ func (n *RuntimeGoNakamaModule) LeaderboardsGetId(ctx context.Context, IDs []str
 // @param ctx(type=context.Context) The context object represents information about the server and requester.
 // @param id(type=string) The unique identifier for the new tournament. This is used by clients to submit scores.
 // @param authoritative(type=bool, optional=true, default=true) Whether the tournament created is server authoritative.
-// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the tournament. Possible values are "asc" or "desc".
+// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the tournament. Possible values are "asc" or "desc", optionally followed by a comma and the subscore sort order, then a comma and "time" to break ties by earliest submission. For example "desc,asc,time".
 // @param operator(type=string, optional=true, default="best") The operator that determines how scores behave when submitted. The possible values are "best", "set", or "incr".
 // @param resetSchedule(type=string, optional=true) The cron format used to define the reset schedule for the tournament. This controls when the underlying leaderboard resets and the tournament is considered active again.
 // @param metadata(type=map[string]interface{}, optional=true) The metadata you want associated to the tournament. Some good examples are weather conditions for a racing game.
@@ -2442,18 +2723,37 @@ func (n *RuntimeGoNakamaModule) LeaderboardsGetId(ctx context.Context, IDs []str
 // @param joinRequired(type=bool, optional=true, default=false) Whether the tournament needs to be joined before a record write is allowed.
 // @return error(error) An optional error value if an error occurred.
 func (n *RuntimeGoNakamaModule) TournamentCreate(ctx context.Context, id string, authoritative bool, sortOrder, operator, resetSchedule string, metadata map[string]interface{}, title, description string, category, startTime, endTime, duration, maxSize, maxNumScore int, joinRequired bool) error {
+	return n.TournamentCreateBucketed(ctx, id, authoritative, sortOrder, operator, resetSchedule, metadata, title, description, category, startTime, endTime, duration, maxSize, maxNumScore, joinRequired, 0)
+}
+
+// @group tournaments
+// @summary Setup a new dynamic tournament like TournamentCreate, ranking participants in buckets of up to bucketSize owners assigned when they join. Not part of runtime.NakamaModule, Go modules call it through an interface assertion on their module.
+// @param ctx(type=context.Context) The context object represents information about the server and requester.
+// @param id(type=string) The unique identifier for the new tournament. This is used by clients to submit scores.
+// @param authoritative(type=bool, optional=true, default=true) Whether the tournament created is server authoritative.
+// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the tournament. Possible values are "asc" or "desc", optionally followed by a comma and the subscore sort order, then a comma and "time" to break ties by earliest submission. For example "desc,asc,time".
+// @param operator(type=string, optional=true, default="best") The operator that determines how scores behave when submitted. The possible values are "best", "set", or "incr".
+// @param resetSchedule(type=string, optional=true) The cron format used to define the reset schedule for the tournament. This controls when the underlying leaderboard resets and the tournament is considered active again.
+// @param metadata(type=map[string]interface{}, optional=true) The metadata you want associated to the tournament. Some good examples are weather conditions for a racing game.
+// @param title(type=string, optional=true) The title of the tournament.
+// @param description(type=string, optional=true) The description of the tournament.
+// @param category(type=int, optional=true) A category associated with the tournament. This can be used to filter different types of tournaments. Between 0 and 127.
+// @param startTime(type=int, optional=true) The start time of the tournament. Leave empty for immediately or a future time.
+// @param endTime(type=int, optional=true, default=never) The end time of the tournament. When the end time is elapsed, the tournament will not reset and will cease to exist. Must be greater than startTime if set.
+// @param duration(type=int) The active duration for a tournament. This is the duration when clients are able to submit new records. The duration starts from either the reset period or tournament start time, whichever is sooner. A game client can query the tournament for results between end of duration and next reset period.
+// @param maxSize(type=int, optional=true) Maximum size of participants in a tournament.
+// @param maxNumScore(type=int) Maximum submission attempts for a tournament record.
+// @param joinRequired(type=bool, optional=true, default=false) Whether the tournament needs to be joined before a record write is allowed.
+// @param bucketSize(type=int) Rank participants in buckets of up to this many owners assigned when they join, rather than all together. Requires joinRequired. 0 to disable.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeGoNakamaModule) TournamentCreateBucketed(ctx context.Context, id string, authoritative bool, sortOrder, operator, resetSchedule string, metadata map[string]interface{}, title, description string, category, startTime, endTime, duration, maxSize, maxNumScore int, joinRequired bool, bucketSize int) error {
 	if id == "" {
 		return errors.New("expects a tournament ID string")
 	}
 
-	sort := LeaderboardSortOrderDescending
-	switch sortOrder {
-	case "desc":
-		sort = LeaderboardSortOrderDescending
-	case "asc":
-		sort = LeaderboardSortOrderAscending
-	default:
-		return errors.New("expects sort order to be 'asc' or 'desc'")
+	sort, err := ParseLeaderboardSort(sortOrder)
+	if err != nil {
+		return errors.New("expects sort order to be 'asc' or 'desc', optionally followed by a subscore sort order and 'time'")
 	}
 
 	oper := LeaderboardOperatorBest
@@ -2506,8 +2806,14 @@ func (n *RuntimeGoNakamaModule) TournamentCreate(ctx context.Context, id string,
 	if maxNumScore < 0 {
 		return errors.New("maxNumScore must be >= 0")
 	}
+	if bucketSize < 0 {
+		return errors.New("bucketSize must be >= 0")
+	}
+	if bucketSize > 0 && !joinRequired {
+		return errors.New("bucketSize requires joinRequired")
+	}
 
-	return TournamentCreate(ctx, n.logger, n.leaderboardCache, n.leaderboardScheduler, id, authoritative, sort, oper, resetSchedule, metadataStr, title, description, category, startTime, endTime, duration, maxSize, maxNumScore, joinRequired)
+	return TournamentCreate(ctx, n.logger, n.leaderboardCache, n.leaderboardScheduler, id, authoritative, sort, oper, resetSchedule, metadataStr, title, description, category, startTime, endTime, duration, maxSize, maxNumScore, joinRequired, bucketSize)
 }
 
 // @group tournaments
@@ -2570,7 +2876,52 @@ func (n *RuntimeGoNakamaModule) TournamentJoin(ctx context.Context, id, ownerID,
 		return errors.New("expects a username string")
 	}
 
-	return TournamentJoin(ctx, n.logger, n.db, n.leaderboardCache, ownerID, username, id)
+	return TournamentJoin(ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardScheduler, ownerID, username, id, nil, n.config.GetLeaderboard().BucketRatingRange)
+}
+
+// @group tournaments
+// @summary Join a tournament with a rating, used by bucketed tournaments to place the owner in a bucket with owners of a similar rating. This operation is idempotent and will always succeed for the owner even if they have already joined the tournament. Not part of runtime.NakamaModule, Go modules call it through an interface assertion on their module.
+// @param ctx(type=context.Context) The context object represents information about the server and requester.
+// @param id(type=string) The unique identifier for the tournament to join.
+// @param ownerId(type=string) The owner of the record.
+// @param username(type=string) The username of the record owner.
+// @param rating(type=int64) The owner's rating, owners whose ratings fall in the same band of the configured bucket rating range share buckets.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeGoNakamaModule) TournamentJoinWithRating(ctx context.Context, id, ownerID, username string, rating int64) error {
+	if id == "" {
+		return errors.New("expects a tournament ID string")
+	}
+
+	if ownerID == "" {
+		return errors.New("expects a owner ID string")
+	} else if _, err := uuid.FromString(ownerID); err != nil {
+		return errors.New("expects owner ID to be a valid identifier")
+	}
+
+	if username == "" {
+		return errors.New("expects a username string")
+	}
+
+	return TournamentJoin(ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardScheduler, ownerID, username, id, &rating, n.config.GetLeaderboard().BucketRatingRange)
+}
+
+// @group tournaments
+// @summary Set the rewards paid out to owners when each active period of a tournament ends. Each reward covers a range of ranks, ranks within the bucket for bucketed tournaments, and may apply a wallet changeset and send a notification to each owner in the range. Not part of runtime.NakamaModule, Go modules call it through an interface assertion on their module.
+// @param ctx(type=context.Context) The context object represents information about the server and requester.
+// @param id(type=string) The unique identifier for the tournament.
+// @param rewards(type=string) A JSON-encoded array of rewards, each with "rank_min", "rank_max", and an optional "changeset", "metadata" and "notification" with "subject", "content", "code" and "persistent". Rank ranges must not overlap. The rewards replace any existing rewards, and an empty array removes all rewards.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeGoNakamaModule) TournamentRewardsSet(ctx context.Context, id string, rewards string) error {
+	if id == "" {
+		return errors.New("expects a tournament ID string")
+	}
+
+	var rewardsList []*TournamentReward
+	if err := json.Unmarshal([]byte(rewards), &rewardsList); err != nil {
+		return errors.New("expects rewards to be a JSON-encoded array of rewards")
+	}
+
+	return TournamentRewardsSet(ctx, n.logger, n.db, n.leaderboardCache, id, rewardsList)
 }
 
 // @group tournaments
@@ -2667,7 +3018,7 @@ func (n *RuntimeGoNakamaModule) TournamentRecordsList(ctx context.Context, tourn
 		return nil, nil, "", "", errors.New("expects expiry to equal or greater than 0")
 	}
 
-	records, err := TournamentRecordsList(ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardRankCache, tournamentId, ownerIDs, limitWrapper, cursor, overrideExpiry)
+	records, err := TournamentRecordsList(ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardRankCache, tournamentId, ownerIDs, nil, limitWrapper, cursor, overrideExpiry)
 	if err != nil {
 		return nil, nil, "", "", err
 	}
@@ -2715,7 +3066,7 @@ func (n *RuntimeGoNakamaModule) TournamentRecordWrite(ctx context.Context, id, o
 		operator = api.Operator(*overrideOperator)
 	}
 
-	return TournamentRecordWrite(ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardRankCache, uuid.Nil, id, owner, username, score, subscore, metadataStr, operator)
+	return TournamentRecordWrite(ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardRankCache, n.metrics, uuid.Nil, id, owner, username, score, subscore, metadataStr, operator)
 }
 
 // @group tournaments
@@ -3871,7 +4222,7 @@ func (n *RuntimeGoNakamaModule) ChannelMessagesList(ctx context.Context, channel
 		return nil, "", "", errors.New("limit must be 1-100")
 	}
 
-	list, err := ChannelMessagesList(ctx, n.logger, n.db, uuid.Nil, channelIdToStreamResult.Stream, channelId, limit, forward, cursor)
+	list, err := ChannelMessagesList(ctx, n.logger, n.db, uuid.Nil, channelIdToStreamResult.Stream, channelId, uuid.Nil, limit, forward, cursor)
 	if err != nil {
 		return nil, "", "", err
 	}
@@ -0,0 +1,80 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// Go modules reach functions that are not part of the nakama-common interfaces with interface assertions, declared
+// with nakama-common and standard library types only, as a module built outside the server would.
+func TestGoInitializerInterfaceAssertion(t *testing.T) {
+	var initializer runtime.Initializer = &RuntimeGoInitializer{}
+
+	_, ok := initializer.(interface {
+		RegisterTournamentStart(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tournament *api.Tournament, start, end int64) error) error
+		RegisterTournamentJoin(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tournament *api.Tournament, userID, username string) error) error
+	})
+	assert.True(t, ok, "tournament hooks")
+
+	_, ok = initializer.(interface {
+		RegisterMatchmakerOverride(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, candidateMatches [][]runtime.MatchmakerEntry) [][]runtime.MatchmakerEntry) error
+	})
+	assert.True(t, ok, "matchmaker override")
+
+	_, ok = initializer.(interface {
+		RegisterStorageIndex(name, collection, key string, fields []string, maxEntries int) error
+		RegisterStorageIndexFilter(indexName string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, write *runtime.StorageWrite) bool) error
+		RegisterStorageExpire(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, objects []*api.StorageObject) error) error
+	})
+	assert.True(t, ok, "storage hooks")
+}
+
+func TestGoNakamaModuleInterfaceAssertion(t *testing.T) {
+	var nk runtime.NakamaModule = &RuntimeGoNakamaModule{}
+
+	_, ok := nk.(interface {
+		StorageWriteWithOptions(ctx context.Context, writes []*runtime.StorageWrite, expireTimes []int64, patches []string) ([]*api.StorageObjectAck, error)
+		StorageIndexList(ctx context.Context, callerID, indexName, query string, limit int) (*api.StorageObjects, error)
+		MultiUpdateWithOptions(ctx context.Context, accountUpdates []*runtime.AccountUpdate, storageWrites []*runtime.StorageWrite, storageExpireTimes []int64, storagePatches []string, walletUpdates []*runtime.WalletUpdate, updateLedger bool) ([]*api.StorageObjectAck, []*runtime.WalletUpdateResult, error)
+	})
+	assert.True(t, ok, "storage")
+
+	_, ok = nk.(interface {
+		RatingsGet(ctx context.Context, queue string, userIDs []string) ([]map[string]interface{}, error)
+		RatingsSubmitMatch(ctx context.Context, queue string, teams [][]string, placements []int) ([]map[string]interface{}, error)
+	})
+	assert.True(t, ok, "ratings")
+
+	_, ok = nk.(interface {
+		LeaderboardPeriodsList(ctx context.Context, id string, limit, topCount int, cursor string) ([]int64, [][]*api.LeaderboardRecord, string, error)
+		LeaderboardPeriodExpiry(ctx context.Context, id string, periodsAgo int) (int64, error)
+		LeaderboardValidationSet(ctx context.Context, id string, minScore, maxScore *int64, maxScoreDelta int64, maxSubmissions, submissionWindowSec int) error
+	})
+	assert.True(t, ok, "leaderboards")
+
+	_, ok = nk.(interface {
+		TournamentCreateBucketed(ctx context.Context, id string, authoritative bool, sortOrder, operator, resetSchedule string, metadata map[string]interface{}, title, description string, category, startTime, endTime, duration, maxSize, maxNumScore int, joinRequired bool, bucketSize int) error
+		TournamentJoinWithRating(ctx context.Context, id, ownerID, username string, rating int64) error
+		TournamentRewardsSet(ctx context.Context, id string, rewards string) error
+	})
+	assert.True(t, ok, "tournaments")
+}
@@ -66,12 +66,22 @@ func (r *RuntimeJS) GetCallback(e RuntimeExecutionMode, key string) string {
 		return r.callbacks.After[key]
 	case RuntimeExecutionModeMatchmaker:
 		return r.callbacks.Matchmaker
+	case RuntimeExecutionModeTournamentStart:
+		return r.callbacks.TournamentStart
+	case RuntimeExecutionModeTournamentJoin:
+		return r.callbacks.TournamentJoin
 	case RuntimeExecutionModeTournamentEnd:
 		return r.callbacks.TournamentEnd
 	case RuntimeExecutionModeTournamentReset:
 		return r.callbacks.TournamentReset
 	case RuntimeExecutionModeLeaderboardReset:
 		return r.callbacks.LeaderboardReset
+	case RuntimeExecutionModeStorageIndexFilter:
+		return r.callbacks.StorageIndexFilter[key]
+	case RuntimeExecutionModeStorageExpire:
+		return r.callbacks.StorageExpire
+	case RuntimeExecutionModeMatchmakerOverride:
+		return r.callbacks.MatchmakerOverride
 	}
 
 	return ""
@@ -131,6 +141,8 @@ type RuntimeProviderJS struct {
 	tracker              Tracker
 	streamManager        StreamManager
 	router               MessageRouter
+	storageIndex         StorageIndex
+	storageFeed          StorageFeed
 	eventFn              RuntimeEventCustomFunction
 	matchCreateFn        RuntimeMatchCreateFunction
 	poolCh               chan *RuntimeJS
@@ -589,7 +601,7 @@ func (rp *RuntimeProviderJS) Put(r *RuntimeJS) {
 	}
 }
 
-func NewRuntimeProviderJS(logger, startupLogger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, leaderboardRankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, eventFn RuntimeEventCustomFunction, path, entrypoint string, matchProvider *MatchProvider) ([]string, map[string]RuntimeRpcFunction, map[string]RuntimeBeforeRtFunction, map[string]RuntimeAfterRtFunction, *RuntimeBeforeReqFunctions, *RuntimeAfterReqFunctions, RuntimeMatchmakerMatchedFunction, RuntimeTournamentEndFunction, RuntimeTournamentResetFunction, RuntimeLeaderboardResetFunction, error) {
+func NewRuntimeProviderJS(logger, startupLogger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, leaderboardRankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, storageIndex StorageIndex, storageFeed StorageFeed, eventFn RuntimeEventCustomFunction, path, entrypoint string, matchProvider *MatchProvider) ([]string, map[string]RuntimeRpcFunction, map[string]RuntimeBeforeRtFunction, map[string]RuntimeAfterRtFunction, *RuntimeBeforeReqFunctions, *RuntimeAfterReqFunctions, RuntimeMatchmakerMatchedFunction, RuntimeTournamentStartFunction, RuntimeTournamentJoinFunction, RuntimeTournamentEndFunction, RuntimeTournamentResetFunction, RuntimeLeaderboardResetFunction, map[string]RuntimeStorageIndexFilterFunction, RuntimeStorageExpireFunction, RuntimeMatchmakerOverrideFunction, error) {
 	startupLogger.Info("Initialising JavaScript runtime provider", zap.String("path", path), zap.String("entrypoint", entrypoint))
 
 	modCache, err := cacheJavascriptModules(startupLogger, path, entrypoint)
@@ -624,6 +636,8 @@ func NewRuntimeProviderJS(logger, startupLogger *zap.Logger, db *sql.DB, protojs
 		tracker:              tracker,
 		streamManager:        streamManager,
 		router:               router,
+		storageIndex:         storageIndex,
+		storageFeed:          storageFeed,
 		metrics:              metrics,
 		poolCh:               make(chan *RuntimeJS, config.GetRuntime().JsMaxCount),
 		maxCount:             uint32(config.GetRuntime().JsMaxCount),
@@ -636,9 +650,14 @@ func NewRuntimeProviderJS(logger, startupLogger *zap.Logger, db *sql.DB, protojs
 	beforeReqFunctions := &RuntimeBeforeReqFunctions{}
 	afterReqFunctions := &RuntimeAfterReqFunctions{}
 	var matchmakerMatchedFunction RuntimeMatchmakerMatchedFunction
+	var tournamentStartFunction RuntimeTournamentStartFunction
+	var tournamentJoinFunction RuntimeTournamentJoinFunction
 	var tournamentEndFunction RuntimeTournamentEndFunction
 	var tournamentResetFunction RuntimeTournamentResetFunction
 	var leaderboardResetFunction RuntimeLeaderboardResetFunction
+	storageIndexFilterFunctions := make(map[string]RuntimeStorageIndexFilterFunction, 0)
+	var storageExpireFunction RuntimeStorageExpireFunction
+	var matchmakerOverrideFunction RuntimeMatchmakerOverrideFunction
 	matchHandlers := &RuntimeJavascriptMatchHandlers{
 		mapping: make(map[string]*jsMatchHandlers, 0),
 	}
@@ -650,7 +669,7 @@ func NewRuntimeProviderJS(logger, startupLogger *zap.Logger, db *sql.DB, protojs
 				return nil, nil
 			}
 
-			return NewRuntimeJavascriptMatchCore(logger, name, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, localCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, matchProvider.CreateMatch, eventFn, id, node, stopped, mc, modCache)
+			return NewRuntimeJavascriptMatchCore(logger, name, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, localCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, storageIndex, storageFeed, matchProvider.CreateMatch, eventFn, id, node, stopped, mc, modCache)
 		})
 
 	callbacks, err := evalRuntimeModules(runtimeProviderJS, modCache, matchHandlers, matchProvider, leaderboardScheduler, localCache, func(mode RuntimeExecutionMode, id string) {
@@ -1583,6 +1602,14 @@ func NewRuntimeProviderJS(logger, startupLogger *zap.Logger, db *sql.DB, protojs
 			matchmakerMatchedFunction = func(ctx context.Context, entries []*MatchmakerEntry) (string, bool, error) {
 				return runtimeProviderJS.MatchmakerMatched(ctx, entries)
 			}
+		case RuntimeExecutionModeTournamentStart:
+			tournamentStartFunction = func(ctx context.Context, tournament *api.Tournament, start, end int64) error {
+				return runtimeProviderJS.TournamentStart(ctx, tournament, start, end)
+			}
+		case RuntimeExecutionModeTournamentJoin:
+			tournamentJoinFunction = func(ctx context.Context, tournament *api.Tournament, userID, username string) error {
+				return runtimeProviderJS.TournamentJoin(ctx, tournament, userID, username)
+			}
 		case RuntimeExecutionModeTournamentEnd:
 			tournamentEndFunction = func(ctx context.Context, tournament *api.Tournament, end, reset int64) error {
 				return runtimeProviderJS.TournamentEnd(ctx, tournament, end, reset)
@@ -1595,11 +1622,23 @@ func NewRuntimeProviderJS(logger, startupLogger *zap.Logger, db *sql.DB, protojs
 			leaderboardResetFunction = func(ctx context.Context, leaderboard *api.Leaderboard, reset int64) error {
 				return runtimeProviderJS.LeaderboardReset(ctx, leaderboard, reset)
 			}
+		case RuntimeExecutionModeStorageIndexFilter:
+			storageIndexFilterFunctions[id] = func(ctx context.Context, write *StorageOpWrite) (bool, error) {
+				return runtimeProviderJS.StorageIndexFilter(ctx, id, write)
+			}
+		case RuntimeExecutionModeStorageExpire:
+			storageExpireFunction = func(ctx context.Context, objects []*api.StorageObject) error {
+				return runtimeProviderJS.StorageExpire(ctx, objects)
+			}
+		case RuntimeExecutionModeMatchmakerOverride:
+			matchmakerOverrideFunction = func(ctx context.Context, candidateMatches [][]*MatchmakerEntry) ([][]*MatchmakerEntry, error) {
+				return runtimeProviderJS.MatchmakerOverride(ctx, candidateMatches)
+			}
 		}
 	}, false)
 	if err != nil {
 		logger.Error("Failed to eval JavaScript modules.", zap.Error(err))
-		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	runtimeProviderJS.newFn = func() *RuntimeJS {
@@ -1613,7 +1652,7 @@ func NewRuntimeProviderJS(logger, startupLogger *zap.Logger, db *sql.DB, protojs
 			logger.Fatal("Failed to initialize JavaScript runtime", zap.Error(err))
 		}
 
-		nakamaModule := NewRuntimeJavascriptNakamaModule(logger, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, localCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, eventFn, matchProvider.CreateMatch)
+		nakamaModule := NewRuntimeJavascriptNakamaModule(logger, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, localCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, storageIndex, storageFeed, eventFn, matchProvider.CreateMatch)
 		nk := runtime.ToValue(nakamaModule.Constructor(runtime))
 		nkInst, err := runtime.New(nk)
 		if err != nil {
@@ -1645,7 +1684,7 @@ func NewRuntimeProviderJS(logger, startupLogger *zap.Logger, db *sql.DB, protojs
 	}
 	startupLogger.Info("Allocated minimum JavaScript runtime pool")
 
-	return modCache.Names, rpcFunctions, beforeRtFunctions, afterRtFunctions, beforeReqFunctions, afterReqFunctions, matchmakerMatchedFunction, tournamentEndFunction, tournamentResetFunction, leaderboardResetFunction, nil
+	return modCache.Names, rpcFunctions, beforeRtFunctions, afterRtFunctions, beforeReqFunctions, afterReqFunctions, matchmakerMatchedFunction, tournamentStartFunction, tournamentJoinFunction, tournamentEndFunction, tournamentResetFunction, leaderboardResetFunction, storageIndexFilterFunctions, storageExpireFunction, matchmakerOverrideFunction, nil
 }
 
 func CheckRuntimeProviderJavascript(logger *zap.Logger, config Config) error {
@@ -1796,6 +1835,192 @@ func (rp *RuntimeProviderJS) MatchmakerMatched(ctx context.Context, entries []*M
 	return "", false, errors.New("Unexpected return type from runtime Matchmaker Matched hook, must be string, null or undefined.")
 }
 
+func (rp *RuntimeProviderJS) MatchmakerOverride(ctx context.Context, candidateMatches [][]*MatchmakerEntry) ([][]*MatchmakerEntry, error) {
+	r, err := rp.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jsFn := r.GetCallback(RuntimeExecutionModeMatchmakerOverride, "")
+	if jsFn == "" {
+		rp.Put(r)
+		return nil, errors.New("Runtime Matchmaker Override function not found.")
+	}
+
+	candidatesSlice := make([]interface{}, 0, len(candidateMatches))
+	for _, entries := range candidateMatches {
+		entriesSlice := make([]interface{}, 0, len(entries))
+		for _, e := range entries {
+			presenceObj := r.vm.NewObject()
+			presenceObj.Set("userId", e.Presence.UserId)
+			presenceObj.Set("sessionId", e.Presence.SessionId)
+			presenceObj.Set("username", e.Presence.Username)
+			presenceObj.Set("node", e.Presence.Node)
+
+			propertiesObj := r.vm.NewObject()
+			for k, v := range e.StringProperties {
+				propertiesObj.Set(k, v)
+			}
+			for k, v := range e.NumericProperties {
+				propertiesObj.Set(k, v)
+			}
+
+			entry := r.vm.NewObject()
+			entry.Set("presence", presenceObj)
+			entry.Set("properties", propertiesObj)
+			entry.Set("ticket", e.Ticket)
+
+			if e.PartyId != "" {
+				entry.Set("partyId", e.PartyId)
+			}
+
+			entriesSlice = append(entriesSlice, entry)
+		}
+		candidatesSlice = append(candidatesSlice, entriesSlice)
+	}
+
+	fn, ok := goja.AssertFunction(r.vm.Get(jsFn))
+	if !ok {
+		rp.logger.Error("JavaScript runtime function invalid.", zap.String("key", jsFn), zap.Error(err))
+		return nil, errors.New("Could not run matchmaker override hook.")
+	}
+
+	jsLogger, err := NewJsLogger(r.vm, r.logger, zap.String("mode", RuntimeExecutionModeMatchmakerOverride.String()))
+	if err != nil {
+		r.logger.Error("Could not instantiate js logger.", zap.Error(err))
+		return nil, errors.New("Could not run matchmaker override hook.")
+	}
+
+	r.SetContext(ctx)
+	retValue, err, _ := r.InvokeFunction(RuntimeExecutionModeMatchmakerOverride, "matchmakerOverride", fn, jsLogger, nil, nil, "", "", nil, 0, "", "", "", "", r.vm.ToValue(candidatesSlice))
+	r.SetContext(context.Background())
+	rp.Put(r)
+	if err != nil {
+		return nil, fmt.Errorf("Error running runtime Matchmaker Override hook: %v", err.Error())
+	}
+
+	if retValue == nil {
+		// Hook decided none of the candidates should be matched.
+		return [][]*MatchmakerEntry{}, nil
+	}
+
+	retSlice, ok := retValue.([]interface{})
+	if !ok {
+		return nil, errors.New("Unexpected return type from runtime Matchmaker Override hook, must be array, null or undefined.")
+	}
+
+	overrideKeys := make([][]matchmakerOverrideKey, 0, len(retSlice))
+	for _, match := range retSlice {
+		entriesSlice, ok := match.([]interface{})
+		if !ok {
+			return nil, errors.New("Invalid return value from runtime Matchmaker Override hook, matches must be arrays of entries.")
+		}
+		keys := make([]matchmakerOverrideKey, 0, len(entriesSlice))
+		for _, e := range entriesSlice {
+			entryMap, ok := e.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("Invalid return value from runtime Matchmaker Override hook, matches must be arrays of entries.")
+			}
+			ticket, _ := entryMap["ticket"].(string)
+			var sessionID string
+			if presenceMap, ok := entryMap["presence"].(map[string]interface{}); ok {
+				sessionID, _ = presenceMap["sessionId"].(string)
+			}
+			keys = append(keys, matchmakerOverrideKey{Ticket: ticket, SessionID: sessionID})
+		}
+		overrideKeys = append(overrideKeys, keys)
+	}
+
+	return matchmakerOverrideEntries(candidateMatches, overrideKeys)
+}
+
+func (rp *RuntimeProviderJS) TournamentStart(ctx context.Context, tournament *api.Tournament, start, end int64) error {
+	r, err := rp.Get(ctx)
+	if err != nil {
+		return err
+	}
+	jsFn := r.GetCallback(RuntimeExecutionModeTournamentStart, "")
+	if jsFn == "" {
+		rp.Put(r)
+		return errors.New("Runtime Tournament Start function not found.")
+	}
+
+	tournamentObj, err := jsTournamentObject(r.vm, tournament)
+	if err != nil {
+		rp.Put(r)
+		return err
+	}
+
+	fn, ok := goja.AssertFunction(r.vm.Get(jsFn))
+	if !ok {
+		rp.logger.Error("JavaScript runtime function invalid.", zap.String("key", jsFn), zap.Error(err))
+		return errors.New("Could not run tournament start hook.")
+	}
+
+	jsLogger, err := NewJsLogger(r.vm, r.logger, zap.String("mode", RuntimeExecutionModeTournamentStart.String()))
+	if err != nil {
+		r.logger.Error("Could not instantiate js logger.", zap.Error(err))
+		return errors.New("Could not run tournament start hook.")
+	}
+
+	r.SetContext(ctx)
+	retValue, err, _ := r.InvokeFunction(RuntimeExecutionModeTournamentStart, "tournamentStart", fn, jsLogger, nil, nil, "", "", nil, 0, "", "", "", "", tournamentObj, r.vm.ToValue(start), r.vm.ToValue(end))
+	r.SetContext(context.Background())
+	rp.Put(r)
+	if err != nil {
+		return fmt.Errorf("Error running runtime Tournament Start hook: %v", err.Error())
+	}
+
+	if retValue == nil {
+		return nil
+	}
+
+	return errors.New("Unexpected return type from runtime Tournament Start hook, must be null or undefined.")
+}
+
+func (rp *RuntimeProviderJS) TournamentJoin(ctx context.Context, tournament *api.Tournament, userID, username string) error {
+	r, err := rp.Get(ctx)
+	if err != nil {
+		return err
+	}
+	jsFn := r.GetCallback(RuntimeExecutionModeTournamentJoin, "")
+	if jsFn == "" {
+		rp.Put(r)
+		return errors.New("Runtime Tournament Join function not found.")
+	}
+
+	tournamentObj, err := jsTournamentObject(r.vm, tournament)
+	if err != nil {
+		rp.Put(r)
+		return err
+	}
+
+	fn, ok := goja.AssertFunction(r.vm.Get(jsFn))
+	if !ok {
+		rp.logger.Error("JavaScript runtime function invalid.", zap.String("key", jsFn), zap.Error(err))
+		return errors.New("Could not run tournament join hook.")
+	}
+
+	jsLogger, err := NewJsLogger(r.vm, r.logger, zap.String("mode", RuntimeExecutionModeTournamentJoin.String()))
+	if err != nil {
+		r.logger.Error("Could not instantiate js logger.", zap.Error(err))
+		return errors.New("Could not run tournament join hook.")
+	}
+
+	r.SetContext(ctx)
+	retValue, err, _ := r.InvokeFunction(RuntimeExecutionModeTournamentJoin, "tournamentJoin", fn, jsLogger, nil, nil, userID, username, nil, 0, "", "", "", "", tournamentObj, r.vm.ToValue(userID), r.vm.ToValue(username))
+	r.SetContext(context.Background())
+	rp.Put(r)
+	if err != nil {
+		return fmt.Errorf("Error running runtime Tournament Join hook: %v", err.Error())
+	}
+
+	if retValue == nil {
+		return nil
+	}
+
+	return errors.New("Unexpected return type from runtime Tournament Join hook, must be null or undefined.")
+}
+
 func (rp *RuntimeProviderJS) TournamentEnd(ctx context.Context, tournament *api.Tournament, end, reset int64) error {
 	r, err := rp.Get(ctx)
 	if err != nil {
@@ -1982,15 +2207,137 @@ func (rp *RuntimeProviderJS) LeaderboardReset(ctx context.Context, leaderboard *
 	return errors.New("Unexpected return type from runtime Leaderboard Reset hook, must be nil.")
 }
 
+func (rp *RuntimeProviderJS) StorageIndexFilter(ctx context.Context, indexName string, write *StorageOpWrite) (bool, error) {
+	r, err := rp.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+	jsFn := r.GetCallback(RuntimeExecutionModeStorageIndexFilter, indexName)
+	if jsFn == "" {
+		rp.Put(r)
+		return false, errors.New("Runtime Storage Index Filter function not found.")
+	}
+
+	fn, ok := goja.AssertFunction(r.vm.Get(jsFn))
+	if !ok {
+		rp.logger.Error("JavaScript runtime function invalid.", zap.String("key", jsFn), zap.Error(err))
+		return false, errors.New("Could not run storage index filter hook.")
+	}
+
+	writeObj := r.vm.NewObject()
+	writeObj.Set("key", write.Object.Key)
+	writeObj.Set("collection", write.Object.Collection)
+	writeObj.Set("userId", write.OwnerID)
+	writeObj.Set("version", write.Object.Version)
+	writeObj.Set("permissionRead", write.Object.GetPermissionRead().GetValue())
+	writeObj.Set("permissionWrite", write.Object.GetPermissionWrite().GetValue())
+	writeObj.Set("expireTime", write.ExpireTime)
+
+	valueMap := make(map[string]interface{})
+	if err = json.Unmarshal([]byte(write.Object.Value), &valueMap); err != nil {
+		rp.Put(r)
+		return false, fmt.Errorf("failed to convert value to json: %s", err.Error())
+	}
+	pointerizeSlices(valueMap)
+	writeObj.Set("value", valueMap)
+
+	jsLogger, err := NewJsLogger(r.vm, r.logger, zap.String("mode", RuntimeExecutionModeStorageIndexFilter.String()))
+	if err != nil {
+		r.logger.Error("Could not instantiate js logger.", zap.Error(err))
+		return false, errors.New("Could not run storage index filter hook.")
+	}
+
+	r.SetContext(ctx)
+	retValue, err, _ := r.InvokeFunction(RuntimeExecutionModeStorageIndexFilter, "storageIndexFilter", fn, jsLogger, nil, nil, "", "", nil, 0, "", "", "", "", writeObj)
+	r.SetContext(context.Background())
+	rp.Put(r)
+	if err != nil {
+		return false, fmt.Errorf("Error running runtime Storage Index Filter hook: %v", err.Error())
+	}
+
+	insert, ok := retValue.(bool)
+	if !ok {
+		return false, errors.New("Unexpected return type from runtime Storage Index Filter hook, must be a boolean.")
+	}
+
+	return insert, nil
+}
+
+func (rp *RuntimeProviderJS) StorageExpire(ctx context.Context, objects []*api.StorageObject) error {
+	r, err := rp.Get(ctx)
+	if err != nil {
+		return err
+	}
+	jsFn := r.GetCallback(RuntimeExecutionModeStorageExpire, "")
+	if jsFn == "" {
+		rp.Put(r)
+		return errors.New("Runtime Storage Expire function not found.")
+	}
+
+	fn, ok := goja.AssertFunction(r.vm.Get(jsFn))
+	if !ok {
+		rp.logger.Error("JavaScript runtime function invalid.", zap.String("key", jsFn), zap.Error(err))
+		return errors.New("Could not run storage expire hook.")
+	}
+
+	objectsArr := make([]interface{}, 0, len(objects))
+	for _, o := range objects {
+		oMap := make(map[string]interface{})
+		oMap["key"] = o.Key
+		oMap["collection"] = o.Collection
+		if o.UserId != "" {
+			oMap["userId"] = o.UserId
+		} else {
+			oMap["userId"] = nil
+		}
+		oMap["version"] = o.Version
+		oMap["permissionRead"] = o.PermissionRead
+		oMap["permissionWrite"] = o.PermissionWrite
+		oMap["createTime"] = o.CreateTime.Seconds
+		oMap["updateTime"] = o.UpdateTime.Seconds
+
+		valueMap := make(map[string]interface{})
+		if err = json.Unmarshal([]byte(o.Value), &valueMap); err != nil {
+			rp.Put(r)
+			return fmt.Errorf("failed to convert value to json: %s", err.Error())
+		}
+		pointerizeSlices(valueMap)
+		oMap["value"] = valueMap
+
+		objectsArr = append(objectsArr, oMap)
+	}
+
+	jsLogger, err := NewJsLogger(r.vm, r.logger, zap.String("mode", RuntimeExecutionModeStorageExpire.String()))
+	if err != nil {
+		r.logger.Error("Could not instantiate js logger.", zap.Error(err))
+		return errors.New("Could not run storage expire hook.")
+	}
+
+	r.SetContext(ctx)
+	retValue, err, _ := r.InvokeFunction(RuntimeExecutionModeStorageExpire, "storageExpire", fn, jsLogger, nil, nil, "", "", nil, 0, "", "", "", "", r.vm.ToValue(objectsArr))
+	r.SetContext(context.Background())
+	rp.Put(r)
+	if err != nil {
+		return fmt.Errorf("Error running runtime Storage Expire hook: %v", err.Error())
+	}
+
+	if retValue == nil {
+		return nil
+	}
+
+	return errors.New("Unexpected return type from runtime Storage Expire hook, must be nil.")
+}
+
 func evalRuntimeModules(rp *RuntimeProviderJS, modCache *RuntimeJSModuleCache, matchHandlers *RuntimeJavascriptMatchHandlers, matchProvider *MatchProvider, leaderboardScheduler LeaderboardScheduler, localCache *RuntimeJavascriptLocalCache, announceCallbackFn func(RuntimeExecutionMode, string), dryRun bool) (*RuntimeJavascriptCallbacks, error) {
 	logger := rp.logger
 
 	r := goja.New()
 
 	callbacks := &RuntimeJavascriptCallbacks{
-		Rpc:    make(map[string]string),
-		Before: make(map[string]string),
-		After:  make(map[string]string),
+		Rpc:                make(map[string]string),
+		Before:             make(map[string]string),
+		After:              make(map[string]string),
+		StorageIndexFilter: make(map[string]string),
 	}
 
 	if len(modCache.Names) == 0 {
@@ -1999,7 +2346,7 @@ func evalRuntimeModules(rp *RuntimeProviderJS, modCache *RuntimeJSModuleCache, m
 	}
 	modName := modCache.Names[0]
 
-	initializer := NewRuntimeJavascriptInitModule(logger, modCache.Modules[modName].Ast, callbacks, matchHandlers, announceCallbackFn)
+	initializer := NewRuntimeJavascriptInitModule(logger, modCache.Modules[modName].Ast, rp.storageIndex, callbacks, matchHandlers, announceCallbackFn)
 	initializerValue := r.ToValue(initializer.Constructor(r))
 	initializerInst, err := r.New(initializerValue)
 	if err != nil {
@@ -2011,7 +2358,7 @@ func evalRuntimeModules(rp *RuntimeProviderJS, modCache *RuntimeJSModuleCache, m
 		return nil, err
 	}
 
-	nakamaModule := NewRuntimeJavascriptNakamaModule(rp.logger, rp.db, rp.protojsonMarshaler, rp.protojsonUnmarshaler, rp.config, rp.socialClient, rp.leaderboardCache, rp.leaderboardRankCache, localCache, leaderboardScheduler, rp.sessionRegistry, rp.sessionCache, rp.statusRegistry, rp.matchRegistry, rp.tracker, rp.metrics, rp.streamManager, rp.router, rp.eventFn, matchProvider.CreateMatch)
+	nakamaModule := NewRuntimeJavascriptNakamaModule(rp.logger, rp.db, rp.protojsonMarshaler, rp.protojsonUnmarshaler, rp.config, rp.socialClient, rp.leaderboardCache, rp.leaderboardRankCache, localCache, leaderboardScheduler, rp.sessionRegistry, rp.sessionCache, rp.statusRegistry, rp.matchRegistry, rp.tracker, rp.metrics, rp.streamManager, rp.router, rp.storageIndex, rp.storageFeed, rp.eventFn, matchProvider.CreateMatch)
 	nk := r.ToValue(nakamaModule.Constructor(r))
 	nkInst, err := r.New(nk)
 	if err != nil {
@@ -2065,3 +2412,34 @@ for (const k of Reflect.ownKeys(globalThis)) {
 }
 `)
 }
+
+func jsTournamentObject(r *goja.Runtime, tournament *api.Tournament) (*goja.Object, error) {
+	tournamentObj := r.NewObject()
+	tournamentObj.Set("id", tournament.Id)
+	tournamentObj.Set("title", tournament.Title)
+	tournamentObj.Set("description", tournament.Description)
+	tournamentObj.Set("category", tournament.Category)
+	tournamentObj.Set("sortOrder", tournament.SortOrder)
+	tournamentObj.Set("size", tournament.Size)
+	tournamentObj.Set("maxSize", tournament.MaxSize)
+	tournamentObj.Set("maxNumScore", tournament.MaxNumScore)
+	tournamentObj.Set("duration", tournament.Duration)
+	tournamentObj.Set("startActive", tournament.StartActive)
+	tournamentObj.Set("endActive", tournament.EndActive)
+	tournamentObj.Set("canEnter", tournament.CanEnter)
+	tournamentObj.Set("nextReset", tournament.NextReset)
+	metadataMap := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(tournament.Metadata), &metadataMap); err != nil {
+		return nil, fmt.Errorf("failed to convert metadata to json: %s", err.Error())
+	}
+	tournamentObj.Set("metadata", metadataMap)
+	tournamentObj.Set("createTime", tournament.CreateTime.Seconds)
+	tournamentObj.Set("startTime", tournament.StartTime.Seconds)
+	if tournament.EndTime == nil {
+		tournamentObj.Set("endTime", goja.Null())
+	} else {
+		tournamentObj.Set("endTime", tournament.EndTime.Seconds)
+	}
+
+	return tournamentObj, nil
+}
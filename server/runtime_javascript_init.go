@@ -15,6 +15,7 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -60,13 +61,18 @@ type jsMatchHandlers struct {
 }
 
 type RuntimeJavascriptCallbacks struct {
-	Rpc              map[string]string
-	Before           map[string]string
-	After            map[string]string
-	Matchmaker       string
-	TournamentEnd    string
-	TournamentReset  string
-	LeaderboardReset string
+	Rpc                map[string]string
+	Before             map[string]string
+	After              map[string]string
+	Matchmaker         string
+	TournamentStart    string
+	TournamentJoin     string
+	TournamentEnd      string
+	TournamentReset    string
+	LeaderboardReset   string
+	StorageIndexFilter map[string]string
+	StorageExpire      string
+	MatchmakerOverride string
 }
 
 type RuntimeJavascriptInitModule struct {
@@ -74,15 +80,17 @@ type RuntimeJavascriptInitModule struct {
 	Callbacks          *RuntimeJavascriptCallbacks
 	MatchCallbacks     *RuntimeJavascriptMatchHandlers
 	announceCallbackFn func(RuntimeExecutionMode, string)
+	storageIndex       StorageIndex
 	ast                *ast.Program
 }
 
-func NewRuntimeJavascriptInitModule(logger *zap.Logger, ast *ast.Program, callbacks *RuntimeJavascriptCallbacks, matchCallbacks *RuntimeJavascriptMatchHandlers, announceCallbackFn func(RuntimeExecutionMode, string)) *RuntimeJavascriptInitModule {
+func NewRuntimeJavascriptInitModule(logger *zap.Logger, ast *ast.Program, storageIndex StorageIndex, callbacks *RuntimeJavascriptCallbacks, matchCallbacks *RuntimeJavascriptMatchHandlers, announceCallbackFn func(RuntimeExecutionMode, string)) *RuntimeJavascriptInitModule {
 	return &RuntimeJavascriptInitModule{
 		Logger:             logger,
 		announceCallbackFn: announceCallbackFn,
 		Callbacks:          callbacks,
 		MatchCallbacks:     matchCallbacks,
+		storageIndex:       storageIndex,
 		ast:                ast,
 	}
 }
@@ -93,9 +101,15 @@ func (im *RuntimeJavascriptInitModule) mappings(r *goja.Runtime) map[string]func
 		"registerRtBefore":                                im.registerRtBefore(r),
 		"registerRtAfter":                                 im.registerRtAfter(r),
 		"registerMatchmakerMatched":                       im.registerMatchmakerMatched(r),
+		"registerMatchmakerOverride":                      im.registerMatchmakerOverride(r),
+		"registerTournamentStart":                         im.registerTournamentStart(r),
+		"registerTournamentJoin":                          im.registerTournamentJoin(r),
 		"registerTournamentEnd":                           im.registerTournamentEnd(r),
 		"registerTournamentReset":                         im.registerTournamentReset(r),
 		"registerLeaderboardReset":                        im.registerLeaderboardReset(r),
+		"registerStorageIndex":                            im.registerStorageIndex(r),
+		"registerStorageIndexFilter":                      im.registerStorageIndexFilter(r),
+		"registerStorageExpire":                           im.registerStorageExpire(r),
 		"registerMatch":                                   im.registerMatch(r),
 		"registerBeforeGetAccount":                        im.registerBeforeGetAccount(r),
 		"registerAfterGetAccount":                         im.registerAfterGetAccount(r),
@@ -1165,6 +1179,63 @@ func (im *RuntimeJavascriptInitModule) registerMatchmakerMatched(r *goja.Runtime
 	}
 }
 
+func (im *RuntimeJavascriptInitModule) registerMatchmakerOverride(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		fn := f.Argument(0)
+		_, ok := goja.AssertFunction(fn)
+		if !ok {
+			panic(r.NewTypeError("expects a function"))
+		}
+
+		fnKey, err := im.extractHookFn("registerMatchmakerOverride")
+		if err != nil {
+			panic(r.NewGoError(err))
+		}
+		im.registerCallbackFn(RuntimeExecutionModeMatchmakerOverride, "", fnKey)
+		im.announceCallbackFn(RuntimeExecutionModeMatchmakerOverride, "")
+
+		return goja.Undefined()
+	}
+}
+
+func (im *RuntimeJavascriptInitModule) registerTournamentStart(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		fn := f.Argument(0)
+		_, ok := goja.AssertFunction(fn)
+		if !ok {
+			panic(r.NewTypeError("expects a function"))
+		}
+
+		fnKey, err := im.extractHookFn("registerTournamentStart")
+		if err != nil {
+			panic(r.NewGoError(err))
+		}
+		im.registerCallbackFn(RuntimeExecutionModeTournamentStart, "", fnKey)
+		im.announceCallbackFn(RuntimeExecutionModeTournamentStart, "")
+
+		return goja.Undefined()
+	}
+}
+
+func (im *RuntimeJavascriptInitModule) registerTournamentJoin(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		fn := f.Argument(0)
+		_, ok := goja.AssertFunction(fn)
+		if !ok {
+			panic(r.NewTypeError("expects a function"))
+		}
+
+		fnKey, err := im.extractHookFn("registerTournamentJoin")
+		if err != nil {
+			panic(r.NewGoError(err))
+		}
+		im.registerCallbackFn(RuntimeExecutionModeTournamentJoin, "", fnKey)
+		im.announceCallbackFn(RuntimeExecutionModeTournamentJoin, "")
+
+		return goja.Undefined()
+	}
+}
+
 func (im *RuntimeJavascriptInitModule) registerTournamentEnd(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
 	return func(f goja.FunctionCall) goja.Value {
 		fn := f.Argument(0)
@@ -1222,6 +1293,84 @@ func (im *RuntimeJavascriptInitModule) registerLeaderboardReset(r *goja.Runtime)
 	}
 }
 
+func (im *RuntimeJavascriptInitModule) registerStorageIndex(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		name := getJsString(r, f.Argument(0))
+		collection := getJsString(r, f.Argument(1))
+		key := ""
+		if !goja.IsUndefined(f.Argument(2)) && !goja.IsNull(f.Argument(2)) {
+			key = getJsString(r, f.Argument(2))
+		}
+
+		fieldsIn, ok := f.Argument(3).Export().([]interface{})
+		if !ok {
+			panic(r.NewTypeError("expects an array of fields"))
+		}
+		fields := make([]string, 0, len(fieldsIn))
+		for _, field := range fieldsIn {
+			fieldStr, ok := field.(string)
+			if !ok {
+				panic(r.NewTypeError("expects each field to be a string"))
+			}
+			fields = append(fields, fieldStr)
+		}
+
+		maxEntries := int(getJsInt(r, f.Argument(4)))
+
+		// Modules are also evaluated to validate them, without a storage index to register with.
+		if im.storageIndex != nil {
+			if err := im.storageIndex.CreateIndex(context.Background(), name, collection, key, fields, maxEntries); err != nil {
+				panic(r.NewGoError(fmt.Errorf("failed to register storage index: %s", err.Error())))
+			}
+		}
+
+		return goja.Undefined()
+	}
+}
+
+func (im *RuntimeJavascriptInitModule) registerStorageIndexFilter(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		indexName := getJsString(r, f.Argument(0))
+		if indexName == "" {
+			panic(r.NewTypeError("expects a non empty string"))
+		}
+
+		fn := f.Argument(1)
+		_, ok := goja.AssertFunction(fn)
+		if !ok {
+			panic(r.NewTypeError("expects a function"))
+		}
+
+		fnKey, err := im.extractRtHookFn(r, "registerStorageIndexFilter", indexName)
+		if err != nil {
+			panic(r.NewGoError(err))
+		}
+		im.registerCallbackFn(RuntimeExecutionModeStorageIndexFilter, indexName, fnKey)
+		im.announceCallbackFn(RuntimeExecutionModeStorageIndexFilter, indexName)
+
+		return goja.Undefined()
+	}
+}
+
+func (im *RuntimeJavascriptInitModule) registerStorageExpire(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		fn := f.Argument(0)
+		_, ok := goja.AssertFunction(fn)
+		if !ok {
+			panic(r.NewTypeError("expects a function"))
+		}
+
+		fnKey, err := im.extractHookFn("registerStorageExpire")
+		if err != nil {
+			panic(r.NewGoError(err))
+		}
+		im.registerCallbackFn(RuntimeExecutionModeStorageExpire, "", fnKey)
+		im.announceCallbackFn(RuntimeExecutionModeStorageExpire, "")
+
+		return goja.Undefined()
+	}
+}
+
 func (im *RuntimeJavascriptInitModule) registerMatch(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
 	return func(f goja.FunctionCall) goja.Value {
 		name := getJsString(r, f.Argument(0))
@@ -1440,11 +1589,21 @@ func (im *RuntimeJavascriptInitModule) registerCallbackFn(mode RuntimeExecutionM
 		im.Callbacks.After[key] = fn
 	case RuntimeExecutionModeMatchmaker:
 		im.Callbacks.Matchmaker = fn
+	case RuntimeExecutionModeTournamentStart:
+		im.Callbacks.TournamentStart = fn
+	case RuntimeExecutionModeTournamentJoin:
+		im.Callbacks.TournamentJoin = fn
 	case RuntimeExecutionModeTournamentEnd:
 		im.Callbacks.TournamentEnd = fn
 	case RuntimeExecutionModeTournamentReset:
 		im.Callbacks.TournamentReset = fn
 	case RuntimeExecutionModeLeaderboardReset:
 		im.Callbacks.LeaderboardReset = fn
+	case RuntimeExecutionModeStorageIndexFilter:
+		im.Callbacks.StorageIndexFilter[key] = fn
+	case RuntimeExecutionModeStorageExpire:
+		im.Callbacks.StorageExpire = fn
+	case RuntimeExecutionModeMatchmakerOverride:
+		im.Callbacks.MatchmakerOverride = fn
 	}
 }
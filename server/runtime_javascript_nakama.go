@@ -77,13 +77,15 @@ type runtimeJavascriptNakamaModule struct {
 	matchRegistry        MatchRegistry
 	streamManager        StreamManager
 	router               MessageRouter
+	storageIndex         StorageIndex
+	storageFeed          StorageFeed
 
 	node          string
 	matchCreateFn RuntimeMatchCreateFunction
 	eventFn       RuntimeEventCustomFunction
 }
 
-func NewRuntimeJavascriptNakamaModule(logger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, localCache *RuntimeJavascriptLocalCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, eventFn RuntimeEventCustomFunction, matchCreateFn RuntimeMatchCreateFunction) *runtimeJavascriptNakamaModule {
+func NewRuntimeJavascriptNakamaModule(logger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, localCache *RuntimeJavascriptLocalCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, storageIndex StorageIndex, storageFeed StorageFeed, eventFn RuntimeEventCustomFunction, matchCreateFn RuntimeMatchCreateFunction) *runtimeJavascriptNakamaModule {
 	return &runtimeJavascriptNakamaModule{
 		ctx:                  context.Background(),
 		logger:               logger,
@@ -97,6 +99,8 @@ func NewRuntimeJavascriptNakamaModule(logger *zap.Logger, db *sql.DB, protojsonM
 		statusRegistry:       statusRegistry,
 		matchRegistry:        matchRegistry,
 		router:               router,
+		storageIndex:         storageIndex,
+		storageFeed:          storageFeed,
 		tracker:              tracker,
 		metrics:              metrics,
 		socialClient:         socialClient,
@@ -207,6 +211,8 @@ func (n *runtimeJavascriptNakamaModule) mappings(r *goja.Runtime) map[string]fun
 		"matchGet":                        n.matchGet(r),
 		"matchList":                       n.matchList(r),
 		"matchSignal":                     n.matchSignal(r),
+		"ratingsGet":                      n.ratingsGet(r),
+		"ratingsSubmitMatch":              n.ratingsSubmitMatch(r),
 		"notificationSend":                n.notificationSend(r),
 		"notificationsSend":               n.notificationsSend(r),
 		"notificationSendAll":             n.notificationSendAll(r),
@@ -219,6 +225,7 @@ func (n *runtimeJavascriptNakamaModule) mappings(r *goja.Runtime) map[string]fun
 		"storageRead":                     n.storageRead(r),
 		"storageWrite":                    n.storageWrite(r),
 		"storageDelete":                   n.storageDelete(r),
+		"storageIndexList":                n.storageIndexList(r),
 		"multiUpdate":                     n.multiUpdate(r),
 		"leaderboardCreate":               n.leaderboardCreate(r),
 		"leaderboardDelete":               n.leaderboardDelete(r),
@@ -228,6 +235,9 @@ func (n *runtimeJavascriptNakamaModule) mappings(r *goja.Runtime) map[string]fun
 		"leaderboardRecordDelete":         n.leaderboardRecordDelete(r),
 		"leaderboardsGetId":               n.leaderboardsGetId(r),
 		"leaderboardRecordsHaystack":      n.leaderboardRecordsHaystack(r),
+		"leaderboardPeriodsList":          n.leaderboardPeriodsList(r),
+		"leaderboardPeriodExpiry":         n.leaderboardPeriodExpiry(r),
+		"leaderboardValidationSet":        n.leaderboardValidationSet(r),
 		"purchaseValidateApple":           n.purchaseValidateApple(r),
 		"purchaseValidateGoogle":          n.purchaseValidateGoogle(r),
 		"purchaseValidateHuawei":          n.purchaseValidateHuawei(r),
@@ -241,6 +251,7 @@ func (n *runtimeJavascriptNakamaModule) mappings(r *goja.Runtime) map[string]fun
 		"tournamentDelete":                n.tournamentDelete(r),
 		"tournamentAddAttempt":            n.tournamentAddAttempt(r),
 		"tournamentJoin":                  n.tournamentJoin(r),
+		"tournamentRewardsSet":            n.tournamentRewardsSet(r),
 		"tournamentList":                  n.tournamentList(r),
 		"tournamentsGetId":                n.tournamentsGetId(r),
 		"tournamentRecordsList":           n.tournamentRecordsList(r),
@@ -2109,7 +2120,7 @@ func (n *runtimeJavascriptNakamaModule) usersUnbanId(r *goja.Runtime) func(goja.
 			userIDs = append(userIDs, uid)
 		}
 
-		err := UnbanUsers(n.ctx, n.logger, n.db, n.sessionCache, userIDs)
+		err := UnbanUsers(n.ctx, n.logger, n.db, userIDs)
 		if err != nil {
 			panic(r.NewGoError(fmt.Errorf("failed to unban users: %s", err.Error())))
 		}
@@ -3504,6 +3515,113 @@ func (n *runtimeJavascriptNakamaModule) matchSignal(r *goja.Runtime) func(goja.F
 	}
 }
 
+// @group ratings
+// @summary Get the skill ratings of users in a rating queue. Users that have not played a rated match in the queue have the default rating.
+// @param queue(type=string) The rating queue.
+// @param userIds(type=string[]) The IDs of the users.
+// @return ratings(nkRating[]) The ratings of the users, in the same order as the user IDs.
+// @return error(error) An optional error value if an error occurred.
+func (n *runtimeJavascriptNakamaModule) ratingsGet(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		queue := getJsString(r, f.Argument(0))
+		if queue == "" {
+			panic(r.NewTypeError("expects a queue string"))
+		}
+
+		userIDsArray, ok := f.Argument(1).Export().([]interface{})
+		if !ok {
+			panic(r.NewTypeError("expects an array of user ids"))
+		}
+		userIDs := make([]uuid.UUID, 0, len(userIDsArray))
+		for _, id := range userIDsArray {
+			ids, ok := id.(string)
+			if !ok {
+				panic(r.NewTypeError("expects user id to be a string"))
+			}
+			userID, err := uuid.FromString(ids)
+			if err != nil {
+				panic(r.NewTypeError("expects user id to be a valid identifier"))
+			}
+			userIDs = append(userIDs, userID)
+		}
+
+		ratings, err := RatingsGet(n.ctx, n.logger, n.db, queue, userIDs)
+		if err != nil {
+			panic(r.NewGoError(fmt.Errorf("error getting ratings: %v", err.Error())))
+		}
+
+		return r.ToValue(ratingsToJsArray(ratings))
+	}
+}
+
+// @group ratings
+// @summary Submit the result of a rated match, and update the skill ratings of all users who played it.
+// @param queue(type=string) The rating queue.
+// @param teams(type=nkRatingMatchTeam[]) The teams that played the match, each with an array of userIds and a placement. Teams with a lower placement beat teams with a higher placement, and equal placements tie.
+// @return ratings(nkRating[]) The new ratings of the users, in the order they appear in the teams.
+// @return error(error) An optional error value if an error occurred.
+func (n *runtimeJavascriptNakamaModule) ratingsSubmitMatch(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		queue := getJsString(r, f.Argument(0))
+		if queue == "" {
+			panic(r.NewTypeError("expects a queue string"))
+		}
+
+		teamsArray, ok := f.Argument(1).Export().([]interface{})
+		if !ok {
+			panic(r.NewTypeError("expects an array of teams"))
+		}
+		teams := make([]*RatingMatchTeam, 0, len(teamsArray))
+		for _, t := range teamsArray {
+			teamMap, ok := t.(map[string]interface{})
+			if !ok {
+				panic(r.NewTypeError("expects team to be an object"))
+			}
+			team := &RatingMatchTeam{}
+			userIDs, ok := teamMap["userIds"].([]interface{})
+			if !ok {
+				panic(r.NewTypeError("expects team userIds to be an array"))
+			}
+			for _, id := range userIDs {
+				ids, ok := id.(string)
+				if !ok {
+					panic(r.NewTypeError("expects user id to be a string"))
+				}
+				team.UserIDs = append(team.UserIDs, ids)
+			}
+			placement, ok := teamMap["placement"].(int64)
+			if !ok {
+				panic(r.NewTypeError("expects team placement to be a whole number"))
+			}
+			team.Placement = int(placement)
+			teams = append(teams, team)
+		}
+
+		ratings, err := RatingsSubmitMatch(n.ctx, n.logger, n.db, queue, teams)
+		if err != nil {
+			panic(r.NewGoError(fmt.Errorf("error submitting rated match: %v", err.Error())))
+		}
+
+		return r.ToValue(ratingsToJsArray(ratings))
+	}
+}
+
+func ratingsToJsArray(ratings []*Rating) []interface{} {
+	results := make([]interface{}, 0, len(ratings))
+	for _, rating := range ratings {
+		results = append(results, map[string]interface{}{
+			"userId":     rating.UserID,
+			"queue":      rating.Queue,
+			"rating":     rating.Rating,
+			"deviation":  rating.Deviation,
+			"volatility": rating.Volatility,
+			"games":      rating.Games,
+			"updateTime": rating.UpdateTime,
+		})
+	}
+	return results
+}
+
 // @group notifications
 // @summary Send one in-app notification to a user.
 // @param userId(type=string) The user ID of the user to be sent the notification.
@@ -4169,6 +4287,77 @@ func (n *runtimeJavascriptNakamaModule) storageList(r *goja.Runtime) func(goja.F
 	}
 }
 
+// @group storage
+// @summary List storage objects from a storage index, using a query over the indexed fields of their values.
+// @param indexName(type=string) Name of the index to list entries from.
+// @param query(type=string) Query to filter index entries, using the same syntax as match listing queries against fields prefixed with "value.".
+// @param limit(type=number, optional=true, default=100) Maximum number of results to be returned.
+// @param callerId(type=string, optional=true) User ID of the caller, will apply permissions checks of the user. If empty defaults to system user and permission checks are bypassed.
+// @return objects(nkruntime.StorageObjectList) A list of storage objects.
+// @return error(error) An optional error value if an error occurred.
+func (n *runtimeJavascriptNakamaModule) storageIndexList(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		indexName := getJsString(r, f.Argument(0))
+
+		query := ""
+		if f.Argument(1) != goja.Undefined() && f.Argument(1) != goja.Null() {
+			query = getJsString(r, f.Argument(1))
+		}
+
+		limit := 100
+		if f.Argument(2) != goja.Undefined() && f.Argument(2) != goja.Null() {
+			limit = int(getJsInt(r, f.Argument(2)))
+			if limit < 1 || limit > 10000 {
+				panic(r.NewTypeError("limit must be 1-10000"))
+			}
+		}
+
+		callerID := uuid.Nil
+		if f.Argument(3) != goja.Undefined() && f.Argument(3) != goja.Null() {
+			callerIDStr := getJsString(r, f.Argument(3))
+			cid, err := uuid.FromString(callerIDStr)
+			if err != nil {
+				panic(r.NewTypeError("expects caller id to be empty or a valid user id"))
+			}
+			callerID = cid
+		}
+
+		objectList, err := n.storageIndex.List(n.ctx, callerID, indexName, query, limit)
+		if err != nil {
+			panic(r.NewGoError(fmt.Errorf("failed to lookup storage index: %s", err.Error())))
+		}
+
+		objects := make([]interface{}, 0, len(objectList.Objects))
+		for _, o := range objectList.Objects {
+			objectMap := make(map[string]interface{})
+			objectMap["key"] = o.Key
+			objectMap["collection"] = o.Collection
+			if o.UserId != "" {
+				objectMap["userId"] = o.UserId
+			} else {
+				objectMap["userId"] = nil
+			}
+			objectMap["version"] = o.Version
+			objectMap["permissionRead"] = o.PermissionRead
+			objectMap["permissionWrite"] = o.PermissionWrite
+			objectMap["createTime"] = o.CreateTime.Seconds
+			objectMap["updateTime"] = o.UpdateTime.Seconds
+
+			valueMap := make(map[string]interface{})
+			err = json.Unmarshal([]byte(o.Value), &valueMap)
+			if err != nil {
+				panic(r.NewGoError(fmt.Errorf("failed to convert value to json: %s", err.Error())))
+			}
+			pointerizeSlices(valueMap)
+			objectMap["value"] = valueMap
+
+			objects = append(objects, objectMap)
+		}
+
+		return r.ToValue(objects)
+	}
+}
+
 // @group storage
 // @summary Fetch one or more records by their bucket/collection/keyname and optional user.
 // @param objectIds(type=nkruntime.StorageReadRequest[]) An array of object identifiers to be fetched.
@@ -4337,12 +4526,28 @@ func (n *runtimeJavascriptNakamaModule) storageWrite(r *goja.Runtime) func(goja.
 				}
 			}
 
+			var patch StoragePatch
+			if patchIn, ok := dataMap["patch"]; ok {
+				patchStr, ok := patchIn.(string)
+				if !ok {
+					panic(r.NewTypeError("expects 'patch' value to be a string"))
+				}
+				var err error
+				if patch, err = ParseStoragePatch(patchStr); err != nil {
+					panic(r.NewTypeError("expects 'patch' value to be 'merge' or 'json'"))
+				}
+			}
+
 			valueIn, ok := dataMap["value"]
-			valueMap, ok := valueIn.(map[string]interface{})
-			if !ok {
+			if patch == StoragePatchJson {
+				// JSON patches are arrays of operations.
+				if _, ok = valueIn.([]interface{}); !ok {
+					panic(r.NewTypeError("expects 'value' value to be an array of operations"))
+				}
+			} else if _, ok = valueIn.(map[string]interface{}); !ok {
 				panic(r.NewTypeError("expects 'value' value to be an object"))
 			}
-			valueBytes, err := json.Marshal(valueMap)
+			valueBytes, err := json.Marshal(valueIn)
 			if err != nil {
 				panic(r.NewGoError(fmt.Errorf("failed to convert value: %s", err.Error())))
 			}
@@ -4379,6 +4584,14 @@ func (n *runtimeJavascriptNakamaModule) storageWrite(r *goja.Runtime) func(goja.
 				writeOp.PermissionWrite = &wrapperspb.Int32Value{Value: 1}
 			}
 
+			var expireTime int64
+			if expireTimeIn, ok := dataMap["expireTime"]; ok {
+				expireTime, ok = expireTimeIn.(int64)
+				if !ok || expireTime < 0 {
+					panic(r.NewTypeError("expects 'expireTime' value to be 0 or a unix timestamp"))
+				}
+			}
+
 			if writeOp.Collection == "" {
 				panic(r.NewTypeError("expects collection to be supplied"))
 			} else if writeOp.Key == "" {
@@ -4388,12 +4601,14 @@ func (n *runtimeJavascriptNakamaModule) storageWrite(r *goja.Runtime) func(goja.
 			}
 
 			ops = append(ops, &StorageOpWrite{
-				OwnerID: userID.String(),
-				Object:  writeOp,
+				OwnerID:    userID.String(),
+				Object:     writeOp,
+				ExpireTime: expireTime,
+				Patch:      patch,
 			})
 		}
 
-		acks, _, err := StorageWriteObjects(n.ctx, n.logger, n.db, n.metrics, true, ops)
+		acks, _, err := StorageWriteObjects(n.ctx, n.logger, n.db, n.metrics, n.storageIndex, n.storageFeed, true, ops)
 		if err != nil {
 			panic(r.NewGoError(fmt.Errorf("failed to write storage objects: %s", err.Error())))
 		}
@@ -4495,7 +4710,7 @@ func (n *runtimeJavascriptNakamaModule) storageDelete(r *goja.Runtime) func(goja
 			})
 		}
 
-		if _, err := StorageDeleteObjects(n.ctx, n.logger, n.db, true, ops); err != nil {
+		if _, err := StorageDeleteObjects(n.ctx, n.logger, n.db, n.storageIndex, n.storageFeed, true, ops); err != nil {
 			panic(r.NewGoError(fmt.Errorf("failed to remove storage: %s", err.Error())))
 		}
 
@@ -4659,12 +4874,28 @@ func (n *runtimeJavascriptNakamaModule) multiUpdate(r *goja.Runtime) func(goja.F
 					}
 				}
 
-				if valueIn, ok := dataMap["value"]; ok {
-					valueMap, ok := valueIn.(map[string]interface{})
+				var patch StoragePatch
+				if patchIn, ok := dataMap["patch"]; ok {
+					patchStr, ok := patchIn.(string)
 					if !ok {
+						panic(r.NewTypeError("expects 'patch' value to be a string"))
+					}
+					var err error
+					if patch, err = ParseStoragePatch(patchStr); err != nil {
+						panic(r.NewTypeError("expects 'patch' value to be 'merge' or 'json'"))
+					}
+				}
+
+				if valueIn, ok := dataMap["value"]; ok {
+					if patch == StoragePatchJson {
+						// JSON patches are arrays of operations.
+						if _, ok = valueIn.([]interface{}); !ok {
+							panic(r.NewTypeError("expects 'value' value to be an array of operations"))
+						}
+					} else if _, ok = valueIn.(map[string]interface{}); !ok {
 						panic(r.NewTypeError("expects 'value' value to be an object"))
 					}
-					valueBytes, err := json.Marshal(valueMap)
+					valueBytes, err := json.Marshal(valueIn)
 					if err != nil {
 						panic(r.NewGoError(fmt.Errorf("failed to convert value: %s", err.Error())))
 					}
@@ -4702,6 +4933,14 @@ func (n *runtimeJavascriptNakamaModule) multiUpdate(r *goja.Runtime) func(goja.F
 					writeOp.PermissionWrite = &wrapperspb.Int32Value{Value: 1}
 				}
 
+				var expireTime int64
+				if expireTimeIn, ok := dataMap["expireTime"]; ok {
+					expireTime, ok = expireTimeIn.(int64)
+					if !ok || expireTime < 0 {
+						panic(r.NewTypeError("expects 'expireTime' value to be 0 or a unix timestamp"))
+					}
+				}
+
 				if writeOp.Collection == "" {
 					panic(r.NewTypeError("expects collection to be supplied"))
 				} else if writeOp.Key == "" {
@@ -4711,8 +4950,10 @@ func (n *runtimeJavascriptNakamaModule) multiUpdate(r *goja.Runtime) func(goja.F
 				}
 
 				storageWriteOps = append(storageWriteOps, &StorageOpWrite{
-					OwnerID: userID.String(),
-					Object:  writeOp,
+					OwnerID:    userID.String(),
+					Object:     writeOp,
+					ExpireTime: expireTime,
+					Patch:      patch,
 				})
 			}
 		}
@@ -4789,7 +5030,7 @@ func (n *runtimeJavascriptNakamaModule) multiUpdate(r *goja.Runtime) func(goja.F
 			updateLedger = getJsBool(r, f.Argument(3))
 		}
 
-		acks, results, err := MultiUpdate(n.ctx, n.logger, n.db, n.metrics, accountUpdates, storageWriteOps, walletUpdates, updateLedger)
+		acks, results, err := MultiUpdate(n.ctx, n.logger, n.db, n.metrics, n.storageIndex, n.storageFeed, accountUpdates, storageWriteOps, walletUpdates, updateLedger)
 		if err != nil {
 			panic(r.NewGoError(fmt.Errorf("error running multi update: %s", err.Error())))
 		}
@@ -4832,7 +5073,7 @@ func (n *runtimeJavascriptNakamaModule) multiUpdate(r *goja.Runtime) func(goja.F
 // @summary Setup a new dynamic leaderboard with the specified ID and various configuration settings. The leaderboard will be created if it doesn't already exist, otherwise its configuration will not be updated.
 // @param leaderboardID(type=string) The unique identifier for the new leaderboard. This is used by clients to submit scores.
 // @param authoritative(type=bool, default=false) Mark the leaderboard as authoritative which ensures updates can only be made via the Go runtime. No client can submit a score directly.
-// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the leaderboard. Possible values are "asc" or "desc".
+// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the leaderboard. Possible values are "asc" or "desc", optionally followed by a comma and the subscore sort order, then a comma and "time" to break ties by earliest submission. For example "desc,asc,time".
 // @param operator(type=string, optional=true, default="best") The operator that determines how scores behave when submitted. Possible values are "best", "set", or "incr".
 // @param resetSchedule(type=string, optional=true) The cron format used to define the reset schedule for the leaderboard. This controls when a leaderboard is reset and can be used to power daily/weekly/monthly leaderboards.
 // @param metadata(type=object, optional=true) The metadata you want associated to the leaderboard. Some good examples are weather conditions for a racing game.
@@ -4854,14 +5095,9 @@ func (n *runtimeJavascriptNakamaModule) leaderboardCreate(r *goja.Runtime) func(
 			sortOrder = getJsString(r, f.Argument(2))
 		}
 
-		var sortOrderNumber int
-		switch sortOrder {
-		case "asc":
-			sortOrderNumber = LeaderboardSortOrderAscending
-		case "desc":
-			sortOrderNumber = LeaderboardSortOrderDescending
-		default:
-			panic(r.NewTypeError("expects sort order to be 'asc' or 'desc'"))
+		sort, err := ParseLeaderboardSort(sortOrder)
+		if err != nil {
+			panic(r.NewTypeError("expects sort order to be 'asc' or 'desc', optionally followed by a subscore sort order and 'time'"))
 		}
 
 		operator := "best"
@@ -4905,7 +5141,7 @@ func (n *runtimeJavascriptNakamaModule) leaderboardCreate(r *goja.Runtime) func(
 			metadataStr = string(metadataBytes)
 		}
 
-		if _, err := n.leaderboardCache.Create(n.ctx, id, authoritative, sortOrderNumber, operatorNumber, resetSchedule, metadataStr); err != nil {
+		if _, err := n.leaderboardCache.Create(n.ctx, id, authoritative, sort, operatorNumber, resetSchedule, metadataStr); err != nil {
 			panic(r.NewGoError(fmt.Errorf("error creating leaderboard: %v", err.Error())))
 		}
 
@@ -5073,7 +5309,7 @@ func (n *runtimeJavascriptNakamaModule) leaderboardRecordsList(r *goja.Runtime)
 			overrideExpiry = getJsInt(r, f.Argument(4))
 		}
 
-		records, err := LeaderboardRecordsList(n.ctx, n.logger, n.db, n.leaderboardCache, n.rankCache, id, limit, cursor, ownerIds, overrideExpiry)
+		records, err := LeaderboardRecordsList(n.ctx, n.logger, n.db, n.leaderboardCache, n.rankCache, id, limit, cursor, ownerIds, nil, overrideExpiry)
 		if err != nil {
 			panic(r.NewGoError(fmt.Errorf("error listing leaderboard records: %v", err.Error())))
 		}
@@ -5150,7 +5386,7 @@ func (n *runtimeJavascriptNakamaModule) leaderboardRecordWrite(r *goja.Runtime)
 			}
 		}
 
-		record, err := LeaderboardRecordWrite(n.ctx, n.logger, n.db, n.leaderboardCache, n.rankCache, uuid.Nil, id, ownerID, username, score, subscore, metadataStr, overrideOperator)
+		record, err := LeaderboardRecordWrite(n.ctx, n.logger, n.db, n.leaderboardCache, n.rankCache, n.metrics, uuid.Nil, id, ownerID, username, score, subscore, metadataStr, overrideOperator)
 		if err != nil {
 			panic(r.NewGoError(fmt.Errorf("error writing leaderboard record: %v", err.Error())))
 		}
@@ -5271,6 +5507,147 @@ func (n *runtimeJavascriptNakamaModule) leaderboardRecordsHaystack(r *goja.Runti
 	}
 }
 
+// @group leaderboards
+// @summary List past periods of a leaderboard with a reset schedule, most recent first, with their final top records.
+// @param id(type=string) The unique identifier for the leaderboard.
+// @param limit(type=number, optional=true, default=10) Return only the required number of periods denoted by this limit value. Between 1-100.
+// @param topCount(type=number, optional=true, default=10) The number of top records to return for each period. Between 0-100.
+// @param cursor(type=string, optional=true, default="") Pagination cursor from previous result. Don't set to start fetching from the beginning.
+// @return periods(nkruntime.LeaderboardPeriodList) A list of periods with their expiry time and top records, and possibly a cursor. If cursor is empty/null there are no further results.
+// @return error(error) An optional error value if an error occurred.
+func (n *runtimeJavascriptNakamaModule) leaderboardPeriodsList(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		id := getJsString(r, f.Argument(0))
+		if id == "" {
+			panic(r.NewTypeError("expects a leaderboard ID string"))
+		}
+
+		limit := 10
+		if f.Argument(1) != goja.Undefined() && f.Argument(1) != goja.Null() {
+			limit = int(getJsInt(r, f.Argument(1)))
+			if limit < 1 || limit > 100 {
+				panic(r.NewTypeError("limit must be 1-100"))
+			}
+		}
+
+		topCount := 10
+		if f.Argument(2) != goja.Undefined() && f.Argument(2) != goja.Null() {
+			topCount = int(getJsInt(r, f.Argument(2)))
+			if topCount < 0 || topCount > 100 {
+				panic(r.NewTypeError("top count must be 0-100"))
+			}
+		}
+
+		cursor := ""
+		if f.Argument(3) != goja.Undefined() && f.Argument(3) != goja.Null() {
+			cursor = getJsString(r, f.Argument(3))
+		}
+
+		periods, nextCursor, err := LeaderboardPeriodsList(n.ctx, n.logger, n.db, n.leaderboardCache, n.rankCache, id, limit, topCount, cursor)
+		if err != nil {
+			panic(r.NewGoError(fmt.Errorf("error listing leaderboard periods: %v", err.Error())))
+		}
+
+		periodsSlice := make([]interface{}, 0, len(periods))
+		for _, period := range periods {
+			recordsSlice := make([]interface{}, 0, len(period.Records))
+			for _, record := range period.Records {
+				recordsSlice = append(recordsSlice, leaderboardRecordToJsMap(r, record))
+			}
+			periodsSlice = append(periodsSlice, map[string]interface{}{
+				"expiryTime": period.ExpiryTime,
+				"records":    recordsSlice,
+			})
+		}
+
+		resultMap := map[string]interface{}{
+			"periods":    periodsSlice,
+			"nextCursor": nil,
+		}
+		if nextCursor != "" {
+			resultMap["nextCursor"] = nextCursor
+		}
+
+		return r.ToValue(resultMap)
+	}
+}
+
+// @group leaderboards
+// @summary Find the expiry time of a past period of a leaderboard with a reset schedule, to list its records.
+// @param id(type=string) The unique identifier for the leaderboard.
+// @param periodsAgo(type=number) The number of periods before the current one, 1 being the period that ended most recently.
+// @return expiry(number) The expiry time of the period, in seconds since epoch.
+// @return error(error) An optional error value if an error occurred.
+func (n *runtimeJavascriptNakamaModule) leaderboardPeriodExpiry(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		id := getJsString(r, f.Argument(0))
+		if id == "" {
+			panic(r.NewTypeError("expects a leaderboard ID string"))
+		}
+
+		periodsAgo := int(getJsInt(r, f.Argument(1)))
+
+		leaderboard := n.leaderboardCache.Get(id)
+		if leaderboard == nil {
+			panic(r.NewGoError(fmt.Errorf("error finding leaderboard period: %v", ErrLeaderboardNotFound.Error())))
+		}
+
+		expiry, err := LeaderboardPeriodExpiry(leaderboard, periodsAgo, time.Now())
+		if err != nil {
+			panic(r.NewGoError(fmt.Errorf("error finding leaderboard period: %v", err.Error())))
+		}
+
+		return r.ToValue(expiry)
+	}
+}
+
+// @group leaderboards
+// @summary Set the limits on scores clients may submit to a leaderboard or tournament. Scores written by the server are not validated.
+// @param id(type=string) The ID of the leaderboard or tournament.
+// @param validation(type=nkLeaderboardValidation, optional=true) The validation rules with optional minScore, maxScore, maxScoreDelta, maxSubmissions, and submissionWindowSec, replacing any existing rules. Null removes all rules.
+// @return error(error) An optional error value if an error occurred.
+func (n *runtimeJavascriptNakamaModule) leaderboardValidationSet(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		id := getJsString(r, f.Argument(0))
+		if id == "" {
+			panic(r.NewTypeError("expects a leaderboard ID string"))
+		}
+
+		var validation *LeaderboardValidation
+		if f.Argument(1) != goja.Undefined() && f.Argument(1) != goja.Null() {
+			validationMap, ok := f.Argument(1).Export().(map[string]interface{})
+			if !ok {
+				panic(r.NewTypeError("expects validation object"))
+			}
+			validation = &LeaderboardValidation{}
+			for k, v := range validationMap {
+				value, ok := v.(int64)
+				if !ok {
+					panic(r.NewTypeError(fmt.Sprintf("expects %v to be a whole number", k)))
+				}
+				switch k {
+				case "minScore":
+					validation.MinScore = &value
+				case "maxScore":
+					validation.MaxScore = &value
+				case "maxScoreDelta":
+					validation.MaxScoreDelta = value
+				case "maxSubmissions":
+					validation.MaxSubmissions = int(value)
+				case "submissionWindowSec":
+					validation.SubmissionWindowSec = int(value)
+				}
+			}
+		}
+
+		if err := LeaderboardValidationSet(n.ctx, n.leaderboardCache, id, validation); err != nil {
+			panic(r.NewGoError(fmt.Errorf("error setting leaderboard validation: %v", err.Error())))
+		}
+
+		return goja.Undefined()
+	}
+}
+
 // @group purchases
 // @summary Validates and stores the purchases present in an Apple App Store Receipt.
 // @param userId(type=string) The user ID of the owner of the receipt.
@@ -5704,7 +6081,7 @@ func (n *runtimeJavascriptNakamaModule) subscriptionsList(r *goja.Runtime) func(
 // @summary Setup a new dynamic tournament with the specified ID and various configuration settings. The underlying leaderboard will be created if it doesn't already exist, otherwise its configuration will not be updated.
 // @param id(type=string) The unique identifier for the new tournament. This is used by clients to submit scores.
 // @param authoritative(type=bool, optional=true, default=true) Whether the tournament created is server authoritative.
-// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the tournament. Possible values are "asc" or "desc".
+// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the tournament. Possible values are "asc" or "desc", optionally followed by a comma and the subscore sort order, then a comma and "time" to break ties by earliest submission. For example "desc,asc,time".
 // @param operator(type=string, optional=true, default="best") The operator that determines how scores behave when submitted. The possible values are "best", "set", or "incr".
 // @param resetSchedule(type=string, optional=true) The cron format used to define the reset schedule for the tournament. This controls when the underlying leaderboard resets and the tournament is considered active again.
 // @param metadata(type=object, optional=true) The metadata you want associated to the tournament. Some good examples are weather conditions for a racing game.
@@ -5717,6 +6094,7 @@ func (n *runtimeJavascriptNakamaModule) subscriptionsList(r *goja.Runtime) func(
 // @param maxSize(type=number, optional=true) Maximum size of participants in a tournament.
 // @param maxNumScore(type=number, optional=true) Maximum submission attempts for a tournament record.
 // @param joinRequired(type=bool, optional=true, default=false) Whether the tournament needs to be joined before a record write is allowed.
+// @param bucketSize(type=number, optional=true, default=0) Rank participants in buckets of up to this many owners assigned when they join, rather than all together. Requires joinRequired. 0 to disable.
 // @return error(error) An optional error value if an error occurred.
 func (n *runtimeJavascriptNakamaModule) tournamentCreate(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
 	return func(f goja.FunctionCall) goja.Value {
@@ -5734,14 +6112,9 @@ func (n *runtimeJavascriptNakamaModule) tournamentCreate(r *goja.Runtime) func(g
 		if f.Argument(2) != goja.Undefined() {
 			sortOrder = getJsString(r, f.Argument(2))
 		}
-		var sortOrderNumber int
-		switch sortOrder {
-		case "asc":
-			sortOrderNumber = LeaderboardSortOrderAscending
-		case "desc":
-			sortOrderNumber = LeaderboardSortOrderDescending
-		default:
-			panic(r.NewTypeError("expects sort order to be 'asc' or 'desc'"))
+		sort, err := ParseLeaderboardSort(sortOrder)
+		if err != nil {
+			panic(r.NewTypeError("expects sort order to be 'asc' or 'desc', optionally followed by a subscore sort order and 'time'"))
 		}
 
 		operator := "best"
@@ -5849,7 +6222,18 @@ func (n *runtimeJavascriptNakamaModule) tournamentCreate(r *goja.Runtime) func(g
 			joinRequired = getJsBool(r, f.Argument(14))
 		}
 
-		if err := TournamentCreate(n.ctx, n.logger, n.leaderboardCache, n.leaderboardScheduler, id, authoritative, sortOrderNumber, operatorNumber, resetSchedule, metadataStr, title, description, category, startTime, endTime, duration, maxSize, maxNumScore, joinRequired); err != nil {
+		var bucketSize int
+		if f.Argument(15) != goja.Undefined() && f.Argument(15) != goja.Null() {
+			bucketSize = int(getJsInt(r, f.Argument(15)))
+			if bucketSize < 0 {
+				panic(r.NewTypeError("bucketSize must be >= 0"))
+			}
+			if bucketSize > 0 && !joinRequired {
+				panic(r.NewTypeError("bucketSize requires joinRequired"))
+			}
+		}
+
+		if err := TournamentCreate(n.ctx, n.logger, n.leaderboardCache, n.leaderboardScheduler, id, authoritative, sort, operatorNumber, resetSchedule, metadataStr, title, description, category, startTime, endTime, duration, maxSize, maxNumScore, joinRequired, bucketSize); err != nil {
 			panic(r.NewGoError(fmt.Errorf("error creating tournament: %v", err.Error())))
 		}
 
@@ -5914,6 +6298,7 @@ func (n *runtimeJavascriptNakamaModule) tournamentAddAttempt(r *goja.Runtime) fu
 // @param id(type=string) The unique identifier for the tournament to join.
 // @param ownerId(type=string) The owner of the record.
 // @param username(type=string) The username of the record owner.
+// @param rating(type=number, optional=true) The owner's rating, used by bucketed tournaments to place the owner in a bucket with owners of a similar rating.
 // @return error(error) An optional error value if an error occurred.
 func (n *runtimeJavascriptNakamaModule) tournamentJoin(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
 	return func(f goja.FunctionCall) goja.Value {
@@ -5934,7 +6319,13 @@ func (n *runtimeJavascriptNakamaModule) tournamentJoin(r *goja.Runtime) func(goj
 			panic(r.NewTypeError("expects a username string"))
 		}
 
-		if err := TournamentJoin(n.ctx, n.logger, n.db, n.leaderboardCache, userID, username, id); err != nil {
+		var rating *int64
+		if f.Argument(3) != goja.Undefined() && f.Argument(3) != goja.Null() {
+			v := getJsInt(r, f.Argument(3))
+			rating = &v
+		}
+
+		if err := TournamentJoin(n.ctx, n.logger, n.db, n.leaderboardCache, n.leaderboardScheduler, userID, username, id, rating, n.config.GetLeaderboard().BucketRatingRange); err != nil {
 			panic(r.NewGoError(fmt.Errorf("error joining tournament: %v", err.Error())))
 		}
 
@@ -5942,6 +6333,99 @@ func (n *runtimeJavascriptNakamaModule) tournamentJoin(r *goja.Runtime) func(goj
 	}
 }
 
+// @group tournaments
+// @summary Set the rewards paid out to owners when each active period of a tournament ends. Each reward covers a range of ranks, ranks within the bucket for bucketed tournaments, and may apply a wallet changeset and send a notification to each owner in the range.
+// @param id(type=string) The unique identifier for the tournament.
+// @param rewards(type=nkTournamentReward[]) An array of rewards, each with rankMin, rankMax, and optionally a changeset, wallet ledger metadata, and a notification object with subject, content, code, and persistent. Rank ranges must not overlap. An empty array removes all rewards.
+// @return error(error) An optional error value if an error occurred.
+func (n *runtimeJavascriptNakamaModule) tournamentRewardsSet(r *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(f goja.FunctionCall) goja.Value {
+		id := getJsString(r, f.Argument(0))
+		if id == "" {
+			panic(r.NewTypeError("expects a tournament ID string"))
+		}
+
+		rewardsIn, ok := f.Argument(1).Export().([]interface{})
+		if !ok {
+			panic(r.NewTypeError("expects an array of tournament reward objects"))
+		}
+
+		rewards := make([]*TournamentReward, 0, len(rewardsIn))
+		for _, rewardIn := range rewardsIn {
+			rewardMap, ok := rewardIn.(map[string]interface{})
+			if !ok {
+				panic(r.NewTypeError("expects a reward to be a tournament reward object"))
+			}
+
+			reward := &TournamentReward{}
+			if reward.RankMin, ok = rewardMap["rankMin"].(int64); !ok {
+				panic(r.NewTypeError("expects rankMin to be a whole number"))
+			}
+			if reward.RankMax, ok = rewardMap["rankMax"].(int64); !ok {
+				panic(r.NewTypeError("expects rankMax to be a whole number"))
+			}
+
+			if changesetRaw, ok := rewardMap["changeset"]; ok {
+				changesetMap, ok := changesetRaw.(map[string]interface{})
+				if !ok {
+					panic(r.NewTypeError("expects changeset object"))
+				}
+				reward.Changeset = make(map[string]int64, len(changesetMap))
+				for k, v := range changesetMap {
+					i64, ok := v.(int64)
+					if !ok {
+						panic(r.NewTypeError("expects changeset values to be whole numbers"))
+					}
+					reward.Changeset[k] = i64
+				}
+			}
+
+			if metadataRaw, ok := rewardMap["metadata"]; ok {
+				if reward.Metadata, ok = metadataRaw.(map[string]interface{}); !ok {
+					panic(r.NewTypeError("expects metadata object"))
+				}
+			}
+
+			if notificationRaw, ok := rewardMap["notification"]; ok {
+				notificationMap, ok := notificationRaw.(map[string]interface{})
+				if !ok {
+					panic(r.NewTypeError("expects notification object"))
+				}
+				notification := &TournamentRewardNotification{}
+				if notification.Subject, ok = notificationMap["subject"].(string); !ok {
+					panic(r.NewTypeError("expects notification subject string"))
+				}
+				if contentRaw, ok := notificationMap["content"]; ok {
+					if notification.Content, ok = contentRaw.(map[string]interface{}); !ok {
+						panic(r.NewTypeError("expects notification content object"))
+					}
+				}
+				if codeRaw, ok := notificationMap["code"]; ok {
+					code, ok := codeRaw.(int64)
+					if !ok {
+						panic(r.NewTypeError("expects notification code to be a whole number"))
+					}
+					notification.Code = int(code)
+				}
+				if persistentRaw, ok := notificationMap["persistent"]; ok {
+					if notification.Persistent, ok = persistentRaw.(bool); !ok {
+						panic(r.NewTypeError("expects notification persistent to be a boolean"))
+					}
+				}
+				reward.Notification = notification
+			}
+
+			rewards = append(rewards, reward)
+		}
+
+		if err := TournamentRewardsSet(n.ctx, n.logger, n.db, n.leaderboardCache, id, rewards); err != nil {
+			panic(r.NewGoError(fmt.Errorf("error setting tournament rewards: %v", err.Error())))
+		}
+
+		return goja.Undefined()
+	}
+}
+
 // @group tournaments
 // @summary Fetch one or more tournaments by ID.
 // @param ids(type=string[]) The table array of tournament ids.
@@ -6045,7 +6529,7 @@ func (n *runtimeJavascriptNakamaModule) tournamentRecordsList(r *goja.Runtime) f
 			overrideExpiry = getJsInt(r, f.Argument(4))
 		}
 
-		records, err := TournamentRecordsList(n.ctx, n.logger, n.db, n.leaderboardCache, n.rankCache, id, ownerIds, limit, cursor, overrideExpiry)
+		records, err := TournamentRecordsList(n.ctx, n.logger, n.db, n.leaderboardCache, n.rankCache, id, ownerIds, nil, limit, cursor, overrideExpiry)
 		if err != nil {
 			panic(r.NewGoError(fmt.Errorf("error listing tournament records: %v", err.Error())))
 		}
@@ -6280,7 +6764,7 @@ func (n *runtimeJavascriptNakamaModule) tournamentRecordWrite(r *goja.Runtime) f
 			}
 		}
 
-		record, err := TournamentRecordWrite(n.ctx, n.logger, n.db, n.leaderboardCache, n.rankCache, uuid.Nil, id, userID, username, score, subscore, metadataStr, api.Operator(overrideOperator))
+		record, err := TournamentRecordWrite(n.ctx, n.logger, n.db, n.leaderboardCache, n.rankCache, n.metrics, uuid.Nil, id, userID, username, score, subscore, metadataStr, api.Operator(overrideOperator))
 		if err != nil {
 			panic(r.NewGoError(fmt.Errorf("error writing tournament record: %v", err.Error())))
 		}
@@ -7893,7 +8377,7 @@ func (n *runtimeJavascriptNakamaModule) channelMessagesList(r *goja.Runtime) fun
 			panic(r.NewTypeError(err.Error()))
 		}
 
-		list, err := ChannelMessagesList(n.ctx, n.logger, n.db, uuid.Nil, channelIdToStreamResult.Stream, channelId, limit, forward, cursor)
+		list, err := ChannelMessagesList(n.ctx, n.logger, n.db, uuid.Nil, channelIdToStreamResult.Stream, channelId, uuid.Nil, limit, forward, cursor)
 		if err != nil {
 			panic(r.NewGoError(fmt.Errorf("failed to list channel messages: %s", err.Error())))
 		}
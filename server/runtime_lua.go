@@ -53,13 +53,18 @@ func (s *LSentinelType) Type() lua.LValueType { return LTSentinel }
 var LSentinel = lua.LValue(&LSentinelType{})
 
 type RuntimeLuaCallbacks struct {
-	RPC              *MapOf[string, *lua.LFunction]
-	Before           *MapOf[string, *lua.LFunction]
-	After            *MapOf[string, *lua.LFunction]
-	Matchmaker       *lua.LFunction
-	TournamentEnd    *lua.LFunction
-	TournamentReset  *lua.LFunction
-	LeaderboardReset *lua.LFunction
+	RPC                *MapOf[string, *lua.LFunction]
+	Before             *MapOf[string, *lua.LFunction]
+	After              *MapOf[string, *lua.LFunction]
+	Matchmaker         *lua.LFunction
+	TournamentStart    *lua.LFunction
+	TournamentJoin     *lua.LFunction
+	TournamentEnd      *lua.LFunction
+	TournamentReset    *lua.LFunction
+	LeaderboardReset   *lua.LFunction
+	StorageIndexFilter *MapOf[string, *lua.LFunction]
+	StorageExpire      *lua.LFunction
+	MatchmakerOverride *lua.LFunction
 }
 
 type RuntimeLuaModule struct {
@@ -106,14 +111,14 @@ type RuntimeProviderLua struct {
 	statsCtx context.Context
 }
 
-func NewRuntimeProviderLua(logger, startupLogger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, leaderboardRankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, eventFn RuntimeEventCustomFunction, rootPath string, paths []string, matchProvider *MatchProvider) ([]string, map[string]RuntimeRpcFunction, map[string]RuntimeBeforeRtFunction, map[string]RuntimeAfterRtFunction, *RuntimeBeforeReqFunctions, *RuntimeAfterReqFunctions, RuntimeMatchmakerMatchedFunction, RuntimeTournamentEndFunction, RuntimeTournamentResetFunction, RuntimeLeaderboardResetFunction, error) {
+func NewRuntimeProviderLua(logger, startupLogger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, leaderboardRankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, storageIndex StorageIndex, storageFeed StorageFeed, eventFn RuntimeEventCustomFunction, rootPath string, paths []string, matchProvider *MatchProvider) ([]string, map[string]RuntimeRpcFunction, map[string]RuntimeBeforeRtFunction, map[string]RuntimeAfterRtFunction, *RuntimeBeforeReqFunctions, *RuntimeAfterReqFunctions, RuntimeMatchmakerMatchedFunction, RuntimeTournamentStartFunction, RuntimeTournamentJoinFunction, RuntimeTournamentEndFunction, RuntimeTournamentResetFunction, RuntimeLeaderboardResetFunction, map[string]RuntimeStorageIndexFilterFunction, RuntimeStorageExpireFunction, RuntimeMatchmakerOverrideFunction, error) {
 	startupLogger.Info("Initialising Lua runtime provider", zap.String("path", rootPath))
 
 	// Load Lua modules into memory by reading the file contents. No evaluation/execution at this stage.
 	moduleCache, modulePaths, stdLibs, err := openLuaModules(startupLogger, rootPath, paths)
 	if err != nil {
 		// Errors already logged in the function call above.
-		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	once := &sync.Once{}
@@ -124,9 +129,14 @@ func NewRuntimeProviderLua(logger, startupLogger *zap.Logger, db *sql.DB, protoj
 	beforeReqFunctions := &RuntimeBeforeReqFunctions{}
 	afterReqFunctions := &RuntimeAfterReqFunctions{}
 	var matchmakerMatchedFunction RuntimeMatchmakerMatchedFunction
+	var tournamentStartFunction RuntimeTournamentStartFunction
+	var tournamentJoinFunction RuntimeTournamentJoinFunction
 	var tournamentEndFunction RuntimeTournamentEndFunction
 	var tournamentResetFunction RuntimeTournamentResetFunction
 	var leaderboardResetFunction RuntimeLeaderboardResetFunction
+	storageIndexFilterFunctions := make(map[string]RuntimeStorageIndexFilterFunction, 0)
+	var storageExpireFunction RuntimeStorageExpireFunction
+	var matchmakerOverrideFunction RuntimeMatchmakerOverrideFunction
 
 	var sharedReg *lua.LTable
 	var sharedGlobals *lua.LTable
@@ -158,11 +168,11 @@ func NewRuntimeProviderLua(logger, startupLogger *zap.Logger, db *sql.DB, protoj
 
 	matchProvider.RegisterCreateFn("lua",
 		func(ctx context.Context, logger *zap.Logger, id uuid.UUID, node string, stopped *atomic.Bool, name string) (RuntimeMatchCore, error) {
-			return NewRuntimeLuaMatchCore(logger, name, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, stdLibs, once, localCache, eventFn, nil, nil, id, node, stopped, name, matchProvider)
+			return NewRuntimeLuaMatchCore(logger, name, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, storageIndex, storageFeed, stdLibs, once, localCache, eventFn, nil, nil, id, node, stopped, name, matchProvider)
 		},
 	)
 
-	r, err := newRuntimeLuaVM(logger, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, stdLibs, moduleCache, once, localCache, matchProvider.CreateMatch, eventFn, func(execMode RuntimeExecutionMode, id string) {
+	r, err := newRuntimeLuaVM(logger, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, storageIndex, storageFeed, stdLibs, moduleCache, once, localCache, matchProvider.CreateMatch, eventFn, func(execMode RuntimeExecutionMode, id string) {
 		switch execMode {
 		case RuntimeExecutionModeRPC:
 			rpcFunctions[id] = func(ctx context.Context, headers, queryParams map[string][]string, userID, username string, vars map[string]string, expiry int64, sessionID, clientIP, clientPort, lang, payload string) (string, error, codes.Code) {
@@ -1092,6 +1102,14 @@ func NewRuntimeProviderLua(logger, startupLogger *zap.Logger, db *sql.DB, protoj
 			matchmakerMatchedFunction = func(ctx context.Context, entries []*MatchmakerEntry) (string, bool, error) {
 				return runtimeProviderLua.MatchmakerMatched(ctx, entries)
 			}
+		case RuntimeExecutionModeTournamentStart:
+			tournamentStartFunction = func(ctx context.Context, tournament *api.Tournament, start, end int64) error {
+				return runtimeProviderLua.TournamentStart(ctx, tournament, start, end)
+			}
+		case RuntimeExecutionModeTournamentJoin:
+			tournamentJoinFunction = func(ctx context.Context, tournament *api.Tournament, userID, username string) error {
+				return runtimeProviderLua.TournamentJoin(ctx, tournament, userID, username)
+			}
 		case RuntimeExecutionModeTournamentEnd:
 			tournamentEndFunction = func(ctx context.Context, tournament *api.Tournament, end, reset int64) error {
 				return runtimeProviderLua.TournamentEnd(ctx, tournament, end, reset)
@@ -1104,10 +1122,22 @@ func NewRuntimeProviderLua(logger, startupLogger *zap.Logger, db *sql.DB, protoj
 			leaderboardResetFunction = func(ctx context.Context, leaderboard *api.Leaderboard, reset int64) error {
 				return runtimeProviderLua.LeaderboardReset(ctx, leaderboard, reset)
 			}
+		case RuntimeExecutionModeStorageIndexFilter:
+			storageIndexFilterFunctions[id] = func(ctx context.Context, write *StorageOpWrite) (bool, error) {
+				return runtimeProviderLua.StorageIndexFilter(ctx, id, write)
+			}
+		case RuntimeExecutionModeStorageExpire:
+			storageExpireFunction = func(ctx context.Context, objects []*api.StorageObject) error {
+				return runtimeProviderLua.StorageExpire(ctx, objects)
+			}
+		case RuntimeExecutionModeMatchmakerOverride:
+			matchmakerOverrideFunction = func(ctx context.Context, candidateMatches [][]*MatchmakerEntry) ([][]*MatchmakerEntry, error) {
+				return runtimeProviderLua.MatchmakerOverride(ctx, candidateMatches)
+			}
 		}
 	})
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	if config.GetRuntime().GetLuaReadOnlyGlobals() {
@@ -1153,7 +1183,7 @@ func NewRuntimeProviderLua(logger, startupLogger *zap.Logger, db *sql.DB, protoj
 		r.Stop()
 
 		runtimeProviderLua.newFn = func() *RuntimeLua {
-			r, err := newRuntimeLuaVM(logger, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, stdLibs, moduleCache, once, localCache, matchProvider.CreateMatch, eventFn, nil)
+			r, err := newRuntimeLuaVM(logger, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, leaderboardRankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, storageIndex, storageFeed, stdLibs, moduleCache, once, localCache, matchProvider.CreateMatch, eventFn, nil)
 			if err != nil {
 				logger.Fatal("Failed to initialize Lua runtime", zap.Error(err))
 			}
@@ -1174,7 +1204,7 @@ func NewRuntimeProviderLua(logger, startupLogger *zap.Logger, db *sql.DB, protoj
 	}
 	startupLogger.Info("Allocated minimum Lua runtime pool")
 
-	return modulePaths, rpcFunctions, beforeRtFunctions, afterRtFunctions, beforeReqFunctions, afterReqFunctions, matchmakerMatchedFunction, tournamentEndFunction, tournamentResetFunction, leaderboardResetFunction, nil
+	return modulePaths, rpcFunctions, beforeRtFunctions, afterRtFunctions, beforeReqFunctions, afterReqFunctions, matchmakerMatchedFunction, tournamentStartFunction, tournamentJoinFunction, tournamentEndFunction, tournamentResetFunction, leaderboardResetFunction, storageIndexFilterFunctions, storageExpireFunction, matchmakerOverrideFunction, nil
 }
 
 func CheckRuntimeProviderLua(logger *zap.Logger, config Config, paths []string) error {
@@ -1635,6 +1665,181 @@ func (rp *RuntimeProviderLua) MatchmakerMatched(ctx context.Context, entries []*
 	return "", false, errors.New("Unexpected return type from runtime Matchmaker Matched hook, must be string or nil.")
 }
 
+func (rp *RuntimeProviderLua) MatchmakerOverride(ctx context.Context, candidateMatches [][]*MatchmakerEntry) ([][]*MatchmakerEntry, error) {
+	r, err := rp.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lf := r.GetCallback(RuntimeExecutionModeMatchmakerOverride, "")
+	if lf == nil {
+		rp.Put(r)
+		return nil, errors.New("Runtime Matchmaker Override function not found.")
+	}
+
+	luaCtx := NewRuntimeLuaContext(r.vm, r.node, r.luaEnv, RuntimeExecutionModeMatchmakerOverride, nil, nil, 0, "", "", nil, "", "", "", "")
+
+	candidatesTable := r.vm.CreateTable(len(candidateMatches), 0)
+	for i, entries := range candidateMatches {
+		entriesTable := r.vm.CreateTable(len(entries), 0)
+		for j, entry := range entries {
+			presenceTable := r.vm.CreateTable(0, 4)
+			presenceTable.RawSetString("user_id", lua.LString(entry.Presence.UserId))
+			presenceTable.RawSetString("session_id", lua.LString(entry.Presence.SessionId))
+			presenceTable.RawSetString("username", lua.LString(entry.Presence.Username))
+			presenceTable.RawSetString("node", lua.LString(entry.Presence.Node))
+
+			propertiesTable := r.vm.CreateTable(0, len(entry.StringProperties)+len(entry.NumericProperties))
+			for k, v := range entry.StringProperties {
+				propertiesTable.RawSetString(k, lua.LString(v))
+			}
+			for k, v := range entry.NumericProperties {
+				propertiesTable.RawSetString(k, lua.LNumber(v))
+			}
+
+			entryTable := r.vm.CreateTable(0, 4)
+			entryTable.RawSetString("presence", presenceTable)
+			entryTable.RawSetString("properties", propertiesTable)
+			entryTable.RawSetString("ticket", lua.LString(entry.Ticket))
+
+			if entry.PartyId != "" {
+				entryTable.RawSetString("party_id", lua.LString(entry.PartyId))
+			}
+
+			entriesTable.RawSetInt(j+1, entryTable)
+		}
+		candidatesTable.RawSetInt(i+1, entriesTable)
+	}
+
+	// Set context value used for logging
+	vmCtx := context.WithValue(ctx, ctxLoggerFields{}, map[string]string{"mode": RuntimeExecutionModeMatchmakerOverride.String()})
+	r.vm.SetContext(vmCtx)
+	retValue, err, _, _ := r.invokeFunction(r.vm, lf, luaCtx, candidatesTable)
+	r.vm.SetContext(context.Background())
+	rp.Put(r)
+	if err != nil {
+		return nil, fmt.Errorf("Error running runtime Matchmaker Override hook: %v", err.Error())
+	}
+
+	if retValue == nil || retValue == lua.LNil {
+		// Hook decided none of the candidates should be matched.
+		return [][]*MatchmakerEntry{}, nil
+	}
+
+	retTable, ok := retValue.(*lua.LTable)
+	if !ok {
+		return nil, errors.New("Unexpected return type from runtime Matchmaker Override hook, must be table or nil.")
+	}
+
+	overrideKeys := make([][]matchmakerOverrideKey, 0, retTable.Len())
+	var conversionErr error
+	retTable.ForEach(func(_ lua.LValue, v lua.LValue) {
+		if conversionErr != nil {
+			return
+		}
+		entriesTable, ok := v.(*lua.LTable)
+		if !ok {
+			conversionErr = errors.New("Invalid return value from runtime Matchmaker Override hook, matches must be tables of entries.")
+			return
+		}
+		keys := make([]matchmakerOverrideKey, 0, entriesTable.Len())
+		entriesTable.ForEach(func(_ lua.LValue, e lua.LValue) {
+			if conversionErr != nil {
+				return
+			}
+			entryTable, ok := e.(*lua.LTable)
+			if !ok {
+				conversionErr = errors.New("Invalid return value from runtime Matchmaker Override hook, matches must be tables of entries.")
+				return
+			}
+			var sessionID string
+			if presenceTable, ok := entryTable.RawGetString("presence").(*lua.LTable); ok {
+				sessionID = presenceTable.RawGetString("session_id").String()
+			}
+			keys = append(keys, matchmakerOverrideKey{Ticket: entryTable.RawGetString("ticket").String(), SessionID: sessionID})
+		})
+		overrideKeys = append(overrideKeys, keys)
+	})
+	if conversionErr != nil {
+		return nil, conversionErr
+	}
+
+	return matchmakerOverrideEntries(candidateMatches, overrideKeys)
+}
+
+func (rp *RuntimeProviderLua) TournamentStart(ctx context.Context, tournament *api.Tournament, start, end int64) error {
+	r, err := rp.Get(ctx)
+	if err != nil {
+		return err
+	}
+	lf := r.GetCallback(RuntimeExecutionModeTournamentStart, "")
+	if lf == nil {
+		rp.Put(r)
+		return errors.New("Runtime Tournament Start function not found.")
+	}
+
+	luaCtx := NewRuntimeLuaContext(r.vm, r.node, r.luaEnv, RuntimeExecutionModeTournamentStart, nil, nil, 0, "", "", nil, "", "", "", "")
+
+	tournamentTable, err := luaTournamentTable(r.vm, tournament)
+	if err != nil {
+		rp.Put(r)
+		return err
+	}
+
+	// Set context value used for logging
+	vmCtx := context.WithValue(ctx, ctxLoggerFields{}, map[string]string{"mode": RuntimeExecutionModeTournamentStart.String()})
+	r.vm.SetContext(vmCtx)
+	retValue, err, _, _ := r.invokeFunction(r.vm, lf, luaCtx, tournamentTable, lua.LNumber(start), lua.LNumber(end))
+	r.vm.SetContext(context.Background())
+	rp.Put(r)
+	if err != nil {
+		return fmt.Errorf("Error running runtime Tournament Start hook: %v", err.Error())
+	}
+
+	if retValue == nil || retValue == lua.LNil {
+		// No return value needed.
+		return nil
+	}
+
+	return errors.New("Unexpected return type from runtime Tournament Start hook, must be nil.")
+}
+
+func (rp *RuntimeProviderLua) TournamentJoin(ctx context.Context, tournament *api.Tournament, userID, username string) error {
+	r, err := rp.Get(ctx)
+	if err != nil {
+		return err
+	}
+	lf := r.GetCallback(RuntimeExecutionModeTournamentJoin, "")
+	if lf == nil {
+		rp.Put(r)
+		return errors.New("Runtime Tournament Join function not found.")
+	}
+
+	luaCtx := NewRuntimeLuaContext(r.vm, r.node, r.luaEnv, RuntimeExecutionModeTournamentJoin, nil, nil, 0, userID, username, nil, "", "", "", "")
+
+	tournamentTable, err := luaTournamentTable(r.vm, tournament)
+	if err != nil {
+		rp.Put(r)
+		return err
+	}
+
+	// Set context value used for logging
+	vmCtx := context.WithValue(ctx, ctxLoggerFields{}, map[string]string{"mode": RuntimeExecutionModeTournamentJoin.String()})
+	r.vm.SetContext(vmCtx)
+	retValue, err, _, _ := r.invokeFunction(r.vm, lf, luaCtx, tournamentTable, lua.LString(userID), lua.LString(username))
+	r.vm.SetContext(context.Background())
+	rp.Put(r)
+	if err != nil {
+		return fmt.Errorf("Error running runtime Tournament Join hook: %v", err.Error())
+	}
+
+	if retValue == nil || retValue == lua.LNil {
+		// No return value needed.
+		return nil
+	}
+
+	return errors.New("Unexpected return type from runtime Tournament Join hook, must be nil.")
+}
+
 func (rp *RuntimeProviderLua) TournamentEnd(ctx context.Context, tournament *api.Tournament, end, reset int64) error {
 	r, err := rp.Get(ctx)
 	if err != nil {
@@ -1832,6 +2037,111 @@ func (rp *RuntimeProviderLua) LeaderboardReset(ctx context.Context, leaderboard
 	return errors.New("Unexpected return type from runtime Leaderboard Reset hook, must be nil.")
 }
 
+func (rp *RuntimeProviderLua) StorageIndexFilter(ctx context.Context, indexName string, write *StorageOpWrite) (bool, error) {
+	r, err := rp.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+	lf := r.GetCallback(RuntimeExecutionModeStorageIndexFilter, indexName)
+	if lf == nil {
+		rp.Put(r)
+		return false, errors.New("Runtime Storage Index Filter function not found.")
+	}
+
+	luaCtx := NewRuntimeLuaContext(r.vm, r.node, r.luaEnv, RuntimeExecutionModeStorageIndexFilter, nil, nil, 0, "", "", nil, "", "", "", "")
+
+	writeTable := r.vm.CreateTable(0, 8)
+	writeTable.RawSetString("key", lua.LString(write.Object.Key))
+	writeTable.RawSetString("collection", lua.LString(write.Object.Collection))
+	writeTable.RawSetString("user_id", lua.LString(write.OwnerID))
+	writeTable.RawSetString("version", lua.LString(write.Object.Version))
+	writeTable.RawSetString("permission_read", lua.LNumber(write.Object.GetPermissionRead().GetValue()))
+	writeTable.RawSetString("permission_write", lua.LNumber(write.Object.GetPermissionWrite().GetValue()))
+	writeTable.RawSetString("expire_time", lua.LNumber(write.ExpireTime))
+
+	valueMap := make(map[string]interface{})
+	err = json.Unmarshal([]byte(write.Object.Value), &valueMap)
+	if err != nil {
+		rp.Put(r)
+		return false, fmt.Errorf("failed to convert value to json: %s", err.Error())
+	}
+	writeTable.RawSetString("value", RuntimeLuaConvertMap(r.vm, valueMap))
+
+	// Set context value used for logging
+	vmCtx := context.WithValue(ctx, ctxLoggerFields{}, map[string]string{"mode": RuntimeExecutionModeStorageIndexFilter.String()})
+	r.vm.SetContext(vmCtx)
+	retValue, err, _, _ := r.invokeFunction(r.vm, lf, luaCtx, writeTable)
+	r.vm.SetContext(context.Background())
+	rp.Put(r)
+	if err != nil {
+		return false, fmt.Errorf("Error running runtime Storage Index Filter hook: %v", err.Error())
+	}
+
+	if retValue == nil || retValue.Type() != lua.LTBool {
+		return false, errors.New("Unexpected return type from runtime Storage Index Filter hook, must be a boolean.")
+	}
+
+	return lua.LVAsBool(retValue), nil
+}
+
+func (rp *RuntimeProviderLua) StorageExpire(ctx context.Context, objects []*api.StorageObject) error {
+	r, err := rp.Get(ctx)
+	if err != nil {
+		return err
+	}
+	lf := r.GetCallback(RuntimeExecutionModeStorageExpire, "")
+	if lf == nil {
+		rp.Put(r)
+		return errors.New("Runtime Storage Expire function not found.")
+	}
+
+	luaCtx := NewRuntimeLuaContext(r.vm, r.node, r.luaEnv, RuntimeExecutionModeStorageExpire, nil, nil, 0, "", "", nil, "", "", "", "")
+
+	objectsTable := r.vm.CreateTable(len(objects), 0)
+	for i, o := range objects {
+		objectTable := r.vm.CreateTable(0, 9)
+		objectTable.RawSetString("key", lua.LString(o.Key))
+		objectTable.RawSetString("collection", lua.LString(o.Collection))
+		if o.UserId != "" {
+			objectTable.RawSetString("user_id", lua.LString(o.UserId))
+		} else {
+			objectTable.RawSetString("user_id", lua.LNil)
+		}
+		objectTable.RawSetString("version", lua.LString(o.Version))
+		objectTable.RawSetString("permission_read", lua.LNumber(o.PermissionRead))
+		objectTable.RawSetString("permission_write", lua.LNumber(o.PermissionWrite))
+		objectTable.RawSetString("create_time", lua.LNumber(o.CreateTime.Seconds))
+		objectTable.RawSetString("update_time", lua.LNumber(o.UpdateTime.Seconds))
+
+		valueMap := make(map[string]interface{})
+		err = json.Unmarshal([]byte(o.Value), &valueMap)
+		if err != nil {
+			rp.Put(r)
+			return fmt.Errorf("failed to convert value to json: %s", err.Error())
+		}
+		objectTable.RawSetString("value", RuntimeLuaConvertMap(r.vm, valueMap))
+
+		objectsTable.RawSetInt(i+1, objectTable)
+	}
+
+	// Set context value used for logging
+	vmCtx := context.WithValue(ctx, ctxLoggerFields{}, map[string]string{"mode": RuntimeExecutionModeStorageExpire.String()})
+	r.vm.SetContext(vmCtx)
+	retValue, err, _, _ := r.invokeFunction(r.vm, lf, luaCtx, objectsTable)
+	r.vm.SetContext(context.Background())
+	rp.Put(r)
+	if err != nil {
+		return fmt.Errorf("Error running runtime Storage Expire hook: %v", err.Error())
+	}
+
+	if retValue == nil || retValue == lua.LNil {
+		// No return value needed.
+		return nil
+	}
+
+	return errors.New("Unexpected return type from runtime Storage Expire hook, must be nil.")
+}
+
 func (rp *RuntimeProviderLua) Get(ctx context.Context) (*RuntimeLua, error) {
 	select {
 	case <-ctx.Done():
@@ -1979,12 +2289,26 @@ func (r *RuntimeLua) GetCallback(e RuntimeExecutionMode, key string) *lua.LFunct
 		return fn
 	case RuntimeExecutionModeMatchmaker:
 		return r.callbacks.Matchmaker
+	case RuntimeExecutionModeTournamentStart:
+		return r.callbacks.TournamentStart
+	case RuntimeExecutionModeTournamentJoin:
+		return r.callbacks.TournamentJoin
 	case RuntimeExecutionModeTournamentEnd:
 		return r.callbacks.TournamentEnd
 	case RuntimeExecutionModeTournamentReset:
 		return r.callbacks.TournamentReset
 	case RuntimeExecutionModeLeaderboardReset:
 		return r.callbacks.LeaderboardReset
+	case RuntimeExecutionModeStorageIndexFilter:
+		fn, found := r.callbacks.StorageIndexFilter.Load(key)
+		if !found {
+			return nil
+		}
+		return fn
+	case RuntimeExecutionModeStorageExpire:
+		return r.callbacks.StorageExpire
+	case RuntimeExecutionModeMatchmakerOverride:
+		return r.callbacks.MatchmakerOverride
 	}
 
 	return nil
@@ -2107,7 +2431,7 @@ func checkRuntimeLuaVM(logger *zap.Logger, config Config, stdLibs map[string]lua
 		vm.Push(lua.LString(name))
 		vm.Call(1, 0)
 	}
-	nakamaModule := NewRuntimeLuaNakamaModule(nil, nil, nil, nil, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	nakamaModule := NewRuntimeLuaNakamaModule(nil, nil, nil, nil, config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	vm.PreloadModule("nakama", nakamaModule.Loader)
 
 	preload := vm.GetField(vm.GetField(vm.Get(lua.EnvironIndex), "package"), "preload")
@@ -2128,7 +2452,7 @@ func checkRuntimeLuaVM(logger *zap.Logger, config Config, stdLibs map[string]lua
 	return nil
 }
 
-func newRuntimeLuaVM(logger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, stdLibs map[string]lua.LGFunction, moduleCache *RuntimeLuaModuleCache, once *sync.Once, localCache *RuntimeLuaLocalCache, matchCreateFn RuntimeMatchCreateFunction, eventFn RuntimeEventCustomFunction, announceCallbackFn func(RuntimeExecutionMode, string)) (*RuntimeLua, error) {
+func newRuntimeLuaVM(logger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, storageIndex StorageIndex, storageFeed StorageFeed, stdLibs map[string]lua.LGFunction, moduleCache *RuntimeLuaModuleCache, once *sync.Once, localCache *RuntimeLuaLocalCache, matchCreateFn RuntimeMatchCreateFunction, eventFn RuntimeEventCustomFunction, announceCallbackFn func(RuntimeExecutionMode, string)) (*RuntimeLua, error) {
 	vm := lua.NewState(lua.Options{
 		CallStackSize:       config.GetRuntime().GetLuaCallStackSize(),
 		RegistrySize:        config.GetRuntime().GetLuaRegistrySize(),
@@ -2142,9 +2466,10 @@ func newRuntimeLuaVM(logger *zap.Logger, db *sql.DB, protojsonMarshaler *protojs
 		vm.Call(1, 0)
 	}
 	callbacks := &RuntimeLuaCallbacks{
-		RPC:    &MapOf[string, *lua.LFunction]{},
-		Before: &MapOf[string, *lua.LFunction]{},
-		After:  &MapOf[string, *lua.LFunction]{},
+		RPC:                &MapOf[string, *lua.LFunction]{},
+		Before:             &MapOf[string, *lua.LFunction]{},
+		After:              &MapOf[string, *lua.LFunction]{},
+		StorageIndexFilter: &MapOf[string, *lua.LFunction]{},
 	}
 	registerCallbackFn := func(e RuntimeExecutionMode, key string, fn *lua.LFunction) {
 		switch e {
@@ -2156,15 +2481,25 @@ func newRuntimeLuaVM(logger *zap.Logger, db *sql.DB, protojsonMarshaler *protojs
 			callbacks.After.Store(key, fn)
 		case RuntimeExecutionModeMatchmaker:
 			callbacks.Matchmaker = fn
+		case RuntimeExecutionModeTournamentStart:
+			callbacks.TournamentStart = fn
+		case RuntimeExecutionModeTournamentJoin:
+			callbacks.TournamentJoin = fn
 		case RuntimeExecutionModeTournamentEnd:
 			callbacks.TournamentEnd = fn
 		case RuntimeExecutionModeTournamentReset:
 			callbacks.TournamentReset = fn
 		case RuntimeExecutionModeLeaderboardReset:
 			callbacks.LeaderboardReset = fn
+		case RuntimeExecutionModeStorageIndexFilter:
+			callbacks.StorageIndexFilter.Store(key, fn)
+		case RuntimeExecutionModeStorageExpire:
+			callbacks.StorageExpire = fn
+		case RuntimeExecutionModeMatchmakerOverride:
+			callbacks.MatchmakerOverride = fn
 		}
 	}
-	nakamaModule := NewRuntimeLuaNakamaModule(logger, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, rankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, once, localCache, matchCreateFn, eventFn, registerCallbackFn, announceCallbackFn)
+	nakamaModule := NewRuntimeLuaNakamaModule(logger, db, protojsonMarshaler, protojsonUnmarshaler, config, socialClient, leaderboardCache, rankCache, leaderboardScheduler, sessionRegistry, sessionCache, statusRegistry, matchRegistry, tracker, metrics, streamManager, router, storageIndex, storageFeed, once, localCache, matchCreateFn, eventFn, registerCallbackFn, announceCallbackFn)
 	vm.PreloadModule("nakama", nakamaModule.Loader)
 	r := &RuntimeLua{
 		logger:    logger,
@@ -2176,3 +2511,46 @@ func newRuntimeLuaVM(logger *zap.Logger, db *sql.DB, protojsonMarshaler *protojs
 
 	return r, r.loadModules(moduleCache)
 }
+
+func luaTournamentTable(l *lua.LState, tournament *api.Tournament) (*lua.LTable, error) {
+	tournamentTable := l.CreateTable(0, 18)
+
+	tournamentTable.RawSetString("id", lua.LString(tournament.Id))
+	tournamentTable.RawSetString("title", lua.LString(tournament.Title))
+	tournamentTable.RawSetString("description", lua.LString(tournament.Description))
+	tournamentTable.RawSetString("category", lua.LNumber(tournament.Category))
+	tournamentTable.RawSetString("sort_order", lua.LString(strconv.FormatUint(uint64(tournament.SortOrder), 10)))
+	tournamentTable.RawSetString("size", lua.LNumber(tournament.Size))
+	tournamentTable.RawSetString("max_size", lua.LNumber(tournament.MaxSize))
+	tournamentTable.RawSetString("max_num_score", lua.LNumber(tournament.MaxNumScore))
+	tournamentTable.RawSetString("duration", lua.LNumber(tournament.Duration))
+	tournamentTable.RawSetString("start_active", lua.LNumber(tournament.StartActive))
+	tournamentTable.RawSetString("end_active", lua.LNumber(tournament.EndActive))
+	tournamentTable.RawSetString("can_enter", lua.LBool(tournament.CanEnter))
+	if tournament.NextReset != 0 {
+		tournamentTable.RawSetString("next_reset", lua.LNumber(tournament.NextReset))
+	} else {
+		tournamentTable.RawSetString("next_reset", lua.LNil)
+	}
+	if tournament.PrevReset != 0 {
+		tournamentTable.RawSetString("prev_reset", lua.LNumber(tournament.PrevReset))
+	} else {
+		tournamentTable.RawSetString("prev_reset", lua.LNil)
+	}
+
+	metadataMap := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(tournament.Metadata), &metadataMap); err != nil {
+		return nil, fmt.Errorf("failed to convert metadata to json: %s", err.Error())
+	}
+	tournamentTable.RawSetString("metadata", RuntimeLuaConvertMap(l, metadataMap))
+	tournamentTable.RawSetString("create_time", lua.LNumber(tournament.CreateTime.Seconds))
+	tournamentTable.RawSetString("start_time", lua.LNumber(tournament.StartTime.Seconds))
+	if tournament.EndTime == nil {
+		tournamentTable.RawSetString("end_time", lua.LNil)
+	} else {
+		tournamentTable.RawSetString("end_time", lua.LNumber(tournament.EndTime.Seconds))
+	}
+	tournamentTable.RawSetString("operator", lua.LString(strings.ToLower(tournament.Operator.String())))
+
+	return tournamentTable, nil
+}
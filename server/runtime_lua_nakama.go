@@ -16,6 +16,7 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/aes"
 	"crypto/cipher"
@@ -76,6 +77,8 @@ type RuntimeLuaNakamaModule struct {
 	metrics              Metrics
 	streamManager        StreamManager
 	router               MessageRouter
+	storageIndex         StorageIndex
+	storageFeed          StorageFeed
 	once                 *sync.Once
 	localCache           *RuntimeLuaLocalCache
 	registerCallbackFn   func(RuntimeExecutionMode, string, *lua.LFunction)
@@ -87,7 +90,7 @@ type RuntimeLuaNakamaModule struct {
 	eventFn       RuntimeEventCustomFunction
 }
 
-func NewRuntimeLuaNakamaModule(logger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, once *sync.Once, localCache *RuntimeLuaLocalCache, matchCreateFn RuntimeMatchCreateFunction, eventFn RuntimeEventCustomFunction, registerCallbackFn func(RuntimeExecutionMode, string, *lua.LFunction), announceCallbackFn func(RuntimeExecutionMode, string)) *RuntimeLuaNakamaModule {
+func NewRuntimeLuaNakamaModule(logger *zap.Logger, db *sql.DB, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, config Config, socialClient *social.Client, leaderboardCache LeaderboardCache, rankCache LeaderboardRankCache, leaderboardScheduler LeaderboardScheduler, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchRegistry MatchRegistry, tracker Tracker, metrics Metrics, streamManager StreamManager, router MessageRouter, storageIndex StorageIndex, storageFeed StorageFeed, once *sync.Once, localCache *RuntimeLuaLocalCache, matchCreateFn RuntimeMatchCreateFunction, eventFn RuntimeEventCustomFunction, registerCallbackFn func(RuntimeExecutionMode, string, *lua.LFunction), announceCallbackFn func(RuntimeExecutionMode, string)) *RuntimeLuaNakamaModule {
 	return &RuntimeLuaNakamaModule{
 		logger:               logger,
 		db:                   db,
@@ -106,6 +109,8 @@ func NewRuntimeLuaNakamaModule(logger *zap.Logger, db *sql.DB, protojsonMarshale
 		metrics:              metrics,
 		streamManager:        streamManager,
 		router:               router,
+		storageIndex:         storageIndex,
+		storageFeed:          storageFeed,
 		once:                 once,
 		localCache:           localCache,
 		registerCallbackFn:   registerCallbackFn,
@@ -128,9 +133,15 @@ func (n *RuntimeLuaNakamaModule) Loader(l *lua.LState) int {
 		"register_rt_before":                 n.registerRTBefore,
 		"register_rt_after":                  n.registerRTAfter,
 		"register_matchmaker_matched":        n.registerMatchmakerMatched,
+		"register_matchmaker_override":       n.registerMatchmakerOverride,
+		"register_tournament_start":          n.registerTournamentStart,
+		"register_tournament_join":           n.registerTournamentJoin,
 		"register_tournament_end":            n.registerTournamentEnd,
 		"register_tournament_reset":          n.registerTournamentReset,
 		"register_leaderboard_reset":         n.registerLeaderboardReset,
+		"register_storage_index":             n.registerStorageIndex,
+		"register_storage_index_filter":      n.registerStorageIndexFilter,
+		"register_storage_expire":            n.registerStorageExpire,
 		"run_once":                           n.runOnce,
 		"get_context":                        n.getContext,
 		"event":                              n.event,
@@ -225,6 +236,8 @@ func (n *RuntimeLuaNakamaModule) Loader(l *lua.LState) int {
 		"match_get":                          n.matchGet,
 		"match_list":                         n.matchList,
 		"match_signal":                       n.matchSignal,
+		"ratings_get":                        n.ratingsGet,
+		"ratings_submit_match":               n.ratingsSubmitMatch,
 		"notification_send":                  n.notificationSend,
 		"notifications_send":                 n.notificationsSend,
 		"notification_send_all":              n.notificationSendAll,
@@ -237,6 +250,7 @@ func (n *RuntimeLuaNakamaModule) Loader(l *lua.LState) int {
 		"storage_read":                       n.storageRead,
 		"storage_write":                      n.storageWrite,
 		"storage_delete":                     n.storageDelete,
+		"storage_index_list":                 n.storageIndexList,
 		"multi_update":                       n.multiUpdate,
 		"leaderboard_create":                 n.leaderboardCreate,
 		"leaderboard_delete":                 n.leaderboardDelete,
@@ -244,6 +258,9 @@ func (n *RuntimeLuaNakamaModule) Loader(l *lua.LState) int {
 		"leaderboard_records_list":           n.leaderboardRecordsList,
 		"leaderboard_record_write":           n.leaderboardRecordWrite,
 		"leaderboard_records_haystack":       n.leaderboardRecordsHaystack,
+		"leaderboard_periods_list":           n.leaderboardPeriodsList,
+		"leaderboard_period_expiry":          n.leaderboardPeriodExpiry,
+		"leaderboard_validation_set":         n.leaderboardValidationSet,
 		"leaderboard_record_delete":          n.leaderboardRecordDelete,
 		"leaderboards_get_id":                n.leaderboardsGetId,
 		"purchase_validate_apple":            n.purchaseValidateApple,
@@ -259,6 +276,7 @@ func (n *RuntimeLuaNakamaModule) Loader(l *lua.LState) int {
 		"tournament_delete":                  n.tournamentDelete,
 		"tournament_add_attempt":             n.tournamentAddAttempt,
 		"tournament_join":                    n.tournamentJoin,
+		"tournament_rewards_set":             n.tournamentRewardsSet,
 		"tournament_list":                    n.tournamentList,
 		"tournaments_get_id":                 n.tournamentsGetId,
 		"tournament_records_list":            n.tournamentRecordsList,
@@ -436,6 +454,54 @@ func (n *RuntimeLuaNakamaModule) registerMatchmakerMatched(l *lua.LState) int {
 	return 0
 }
 
+// @group hooks
+// @summary Registers a function invoked with the candidate matches found by the matchmaker before they are finalized. The function returns the matches to form in order, and may reorder or omit candidates to veto them.
+// @param fn(type=function) A function reference which will be executed with the candidate matches on each matchmaker process.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) registerMatchmakerOverride(l *lua.LState) int {
+	fn := l.CheckFunction(1)
+
+	if n.registerCallbackFn != nil {
+		n.registerCallbackFn(RuntimeExecutionModeMatchmakerOverride, "", fn)
+	}
+	if n.announceCallbackFn != nil {
+		n.announceCallbackFn(RuntimeExecutionModeMatchmakerOverride, "")
+	}
+	return 0
+}
+
+// @group hooks
+// @summary Registers a function to be run when an active period of a tournament starts.
+// @param fn(type=function) A function reference which will be executed on each tournament start.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) registerTournamentStart(l *lua.LState) int {
+	fn := l.CheckFunction(1)
+
+	if n.registerCallbackFn != nil {
+		n.registerCallbackFn(RuntimeExecutionModeTournamentStart, "", fn)
+	}
+	if n.announceCallbackFn != nil {
+		n.announceCallbackFn(RuntimeExecutionModeTournamentStart, "")
+	}
+	return 0
+}
+
+// @group hooks
+// @summary Registers a function to be run after a user joins a tournament.
+// @param fn(type=function) A function reference which will be executed with the tournament, user ID and username on each join.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) registerTournamentJoin(l *lua.LState) int {
+	fn := l.CheckFunction(1)
+
+	if n.registerCallbackFn != nil {
+		n.registerCallbackFn(RuntimeExecutionModeTournamentJoin, "", fn)
+	}
+	if n.announceCallbackFn != nil {
+		n.announceCallbackFn(RuntimeExecutionModeTournamentJoin, "")
+	}
+	return 0
+}
+
 // @group hooks
 // @summary Registers a function to be run when a tournament ends.
 // @param fn(type=function) A function reference which will be executed on each tournament end.
@@ -484,6 +550,80 @@ func (n *RuntimeLuaNakamaModule) registerLeaderboardReset(l *lua.LState) int {
 	return 0
 }
 
+// @group hooks
+// @summary Create a new storage index over fields of storage object values.
+// @param indexName(type=string) Name of the index to register.
+// @param collection(type=string) Collection of storage engine to index objects from.
+// @param key(type=string) Key of storage objects to index. Set to empty string to index all objects of collection.
+// @param fields(type=table) A table of strings with the keys of the storage object values to index.
+// @param maxEntries(type=int) Maximum number of entries kept in the index, the least recently updated entries are dropped first.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) registerStorageIndex(l *lua.LState) int {
+	indexName := l.CheckString(1)
+	collection := l.CheckString(2)
+	key := l.OptString(3, "")
+	fieldsTable := l.CheckTable(4)
+	fields := make([]string, 0, fieldsTable.Len())
+	conversionError := false
+	fieldsTable.ForEach(func(k, v lua.LValue) {
+		if conversionError {
+			return
+		}
+		if v.Type() != lua.LTString {
+			conversionError = true
+			l.ArgError(4, "expects each field to be string")
+			return
+		}
+		fields = append(fields, v.String())
+	})
+	if conversionError {
+		return 0
+	}
+	maxEntries := l.CheckInt(5)
+
+	// Indexes are shared by all Lua runtime instances, only create them during the initial module load.
+	if n.announceCallbackFn != nil {
+		if err := n.storageIndex.CreateIndex(context.Background(), indexName, collection, key, fields, maxEntries); err != nil {
+			l.RaiseError("failed to register storage index: %s", err.Error())
+		}
+	}
+	return 0
+}
+
+// @group hooks
+// @summary Registers a function deciding which written storage objects are added to a storage index.
+// @param indexName(type=string) Name of the index to register the filter function for.
+// @param fn(type=function) A function reference which will be executed on each storage object to be written that is a candidate for the index. It receives the storage write and must return a boolean, where true indexes the object.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) registerStorageIndexFilter(l *lua.LState) int {
+	indexName := l.CheckString(1)
+	fn := l.CheckFunction(2)
+
+	if n.registerCallbackFn != nil {
+		n.registerCallbackFn(RuntimeExecutionModeStorageIndexFilter, indexName, fn)
+	}
+	if n.announceCallbackFn != nil {
+		n.announceCallbackFn(RuntimeExecutionModeStorageIndexFilter, indexName)
+	}
+	return 0
+}
+
+// @group hooks
+// @summary Registers a function to be run when storage objects are removed after their expiry time passed.
+// @param fn(type=function) A function reference which will be executed with a table of the expired storage objects.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) registerStorageExpire(l *lua.LState) int {
+	fn := l.CheckFunction(1)
+
+	if n.registerCallbackFn != nil {
+		n.registerCallbackFn(RuntimeExecutionModeStorageExpire, "", fn)
+	}
+	if n.announceCallbackFn != nil {
+		n.announceCallbackFn(RuntimeExecutionModeStorageExpire, "")
+	}
+	return 0
+}
+
 // @group hooks
 // @summary Registers a function to be run only once.
 // @param fn(type=function) A function reference which will be executed only once.
@@ -2831,7 +2971,7 @@ func (n *RuntimeLuaNakamaModule) usersUnbanId(l *lua.LState) int {
 	}
 
 	// Unban the user accounts.
-	err := UnbanUsers(l.Context(), n.logger, n.db, n.sessionCache, uids)
+	err := UnbanUsers(l.Context(), n.logger, n.db, uids)
 	if err != nil {
 		l.RaiseError(fmt.Sprintf("failed to unban users: %s", err.Error()))
 		return 0
@@ -4646,6 +4786,123 @@ func (n *RuntimeLuaNakamaModule) matchSignal(l *lua.LState) int {
 	return 1
 }
 
+// @group ratings
+// @summary Get the skill ratings of users in a rating queue. Users that have not played a rated match in the queue have the default rating.
+// @param queue(type=string) The rating queue.
+// @param userIds(type=table) A table of the IDs of the users.
+// @return ratings(table) The ratings of the users, in the same order as the user IDs.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) ratingsGet(l *lua.LState) int {
+	queue := l.CheckString(1)
+	if queue == "" {
+		l.ArgError(1, "expects a queue string")
+		return 0
+	}
+
+	userIDsTable, ok := RuntimeLuaConvertLuaValue(l.CheckTable(2)).([]interface{})
+	if !ok {
+		l.ArgError(2, "invalid user ids list")
+		return 0
+	}
+	userIDs := make([]uuid.UUID, 0, len(userIDsTable))
+	for _, id := range userIDsTable {
+		ids, ok := id.(string)
+		if !ok {
+			l.ArgError(2, "each user id must be a string")
+			return 0
+		}
+		userID, err := uuid.FromString(ids)
+		if err != nil {
+			l.ArgError(2, "each user id must be a valid id string")
+			return 0
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	ratings, err := RatingsGet(l.Context(), n.logger, n.db, queue, userIDs)
+	if err != nil {
+		l.RaiseError("error getting ratings: %v", err.Error())
+		return 0
+	}
+
+	l.Push(ratingsToLuaTable(l, ratings))
+	return 1
+}
+
+// @group ratings
+// @summary Submit the result of a rated match, and update the skill ratings of all users who played it.
+// @param queue(type=string) The rating queue.
+// @param teams(type=table) A table of the teams that played the match, each with a table of "user_ids" and a "placement". Teams with a lower placement beat teams with a higher placement, and equal placements tie.
+// @return ratings(table) The new ratings of the users, in the order they appear in the teams.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) ratingsSubmitMatch(l *lua.LState) int {
+	queue := l.CheckString(1)
+	if queue == "" {
+		l.ArgError(1, "expects a queue string")
+		return 0
+	}
+
+	teamsTable, ok := RuntimeLuaConvertLuaValue(l.CheckTable(2)).([]interface{})
+	if !ok {
+		l.ArgError(2, "invalid teams list")
+		return 0
+	}
+	teams := make([]*RatingMatchTeam, 0, len(teamsTable))
+	for _, t := range teamsTable {
+		teamMap, ok := t.(map[string]interface{})
+		if !ok {
+			l.ArgError(2, "expects each team to be a table")
+			return 0
+		}
+		team := &RatingMatchTeam{}
+		userIDs, ok := teamMap["user_ids"].([]interface{})
+		if !ok {
+			l.ArgError(2, "expects each team to have a user_ids table")
+			return 0
+		}
+		for _, id := range userIDs {
+			ids, ok := id.(string)
+			if !ok {
+				l.ArgError(2, "each user id must be a string")
+				return 0
+			}
+			team.UserIDs = append(team.UserIDs, ids)
+		}
+		placement, ok := teamMap["placement"].(int64)
+		if !ok {
+			l.ArgError(2, "expects each team to have a placement number")
+			return 0
+		}
+		team.Placement = int(placement)
+		teams = append(teams, team)
+	}
+
+	ratings, err := RatingsSubmitMatch(l.Context(), n.logger, n.db, queue, teams)
+	if err != nil {
+		l.RaiseError("error submitting rated match: %v", err.Error())
+		return 0
+	}
+
+	l.Push(ratingsToLuaTable(l, ratings))
+	return 1
+}
+
+func ratingsToLuaTable(l *lua.LState, ratings []*Rating) *lua.LTable {
+	ratingsTable := l.CreateTable(len(ratings), 0)
+	for i, rating := range ratings {
+		ratingTable := l.CreateTable(0, 7)
+		ratingTable.RawSetString("user_id", lua.LString(rating.UserID))
+		ratingTable.RawSetString("queue", lua.LString(rating.Queue))
+		ratingTable.RawSetString("rating", lua.LNumber(rating.Rating))
+		ratingTable.RawSetString("deviation", lua.LNumber(rating.Deviation))
+		ratingTable.RawSetString("volatility", lua.LNumber(rating.Volatility))
+		ratingTable.RawSetString("games", lua.LNumber(rating.Games))
+		ratingTable.RawSetString("update_time", lua.LNumber(rating.UpdateTime))
+		ratingsTable.RawSetInt(i+1, ratingTable)
+	}
+	return ratingsTable
+}
+
 // @group matches
 // @summary List currently running realtime multiplayer matches and optionally filter them by authoritative mode, label, and current participant count.
 // @param limit(type=number, optional=true, default=1) The maximum number of matches to list.
@@ -5506,6 +5763,71 @@ func (n *RuntimeLuaNakamaModule) storageList(l *lua.LState) int {
 	return 2
 }
 
+// @group storage
+// @summary List storage objects from a storage index, using a query over the indexed fields of their values.
+// @param indexName(type=string) Name of the index to list entries from.
+// @param query(type=string) Query to filter index entries, using the same syntax as match listing queries against fields prefixed with "value.".
+// @param limit(type=int) Maximum number of results to be returned.
+// @param callerId(type=string, optional=true) User ID of the caller, will apply permissions checks of the user. If empty defaults to system user and permission checks are bypassed.
+// @return objects(table) A list of storage objects.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) storageIndexList(l *lua.LState) int {
+	indexName := l.CheckString(1)
+	query := l.OptString(2, "")
+	limit := l.OptInt(3, 100)
+	if limit < 1 || limit > 10000 {
+		l.ArgError(3, "invalid limit: expects value 1-10000")
+		return 0
+	}
+	callerID := uuid.Nil
+	callerIDStr := l.OptString(4, "")
+	if callerIDStr != "" {
+		cid, err := uuid.FromString(callerIDStr)
+		if err != nil {
+			l.ArgError(4, "expects caller ID to be empty or a valid identifier")
+			return 0
+		}
+		callerID = cid
+	}
+
+	objectList, err := n.storageIndex.List(l.Context(), callerID, indexName, query, limit)
+	if err != nil {
+		l.RaiseError(err.Error())
+		return 0
+	}
+
+	lv := l.CreateTable(len(objectList.GetObjects()), 0)
+	for i, v := range objectList.GetObjects() {
+		vt := l.CreateTable(0, 9)
+		vt.RawSetString("key", lua.LString(v.Key))
+		vt.RawSetString("collection", lua.LString(v.Collection))
+		if v.UserId != "" {
+			vt.RawSetString("user_id", lua.LString(v.UserId))
+		} else {
+			vt.RawSetString("user_id", lua.LNil)
+		}
+		vt.RawSetString("version", lua.LString(v.Version))
+		vt.RawSetString("permission_read", lua.LNumber(v.PermissionRead))
+		vt.RawSetString("permission_write", lua.LNumber(v.PermissionWrite))
+		vt.RawSetString("create_time", lua.LNumber(v.CreateTime.Seconds))
+		vt.RawSetString("update_time", lua.LNumber(v.UpdateTime.Seconds))
+
+		valueMap := make(map[string]interface{})
+		err = json.Unmarshal([]byte(v.Value), &valueMap)
+		if err != nil {
+			l.RaiseError(fmt.Sprintf("failed to convert value to json: %s", err.Error()))
+			return 0
+		}
+		valueTable := RuntimeLuaConvertMap(l, valueMap)
+		vt.RawSetString("value", valueTable)
+
+		lv.RawSetInt(i+1, vt)
+	}
+	l.Push(lv)
+
+	return 1
+}
+
 // @group storage
 // @summary Fetch one or more records by their bucket/collection/keyname and optional user.
 // @param objectIds(type=table) A table of object identifiers to be fetched.
@@ -5680,6 +6002,9 @@ func (n *RuntimeLuaNakamaModule) storageWrite(l *lua.LState) int {
 		}
 
 		var userID uuid.UUID
+		var expireTime int64
+		var patch StoragePatch
+		var valueTable *lua.LTable
 		d := &api.WriteStorageObject{}
 		dataTable.ForEach(func(k, v lua.LValue) {
 			if conversionError {
@@ -5729,14 +6054,7 @@ func (n *RuntimeLuaNakamaModule) storageWrite(l *lua.LState) int {
 					l.ArgError(1, "expects value to be table")
 					return
 				}
-				valueMap := RuntimeLuaConvertLuaTable(v.(*lua.LTable))
-				valueBytes, err := json.Marshal(valueMap)
-				if err != nil {
-					conversionError = true
-					l.ArgError(1, fmt.Sprintf("failed to convert value: %s", err.Error()))
-					return
-				}
-				d.Value = string(valueBytes)
+				valueTable = v.(*lua.LTable)
 			case "version":
 				if v.Type() != lua.LTString {
 					conversionError = true
@@ -5763,6 +6081,30 @@ func (n *RuntimeLuaNakamaModule) storageWrite(l *lua.LState) int {
 					return
 				}
 				d.PermissionWrite = &wrapperspb.Int32Value{Value: int32(v.(lua.LNumber))}
+			case "expire_time":
+				if v.Type() != lua.LTNumber {
+					conversionError = true
+					l.ArgError(1, "expects expire_time to be number")
+					return
+				}
+				expireTime = int64(v.(lua.LNumber))
+				if expireTime < 0 {
+					conversionError = true
+					l.ArgError(1, "expects expire_time to be 0 or a unix timestamp")
+					return
+				}
+			case "patch":
+				if v.Type() != lua.LTString {
+					conversionError = true
+					l.ArgError(1, "expects patch to be string")
+					return
+				}
+				var err error
+				if patch, err = ParseStoragePatch(v.String()); err != nil {
+					conversionError = true
+					l.ArgError(1, "expects patch to be merge or json")
+					return
+				}
 			}
 		})
 
@@ -5778,12 +6120,25 @@ func (n *RuntimeLuaNakamaModule) storageWrite(l *lua.LState) int {
 			conversionError = true
 			l.ArgError(1, "expects key to be supplied")
 			return
-		} else if d.Value == "" {
+		} else if valueTable == nil {
 			conversionError = true
 			l.ArgError(1, "expects value to be supplied")
 			return
 		}
 
+		// JSON patches are arrays of operations, other values are objects.
+		var value interface{} = RuntimeLuaConvertLuaTable(valueTable)
+		if patch == StoragePatchJson {
+			value = RuntimeLuaConvertLuaValue(valueTable)
+		}
+		valueBytes, err := json.Marshal(value)
+		if err != nil {
+			conversionError = true
+			l.ArgError(1, fmt.Sprintf("failed to convert value: %s", err.Error()))
+			return
+		}
+		d.Value = string(valueBytes)
+
 		if d.PermissionRead == nil {
 			// Default to owner read if no permission_read is supplied.
 			d.PermissionRead = &wrapperspb.Int32Value{Value: 1}
@@ -5794,15 +6149,17 @@ func (n *RuntimeLuaNakamaModule) storageWrite(l *lua.LState) int {
 		}
 
 		ops = append(ops, &StorageOpWrite{
-			OwnerID: userID.String(),
-			Object:  d,
+			OwnerID:    userID.String(),
+			Object:     d,
+			ExpireTime: expireTime,
+			Patch:      patch,
 		})
 	})
 	if conversionError {
 		return 0
 	}
 
-	acks, _, err := StorageWriteObjects(l.Context(), n.logger, n.db, n.metrics, true, ops)
+	acks, _, err := StorageWriteObjects(l.Context(), n.logger, n.db, n.metrics, n.storageIndex, n.storageFeed, true, ops)
 	if err != nil {
 		l.RaiseError(fmt.Sprintf("failed to write storage objects: %s", err.Error()))
 		return 0
@@ -5934,7 +6291,7 @@ func (n *RuntimeLuaNakamaModule) storageDelete(l *lua.LState) int {
 		return 0
 	}
 
-	if _, err := StorageDeleteObjects(l.Context(), n.logger, n.db, true, ops); err != nil {
+	if _, err := StorageDeleteObjects(l.Context(), n.logger, n.db, n.storageIndex, n.storageFeed, true, ops); err != nil {
 		l.RaiseError(fmt.Sprintf("failed to remove storage: %s", err.Error()))
 	}
 
@@ -6082,6 +6439,9 @@ func (n *RuntimeLuaNakamaModule) multiUpdate(l *lua.LState) int {
 			}
 
 			var userID uuid.UUID
+			var expireTime int64
+			var patch StoragePatch
+			var valueTable *lua.LTable
 			d := &api.WriteStorageObject{}
 			dataTable.ForEach(func(k, v lua.LValue) {
 				if conversionError {
@@ -6131,14 +6491,7 @@ func (n *RuntimeLuaNakamaModule) multiUpdate(l *lua.LState) int {
 						l.ArgError(2, "expects value to be table")
 						return
 					}
-					valueMap := RuntimeLuaConvertLuaTable(v.(*lua.LTable))
-					valueBytes, err := json.Marshal(valueMap)
-					if err != nil {
-						conversionError = true
-						l.ArgError(2, fmt.Sprintf("failed to convert value: %s", err.Error()))
-						return
-					}
-					d.Value = string(valueBytes)
+					valueTable = v.(*lua.LTable)
 				case "version":
 					if v.Type() != lua.LTString {
 						conversionError = true
@@ -6165,6 +6518,30 @@ func (n *RuntimeLuaNakamaModule) multiUpdate(l *lua.LState) int {
 						return
 					}
 					d.PermissionWrite = &wrapperspb.Int32Value{Value: int32(v.(lua.LNumber))}
+				case "expire_time":
+					if v.Type() != lua.LTNumber {
+						conversionError = true
+						l.ArgError(2, "expects expire_time to be number")
+						return
+					}
+					expireTime = int64(v.(lua.LNumber))
+					if expireTime < 0 {
+						conversionError = true
+						l.ArgError(2, "expects expire_time to be 0 or a unix timestamp")
+						return
+					}
+				case "patch":
+					if v.Type() != lua.LTString {
+						conversionError = true
+						l.ArgError(2, "expects patch to be string")
+						return
+					}
+					var err error
+					if patch, err = ParseStoragePatch(v.String()); err != nil {
+						conversionError = true
+						l.ArgError(2, "expects patch to be merge or json")
+						return
+					}
 				}
 			})
 
@@ -6180,12 +6557,25 @@ func (n *RuntimeLuaNakamaModule) multiUpdate(l *lua.LState) int {
 				conversionError = true
 				l.ArgError(2, "expects key to be supplied")
 				return
-			} else if d.Value == "" {
+			} else if valueTable == nil {
 				conversionError = true
 				l.ArgError(2, "expects value to be supplied")
 				return
 			}
 
+			// JSON patches are arrays of operations, other values are objects.
+			var value interface{} = RuntimeLuaConvertLuaTable(valueTable)
+			if patch == StoragePatchJson {
+				value = RuntimeLuaConvertLuaValue(valueTable)
+			}
+			valueBytes, err := json.Marshal(value)
+			if err != nil {
+				conversionError = true
+				l.ArgError(2, fmt.Sprintf("failed to convert value: %s", err.Error()))
+				return
+			}
+			d.Value = string(valueBytes)
+
 			if d.PermissionRead == nil {
 				// Default to owner read if no permission_read is supplied.
 				d.PermissionRead = &wrapperspb.Int32Value{Value: 1}
@@ -6196,8 +6586,10 @@ func (n *RuntimeLuaNakamaModule) multiUpdate(l *lua.LState) int {
 			}
 
 			storageWriteOps = append(storageWriteOps, &StorageOpWrite{
-				OwnerID: userID.String(),
-				Object:  d,
+				OwnerID:    userID.String(),
+				Object:     d,
+				ExpireTime: expireTime,
+				Patch:      patch,
 			})
 		})
 		if conversionError {
@@ -6302,7 +6694,7 @@ func (n *RuntimeLuaNakamaModule) multiUpdate(l *lua.LState) int {
 
 	updateLedger := l.OptBool(4, false)
 
-	acks, results, err := MultiUpdate(l.Context(), n.logger, n.db, n.metrics, accountUpdates, storageWriteOps, walletUpdates, updateLedger)
+	acks, results, err := MultiUpdate(l.Context(), n.logger, n.db, n.metrics, n.storageIndex, n.storageFeed, accountUpdates, storageWriteOps, walletUpdates, updateLedger)
 	if err != nil {
 		l.RaiseError("error running multi update: %v", err.Error())
 		return 0
@@ -6357,7 +6749,7 @@ func (n *RuntimeLuaNakamaModule) multiUpdate(l *lua.LState) int {
 // @summary Setup a new dynamic leaderboard with the specified ID and various configuration settings. The leaderboard will be created if it doesn't already exist, otherwise its configuration will not be updated.
 // @param leaderboardID(type=string) The unique identifier for the new leaderboard. This is used by clients to submit scores.
 // @param authoritative(type=bool, default=false) Mark the leaderboard as authoritative which ensures updates can only be made via the Go runtime. No client can submit a score directly.
-// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the leaderboard. Possible values are "asc" or "desc".
+// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the leaderboard. Possible values are "asc" or "desc", optionally followed by a comma and the subscore sort order, then a comma and "time" to break ties by earliest submission. For example "desc,asc,time".
 // @param operator(type=string, optional=true, default="best") The operator that determines how scores behave when submitted; possible values are "best", "set", or "incr".
 // @param resetSchedule(type=string, optional=true) The cron format used to define the reset schedule for the leaderboard. This controls when a leaderboard is reset and can be used to power daily/weekly/monthly leaderboards.
 // @param metadata(type=table, optional=true) The metadata you want associated to the leaderboard. Some good examples are weather conditions for a racing game.
@@ -6372,14 +6764,9 @@ func (n *RuntimeLuaNakamaModule) leaderboardCreate(l *lua.LState) int {
 	authoritative := l.OptBool(2, false)
 
 	sortOrder := l.OptString(3, "desc")
-	var sortOrderNumber int
-	switch sortOrder {
-	case "asc":
-		sortOrderNumber = LeaderboardSortOrderAscending
-	case "desc":
-		sortOrderNumber = LeaderboardSortOrderDescending
-	default:
-		l.ArgError(3, "expects sort order to be 'asc' or 'desc'")
+	sort, err := ParseLeaderboardSort(sortOrder)
+	if err != nil {
+		l.ArgError(3, "expects sort order to be 'asc' or 'desc', optionally followed by a subscore sort order and 'time'")
 		return 0
 	}
 
@@ -6419,7 +6806,7 @@ func (n *RuntimeLuaNakamaModule) leaderboardCreate(l *lua.LState) int {
 		metadataStr = string(metadataBytes)
 	}
 
-	if _, err := n.leaderboardCache.Create(l.Context(), id, authoritative, sortOrderNumber, operatorNumber, resetSchedule, metadataStr); err != nil {
+	if _, err := n.leaderboardCache.Create(l.Context(), id, authoritative, sort, operatorNumber, resetSchedule, metadataStr); err != nil {
 		l.RaiseError("error creating leaderboard: %v", err.Error())
 	}
 
@@ -6581,7 +6968,7 @@ func (n *RuntimeLuaNakamaModule) leaderboardRecordsList(l *lua.LState) int {
 	cursor := l.OptString(4, "")
 	overrideExpiry := l.OptInt64(5, 0)
 
-	records, err := LeaderboardRecordsList(l.Context(), n.logger, n.db, n.leaderboardCache, n.rankCache, id, limit, cursor, ownerIds, overrideExpiry)
+	records, err := LeaderboardRecordsList(l.Context(), n.logger, n.db, n.leaderboardCache, n.rankCache, id, limit, cursor, ownerIds, nil, overrideExpiry)
 	if err != nil {
 		l.RaiseError("error listing leaderboard records: %v", err.Error())
 		return 0
@@ -6657,7 +7044,7 @@ func (n *RuntimeLuaNakamaModule) leaderboardRecordWrite(l *lua.LState) int {
 		}
 	}
 
-	record, err := LeaderboardRecordWrite(l.Context(), n.logger, n.db, n.leaderboardCache, n.rankCache, uuid.Nil, id, ownerID, username, score, subscore, metadataStr, overrideOperator)
+	record, err := LeaderboardRecordWrite(l.Context(), n.logger, n.db, n.leaderboardCache, n.rankCache, n.metrics, uuid.Nil, id, ownerID, username, score, subscore, metadataStr, overrideOperator)
 	if err != nil {
 		l.RaiseError("error writing leaderboard record: %v", err.Error())
 		return 0
@@ -6720,6 +7107,149 @@ func (n *RuntimeLuaNakamaModule) leaderboardRecordsHaystack(l *lua.LState) int {
 	return leaderboardRecordsToLua(l, records.Records, records.OwnerRecords, records.PrevCursor, records.NextCursor, true)
 }
 
+// @group leaderboards
+// @summary List past periods of a leaderboard with a reset schedule, most recent first, with their final top records.
+// @param id(type=string) The ID of the leaderboard to list periods for.
+// @param limit(type=number, optional=true, default=10) Return only the required number of periods denoted by this limit value. Between 1-100.
+// @param topCount(type=number, optional=true, default=10) The number of top records to return for each period. Between 0-100.
+// @param cursor(type=string, optional=true, default="") Pagination cursor from previous result. Don't set to start fetching from the beginning.
+// @return periods(table) A list of periods with their expiry time and top records.
+// @return nextCursor(string) An optional next page cursor that can be used to retrieve the next page of periods (if any).
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) leaderboardPeriodsList(l *lua.LState) int {
+	id := l.CheckString(1)
+	if id == "" {
+		l.ArgError(1, "expects a leaderboard ID string")
+		return 0
+	}
+
+	limit := l.OptInt(2, 10)
+	if limit < 1 || limit > 100 {
+		l.ArgError(2, "limit must be 1-100")
+		return 0
+	}
+
+	topCount := l.OptInt(3, 10)
+	if topCount < 0 || topCount > 100 {
+		l.ArgError(3, "top count must be 0-100")
+		return 0
+	}
+
+	cursor := l.OptString(4, "")
+
+	periods, nextCursor, err := LeaderboardPeriodsList(l.Context(), n.logger, n.db, n.leaderboardCache, n.rankCache, id, limit, topCount, cursor)
+	if err != nil {
+		l.RaiseError("error listing leaderboard periods: %v", err.Error())
+		return 0
+	}
+
+	periodsTable := l.CreateTable(len(periods), 0)
+	for i, period := range periods {
+		recordsTable := l.CreateTable(len(period.Records), 0)
+		for j, record := range period.Records {
+			recordTable, err := recordToLuaTable(l, record)
+			if err != nil {
+				l.RaiseError(err.Error())
+				return 0
+			}
+			recordsTable.RawSetInt(j+1, recordTable)
+		}
+
+		periodTable := l.CreateTable(0, 2)
+		periodTable.RawSetString("expiry_time", lua.LNumber(period.ExpiryTime))
+		periodTable.RawSetString("records", recordsTable)
+		periodsTable.RawSetInt(i+1, periodTable)
+	}
+	l.Push(periodsTable)
+
+	if nextCursor != "" {
+		l.Push(lua.LString(nextCursor))
+	} else {
+		l.Push(lua.LNil)
+	}
+	return 2
+}
+
+// @group leaderboards
+// @summary Find the expiry time of a past period of a leaderboard with a reset schedule, to list its records.
+// @param id(type=string) The ID of the leaderboard.
+// @param periodsAgo(type=number) The number of periods before the current one, 1 being the period that ended most recently.
+// @return expiry(number) The expiry time of the period, in seconds since epoch.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) leaderboardPeriodExpiry(l *lua.LState) int {
+	id := l.CheckString(1)
+	if id == "" {
+		l.ArgError(1, "expects a leaderboard ID string")
+		return 0
+	}
+
+	leaderboard := n.leaderboardCache.Get(id)
+	if leaderboard == nil {
+		l.RaiseError("error finding leaderboard period: %v", ErrLeaderboardNotFound.Error())
+		return 0
+	}
+
+	expiry, err := LeaderboardPeriodExpiry(leaderboard, l.CheckInt(2), time.Now())
+	if err != nil {
+		l.RaiseError("error finding leaderboard period: %v", err.Error())
+		return 0
+	}
+
+	l.Push(lua.LNumber(expiry))
+	return 1
+}
+
+// @group leaderboards
+// @summary Set the limits on scores clients may submit to a leaderboard or tournament. Scores written by the server are not validated.
+// @param id(type=string) The ID of the leaderboard or tournament.
+// @param validation(type=table, optional=true) The validation rules with optional min_score, max_score, max_score_delta, max_submissions, and submission_window_sec, replacing any existing rules. Nil removes all rules.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) leaderboardValidationSet(l *lua.LState) int {
+	id := l.CheckString(1)
+	if id == "" {
+		l.ArgError(1, "expects a leaderboard ID string")
+		return 0
+	}
+
+	var validation *LeaderboardValidation
+	if validationTable := l.OptTable(2, nil); validationTable != nil {
+		validation = &LeaderboardValidation{}
+		conversionError := false
+		validationTable.ForEach(func(k, v lua.LValue) {
+			if conversionError {
+				return
+			}
+
+			if v.Type() != lua.LTNumber {
+				conversionError = true
+				l.ArgError(2, fmt.Sprintf("expects %v to be number", k.String()))
+				return
+			}
+			value := int64(v.(lua.LNumber))
+			switch k.String() {
+			case "min_score":
+				validation.MinScore = &value
+			case "max_score":
+				validation.MaxScore = &value
+			case "max_score_delta":
+				validation.MaxScoreDelta = value
+			case "max_submissions":
+				validation.MaxSubmissions = int(value)
+			case "submission_window_sec":
+				validation.SubmissionWindowSec = int(value)
+			}
+		})
+		if conversionError {
+			return 0
+		}
+	}
+
+	if err := LeaderboardValidationSet(l.Context(), n.leaderboardCache, id, validation); err != nil {
+		l.RaiseError("error setting leaderboard validation: %v", err.Error())
+	}
+	return 0
+}
+
 // @group leaderboards
 // @summary Remove an owner's record from a leaderboard, if one exists.
 // @param id(type=string) The unique identifier for the leaderboard to delete from.
@@ -7225,7 +7755,7 @@ func (n *RuntimeLuaNakamaModule) subscriptionsList(l *lua.LState) int {
 // @summary Setup a new dynamic tournament with the specified ID and various configuration settings. The underlying leaderboard will be created if it doesn't already exist, otherwise its configuration will not be updated.
 // @param id(type=string) The unique identifier for the new tournament. This is used by clients to submit scores.
 // @param authoritative(type=bool, optional=true, default=true) Whether the tournament created is server authoritative.
-// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the tournament. Possible values are "asc" or "desc".
+// @param sortOrder(type=string, optional=true, default="desc") The sort order for records in the tournament. Possible values are "asc" or "desc", optionally followed by a comma and the subscore sort order, then a comma and "time" to break ties by earliest submission. For example "desc,asc,time".
 // @param operator(type=string, optional=true, default="best") The operator that determines how scores behave when submitted. The possible values are "best", "set", or "incr".
 // @param resetSchedule(type=string, optional=true) The cron format used to define the reset schedule for the tournament. This controls when the underlying leaderboard resets and the tournament is considered active again.
 // @param metadata(type=table, optional=true) The metadata you want associated to the tournament. Some good examples are weather conditions for a racing game.
@@ -7238,6 +7768,7 @@ func (n *RuntimeLuaNakamaModule) subscriptionsList(l *lua.LState) int {
 // @param maxSize(type=number, optional=true) Maximum size of participants in a tournament.
 // @param maxNumScore(type=number, optional=true) Maximum submission attempts for a tournament record.
 // @param joinRequired(type=bool, optional=true, default=false) Whether the tournament needs to be joined before a record write is allowed.
+// @param bucketSize(type=number, optional=true, default=0) Rank participants in buckets of up to this many owners assigned when they join, rather than all together. Requires joinRequired. 0 to disable.
 // @return error(error) An optional error value if an error occurred.
 func (n *RuntimeLuaNakamaModule) tournamentCreate(l *lua.LState) int {
 	id := l.CheckString(1)
@@ -7249,14 +7780,9 @@ func (n *RuntimeLuaNakamaModule) tournamentCreate(l *lua.LState) int {
 	authoritative := l.OptBool(2, true)
 
 	sortOrder := l.OptString(3, "desc")
-	var sortOrderNumber int
-	switch sortOrder {
-	case "asc":
-		sortOrderNumber = LeaderboardSortOrderAscending
-	case "desc":
-		sortOrderNumber = LeaderboardSortOrderDescending
-	default:
-		l.ArgError(3, "expects sort order to be 'asc' or 'desc'")
+	sort, err := ParseLeaderboardSort(sortOrder)
+	if err != nil {
+		l.ArgError(3, "expects sort order to be 'asc' or 'desc', optionally followed by a subscore sort order and 'time'")
 		return 0
 	}
 
@@ -7330,8 +7856,17 @@ func (n *RuntimeLuaNakamaModule) tournamentCreate(l *lua.LState) int {
 		return 0
 	}
 	joinRequired := l.OptBool(15, false)
+	bucketSize := l.OptInt(16, 0)
+	if bucketSize < 0 {
+		l.ArgError(16, "bucketSize must be >= 0")
+		return 0
+	}
+	if bucketSize > 0 && !joinRequired {
+		l.ArgError(16, "bucketSize requires joinRequired")
+		return 0
+	}
 
-	if err := TournamentCreate(l.Context(), n.logger, n.leaderboardCache, n.leaderboardScheduler, id, authoritative, sortOrderNumber, operatorNumber, resetSchedule, metadataStr, title, description, category, startTime, endTime, duration, maxSize, maxNumScore, joinRequired); err != nil {
+	if err := TournamentCreate(l.Context(), n.logger, n.leaderboardCache, n.leaderboardScheduler, id, authoritative, sort, operatorNumber, resetSchedule, metadataStr, title, description, category, startTime, endTime, duration, maxSize, maxNumScore, joinRequired, bucketSize); err != nil {
 		l.RaiseError("error creating tournament: %v", err.Error())
 	}
 	return 0
@@ -7393,6 +7928,7 @@ func (n *RuntimeLuaNakamaModule) tournamentAddAttempt(l *lua.LState) int {
 // @param id(type=string) The unique identifier for the tournament to join.
 // @param userId(type=string) The owner of the record.
 // @param username(type=string) The username of the record owner.
+// @param rating(type=number, optional=true) The owner's rating, used by bucketed tournaments to place the owner in a bucket with owners of a similar rating.
 // @return error(error) An optional error value if an error occurred.
 func (n *RuntimeLuaNakamaModule) tournamentJoin(l *lua.LState) int {
 	id := l.CheckString(1)
@@ -7416,12 +7952,149 @@ func (n *RuntimeLuaNakamaModule) tournamentJoin(l *lua.LState) int {
 		return 0
 	}
 
-	if err := TournamentJoin(l.Context(), n.logger, n.db, n.leaderboardCache, userID, username, id); err != nil {
+	var rating *int64
+	if l.Get(4) != lua.LNil {
+		r := l.CheckInt64(4)
+		rating = &r
+	}
+
+	if err := TournamentJoin(l.Context(), n.logger, n.db, n.leaderboardCache, n.leaderboardScheduler, userID, username, id, rating, n.config.GetLeaderboard().BucketRatingRange); err != nil {
 		l.RaiseError("error joining tournament: %v", err.Error())
 	}
 	return 0
 }
 
+// @group tournaments
+// @summary Set the rewards paid out to owners when each active period of a tournament ends. Each reward covers a range of ranks, ranks within the bucket for bucketed tournaments, and may apply a wallet changeset and send a notification to each owner in the range.
+// @param id(type=string) The unique identifier for the tournament.
+// @param rewards(type=table) A table of rewards, each with rank_min, rank_max, and optionally a changeset, wallet ledger metadata, and a notification table with subject, content, code, and persistent. Rank ranges must not overlap. An empty table removes all rewards.
+// @return error(error) An optional error value if an error occurred.
+func (n *RuntimeLuaNakamaModule) tournamentRewardsSet(l *lua.LState) int {
+	id := l.CheckString(1)
+	if id == "" {
+		l.ArgError(1, "expects a tournament ID string")
+		return 0
+	}
+
+	rewardsTable := l.CheckTable(2)
+	rewards := make([]*TournamentReward, 0, rewardsTable.Len())
+	conversionError := false
+	rewardsTable.ForEach(func(k, v lua.LValue) {
+		if conversionError {
+			return
+		}
+
+		rewardTable, ok := v.(*lua.LTable)
+		if !ok {
+			conversionError = true
+			l.ArgError(2, "expects a valid set of rewards")
+			return
+		}
+
+		reward := &TournamentReward{}
+		rewardTable.ForEach(func(k, v lua.LValue) {
+			if conversionError {
+				return
+			}
+
+			switch k.String() {
+			case "rank_min":
+				if v.Type() != lua.LTNumber {
+					conversionError = true
+					l.ArgError(2, "expects rank_min to be number")
+					return
+				}
+				reward.RankMin = int64(v.(lua.LNumber))
+			case "rank_max":
+				if v.Type() != lua.LTNumber {
+					conversionError = true
+					l.ArgError(2, "expects rank_max to be number")
+					return
+				}
+				reward.RankMax = int64(v.(lua.LNumber))
+			case "changeset":
+				if v.Type() != lua.LTTable {
+					conversionError = true
+					l.ArgError(2, "expects changeset to be table")
+					return
+				}
+				changeset := RuntimeLuaConvertLuaTable(v.(*lua.LTable))
+				reward.Changeset = make(map[string]int64, len(changeset))
+				for ck, cv := range changeset {
+					cvi, ok := cv.(int64)
+					if !ok {
+						conversionError = true
+						l.ArgError(2, "expects changeset values to be whole numbers")
+						return
+					}
+					reward.Changeset[ck] = cvi
+				}
+			case "metadata":
+				if v.Type() != lua.LTTable {
+					conversionError = true
+					l.ArgError(2, "expects metadata to be table")
+					return
+				}
+				reward.Metadata = RuntimeLuaConvertLuaTable(v.(*lua.LTable))
+			case "notification":
+				if v.Type() != lua.LTTable {
+					conversionError = true
+					l.ArgError(2, "expects notification to be table")
+					return
+				}
+				notification := &TournamentRewardNotification{}
+				v.(*lua.LTable).ForEach(func(k, v lua.LValue) {
+					if conversionError {
+						return
+					}
+
+					switch k.String() {
+					case "subject":
+						if v.Type() != lua.LTString {
+							conversionError = true
+							l.ArgError(2, "expects notification subject to be string")
+							return
+						}
+						notification.Subject = v.String()
+					case "content":
+						if v.Type() != lua.LTTable {
+							conversionError = true
+							l.ArgError(2, "expects notification content to be table")
+							return
+						}
+						notification.Content = RuntimeLuaConvertLuaTable(v.(*lua.LTable))
+					case "code":
+						if v.Type() != lua.LTNumber {
+							conversionError = true
+							l.ArgError(2, "expects notification code to be number")
+							return
+						}
+						notification.Code = int(v.(lua.LNumber))
+					case "persistent":
+						if v.Type() != lua.LTBool {
+							conversionError = true
+							l.ArgError(2, "expects notification persistent to be boolean")
+							return
+						}
+						notification.Persistent = lua.LVAsBool(v)
+					}
+				})
+				reward.Notification = notification
+			}
+		})
+
+		rewards = append(rewards, reward)
+	})
+	if conversionError {
+		return 0
+	}
+
+	if err := TournamentRewardsSet(l.Context(), n.logger, n.db, n.leaderboardCache, id, rewards); err != nil {
+		l.RaiseError("error setting tournament rewards: %v", err.Error())
+	}
+	return 0
+}
+
 // @group tournaments
 // @summary Fetch one or more tournaments by ID.
 // @param ids(type=table) The table of tournament ids.
@@ -7591,7 +8264,7 @@ func (n *RuntimeLuaNakamaModule) tournamentRecordsList(l *lua.LState) int {
 	cursor := l.OptString(4, "")
 	overrideExpiry := l.OptInt64(5, 0)
 
-	records, err := TournamentRecordsList(l.Context(), n.logger, n.db, n.leaderboardCache, n.rankCache, id, ownerIds, limit, cursor, overrideExpiry)
+	records, err := TournamentRecordsList(l.Context(), n.logger, n.db, n.leaderboardCache, n.rankCache, id, ownerIds, nil, limit, cursor, overrideExpiry)
 	if err != nil {
 		l.RaiseError("error listing tournament records: %v", err.Error())
 		return 0
@@ -7817,7 +8490,7 @@ func (n *RuntimeLuaNakamaModule) tournamentRecordWrite(l *lua.LState) int {
 		return 0
 	}
 
-	record, err := TournamentRecordWrite(l.Context(), n.logger, n.db, n.leaderboardCache, n.rankCache, uuid.Nil, id, userID, username, score, subscore, metadataStr, api.Operator(overrideOperator))
+	record, err := TournamentRecordWrite(l.Context(), n.logger, n.db, n.leaderboardCache, n.rankCache, n.metrics, uuid.Nil, id, userID, username, score, subscore, metadataStr, api.Operator(overrideOperator))
 	if err != nil {
 		l.RaiseError("error writing tournament record: %v", err.Error())
 		return 0
@@ -9467,7 +10140,7 @@ func (n *RuntimeLuaNakamaModule) channelMessagesList(l *lua.LState) int {
 		return 0
 	}
 
-	list, err := ChannelMessagesList(l.Context(), n.logger, n.db, uuid.Nil, channelIdToStreamResult.Stream, channelId, limit, forward, cursor)
+	list, err := ChannelMessagesList(l.Context(), n.logger, n.db, uuid.Nil, channelIdToStreamResult.Stream, channelId, uuid.Nil, limit, forward, cursor)
 	if err != nil {
 		l.RaiseError("failed to list channel messages: %v", err.Error())
 		return 0
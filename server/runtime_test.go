@@ -24,12 +24,15 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/heroiclabs/nakama-common/api"
 	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama/v3/apigrpc"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -76,7 +79,7 @@ func runtimeWithModules(t *testing.T, modules map[string]string) (*Runtime, *Run
 	cfg := NewConfig(logger)
 	cfg.Runtime.Path = dir
 
-	return NewRuntime(context.Background(), logger, logger, NewDB(t), protojsonMarshaler, protojsonUnmarshaler, cfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, metrics, nil, &DummyMessageRouter{})
+	return NewRuntime(context.Background(), logger, logger, NewDB(t), protojsonMarshaler, protojsonUnmarshaler, cfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, metrics, nil, &DummyMessageRouter{}, storageIdx, storageFeed)
 }
 
 func TestRuntimeSampleScript(t *testing.T) {
@@ -329,6 +332,23 @@ nakama.register_rpc(test.printWorld, "helloworld")`,
 	}
 }
 
+func TestRuntimeRegisterRPCReservedID(t *testing.T) {
+	modules := map[string]string{
+		"test": `
+local nakama = require("nakama")
+nakama.register_rpc(function(ctx, payload) return payload end, "nakama.storage_subscribe")`,
+	}
+
+	// Functions registered with the IDs of built-in socket RPCs take precedence over them.
+	runtime, _, err := runtimeWithModules(t, modules)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if runtime.Rpc("nakama.storage_subscribe") == nil {
+		t.Fatal("Expected RPC function registered with a built-in socket RPC ID")
+	}
+}
+
 func TestRuntimeRegisterRPCWithPayloadEndToEnd(t *testing.T) {
 	modules := map[string]string{
 		"test": `
@@ -354,7 +374,7 @@ nakama.register_rpc(test.printWorld, "helloworld")`,
 
 	db := NewDB(t)
 	pipeline := NewPipeline(logger, cfg, db, protojsonMarshaler, protojsonUnmarshaler, nil, nil, nil, nil, nil, nil, nil, runtime)
-	apiServer := StartApiServer(logger, logger, db, protojsonMarshaler, protojsonUnmarshaler, cfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, metrics, pipeline, runtime)
+	apiServer := StartApiServer(logger, logger, db, protojsonMarshaler, protojsonUnmarshaler, cfg, nil, nil, nil, nil, storageIdx, storageFeed, nil, nil, nil, nil, nil, nil, nil, nil, metrics, pipeline, runtime)
 	defer apiServer.Stop()
 
 	payload := "\"Hello World\""
@@ -827,6 +847,72 @@ nakama.register_req_before(before_storage_write, "WriteStorageObjects")`,
 	}
 }
 
+func TestRuntimeReqBeforeHookWriteWithOptions(t *testing.T) {
+	modules := map[string]string{
+		"test": `
+local nakama = require("nakama")
+function before_storage_write(ctx, payload)
+	payload.objects[1].value = '{"key": "hooked"}'
+	return payload
+end
+nakama.register_req_before(before_storage_write, "WriteStorageObjects")`,
+	}
+
+	runtime, _, err := runtimeWithModules(t, modules)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	apiserver, _ := NewAPIServer(t, runtime)
+	defer apiserver.Stop()
+	conn, client, session, ctx := NewAuthenticatedAPIClient(t, uuid.Must(uuid.NewV4()).String())
+	defer conn.Close()
+
+	expireTime := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	acks, err := client.WriteStorageObjectsWithOptions(ctx, &apigrpc.WriteStorageObjectsWithOptionsRequest{
+		Objects: []*apigrpc.WriteStorageObjectWithOptions{{
+			Collection: "collection",
+			Key:        "key",
+			Value:      `{"key": "value"}`,
+			ExpireTime: timestamppb.New(expireTime),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(acks.Acks) != 1 {
+		t.Fatal("Invocation failed. Return result not expected: ", len(acks.Acks))
+	}
+
+	userID, err := UserIDFromSession(session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var value string
+	var dbExpireTime time.Time
+	if err = apiserver.db.QueryRow("SELECT value, expire_time FROM storage WHERE collection = $1 AND key = $2 AND user_id = $3", "collection", "key", userID).Scan(&value, &dbExpireTime); err != nil {
+		t.Fatal(err)
+	}
+	if value != `{"key": "hooked"}` {
+		t.Errorf("Before hook was not applied, value: %v", value)
+	}
+	if !dbExpireTime.Equal(expireTime) {
+		t.Errorf("Expire time not stored, expected %v got %v", expireTime, dbExpireTime)
+	}
+
+	_, err = client.WriteStorageObjectsWithOptions(ctx, &apigrpc.WriteStorageObjectsWithOptionsRequest{
+		Objects: []*apigrpc.WriteStorageObjectWithOptions{{
+			Collection: "collection",
+			Key:        "key",
+			Value:      `{"key": "value"}`,
+			ExpireTime: timestamppb.New(time.Now().Add(-time.Hour)),
+		}},
+	})
+	if err == nil {
+		t.Fatal("Expire time in the past should have been rejected.")
+	}
+}
+
 func TestRuntimeReqAfterHook(t *testing.T) {
 	modules := map[string]string{
 		"test": `
@@ -35,10 +35,9 @@ type SessionCache interface {
 	Remove(userID uuid.UUID, sessionExp int64, sessionToken string, refreshExp int64, refreshToken string)
 	// Remove all of a user's session and refresh tokens.
 	RemoveAll(userID uuid.UUID)
-	// Mark a set of users as banned.
+	// Mark a set of users as banned. Their tokens are removed, the ban itself is enforced by the user account, so
+	// unbanning needs no change to the cache and users authenticate again to get new tokens.
 	Ban(userIDs []uuid.UUID)
-	// Unban a set of users.
-	Unban(userIDs []uuid.UUID)
 }
 
 type sessionCacheUser struct {
@@ -177,5 +176,3 @@ func (s *LocalSessionCache) Ban(userIDs []uuid.UUID) {
 	}
 	s.Unlock()
 }
-
-func (s *LocalSessionCache) Unban(userIDs []uuid.UUID) {}
@@ -0,0 +1,261 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	SessionCacheBackendLocal    = "local"
+	SessionCacheBackendDatabase = "database"
+
+	clusterMessageSessionCacheInvalidate = "session_cache_invalidate"
+)
+
+// NewSessionCache selects the session cache implementation matching the session configuration.
+func NewSessionCache(logger *zap.Logger, db *sql.DB, transport ClusterTransport, config Config) SessionCache {
+	if config.GetSession().CacheBackend == SessionCacheBackendDatabase {
+		return NewDbSessionCache(logger, db, transport, config.GetSession().TokenExpirySec, time.Duration(config.GetSession().CacheTtlMs)*time.Millisecond)
+	}
+	return NewLocalSessionCache(config.GetSession().TokenExpirySec)
+}
+
+// A token recently found valid in the database.
+type dbSessionCacheEntry struct {
+	userID    uuid.UUID
+	checkTime time.Time
+}
+
+// DbSessionCache stores valid tokens in the database so they are shared between all nodes. Tokens found valid are
+// trusted locally for a short TTL only, so a revocation on any node applies everywhere within the TTL even when nodes
+// are not clustered. Revocations are also sent over the cluster transport, for other nodes to apply immediately.
+type DbSessionCache struct {
+	sync.Mutex
+	logger    *zap.Logger
+	db        *sql.DB
+	transport ClusterTransport
+	ttl       time.Duration
+	// Keyed by the token hash, prefixed with whether it is a refresh token.
+	verified map[string]*dbSessionCacheEntry
+
+	ctx         context.Context
+	ctxCancelFn context.CancelFunc
+}
+
+func NewDbSessionCache(logger *zap.Logger, db *sql.DB, transport ClusterTransport, tokenExpirySec int64, ttl time.Duration) SessionCache {
+	ctx, ctxCancelFn := context.WithCancel(context.Background())
+
+	s := &DbSessionCache{
+		logger:    logger,
+		db:        db,
+		transport: transport,
+		ttl:       ttl,
+		verified:  make(map[string]*dbSessionCacheEntry),
+
+		ctx:         ctx,
+		ctxCancelFn: ctxCancelFn,
+	}
+
+	transport.Handle(clusterMessageSessionCacheInvalidate, s.handleInvalidate)
+
+	go func() {
+		ticker := time.NewTicker(2 * time.Duration(tokenExpirySec) * time.Second)
+		for {
+			select {
+			case <-s.ctx.Done():
+				ticker.Stop()
+				return
+			case t := <-ticker.C:
+				if _, err := s.db.ExecContext(s.ctx, "DELETE FROM user_session_token WHERE expire_time <= now()"); err != nil {
+					s.logger.Error("Error removing expired session tokens", zap.Error(err))
+				}
+				expired := t.Add(-s.ttl)
+				s.Lock()
+				for key, entry := range s.verified {
+					if !entry.checkTime.After(expired) {
+						delete(s.verified, key)
+					}
+				}
+				s.Unlock()
+			}
+		}
+	}()
+
+	return s
+}
+
+func (s *DbSessionCache) Stop() {
+	s.ctxCancelFn()
+}
+
+func (s *DbSessionCache) IsValidSession(userID uuid.UUID, exp int64, token string) bool {
+	return s.isValid(userID, token, false)
+}
+
+func (s *DbSessionCache) IsValidRefresh(userID uuid.UUID, exp int64, token string) bool {
+	return s.isValid(userID, token, true)
+}
+
+func (s *DbSessionCache) Add(userID uuid.UUID, sessionExp int64, sessionToken string, refreshExp int64, refreshToken string) {
+	query := "INSERT INTO user_session_token (token_hash, user_id, refresh, expire_time) VALUES ($1, $2, $3, $4) ON CONFLICT (token_hash) DO NOTHING"
+	if sessionToken != "" {
+		if _, err := s.db.ExecContext(s.ctx, query, sessionTokenHash(sessionToken), userID, false, time.Unix(sessionExp+1, 0).UTC()); err != nil {
+			s.logger.Error("Error storing session token", zap.Error(err), zap.String("user_id", userID.String()))
+		} else {
+			s.setVerified(userID, sessionToken, false)
+		}
+	}
+	if refreshToken != "" {
+		if _, err := s.db.ExecContext(s.ctx, query, sessionTokenHash(refreshToken), userID, true, time.Unix(refreshExp+1, 0).UTC()); err != nil {
+			s.logger.Error("Error storing refresh token", zap.Error(err), zap.String("user_id", userID.String()))
+		} else {
+			s.setVerified(userID, refreshToken, true)
+		}
+	}
+}
+
+func (s *DbSessionCache) Remove(userID uuid.UUID, sessionExp int64, sessionToken string, refreshExp int64, refreshToken string) {
+	s.Lock()
+	delete(s.verified, dbSessionCacheKey(sessionToken, false))
+	delete(s.verified, dbSessionCacheKey(refreshToken, true))
+	s.Unlock()
+
+	for _, token := range []string{sessionToken, refreshToken} {
+		if token == "" {
+			continue
+		}
+		if _, err := s.db.ExecContext(s.ctx, "DELETE FROM user_session_token WHERE token_hash = $1 AND user_id = $2", sessionTokenHash(token), userID); err != nil {
+			s.logger.Error("Error removing session token", zap.Error(err), zap.String("user_id", userID.String()))
+		}
+	}
+	s.invalidate([]uuid.UUID{userID})
+}
+
+func (s *DbSessionCache) RemoveAll(userID uuid.UUID) {
+	s.removeVerified([]uuid.UUID{userID})
+
+	if _, err := s.db.ExecContext(s.ctx, "DELETE FROM user_session_token WHERE user_id = $1", userID); err != nil {
+		s.logger.Error("Error removing session tokens", zap.Error(err), zap.String("user_id", userID.String()))
+	}
+	s.invalidate([]uuid.UUID{userID})
+}
+
+func (s *DbSessionCache) Ban(userIDs []uuid.UUID) {
+	if len(userIDs) == 0 {
+		return
+	}
+	statements := make([]string, 0, len(userIDs))
+	params := make([]interface{}, 0, len(userIDs))
+	for i, userID := range userIDs {
+		statements = append(statements, "$"+strconv.Itoa(i+1))
+		params = append(params, userID)
+	}
+	s.removeVerified(userIDs)
+	if _, err := s.db.ExecContext(s.ctx, "DELETE FROM user_session_token WHERE user_id IN ("+strings.Join(statements, ", ")+")", params...); err != nil {
+		s.logger.Error("Error removing session tokens for banned users", zap.Error(err))
+	}
+	s.invalidate(userIDs)
+}
+
+func (s *DbSessionCache) isValid(userID uuid.UUID, token string, refresh bool) bool {
+	key := dbSessionCacheKey(token, refresh)
+	s.Lock()
+	entry, found := s.verified[key]
+	s.Unlock()
+	if found && entry.userID == userID && time.Since(entry.checkTime) < s.ttl {
+		return true
+	}
+
+	var exists bool
+	query := "SELECT EXISTS (SELECT 1 FROM user_session_token WHERE token_hash = $1 AND user_id = $2 AND refresh = $3 AND expire_time > now())"
+	if err := s.db.QueryRowContext(s.ctx, query, sessionTokenHash(token), userID, refresh).Scan(&exists); err != nil {
+		s.logger.Error("Error checking session token", zap.Error(err), zap.String("user_id", userID.String()))
+		return false
+	}
+	if !exists {
+		s.Lock()
+		delete(s.verified, key)
+		s.Unlock()
+		return false
+	}
+	s.setVerified(userID, token, refresh)
+	return true
+}
+
+func (s *DbSessionCache) setVerified(userID uuid.UUID, token string, refresh bool) {
+	if s.ttl <= 0 {
+		return
+	}
+	s.Lock()
+	s.verified[dbSessionCacheKey(token, refresh)] = &dbSessionCacheEntry{userID: userID, checkTime: time.Now()}
+	s.Unlock()
+}
+
+func (s *DbSessionCache) removeVerified(userIDs []uuid.UUID) {
+	users := make(map[uuid.UUID]struct{}, len(userIDs))
+	for _, userID := range userIDs {
+		users[userID] = struct{}{}
+	}
+	s.Lock()
+	for key, entry := range s.verified {
+		if _, found := users[entry.userID]; found {
+			delete(s.verified, key)
+		}
+	}
+	s.Unlock()
+}
+
+// Other nodes may have any of these users' tokens cached, make them check the database again on next use rather
+// than waiting for the TTL.
+func (s *DbSessionCache) invalidate(userIDs []uuid.UUID) {
+	payload, err := gobEncode(userIDs)
+	if err != nil {
+		s.logger.Error("Error encoding session cache invalidation", zap.Error(err))
+		return
+	}
+	s.transport.Broadcast(clusterMessageSessionCacheInvalidate, payload)
+}
+
+func (s *DbSessionCache) handleInvalidate(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var userIDs []uuid.UUID
+	if err := gobDecode(payload, &userIDs); err != nil {
+		return nil, err
+	}
+	s.removeVerified(userIDs)
+	return nil, nil
+}
+
+func dbSessionCacheKey(token string, refresh bool) string {
+	if refresh {
+		return "r" + string(sessionTokenHash(token))
+	}
+	return "s" + string(sessionTokenHash(token))
+}
+
+// Tokens are only stored as hashes, a copy of the table is not enough to impersonate users.
+func sessionTokenHash(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
@@ -0,0 +1,133 @@
+// Copyright 2019 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Two nodes sharing a database without a cluster connection between them, so revocations are never sent directly.
+func newDbSessionCacheNodes(t *testing.T, ttl time.Duration) (SessionCache, SessionCache) {
+	db := NewDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	a := NewDbSessionCache(logger, db, NewLocalClusterTransport("node-a"), 60, ttl)
+	b := NewDbSessionCache(logger, db, NewLocalClusterTransport("node-b"), 60, ttl)
+	t.Cleanup(a.Stop)
+	t.Cleanup(b.Stop)
+	return a, b
+}
+
+func TestDbSessionCacheSharedTokens(t *testing.T) {
+	a, b := newDbSessionCacheNodes(t, time.Minute)
+
+	userID := uuid.Must(uuid.NewV4())
+	exp := time.Now().Add(time.Minute).Unix()
+	sessionToken, refreshToken := GenerateString(), GenerateString()
+	a.Add(userID, exp, sessionToken, exp, refreshToken)
+
+	assert.True(t, b.IsValidSession(userID, exp, sessionToken))
+	assert.True(t, b.IsValidRefresh(userID, exp, refreshToken))
+	// Tokens are only valid for their own user and kind.
+	assert.False(t, b.IsValidSession(uuid.Must(uuid.NewV4()), exp, sessionToken))
+	assert.False(t, b.IsValidSession(userID, exp, refreshToken))
+	assert.False(t, b.IsValidRefresh(userID, exp, sessionToken))
+}
+
+func TestDbSessionCacheRemoveAcrossNodes(t *testing.T) {
+	a, b := newDbSessionCacheNodes(t, 0)
+
+	userID := uuid.Must(uuid.NewV4())
+	exp := time.Now().Add(time.Minute).Unix()
+	sessionToken, refreshToken := GenerateString(), GenerateString()
+	a.Add(userID, exp, sessionToken, exp, refreshToken)
+	assert.True(t, b.IsValidSession(userID, exp, sessionToken))
+
+	a.Remove(userID, exp, sessionToken, exp, refreshToken)
+	assert.False(t, a.IsValidSession(userID, exp, sessionToken))
+	assert.False(t, b.IsValidSession(userID, exp, sessionToken))
+	assert.False(t, b.IsValidRefresh(userID, exp, refreshToken))
+}
+
+func TestDbSessionCacheRemoveAllAfterTtl(t *testing.T) {
+	ttl := 200 * time.Millisecond
+	a, b := newDbSessionCacheNodes(t, ttl)
+
+	userID := uuid.Must(uuid.NewV4())
+	exp := time.Now().Add(time.Minute).Unix()
+	sessionToken := GenerateString()
+	a.Add(userID, exp, sessionToken, 0, "")
+	assert.True(t, b.IsValidSession(userID, exp, sessionToken))
+
+	// The revoking node stops accepting the token at once, the other node once its cached check expires.
+	a.RemoveAll(userID)
+	assert.False(t, a.IsValidSession(userID, exp, sessionToken))
+	time.Sleep(ttl)
+	assert.False(t, b.IsValidSession(userID, exp, sessionToken))
+}
+
+func TestDbSessionCacheBanAcrossNodes(t *testing.T) {
+	a, b := newDbSessionCacheNodes(t, 0)
+
+	userIDs := []uuid.UUID{uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())}
+	otherUserID := uuid.Must(uuid.NewV4())
+	exp := time.Now().Add(time.Minute).Unix()
+	tokens := []string{GenerateString(), GenerateString()}
+	otherToken := GenerateString()
+	for i, userID := range userIDs {
+		a.Add(userID, exp, tokens[i], 0, "")
+	}
+	a.Add(otherUserID, exp, otherToken, 0, "")
+
+	b.Ban(userIDs)
+	for i, userID := range userIDs {
+		assert.False(t, a.IsValidSession(userID, exp, tokens[i]))
+	}
+	assert.True(t, a.IsValidSession(otherUserID, exp, otherToken))
+}
+
+func TestDbSessionCacheInvalidate(t *testing.T) {
+	db := NewDB(t)
+	defer db.Close()
+
+	hub := NewLoopbackClusterHub()
+	transportA, transportB := hub.NewTransport("node-a"), hub.NewTransport("node-b")
+	a := NewDbSessionCache(logger, db, transportA, 60, time.Minute)
+	b := NewDbSessionCache(logger, db, transportB, 60, time.Minute)
+	defer a.Stop()
+	defer b.Stop()
+	for _, transport := range []*LoopbackClusterTransport{transportA, transportB} {
+		if err := transport.Start(); err != nil {
+			t.Fatalf("error starting transport: %v", err)
+		}
+		defer transport.Stop()
+	}
+
+	userID := uuid.Must(uuid.NewV4())
+	exp := time.Now().Add(time.Minute).Unix()
+	sessionToken := GenerateString()
+	a.Add(userID, exp, sessionToken, 0, "")
+	assert.True(t, b.IsValidSession(userID, exp, sessionToken))
+
+	// Clustered nodes drop their cached checks without waiting for the TTL.
+	a.RemoveAll(userID)
+	assert.Eventually(t, func() bool {
+		return !b.IsValidSession(userID, exp, sessionToken)
+	}, time.Second, 10*time.Millisecond)
+}
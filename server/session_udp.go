@@ -0,0 +1,611 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+type sessionUDPOutgoing struct {
+	payload  []byte
+	reliable bool
+}
+
+type sessionUDPUnacked struct {
+	packet    []byte
+	firstSent time.Time
+	lastSent  time.Time
+}
+
+type sessionUDP struct {
+	sync.Mutex
+	logger     *zap.Logger
+	config     Config
+	id         uuid.UUID
+	format     SessionFormat
+	userID     uuid.UUID
+	username   *atomic.String
+	vars       map[string]string
+	expiry     int64
+	clientIP   string
+	clientPort string
+	lang       string
+	token      string
+	key        []byte
+
+	ctx         context.Context
+	ctxCancelFn context.CancelFunc
+
+	protojsonMarshaler   *protojson.MarshalOptions
+	protojsonUnmarshaler *protojson.UnmarshalOptions
+	pingPeriodDuration   time.Duration
+	pongWaitDuration     time.Duration
+	writeWaitDuration    time.Duration
+
+	sessionRegistry SessionRegistry
+	statusRegistry  *StatusRegistry
+	matchmaker      Matchmaker
+	tracker         Tracker
+	metrics         Metrics
+	pipeline        *Pipeline
+	runtime         *Runtime
+
+	stopped      bool
+	conn         *net.UDPConn
+	addr         *net.UDPAddr
+	onClose      func(*sessionUDP)
+	lastReceived *atomic.Int64
+	incomingCh   chan []byte
+	outgoingCh   chan *sessionUDPOutgoing
+
+	// Reliable packets sent but not yet acknowledged by the client.
+	sendMutex sync.Mutex
+	sendSeq   uint32
+	unacked   map[uint32]*sessionUDPUnacked
+	ackCh     chan struct{}
+
+	// Only accessed by the incoming processing routine.
+	receiveSeq uint32
+	received   map[uint32][]byte
+	assembly   []byte
+}
+
+func NewSessionUDP(logger *zap.Logger, config Config, format SessionFormat, sessionID, userID uuid.UUID, username string, vars map[string]string, expiry int64, addr *net.UDPAddr, token string, key []byte, lang string, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, conn *net.UDPConn, onClose func(*sessionUDP), sessionRegistry SessionRegistry, statusRegistry *StatusRegistry, matchmaker Matchmaker, tracker Tracker, metrics Metrics, pipeline *Pipeline, runtime *Runtime) *sessionUDP {
+	sessionLogger := logger.With(zap.String("uid", userID.String()), zap.String("sid", sessionID.String()))
+
+	sessionLogger.Info("New UDP session connected", zap.Uint8("format", uint8(format)))
+
+	ctx, ctxCancelFn := context.WithCancel(context.Background())
+
+	return &sessionUDP{
+		logger:     sessionLogger,
+		config:     config,
+		id:         sessionID,
+		format:     format,
+		userID:     userID,
+		username:   atomic.NewString(username),
+		vars:       vars,
+		expiry:     expiry,
+		clientIP:   addr.IP.String(),
+		clientPort: strconv.Itoa(addr.Port),
+		lang:       lang,
+		token:      token,
+		key:        key,
+
+		ctx:         ctx,
+		ctxCancelFn: ctxCancelFn,
+
+		protojsonMarshaler:   protojsonMarshaler,
+		protojsonUnmarshaler: protojsonUnmarshaler,
+		pingPeriodDuration:   time.Duration(config.GetSocket().PingPeriodMs) * time.Millisecond,
+		pongWaitDuration:     time.Duration(config.GetSocket().PongWaitMs) * time.Millisecond,
+		writeWaitDuration:    time.Duration(config.GetSocket().WriteWaitMs) * time.Millisecond,
+
+		sessionRegistry: sessionRegistry,
+		statusRegistry:  statusRegistry,
+		matchmaker:      matchmaker,
+		tracker:         tracker,
+		metrics:         metrics,
+		pipeline:        pipeline,
+		runtime:         runtime,
+
+		stopped:      false,
+		conn:         conn,
+		addr:         addr,
+		onClose:      onClose,
+		lastReceived: atomic.NewInt64(time.Now().UnixNano()),
+		incomingCh:   make(chan []byte, udpWindowSize),
+		outgoingCh:   make(chan *sessionUDPOutgoing, config.GetSocket().OutgoingQueueSize),
+
+		unacked: make(map[uint32]*sessionUDPUnacked),
+		ackCh:   make(chan struct{}, 1),
+
+		received: make(map[uint32][]byte),
+	}
+}
+
+func (s *sessionUDP) Logger() *zap.Logger {
+	return s.logger
+}
+
+func (s *sessionUDP) ID() uuid.UUID {
+	return s.id
+}
+
+func (s *sessionUDP) UserID() uuid.UUID {
+	return s.userID
+}
+
+func (s *sessionUDP) ClientIP() string {
+	return s.clientIP
+}
+
+func (s *sessionUDP) ClientPort() string {
+	return s.clientPort
+}
+
+func (s *sessionUDP) Lang() string {
+	return s.lang
+}
+
+func (s *sessionUDP) Context() context.Context {
+	return s.ctx
+}
+
+func (s *sessionUDP) Username() string {
+	return s.username.Load()
+}
+
+func (s *sessionUDP) SetUsername(username string) {
+	s.username.Store(username)
+}
+
+func (s *sessionUDP) Vars() map[string]string {
+	return s.vars
+}
+
+func (s *sessionUDP) Expiry() int64 {
+	return s.expiry
+}
+
+// Called by the acceptor with a packet addressed to this session, starting with its type followed by everything
+// after the session header.
+func (s *sessionUDP) receive(packet []byte) {
+	s.lastReceived.Store(time.Now().UnixNano())
+
+	if packet[0] == udpPacketAck {
+		if len(packet) == 5 {
+			s.ack(binary.BigEndian.Uint32(packet[1:]))
+		}
+		return
+	}
+
+	select {
+	case s.incomingCh <- packet:
+	default:
+		// Dropped like any other datagram, reliable packets are retransmitted.
+	}
+}
+
+func (s *sessionUDP) Consume() {
+	// Fire an event for session start.
+	if fn := s.runtime.EventSessionStart(); fn != nil {
+		fn(s.userID.String(), s.username.Load(), s.vars, s.expiry, s.id.String(), s.clientIP, s.clientPort, s.lang, time.Now().UTC().Unix())
+	}
+
+	// Start a routine to process outbound messages.
+	go s.processOutgoing()
+
+	var reason string
+
+IncomingLoop:
+	for {
+		select {
+		case <-s.ctx.Done():
+			break IncomingLoop
+		case packet := <-s.incomingCh:
+			switch packet[0] {
+			case udpPacketPing:
+				s.write(newUdpPacket(udpPacketPong, s.id, 0))
+			case udpPacketPong:
+				// Receipt time is already recorded.
+			case udpPacketClose:
+				// Client closed the connection.
+				break IncomingLoop
+			case udpPacketUnreliable:
+				if reason = s.processMessage(packet[1:]); reason != "" {
+					break IncomingLoop
+				}
+			case udpPacketReliable:
+				if reason = s.processReliable(packet[1:]); reason != "" {
+					break IncomingLoop
+				}
+			}
+		}
+	}
+
+	s.Close(reason, runtime.PresenceReasonDisconnect)
+}
+
+// Acknowledge a reliable packet, and deliver any messages it completes in sequence order.
+func (s *sessionUDP) processReliable(packet []byte) string {
+	if len(packet) < 5 {
+		return "received malformed packet"
+	}
+	seq := binary.BigEndian.Uint32(packet)
+
+	// Sequence numbers wrap around, compare relative to the next expected one.
+	diff := int32(seq - s.receiveSeq)
+	if diff >= udpWindowSize {
+		// Too far ahead to buffer. Not acknowledged, so the client retransmits it until earlier packets are delivered
+		// and it fits in the window.
+		return ""
+	}
+
+	// Only acknowledge packets that are kept, or were already delivered.
+	ack := newUdpPacket(udpPacketAck, s.id, 4)[:udpSessionHeaderBytes+4]
+	binary.BigEndian.PutUint32(ack[udpSessionHeaderBytes:], seq)
+	s.write(ack)
+
+	if diff < 0 {
+		// Duplicate of a packet already delivered, the client did not receive its ack.
+		return ""
+	}
+	s.received[seq] = packet[4:]
+
+	for {
+		fragment, found := s.received[s.receiveSeq]
+		if !found {
+			return ""
+		}
+		delete(s.received, s.receiveSeq)
+		s.receiveSeq++
+
+		s.assembly = append(s.assembly, fragment[1:]...)
+		if int64(len(s.assembly)) > s.config.GetSocket().MaxMessageSizeBytes {
+			s.logger.Debug("Received message exceeding max size", zap.Int("size", len(s.assembly)))
+			return "received message exceeding max size"
+		}
+		if fragment[0]&udpFlagMore != 0 {
+			continue
+		}
+
+		data := s.assembly
+		s.assembly = nil
+		if reason := s.processMessage(data); reason != "" {
+			return reason
+		}
+	}
+}
+
+func (s *sessionUDP) processMessage(data []byte) string {
+	if int64(len(data)) > s.config.GetSocket().MaxMessageSizeBytes {
+		s.metrics.Message(int64(len(data)), true)
+		return "received message exceeding max size"
+	}
+
+	var err error
+	request := &rtapi.Envelope{}
+	switch s.format {
+	case SessionFormatProtobuf:
+		err = proto.Unmarshal(data, request)
+	case SessionFormatJson:
+		fallthrough
+	default:
+		err = s.protojsonUnmarshaler.Unmarshal(data, request)
+	}
+	if err != nil {
+		// If the payload is malformed the client is incompatible or misbehaving, either way disconnect it now.
+		s.logger.Warn("Received malformed payload", zap.Binary("data", data))
+		s.metrics.Message(int64(len(data)), true)
+		return "received malformed payload"
+	}
+
+	switch request.Cid {
+	case "":
+		if !s.pipeline.ProcessRequest(s.logger, s, request) {
+			s.metrics.Message(int64(len(data)), true)
+			return "error processing message"
+		}
+	default:
+		requestLogger := s.logger.With(zap.String("cid", request.Cid))
+		if !s.pipeline.ProcessRequest(requestLogger, s, request) {
+			s.metrics.Message(int64(len(data)), true)
+			return "error processing message"
+		}
+	}
+
+	// Update incoming message metrics.
+	s.metrics.Message(int64(len(data)), false)
+	return ""
+}
+
+func (s *sessionUDP) ack(seq uint32) {
+	s.sendMutex.Lock()
+	delete(s.unacked, seq)
+	s.sendMutex.Unlock()
+
+	select {
+	case s.ackCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *sessionUDP) processOutgoing() {
+	var reason string
+
+	pingTicker := time.NewTicker(s.pingPeriodDuration)
+	defer pingTicker.Stop()
+	resendTicker := time.NewTicker(udpResendInterval)
+	defer resendTicker.Stop()
+
+OutgoingLoop:
+	for {
+		// Stop accepting new messages while the send window is full, the queue fills up if the client cannot keep up.
+		outgoingCh := s.outgoingCh
+		s.sendMutex.Lock()
+		if len(s.unacked) >= udpWindowSize {
+			outgoingCh = nil
+		}
+		s.sendMutex.Unlock()
+
+		select {
+		case <-s.ctx.Done():
+			// Session is closing, close the outgoing process routine.
+			break OutgoingLoop
+		case <-s.ackCh:
+			// Acknowledgements may have opened the send window.
+		case <-pingTicker.C:
+			if time.Since(time.Unix(0, s.lastReceived.Load())) > s.pongWaitDuration {
+				reason = "client ping timeout"
+				break OutgoingLoop
+			}
+			s.write(newUdpPacket(udpPacketPing, s.id, 0))
+		case now := <-resendTicker.C:
+			if !s.resend(now) {
+				reason = "client did not acknowledge messages"
+				break OutgoingLoop
+			}
+		case outgoing, ok := <-outgoingCh:
+			if !ok {
+				// Session is closing.
+				break OutgoingLoop
+			}
+			if !outgoing.reliable && len(outgoing.payload) <= udpMaxDatagramBytes-udpSessionHeaderBytes-udpTagBytes {
+				packet := newUdpPacket(udpPacketUnreliable, s.id, len(outgoing.payload))
+				s.write(append(packet, outgoing.payload...))
+			} else {
+				// Unreliable messages too large for a single datagram are sent reliably, rather than relying on
+				// every one of their fragments arriving.
+				s.sendReliable(outgoing.payload, true)
+			}
+
+			// Update outgoing message metrics.
+			s.metrics.MessageBytesSent(int64(len(outgoing.payload)))
+		}
+	}
+
+	s.Close(reason, runtime.PresenceReasonDisconnect)
+}
+
+// Split a message into reliable fragments, and send them. Fragments are retransmitted until acknowledged if tracked.
+func (s *sessionUDP) sendReliable(payload []byte, track bool) {
+	const maxFragmentBytes = udpMaxDatagramBytes - udpReliableHeaderBytes - udpTagBytes
+
+	now := time.Now()
+	s.sendMutex.Lock()
+	for {
+		fragment := payload
+		var flags byte
+		if len(fragment) > maxFragmentBytes {
+			fragment = payload[:maxFragmentBytes]
+			flags = udpFlagMore
+		}
+		payload = payload[len(fragment):]
+
+		packet := newUdpPacket(udpPacketReliable, s.id, 5+len(fragment))[:udpReliableHeaderBytes]
+		binary.BigEndian.PutUint32(packet[udpSessionHeaderBytes:], s.sendSeq)
+		packet[udpReliableHeaderBytes-1] = flags
+		packet = append(packet, fragment...)
+		if track {
+			s.unacked[s.sendSeq] = &sessionUDPUnacked{packet: packet, firstSent: now, lastSent: now}
+		}
+		s.sendSeq++
+		s.write(packet)
+
+		if flags&udpFlagMore == 0 {
+			break
+		}
+	}
+	s.sendMutex.Unlock()
+}
+
+// Retransmit reliable packets not acknowledged in time. Returns false if the client failed to acknowledge
+// a packet within the write wait duration.
+func (s *sessionUDP) resend(now time.Time) bool {
+	s.sendMutex.Lock()
+	defer s.sendMutex.Unlock()
+	for _, unacked := range s.unacked {
+		if now.Sub(unacked.firstSent) > s.writeWaitDuration {
+			return false
+		}
+		if now.Sub(unacked.lastSent) >= udpResendInterval {
+			unacked.lastSent = now
+			s.write(unacked.packet)
+		}
+	}
+	return true
+}
+
+// Sign and send a packet. Packets built with newUdpPacket have room for the tag, so retransmissions do not allocate.
+func (s *sessionUDP) write(packet []byte) {
+	if _, err := s.conn.WriteToUDP(append(packet, udpPacketTag(s.key, packet)...), s.addr); err != nil {
+		s.logger.Debug("Could not write UDP packet", zap.Error(err))
+	}
+}
+
+func (s *sessionUDP) Format() SessionFormat {
+	return s.format
+}
+
+func (s *sessionUDP) Send(envelope *rtapi.Envelope, reliable bool) error {
+	var payload []byte
+	var err error
+	switch s.format {
+	case SessionFormatProtobuf:
+		payload, err = proto.Marshal(envelope)
+	case SessionFormatJson:
+		fallthrough
+	default:
+		if buf, err := s.protojsonMarshaler.Marshal(envelope); err == nil {
+			payload = buf
+		}
+	}
+	if err != nil {
+		s.logger.Warn("Could not marshal envelope", zap.Error(err))
+		return err
+	}
+
+	if s.logger.Core().Enabled(zap.DebugLevel) {
+		switch envelope.Message.(type) {
+		case *rtapi.Envelope_Error:
+			s.logger.Debug("Sending error message", zap.Binary("payload", payload))
+		default:
+			s.logger.Debug(fmt.Sprintf("Sending %T message", envelope.Message), zap.Any("envelope", envelope))
+		}
+	}
+
+	return s.SendBytes(payload, reliable)
+}
+
+func (s *sessionUDP) SendBytes(payload []byte, reliable bool) error {
+	s.Lock()
+	if s.stopped {
+		s.Unlock()
+		return nil
+	}
+
+	// Attempt to queue messages and observe failures.
+	select {
+	case s.outgoingCh <- &sessionUDPOutgoing{payload: payload, reliable: reliable}:
+		s.Unlock()
+		return nil
+	default:
+		// The outgoing queue is full, likely because the remote client can't keep up.
+		// Terminate the connection immediately because the only alternative that doesn't block the server is
+		// to start dropping messages, which might cause unexpected behaviour.
+		s.Unlock()
+		s.logger.Warn("Could not write message, session outgoing queue full")
+		s.Close(ErrSessionQueueFull.Error(), runtime.PresenceReasonDisconnect)
+		return ErrSessionQueueFull
+	}
+}
+
+func (s *sessionUDP) Close(msg string, reason runtime.PresenceReason, envelopes ...*rtapi.Envelope) {
+	s.Lock()
+	if s.stopped {
+		s.Unlock()
+		return
+	}
+	s.stopped = true
+	s.Unlock()
+
+	// Cancel any ongoing operations tied to this session.
+	s.ctxCancelFn()
+
+	if s.logger.Core().Enabled(zap.DebugLevel) {
+		s.logger.Info("Cleaning up closed client connection")
+	}
+
+	// When connection close originates internally in the session, ensure cleanup of external resources and references.
+	if err := s.matchmaker.RemoveSessionAll(s.id.String()); err != nil {
+		s.logger.Warn("Failed to remove all matchmaking tickets", zap.Error(err))
+	}
+	if s.logger.Core().Enabled(zap.DebugLevel) {
+		s.logger.Info("Cleaned up closed connection matchmaker")
+	}
+	s.tracker.UntrackAll(s.id, reason)
+	if s.logger.Core().Enabled(zap.DebugLevel) {
+		s.logger.Info("Cleaned up closed connection tracker")
+	}
+	s.statusRegistry.UnfollowAll(s.id)
+	if s.logger.Core().Enabled(zap.DebugLevel) {
+		s.logger.Info("Cleaned up closed connection status registry")
+	}
+	s.sessionRegistry.Remove(s.id)
+	if s.logger.Core().Enabled(zap.DebugLevel) {
+		s.logger.Info("Cleaned up closed connection session registry")
+	}
+
+	// Clean up internals.
+	close(s.outgoingCh)
+
+	// Send final messages, if any are specified. There are no further retransmissions once the session is closed.
+	for _, envelope := range envelopes {
+		var payload []byte
+		var err error
+		switch s.format {
+		case SessionFormatProtobuf:
+			payload, err = proto.Marshal(envelope)
+		case SessionFormatJson:
+			fallthrough
+		default:
+			if buf, err := s.protojsonMarshaler.Marshal(envelope); err == nil {
+				payload = buf
+			}
+		}
+		if err != nil {
+			s.logger.Warn("Could not marshal envelope", zap.Error(err))
+			continue
+		}
+
+		if s.logger.Core().Enabled(zap.DebugLevel) {
+			switch envelope.Message.(type) {
+			case *rtapi.Envelope_Error:
+				s.logger.Debug("Sending error message", zap.Binary("payload", payload))
+			default:
+				s.logger.Debug(fmt.Sprintf("Sending %T message", envelope.Message), zap.Any("envelope", envelope))
+			}
+		}
+
+		s.sendReliable(payload, false)
+	}
+
+	// Send close message.
+	s.write(newUdpPacket(udpPacketClose, s.id, 0))
+	// Stop receiving packets for this session.
+	s.onClose(s)
+
+	s.logger.Info("Closed client connection")
+
+	// Fire an event for session end.
+	if fn := s.runtime.EventSessionEnd(); fn != nil {
+		fn(s.userID.String(), s.username.Load(), s.vars, s.expiry, s.id.String(), s.clientIP, s.clientPort, s.lang, time.Now().UTC().Unix(), msg)
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+type testUdpClient struct {
+	t         *testing.T
+	conn      *net.UDPConn
+	sessionID uuid.UUID
+	key       []byte
+}
+
+func createTestUdpSession(t *testing.T) (*testUdpClient, Session) {
+	cfg := NewConfig(logger)
+	sessionRegistry := NewLocalSessionRegistry(&testMetrics{})
+	sessionCache := NewLocalSessionCache(cfg.GetSession().TokenExpirySec)
+	statusRegistry := NewStatusRegistry(logger, cfg, sessionRegistry, protojsonMarshaler)
+	runtime := &Runtime{eventFunctions: &RuntimeEventFunctions{}}
+	matchmaker := NewLocalMatchmaker(logger, logger, cfg, nil, &testMessageRouter{}, &testMetrics{}, runtime, nil)
+	pipeline := NewPipeline(logger, cfg, nil, protojsonMarshaler, protojsonUnmarshaler, sessionRegistry, statusRegistry, nil, nil, matchmaker, &testTracker{}, nil, runtime)
+
+	serverConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+	acceptor, err := NewSocketUdpAcceptor(logger, cfg, serverConn, sessionRegistry, sessionCache, statusRegistry, matchmaker, &testTracker{}, &testMetrics{}, runtime, protojsonMarshaler, protojsonUnmarshaler, pipeline)
+	if err != nil {
+		t.Fatalf("error creating acceptor: %v", err)
+	}
+
+	clientConn, err := net.DialUDP("udp", nil, acceptor.Addr().(*net.UDPAddr))
+	if err != nil {
+		t.Fatalf("error dialing: %v", err)
+	}
+	t.Cleanup(func() {
+		clientConn.Close()
+		acceptor.Stop()
+		matchmaker.Stop()
+	})
+
+	userID := uuid.Must(uuid.NewV4())
+	token, exp := generateTokenWithExpiry(cfg.GetSession().EncryptionKey, userID.String(), "username", nil, time.Now().Add(time.Hour).Unix())
+	sessionCache.Add(userID, exp, token, 0, "")
+
+	client := &testUdpClient{t: t, conn: clientConn}
+	client.connect(url.Values{"token": {token}}.Encode())
+
+	// The session is registered after it is accepted.
+	var session Session
+	for i := 0; i < 100 && session == nil; i++ {
+		if session = sessionRegistry.Get(client.sessionID); session == nil {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if session == nil {
+		t.Fatal("expected session to be registered")
+	}
+	return client, session
+}
+
+// Complete the handshake, and keep the session ID and derived session key.
+func (c *testUdpClient) connect(query string) {
+	connect := c.connectPacket(query)
+	c.writeRaw(connect)
+	packet := c.readRaw()
+	if len(packet) != udpSessionHeaderBytes+udpTagBytes || packet[0] != udpPacketAccept {
+		c.t.Fatalf("expected accept packet, got %v", packet)
+	}
+	c.sessionID = uuid.FromBytesOrNil(packet[1:udpSessionHeaderBytes])
+	if !hmac.Equal(packet[udpSessionHeaderBytes:], udpPacketTag(c.key, packet[:udpSessionHeaderBytes])) {
+		c.t.Fatalf("expected signed accept packet, got %v", packet)
+	}
+}
+
+// Exchange public keys with the server, and build a connect with the query string encrypted.
+func (c *testUdpClient) connectPacket(query string) []byte {
+	private, x, y, err := elliptic.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		c.t.Fatalf("error generating key: %v", err)
+	}
+	clientPublic := elliptic.Marshal(elliptic.P256(), x, y)
+	c.writeRaw(append(append([]byte{udpPacketHello}, clientPublic...), make([]byte, udpChallengeBytes-1-udpPublicKeyBytes)...))
+	challenge := c.readRaw()
+	if len(challenge) != udpChallengeBytes || challenge[0] != udpPacketChallenge {
+		c.t.Fatalf("expected challenge packet, got %v", challenge)
+	}
+	serverPublic := challenge[1 : 1+udpPublicKeyBytes]
+	cookie := challenge[1+udpPublicKeyBytes:]
+
+	connectKey, sessionKey, err := udpHandshakeDerive(private, clientPublic, serverPublic, serverPublic)
+	if err != nil {
+		c.t.Fatalf("error deriving keys: %v", err)
+	}
+	c.key = sessionKey
+	aead, err := udpConnectCipher(connectKey)
+	if err != nil {
+		c.t.Fatalf("error creating cipher: %v", err)
+	}
+	header := append(append(append([]byte{udpPacketConnect}, clientPublic...), serverPublic...), cookie...)
+	nonce := make([]byte, udpNonceBytes)
+	if _, err := rand.Read(nonce); err != nil {
+		c.t.Fatalf("error generating nonce: %v", err)
+	}
+	return aead.Seal(append(append([]byte{}, header...), nonce...), nonce, []byte(query), header)
+}
+
+func (c *testUdpClient) writeRaw(packet []byte) {
+	if _, err := c.conn.Write(packet); err != nil {
+		c.t.Fatalf("error writing: %v", err)
+	}
+}
+
+func (c *testUdpClient) readRaw() []byte {
+	return c.readTimeout(5 * time.Second)
+}
+
+func (c *testUdpClient) readTimeout(timeout time.Duration) []byte {
+	buf := make([]byte, 65536)
+	_ = c.conn.SetReadDeadline(time.Now().Add(timeout))
+	n, err := c.conn.Read(buf)
+	if err != nil {
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			return nil
+		}
+		c.t.Fatalf("error reading: %v", err)
+	}
+	return buf[:n]
+}
+
+// Send a session packet signed with the session key.
+func (c *testUdpClient) write(packet []byte) {
+	c.writeRaw(append(packet, udpPacketTag(c.key, packet)...))
+}
+
+// Receive a session packet, checking and removing its tag.
+func (c *testUdpClient) read() []byte {
+	packet := c.readRaw()
+	if packet == nil {
+		c.t.Fatal("error reading: timeout")
+	}
+	n := len(packet) - udpTagBytes
+	if n < udpSessionHeaderBytes || !hmac.Equal(packet[n:], udpPacketTag(c.key, packet[:n])) {
+		c.t.Fatalf("expected signed packet, got %v", packet)
+	}
+	return packet[:n]
+}
+
+func (c *testUdpClient) writeReliable(seq uint32, more bool, fragment []byte) {
+	packet := newUdpPacket(udpPacketReliable, c.sessionID, 5+len(fragment))[:udpReliableHeaderBytes]
+	binary.BigEndian.PutUint32(packet[udpSessionHeaderBytes:], seq)
+	if more {
+		packet[udpReliableHeaderBytes-1] = udpFlagMore
+	}
+	c.write(append(packet, fragment...))
+}
+
+func (c *testUdpClient) ack(seq uint32) {
+	packet := newUdpPacket(udpPacketAck, c.sessionID, 4)[:udpSessionHeaderBytes+4]
+	binary.BigEndian.PutUint32(packet[udpSessionHeaderBytes:], seq)
+	c.write(packet)
+}
+
+func TestSessionUdpReliableFragments(t *testing.T) {
+	client, session := createTestUdpSession(t)
+	defer session.Close("", runtime.PresenceReasonDisconnect)
+
+	// Deliver a ping envelope in two fragments, out of order.
+	data := []byte(`{"cid":"1","ping":{}}`)
+	client.writeReliable(1, false, data[10:])
+	client.writeReliable(0, true, data[:10])
+
+	acks := make(map[uint32]bool)
+	var pong []byte
+	for pong == nil {
+		packet := client.read()
+		switch packet[0] {
+		case udpPacketAck:
+			acks[binary.BigEndian.Uint32(packet[udpSessionHeaderBytes:])] = true
+		case udpPacketReliable:
+			assert.Equal(t, uint32(0), binary.BigEndian.Uint32(packet[udpSessionHeaderBytes:]))
+			assert.Equal(t, byte(0), packet[udpReliableHeaderBytes-1])
+			pong = packet[udpReliableHeaderBytes:]
+			client.ack(0)
+		}
+	}
+	assert.True(t, acks[0])
+	assert.True(t, acks[1])
+
+	envelope := &rtapi.Envelope{}
+	if err := protojsonUnmarshaler.Unmarshal(pong, envelope); err != nil {
+		t.Fatalf("error unmarshalling: %v", err)
+	}
+	assert.Equal(t, "1", envelope.Cid)
+	assert.NotNil(t, envelope.GetPong())
+}
+
+func TestSessionUdpUnreliable(t *testing.T) {
+	client, session := createTestUdpSession(t)
+	defer session.Close("", runtime.PresenceReasonDisconnect)
+
+	if err := session.SendBytes([]byte("unreliable"), false); err != nil {
+		t.Fatalf("error sending: %v", err)
+	}
+	packet := client.read()
+	assert.Equal(t, udpPacketUnreliable, packet[0])
+	assert.Equal(t, "unreliable", string(packet[udpSessionHeaderBytes:]))
+
+	// Messages that do not fit a single datagram are sent reliably, in fragments.
+	large := strings.Repeat("a", 2*udpMaxDatagramBytes)
+	if err := session.SendBytes([]byte(large), false); err != nil {
+		t.Fatalf("error sending: %v", err)
+	}
+	var received []byte
+	for seq := uint32(0); ; seq++ {
+		packet := client.read()
+		for binary.BigEndian.Uint32(packet[udpSessionHeaderBytes:]) < seq {
+			// Retransmission sent before the ack arrived.
+			packet = client.read()
+		}
+		assert.Equal(t, udpPacketReliable, packet[0])
+		assert.Equal(t, seq, binary.BigEndian.Uint32(packet[udpSessionHeaderBytes:]))
+		received = append(received, packet[udpReliableHeaderBytes:]...)
+		client.ack(seq)
+		if packet[udpReliableHeaderBytes-1]&udpFlagMore == 0 {
+			break
+		}
+	}
+	assert.Equal(t, large, string(received))
+}
+
+func TestSessionUdpRetransmit(t *testing.T) {
+	client, session := createTestUdpSession(t)
+	defer session.Close("", runtime.PresenceReasonDisconnect)
+
+	if err := session.SendBytes([]byte("reliable"), true); err != nil {
+		t.Fatalf("error sending: %v", err)
+	}
+
+	// Not acknowledged, so it is sent again.
+	first := client.read()
+	second := client.read()
+	assert.Equal(t, udpPacketReliable, first[0])
+	assert.Equal(t, first, second)
+
+	client.ack(0)
+	if err := session.SendBytes([]byte("next"), true); err != nil {
+		t.Fatalf("error sending: %v", err)
+	}
+	packet := client.read()
+	for binary.BigEndian.Uint32(packet[udpSessionHeaderBytes:]) == 0 {
+		// Retransmission sent before the ack arrived.
+		packet = client.read()
+	}
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(packet[udpSessionHeaderBytes:]))
+	assert.Equal(t, "next", string(packet[udpReliableHeaderBytes:]))
+}
+
+func TestSessionUdpWindow(t *testing.T) {
+	client, session := createTestUdpSession(t)
+	defer session.Close("", runtime.PresenceReasonDisconnect)
+
+	// Too far ahead to be buffered, so it must not be acknowledged either.
+	client.writeReliable(udpWindowSize, false, []byte(`{"ping":{}}`))
+	client.writeReliable(udpWindowSize-1, false, []byte(`{"ping":{}}`))
+
+	packet := client.read()
+	assert.Equal(t, udpPacketAck, packet[0])
+	assert.Equal(t, uint32(udpWindowSize-1), binary.BigEndian.Uint32(packet[udpSessionHeaderBytes:]))
+	for packet = client.readTimeout(200 * time.Millisecond); packet != nil; packet = client.readTimeout(200 * time.Millisecond) {
+		packet = packet[:len(packet)-udpTagBytes]
+		if packet[0] == udpPacketAck {
+			assert.NotEqual(t, uint32(udpWindowSize), binary.BigEndian.Uint32(packet[udpSessionHeaderBytes:]))
+		}
+	}
+}
+
+func TestSessionUdpForgedPacket(t *testing.T) {
+	client, session := createTestUdpSession(t)
+	defer session.Close("", runtime.PresenceReasonDisconnect)
+
+	// Same session ID and source address, but not signed with the session key.
+	forged := newUdpPacket(udpPacketReliable, client.sessionID, 5)[:udpReliableHeaderBytes]
+	forged = append(forged, `{"ping":{}}`...)
+	client.writeRaw(append(forged, make([]byte, udpTagBytes)...))
+	assert.Nil(t, client.readTimeout(200*time.Millisecond))
+
+	// The genuine packet with the same sequence number is still accepted.
+	client.writeReliable(0, false, []byte(`{"ping":{}}`))
+	packet := client.read()
+	assert.Equal(t, udpPacketAck, packet[0])
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(packet[udpSessionHeaderBytes:]))
+}
+
+func TestSocketUdpHandshake(t *testing.T) {
+	client, session := createTestUdpSession(t)
+	defer session.Close("", runtime.PresenceReasonDisconnect)
+
+	// Neither the token nor the session key is ever sent in the clear.
+	token := session.(*sessionUDP).token
+	connect := client.connectPacket(url.Values{"token": {token}}.Encode())
+	assert.False(t, bytes.Contains(connect, []byte(token)))
+	assert.False(t, bytes.Contains(connect, client.key))
+
+	// A connect with a cookie issued for other keys is dropped without a response.
+	forged := append([]byte{}, connect...)
+	forged[1] ^= 0xff
+	client.writeRaw(forged)
+	assert.Nil(t, client.readTimeout(200*time.Millisecond))
+
+	// A connect tampered with after the cookie fails to decrypt.
+	tampered := append([]byte{}, connect...)
+	tampered[len(tampered)-1] ^= 0xff
+	client.writeRaw(tampered)
+	packet := client.readRaw()
+	assert.Equal(t, udpPacketReject, packet[0])
+}
+
+func TestSocketUdpConnectLimits(t *testing.T) {
+	a := &SocketUdpAcceptor{connectLimits: make(map[string]*udpConnectLimit)}
+	now := time.Now()
+
+	// Pending connects are bounded for each source address.
+	for i := 0; i < udpMaxPendingConnectsPerAddr; i++ {
+		assert.True(t, a.acquireConnect("10.0.0.1", now))
+	}
+	assert.False(t, a.acquireConnect("10.0.0.1", now))
+	assert.True(t, a.acquireConnect("10.0.0.2", now))
+
+	// Completed connects still count towards the rate of each source address.
+	for i := 0; i < udpMaxPendingConnectsPerAddr; i++ {
+		a.releaseConnect("10.0.0.1")
+	}
+	for i := udpMaxPendingConnectsPerAddr; i < udpConnectBurst; i++ {
+		assert.True(t, a.acquireConnect("10.0.0.1", now))
+		a.releaseConnect("10.0.0.1")
+	}
+	assert.False(t, a.acquireConnect("10.0.0.1", now))
+	assert.True(t, a.acquireConnect("10.0.0.1", now.Add(time.Second/udpConnectRate)))
+	a.releaseConnect("10.0.0.1")
+
+	// Pending connects are bounded overall.
+	a.pendingConnects = udpMaxPendingConnects
+	assert.False(t, a.acquireConnect("10.0.0.3", now))
+}
@@ -0,0 +1,411 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Realtime sessions over UDP exchange the same envelopes as WebSocket sessions, in datagrams prefixed by a packet type.
+// Connections start with the handshake described in socket_udp_handshake.go, where the client sends a query string
+// with the same parameters as the WebSocket endpoint, encrypted. All packets after the handshake except reject carry
+// the 16 byte session ID assigned when the connection is accepted, and end with a truncated HMAC-SHA256 of the rest of
+// the packet keyed by the session key both sides derive during the handshake. Packets that fail the check are dropped,
+// so knowing the session ID and source address is not enough to inject data.
+//
+// Hello, Challenge, Connect: see socket_udp_handshake.go
+// Accept:     [type] [session ID]
+// Reject:     [type] [reason]
+// Reliable:   [type] [session ID] [uint32 sequence] [flags] [payload fragment]
+// Unreliable: [type] [session ID] [payload]
+// Ack:        [type] [session ID] [uint32 sequence]
+// Ping, Pong, Close: [type] [session ID]
+// Each of the packets above is followed by: [16 byte tag]
+//
+// Reliable packets are acknowledged individually, retransmitted until acknowledged, and delivered in sequence order.
+// Envelopes too large for a single datagram are split into consecutive reliable fragments.
+const (
+	udpPacketHello byte = iota + 1
+	udpPacketChallenge
+	udpPacketConnect
+	udpPacketAccept
+	udpPacketReject
+	udpPacketReliable
+	udpPacketUnreliable
+	udpPacketAck
+	udpPacketPing
+	udpPacketPong
+	udpPacketClose
+)
+
+const (
+	// Set on every fragment of an envelope except the last.
+	udpFlagMore byte = 1
+
+	udpSessionHeaderBytes  = 1 + 16
+	udpReliableHeaderBytes = udpSessionHeaderBytes + 4 + 1
+	udpTagBytes            = 16
+	// Kept below common path MTUs to avoid IP fragmentation, including the tag.
+	udpMaxDatagramBytes = 1200
+
+	// Maximum number of reliable packets in flight, or buffered out of order, per session.
+	udpWindowSize     = 256
+	udpResendInterval = 100 * time.Millisecond
+)
+
+type SocketUdpAcceptor struct {
+	sync.Mutex
+	logger               *zap.Logger
+	config               Config
+	sessionRegistry      SessionRegistry
+	sessionCache         SessionCache
+	statusRegistry       *StatusRegistry
+	matchmaker           Matchmaker
+	tracker              Tracker
+	metrics              Metrics
+	runtime              *Runtime
+	protojsonMarshaler   *protojson.MarshalOptions
+	protojsonUnmarshaler *protojson.UnmarshalOptions
+	pipeline             *Pipeline
+
+	ctx          context.Context
+	ctxCancelFn  context.CancelFunc
+	conn         *net.UDPConn
+	sessionIdGen uuid.Generator
+	stopped      *atomic.Bool
+
+	// The current and previous server handshake key pairs.
+	handshakeKeys   [2]*udpHandshakeKey
+	cookieSecret    []byte
+	pendingConnects int
+	connectLimits   map[string]*udpConnectLimit
+
+	sessions       map[uuid.UUID]*sessionUDP
+	sessionsByAddr map[string]*sessionUDP
+}
+
+func StartSocketUdpAcceptor(logger, startupLogger *zap.Logger, config Config, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchmaker Matchmaker, tracker Tracker, metrics Metrics, runtime *Runtime, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, pipeline *Pipeline) *SocketUdpAcceptor {
+	addr, err := net.ResolveUDPAddr("udp", net.JoinHostPort(config.GetSocket().Address, strconv.Itoa(config.GetSocket().UdpPort)))
+	if err != nil {
+		startupLogger.Fatal("Invalid UDP socket address", zap.Error(err))
+	}
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		startupLogger.Fatal("UDP socket listener failed", zap.Error(err))
+	}
+	startupLogger.Info("Starting UDP socket for realtime connections", zap.Int("port", config.GetSocket().UdpPort))
+
+	a, err := NewSocketUdpAcceptor(logger, config, conn, sessionRegistry, sessionCache, statusRegistry, matchmaker, tracker, metrics, runtime, protojsonMarshaler, protojsonUnmarshaler, pipeline)
+	if err != nil {
+		startupLogger.Fatal("Could not generate UDP handshake keys", zap.Error(err))
+	}
+	return a
+}
+
+func NewSocketUdpAcceptor(logger *zap.Logger, config Config, conn *net.UDPConn, sessionRegistry SessionRegistry, sessionCache SessionCache, statusRegistry *StatusRegistry, matchmaker Matchmaker, tracker Tracker, metrics Metrics, runtime *Runtime, protojsonMarshaler *protojson.MarshalOptions, protojsonUnmarshaler *protojson.UnmarshalOptions, pipeline *Pipeline) (*SocketUdpAcceptor, error) {
+	handshakeKey, err := newUdpHandshakeKey()
+	if err != nil {
+		return nil, err
+	}
+	cookieSecret := make([]byte, 32)
+	if _, err := rand.Read(cookieSecret); err != nil {
+		return nil, err
+	}
+
+	ctx, ctxCancelFn := context.WithCancel(context.Background())
+
+	a := &SocketUdpAcceptor{
+		logger:               logger,
+		config:               config,
+		sessionRegistry:      sessionRegistry,
+		sessionCache:         sessionCache,
+		statusRegistry:       statusRegistry,
+		matchmaker:           matchmaker,
+		tracker:              tracker,
+		metrics:              metrics,
+		runtime:              runtime,
+		protojsonMarshaler:   protojsonMarshaler,
+		protojsonUnmarshaler: protojsonUnmarshaler,
+		pipeline:             pipeline,
+
+		ctx:         ctx,
+		ctxCancelFn: ctxCancelFn,
+		conn:        conn,
+		sessionIdGen: uuid.NewGenWithHWAF(func() (net.HardwareAddr, error) {
+			hash := NodeToHash(config.GetName())
+			return hash[:], nil
+		}),
+		stopped: atomic.NewBool(false),
+
+		handshakeKeys: [2]*udpHandshakeKey{handshakeKey},
+		cookieSecret:  cookieSecret,
+		connectLimits: make(map[string]*udpConnectLimit),
+
+		sessions:       make(map[uuid.UUID]*sessionUDP),
+		sessionsByAddr: make(map[string]*sessionUDP),
+	}
+
+	go a.processIncoming()
+	go a.processHandshakeKeys()
+
+	return a, nil
+}
+
+func (a *SocketUdpAcceptor) Addr() net.Addr {
+	return a.conn.LocalAddr()
+}
+
+func (a *SocketUdpAcceptor) Stop() {
+	if !a.stopped.CAS(false, true) {
+		return
+	}
+	a.ctxCancelFn()
+	if err := a.conn.Close(); err != nil {
+		a.logger.Debug("Could not close UDP socket", zap.Error(err))
+	}
+}
+
+func (a *SocketUdpAcceptor) processIncoming() {
+	buf := make([]byte, 65536)
+	for {
+		n, addr, err := a.conn.ReadFromUDP(buf)
+		if err != nil {
+			if a.stopped.Load() {
+				return
+			}
+			a.logger.Debug("Error reading UDP socket", zap.Error(err))
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+
+		switch buf[0] {
+		case udpPacketHello:
+			a.challenge(addr, buf[:n])
+			continue
+		case udpPacketConnect:
+			key := a.verifyConnect(addr, buf[:n])
+			if key == nil {
+				// Not a response to a challenge sent to this address.
+				continue
+			}
+			ip := addr.IP.String()
+			if !a.acquireConnect(ip, time.Now()) {
+				a.reject(addr, "Too many connection attempts")
+				continue
+			}
+			// Token validation may need the database, do not hold up packets for other sessions.
+			connect := make([]byte, n)
+			copy(connect, buf[:n])
+			go func() {
+				a.connect(addr, key, connect)
+				a.releaseConnect(ip)
+			}()
+			continue
+		}
+
+		if n < udpSessionHeaderBytes+udpTagBytes {
+			continue
+		}
+		sessionID := uuid.FromBytesOrNil(buf[1:udpSessionHeaderBytes])
+		a.Lock()
+		session, found := a.sessions[sessionID]
+		a.Unlock()
+		if !found || session.addr.String() != addr.String() {
+			// Unknown session, or a packet from another address claiming to be part of it.
+			continue
+		}
+		n -= udpTagBytes
+		if !hmac.Equal(buf[n:n+udpTagBytes], udpPacketTag(session.key, buf[:n])) {
+			// Not signed with the session key, likely a forged source address.
+			continue
+		}
+
+		// The read buffer is reused, sessions receive their own copy.
+		packet := make([]byte, n-udpSessionHeaderBytes+1)
+		packet[0] = buf[0]
+		copy(packet[1:], buf[udpSessionHeaderBytes:n])
+		session.receive(packet)
+	}
+}
+
+func (a *SocketUdpAcceptor) connect(addr *net.UDPAddr, handshakeKey *udpHandshakeKey, connect []byte) {
+	query, key, err := a.openConnect(handshakeKey, connect)
+	if err != nil {
+		a.reject(addr, "Invalid handshake")
+		return
+	}
+
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		a.reject(addr, "Invalid connect parameters")
+		return
+	}
+
+	// Check format.
+	var format SessionFormat
+	switch params.Get("format") {
+	case "protobuf":
+		format = SessionFormatProtobuf
+	case "json":
+		fallthrough
+	case "":
+		format = SessionFormatJson
+	default:
+		// Invalid values are rejected.
+		a.reject(addr, "Invalid format parameter")
+		return
+	}
+
+	// Check authentication.
+	token := params.Get("token")
+	if token == "" {
+		a.reject(addr, "Missing or invalid token")
+		return
+	}
+
+	userID, username, vars, expiry, _, ok := parseToken([]byte(a.config.GetSession().EncryptionKey), token)
+	if !ok || !a.sessionCache.IsValidSession(userID, expiry, token) {
+		a.reject(addr, "Missing or invalid token")
+		return
+	}
+
+	// Extract lang query parameter. Use a default if empty or not present.
+	lang := "en"
+	if langParam := params.Get("lang"); langParam != "" {
+		lang = langParam
+	}
+
+	status, _ := strconv.ParseBool(params.Get("status"))
+
+	a.Lock()
+	previous, found := a.sessionsByAddr[addr.String()]
+	if found && previous.token == token && hmac.Equal(previous.key, key) {
+		// The accept packet may have been lost, the client retries the same connect.
+		a.Unlock()
+		a.accept(previous)
+		return
+	}
+
+	sessionID := uuid.Must(a.sessionIdGen.NewV1())
+
+	// Wrap the connection for application handling.
+	session := NewSessionUDP(a.logger, a.config, format, sessionID, userID, username, vars, expiry, addr, token, key, lang, a.protojsonMarshaler, a.protojsonUnmarshaler, a.conn, a.remove, a.sessionRegistry, a.statusRegistry, a.matchmaker, a.tracker, a.metrics, a.pipeline, a.runtime)
+	a.sessions[sessionID] = session
+	a.sessionsByAddr[addr.String()] = session
+	a.Unlock()
+
+	if found {
+		// The client started over with a new connection from the same address.
+		previous.Close("reconnected", runtime.PresenceReasonDisconnect)
+	}
+
+	// Mark the start of the session.
+	a.metrics.CountUdpOpened(1)
+
+	a.accept(session)
+
+	// Add to the session registry.
+	a.sessionRegistry.Add(session)
+
+	// Register initial status tracking and presence(s) for this session.
+	a.statusRegistry.Follow(sessionID, map[uuid.UUID]struct{}{userID: {}})
+	if status {
+		// Both notification and status presence.
+		a.tracker.TrackMulti(session.Context(), sessionID, []*TrackerOp{
+			{
+				Stream: PresenceStream{Mode: StreamModeNotifications, Subject: userID},
+				Meta:   PresenceMeta{Format: format, Username: username, Hidden: true},
+			},
+			{
+				Stream: PresenceStream{Mode: StreamModeStatus, Subject: userID},
+				Meta:   PresenceMeta{Format: format, Username: username, Status: ""},
+			},
+		}, userID, true)
+	} else {
+		// Only notification presence.
+		a.tracker.Track(session.Context(), sessionID, PresenceStream{Mode: StreamModeNotifications, Subject: userID}, userID, PresenceMeta{Format: format, Username: username, Hidden: true}, true)
+	}
+
+	if a.config.GetSession().SingleSocket {
+		// Kick any other sockets for this user.
+		go a.sessionRegistry.SingleSession(session.Context(), a.tracker, userID, sessionID)
+	}
+
+	go func() {
+		// Allow the server to begin processing incoming messages from this session.
+		session.Consume()
+
+		// Mark the end of the session.
+		a.metrics.CountUdpClosed(1)
+	}()
+}
+
+func (a *SocketUdpAcceptor) accept(session *sessionUDP) {
+	// Signed like any session packet, proving to the client the server derived the same session key.
+	packet := newUdpPacket(udpPacketAccept, session.id, 0)
+	packet = append(packet, udpPacketTag(session.key, packet)...)
+	if _, err := a.conn.WriteToUDP(packet, session.addr); err != nil {
+		a.logger.Debug("Could not send UDP accept", zap.Error(err))
+	}
+}
+
+func (a *SocketUdpAcceptor) reject(addr *net.UDPAddr, reason string) {
+	packet := append([]byte{udpPacketReject}, reason...)
+	if _, err := a.conn.WriteToUDP(packet, addr); err != nil {
+		a.logger.Debug("Could not send UDP reject", zap.Error(err))
+	}
+}
+
+func (a *SocketUdpAcceptor) remove(session *sessionUDP) {
+	a.Lock()
+	if a.sessions[session.id] == session {
+		delete(a.sessions, session.id)
+	}
+	if a.sessionsByAddr[session.addr.String()] == session {
+		delete(a.sessionsByAddr, session.addr.String())
+	}
+	a.Unlock()
+}
+
+// Build a packet with the session header, leaving room for the given number of additional bytes and the tag.
+func newUdpPacket(packetType byte, sessionID uuid.UUID, size int) []byte {
+	packet := make([]byte, udpSessionHeaderBytes, udpSessionHeaderBytes+size+udpTagBytes)
+	packet[0] = packetType
+	copy(packet[1:], sessionID.Bytes())
+	return packet
+}
+
+// Compute the tag authenticating a packet, everything except the tag itself, with the session key.
+func udpPacketTag(key, packet []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(packet)
+	return mac.Sum(nil)[:udpTagBytes]
+}
@@ -0,0 +1,242 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// UDP connections start with an ephemeral P-256 Diffie-Hellman key exchange. The client sends its public key in a
+// hello, and the server answers with its current public key and a cookie binding both keys to the client address. The
+// client then sends the connect parameters, including the session token, encrypted with AES-GCM under a key derived
+// from the shared secret, along with the cookie. The server only does any work for a connect with a valid cookie, so
+// connects from spoofed source addresses are dropped before reaching the session cache. The session key that signs
+// session packets is derived from the same shared secret, so it is never sent.
+//
+// Hello:     [type] [65 byte client public key] [zero padding to at least the length of a challenge]
+// Challenge: [type] [65 byte server public key] [16 byte cookie]
+// Connect:   [type] [client public key] [server public key] [cookie] [12 byte nonce] [encrypted query string]
+//
+// Server key pairs are replaced every minute, connects are accepted with the current or the previous one.
+const (
+	udpPublicKeyBytes       = 65
+	udpCookieBytes          = 16
+	udpChallengeBytes       = 1 + udpPublicKeyBytes + udpCookieBytes
+	udpConnectHeaderBytes   = 1 + 2*udpPublicKeyBytes + udpCookieBytes
+	udpNonceBytes           = 12
+	udpHandshakeKeyRotation = time.Minute
+
+	// Connects with a valid cookie waiting for token validation, overall and from each source IP.
+	udpMaxPendingConnects        = 128
+	udpMaxPendingConnectsPerAddr = 4
+	// Connects accepted from each source IP, as a sustained rate per second and a burst.
+	udpConnectRate  = 5
+	udpConnectBurst = 10
+)
+
+var errUdpHandshakeInvalid = errors.New("invalid udp handshake")
+
+type udpHandshakeKey struct {
+	private []byte
+	public  []byte
+}
+
+func newUdpHandshakeKey() (*udpHandshakeKey, error) {
+	private, x, y, err := elliptic.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &udpHandshakeKey{private: private, public: elliptic.Marshal(elliptic.P256(), x, y)}, nil
+}
+
+// Connect attempts from a single source IP.
+type udpConnectLimit struct {
+	pending int
+	tokens  float64
+	last    time.Time
+}
+
+// Compute the cookie proving a client received the challenge sent to its address.
+func udpHandshakeCookie(secret []byte, addr *net.UDPAddr, clientPublic, serverPublic []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(addr.String()))
+	mac.Write(clientPublic)
+	mac.Write(serverPublic)
+	return mac.Sum(nil)[:udpCookieBytes]
+}
+
+// Derive the key encrypting the connect parameters and the session key from the Diffie-Hellman shared secret, bound
+// to both public keys.
+func udpHandshakeDerive(private, clientPublic, serverPublic, peerPublic []byte) (connectKey, sessionKey []byte, err error) {
+	x, y := elliptic.Unmarshal(elliptic.P256(), peerPublic)
+	if x == nil {
+		return nil, nil, errUdpHandshakeInvalid
+	}
+	shared, _ := elliptic.P256().ScalarMult(x, y, private)
+	secret := make([]byte, 32)
+	shared.FillBytes(secret)
+
+	extract := hmac.New(sha256.New, append(append([]byte{}, clientPublic...), serverPublic...))
+	extract.Write(secret)
+	prk := extract.Sum(nil)
+	expand := func(info string) []byte {
+		mac := hmac.New(sha256.New, prk)
+		mac.Write([]byte(info))
+		mac.Write([]byte{1})
+		return mac.Sum(nil)
+	}
+	return expand("nakama udp connect"), expand("nakama udp session"), nil
+}
+
+func udpConnectCipher(connectKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(connectKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Answer a hello with the current server public key, and a cookie for the client to return with its connect. No state
+// is kept until the client proves it received the challenge.
+func (a *SocketUdpAcceptor) challenge(addr *net.UDPAddr, hello []byte) {
+	if len(hello) < udpChallengeBytes {
+		// Hellos are padded so challenges do not amplify traffic sent to spoofed addresses.
+		return
+	}
+	clientPublic := hello[1 : 1+udpPublicKeyBytes]
+
+	a.Lock()
+	serverPublic := a.handshakeKeys[0].public
+	a.Unlock()
+
+	packet := make([]byte, 0, udpChallengeBytes)
+	packet = append(packet, udpPacketChallenge)
+	packet = append(packet, serverPublic...)
+	packet = append(packet, udpHandshakeCookie(a.cookieSecret, addr, clientPublic, serverPublic)...)
+	if _, err := a.conn.WriteToUDP(packet, addr); err != nil {
+		a.logger.Debug("Could not send UDP challenge", zap.Error(err))
+	}
+}
+
+// Check the cookie of a connect, and return the server key pair it was made with.
+func (a *SocketUdpAcceptor) verifyConnect(addr *net.UDPAddr, connect []byte) *udpHandshakeKey {
+	if len(connect) < udpConnectHeaderBytes+udpNonceBytes {
+		return nil
+	}
+	clientPublic := connect[1 : 1+udpPublicKeyBytes]
+	serverPublic := connect[1+udpPublicKeyBytes : 1+2*udpPublicKeyBytes]
+	cookie := connect[1+2*udpPublicKeyBytes : udpConnectHeaderBytes]
+	if !hmac.Equal(cookie, udpHandshakeCookie(a.cookieSecret, addr, clientPublic, serverPublic)) {
+		return nil
+	}
+
+	a.Lock()
+	defer a.Unlock()
+	for _, key := range a.handshakeKeys {
+		if key != nil && hmac.Equal(key.public, serverPublic) {
+			return key
+		}
+	}
+	return nil
+}
+
+// Decrypt the connect parameters, and derive the session key.
+func (a *SocketUdpAcceptor) openConnect(key *udpHandshakeKey, connect []byte) (query string, sessionKey []byte, err error) {
+	clientPublic := connect[1 : 1+udpPublicKeyBytes]
+	connectKey, sessionKey, err := udpHandshakeDerive(key.private, clientPublic, key.public, clientPublic)
+	if err != nil {
+		return "", nil, err
+	}
+	aead, err := udpConnectCipher(connectKey)
+	if err != nil {
+		return "", nil, err
+	}
+	nonce := connect[udpConnectHeaderBytes : udpConnectHeaderBytes+udpNonceBytes]
+	plaintext, err := aead.Open(nil, nonce, connect[udpConnectHeaderBytes+udpNonceBytes:], connect[:udpConnectHeaderBytes])
+	if err != nil {
+		return "", nil, errUdpHandshakeInvalid
+	}
+	return string(plaintext), sessionKey, nil
+}
+
+// Reserve a pending connect for a source IP, if neither the overall nor the per address limits are reached.
+func (a *SocketUdpAcceptor) acquireConnect(ip string, now time.Time) bool {
+	a.Lock()
+	defer a.Unlock()
+	if a.pendingConnects >= udpMaxPendingConnects {
+		return false
+	}
+	limit, found := a.connectLimits[ip]
+	if !found {
+		limit = &udpConnectLimit{tokens: udpConnectBurst, last: now}
+		a.connectLimits[ip] = limit
+	}
+	limit.tokens = math.Min(udpConnectBurst, limit.tokens+now.Sub(limit.last).Seconds()*udpConnectRate)
+	limit.last = now
+	if limit.pending >= udpMaxPendingConnectsPerAddr || limit.tokens < 1 {
+		return false
+	}
+	limit.tokens--
+	limit.pending++
+	a.pendingConnects++
+	return true
+}
+
+func (a *SocketUdpAcceptor) releaseConnect(ip string) {
+	a.Lock()
+	if limit, found := a.connectLimits[ip]; found {
+		limit.pending--
+	}
+	a.pendingConnects--
+	a.Unlock()
+}
+
+// Replace the server key pair periodically, and forget source addresses that have no connects pending and would be
+// allowed a full burst again.
+func (a *SocketUdpAcceptor) processHandshakeKeys() {
+	ticker := time.NewTicker(udpHandshakeKeyRotation)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case now := <-ticker.C:
+			key, err := newUdpHandshakeKey()
+			if err != nil {
+				a.logger.Error("Could not generate UDP handshake key", zap.Error(err))
+				continue
+			}
+			a.Lock()
+			a.handshakeKeys[1], a.handshakeKeys[0] = a.handshakeKeys[0], key
+			for ip, limit := range a.connectLimits {
+				if limit.pending == 0 && now.Sub(limit.last).Seconds()*udpConnectRate >= udpConnectBurst {
+					delete(a.connectLimits, ip)
+				}
+			}
+			a.Unlock()
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Maximum number of expired objects removed, and passed to the runtime hook, at once.
+const storageExpiryBatchSize = 1000
+
+// StorageExpiry periodically removes storage objects whose expiry time has passed. Reads and storage index
+// queries already exclude them, so this only reclaims space, removes them from storage indexes, and notifies the runtime. Each object is removed by
+// exactly one node even if all nodes in a cluster run it.
+type StorageExpiry struct {
+	logger       *zap.Logger
+	db           *sql.DB
+	storageIndex StorageIndex
+	storageFeed  StorageFeed
+	fnExpire     RuntimeStorageExpireFunction
+
+	ctx         context.Context
+	ctxCancelFn context.CancelFunc
+}
+
+func StartStorageExpiry(logger *zap.Logger, db *sql.DB, config Config, storageIndex StorageIndex, storageFeed StorageFeed, runtime *Runtime) *StorageExpiry {
+	ctx, ctxCancelFn := context.WithCancel(context.Background())
+
+	s := &StorageExpiry{
+		logger:       logger,
+		db:           db,
+		storageIndex: storageIndex,
+		storageFeed:  storageFeed,
+		fnExpire:     runtime.StorageExpire(),
+
+		ctx:         ctx,
+		ctxCancelFn: ctxCancelFn,
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(config.GetDatabase().StorageExpiryIntervalSec) * time.Second)
+		for {
+			select {
+			case <-s.ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				s.sweep()
+			}
+		}
+	}()
+
+	return s
+}
+
+func (s *StorageExpiry) Stop() {
+	s.ctxCancelFn()
+}
+
+func (s *StorageExpiry) sweep() {
+	for {
+		objects, err := StorageExpireObjects(s.ctx, s.logger, s.db, s.storageIndex, s.storageFeed, storageExpiryBatchSize)
+		if err != nil || len(objects) == 0 {
+			return
+		}
+
+		if s.fnExpire != nil {
+			if err := s.fnExpire(s.ctx, objects); err != nil {
+				s.logger.Error("Error invoking storage expire hook", zap.Error(err))
+			}
+		}
+
+		if len(objects) < storageExpiryBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Realtime sessions subscribe to storage changes with these socket RPC IDs, and a JSON payload with a "collection",
+// and optionally a "key" and "user_id" to only follow a single object. Changes are delivered as stream data messages
+// on a storage stream with the collection as label, the owner as subject and a hash of the key as subcontext, or
+// only the collection as label for whole collection subscriptions. Runtime RPC functions registered with the same IDs
+// take precedence over them.
+const (
+	StorageSubscribeRpcID   = "nakama.storage_subscribe"
+	StorageUnsubscribeRpcID = "nakama.storage_unsubscribe"
+)
+
+type StorageFeed interface {
+	// Write delivers objects that were written to the sessions subscribed to them, if they are allowed to read them.
+	Write(ctx context.Context, objects []*api.StorageObject)
+	// Delete delivers objects that were deleted to the sessions subscribed to them, if they were allowed to read them.
+	// Deleted objects only need their collection, key, owner and read permission.
+	Delete(ctx context.Context, objects []*api.StorageObject)
+}
+
+type storageFeedChange struct {
+	Object  json.RawMessage `json:"object"`
+	Deleted bool            `json:"deleted"`
+}
+
+type LocalStorageFeed struct {
+	logger             *zap.Logger
+	tracker            Tracker
+	router             MessageRouter
+	protojsonMarshaler *protojson.MarshalOptions
+}
+
+func NewLocalStorageFeed(logger *zap.Logger, tracker Tracker, router MessageRouter, protojsonMarshaler *protojson.MarshalOptions) StorageFeed {
+	return &LocalStorageFeed{
+		logger:             logger,
+		tracker:            tracker,
+		router:             router,
+		protojsonMarshaler: protojsonMarshaler,
+	}
+}
+
+func (f *LocalStorageFeed) Write(ctx context.Context, objects []*api.StorageObject) {
+	for _, object := range objects {
+		f.send(object, false)
+	}
+}
+
+func (f *LocalStorageFeed) Delete(ctx context.Context, objects []*api.StorageObject) {
+	for _, object := range objects {
+		f.send(object, true)
+	}
+}
+
+func (f *LocalStorageFeed) send(object *api.StorageObject, deleted bool) {
+	if object.PermissionRead == 0 {
+		// Objects only readable by the server are never delivered.
+		return
+	}
+	ownerID := uuid.FromStringOrNil(object.UserId)
+
+	var data []byte
+	for _, stream := range []PresenceStream{storageFeedStream(object.Collection, "", uuid.Nil), storageFeedStream(object.Collection, object.Key, ownerID)} {
+		presences := f.tracker.ListByStream(stream, true, false)
+		presenceIDs := make([]*PresenceID, 0, len(presences))
+		for _, presence := range presences {
+			if object.PermissionRead == 1 && presence.UserID != ownerID {
+				continue
+			}
+			presenceIDs = append(presenceIDs, &presence.ID)
+		}
+		if len(presenceIDs) == 0 {
+			continue
+		}
+
+		if data == nil {
+			objectBytes, err := f.protojsonMarshaler.Marshal(object)
+			if err != nil {
+				f.logger.Error("Could not marshal storage object", zap.Error(err))
+				return
+			}
+			if data, err = json.Marshal(&storageFeedChange{Object: objectBytes, Deleted: deleted}); err != nil {
+				f.logger.Error("Could not marshal storage change", zap.Error(err))
+				return
+			}
+		}
+
+		streamWire := &rtapi.Stream{
+			Mode:  int32(stream.Mode),
+			Label: stream.Label,
+		}
+		if stream.Subcontext != uuid.Nil {
+			// Single object subscriptions always have a subcontext, but may be for objects owned by the system.
+			streamWire.Subject = stream.Subject.String()
+			streamWire.Subcontext = stream.Subcontext.String()
+		}
+		envelope := &rtapi.Envelope{Message: &rtapi.Envelope_StreamData{StreamData: &rtapi.StreamData{
+			Stream: streamWire,
+			// No sender.
+			Data:     string(data),
+			Reliable: true,
+		}}}
+		f.router.SendToPresenceIDs(f.logger, presenceIDs, envelope, true)
+	}
+}
+
+// The stream followed by subscribers of a single object, or of a whole collection if the key is empty.
+func storageFeedStream(collection, key string, ownerID uuid.UUID) PresenceStream {
+	stream := PresenceStream{Mode: StreamModeStorage, Label: collection}
+	if key != "" {
+		stream.Subject = ownerID
+		stream.Subcontext = uuid.NewV5(uuid.Nil, key)
+	}
+	return stream
+}
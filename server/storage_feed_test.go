@@ -0,0 +1,91 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageFeedPermissions(t *testing.T) {
+	tracker := StartLocalTracker(logger, cfg, NewLocalSessionRegistry(&testMetrics{}), nil, &testMetrics{}, protojsonMarshaler, NewLocalClusterTransport("node"))
+	defer tracker.Stop()
+
+	received := make(map[uuid.UUID][]*rtapi.StreamData)
+	router := &testMessageRouter{sendToPresence: func(presences []*PresenceID, envelope *rtapi.Envelope) {
+		for _, presence := range presences {
+			received[presence.SessionID] = append(received[presence.SessionID], envelope.GetStreamData())
+		}
+	}}
+	feed := NewLocalStorageFeed(logger, tracker, router, protojsonMarshaler)
+
+	owner := uuid.Must(uuid.NewV4())
+	other := uuid.Must(uuid.NewV4())
+	subscribe := func(userID uuid.UUID, stream PresenceStream) uuid.UUID {
+		sessionID := uuid.Must(uuid.NewV4())
+		if success, _ := tracker.Track(context.Background(), sessionID, stream, userID, PresenceMeta{Hidden: true}, true); !success {
+			t.Fatal("expected subscription to be tracked")
+		}
+		return sessionID
+	}
+	ownerCollection := subscribe(owner, storageFeedStream("players", "", uuid.Nil))
+	otherCollection := subscribe(other, storageFeedStream("players", "", uuid.Nil))
+	otherObject := subscribe(other, storageFeedStream("players", "a", owner))
+
+	feed.Write(context.Background(), []*api.StorageObject{
+		newTestStorageObject(owner, "a", 2, 1, map[string]interface{}{"level": 1}),
+		newTestStorageObject(owner, "b", 1, 2, map[string]interface{}{"level": 2}),
+		newTestStorageObject(owner, "c", 0, 3, map[string]interface{}{"level": 3}),
+	})
+	feed.Delete(context.Background(), []*api.StorageObject{
+		{Collection: "players", Key: "a", UserId: owner.String(), PermissionRead: 2},
+	})
+
+	changes := func(sessionID uuid.UUID) []string {
+		keys := make([]string, 0, len(received[sessionID]))
+		for _, data := range received[sessionID] {
+			var change storageFeedChange
+			if err := json.Unmarshal([]byte(data.Data), &change); err != nil {
+				t.Fatalf("error unmarshalling change: %v", err)
+			}
+			object := &api.StorageObject{}
+			if err := protojsonUnmarshaler.Unmarshal(change.Object, object); err != nil {
+				t.Fatalf("error unmarshalling object: %v", err)
+			}
+			if change.Deleted {
+				keys = append(keys, "-"+object.Key)
+			} else {
+				keys = append(keys, object.Key)
+			}
+		}
+		return keys
+	}
+	// Owner only objects are not sent to other users, server only objects are not sent at all.
+	assert.Equal(t, []string{"a", "b", "-a"}, changes(ownerCollection))
+	assert.Equal(t, []string{"a", "-a"}, changes(otherCollection))
+	assert.Equal(t, []string{"a", "-a"}, changes(otherObject))
+
+	stream := received[otherObject][0].Stream
+	assert.Equal(t, int32(StreamModeStorage), stream.Mode)
+	assert.Equal(t, "players", stream.Label)
+	assert.Equal(t, owner.String(), stream.Subject)
+	assert.Equal(t, "", received[otherCollection][0].Stream.Subject)
+}
@@ -0,0 +1,642 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/index"
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/jackc/pgtype"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const clusterMessageStorageIndexUpdate = "storage_index_update"
+
+var (
+	ErrStorageIndexNotFound       = errors.New("storage index not found")
+	ErrStorageIndexExists         = errors.New("storage index already exists")
+	ErrStorageIndexInvalidName    = errors.New("storage index name is required")
+	ErrStorageIndexInvalidFields  = errors.New("storage index requires a collection and at least one field")
+	ErrStorageIndexInvalidEntries = errors.New("storage index max entries must be greater than 0")
+)
+
+// StorageIndex maintains in-memory search indexes over selected fields of storage object values. Objects are indexed
+// when written and removed when deleted, and indexes are queried with the same query syntax as match listing.
+type StorageIndex interface {
+	// Index or re-index written objects, with their expiry times in seconds since epoch or 0 if they never expire, or
+	// nil expiry times if none expire. Returns the number of objects indexed and removed from indexes.
+	Write(ctx context.Context, objects []*api.StorageObject, expireTimes []int64) (creates int, deletes int)
+	// Remove deleted objects from any indexes they are in.
+	Delete(ctx context.Context, objects StorageOpDeletes) (deletes int)
+	// Query an index. Callers other than the runtime only see objects they have permission to read.
+	List(ctx context.Context, callerID uuid.UUID, indexName, query string, limit int) (*api.StorageObjects, error)
+	// Populate all indexes with the objects currently in the database.
+	Load(ctx context.Context) error
+	CreateIndex(ctx context.Context, name, collection, key string, fields []string, maxEntries int) error
+	RegisterFilters(runtime *Runtime)
+	Stop()
+}
+
+type storageIndex struct {
+	Name       string
+	Collection string
+	Key        string
+	Fields     []string
+	MaxEntries int
+	Index      *bluge.Writer
+}
+
+// Updates applied on one node are replayed on its peers, which apply their own filters.
+type storageIndexClusterUpdate struct {
+	Writes           []*api.StorageObject
+	WriteExpireTimes []int64
+	Deletes          StorageOpDeletes
+}
+
+type LocalStorageIndex struct {
+	sync.RWMutex
+	logger    *zap.Logger
+	db        *sql.DB
+	transport ClusterTransport
+
+	indexByName         map[string]*storageIndex
+	indicesByCollection map[string][]*storageIndex
+	filters             map[string]RuntimeStorageIndexFilterFunction
+}
+
+func NewLocalStorageIndex(logger *zap.Logger, db *sql.DB, transport ClusterTransport) StorageIndex {
+	si := &LocalStorageIndex{
+		logger:    logger,
+		db:        db,
+		transport: transport,
+
+		indexByName:         make(map[string]*storageIndex),
+		indicesByCollection: make(map[string][]*storageIndex),
+		filters:             make(map[string]RuntimeStorageIndexFilterFunction),
+	}
+
+	transport.Handle(clusterMessageStorageIndexUpdate, si.handleClusterUpdate)
+
+	return si
+}
+
+func (si *LocalStorageIndex) Write(ctx context.Context, objects []*api.StorageObject, expireTimes []int64) (int, int) {
+	creates, deletes, replicate, replicateExpireTimes := si.write(ctx, objects, expireTimes)
+	if len(replicate) != 0 {
+		si.broadcast(&storageIndexClusterUpdate{Writes: replicate, WriteExpireTimes: replicateExpireTimes})
+	}
+	return creates, deletes
+}
+
+func (si *LocalStorageIndex) write(ctx context.Context, objects []*api.StorageObject, expireTimes []int64) (int, int, []*api.StorageObject, []int64) {
+	si.RLock()
+	defer si.RUnlock()
+
+	var creates, deletes int
+	var indexed []*api.StorageObject
+	var indexedExpireTimes []int64
+	batches := make(map[*storageIndex]*index.Batch)
+	for i, object := range objects {
+		var expireTime int64
+		if expireTimes != nil {
+			expireTime = expireTimes[i]
+		}
+		indices := si.indicesByCollection[object.Collection]
+		if len(indices) == 0 {
+			continue
+		}
+		matched := false
+		for _, idx := range indices {
+			if idx.Key != "" && idx.Key != object.Key {
+				continue
+			}
+			matched = true
+
+			batch, found := batches[idx]
+			if !found {
+				batch = bluge.NewBatch()
+				batches[idx] = batch
+			}
+
+			id := storageIndexID(object.Collection, object.Key, object.UserId)
+			if filterFn, found := si.filters[idx.Name]; found {
+				insert, err := filterFn(ctx, storageIndexWriteOp(object))
+				if err != nil {
+					si.logger.Error("Error invoking storage index filter function", zap.String("index", idx.Name), zap.Error(err))
+					continue
+				}
+				if !insert {
+					// Objects may have been indexed before they stopped matching the filter.
+					batch.Delete(bluge.Identifier(id))
+					deletes++
+					continue
+				}
+			}
+
+			doc, err := si.mapIndexEntry(idx, id, object, expireTime)
+			if err != nil {
+				si.logger.Error("Error mapping storage object to index entry", zap.String("index", idx.Name), zap.Error(err))
+				continue
+			}
+			batch.Update(doc.ID(), doc)
+			creates++
+		}
+		if matched {
+			indexed = append(indexed, object)
+			indexedExpireTimes = append(indexedExpireTimes, expireTime)
+		}
+	}
+
+	for idx, batch := range batches {
+		if err := idx.Index.Batch(batch); err != nil {
+			si.logger.Error("Error updating storage index", zap.String("index", idx.Name), zap.Error(err))
+			continue
+		}
+		si.evict(ctx, idx)
+	}
+
+	return creates, deletes, indexed, indexedExpireTimes
+}
+
+func (si *LocalStorageIndex) Delete(ctx context.Context, objects StorageOpDeletes) int {
+	deletes, replicate := si.delete(objects)
+	if len(replicate) != 0 {
+		si.broadcast(&storageIndexClusterUpdate{Deletes: replicate})
+	}
+	return deletes
+}
+
+func (si *LocalStorageIndex) delete(objects StorageOpDeletes) (int, StorageOpDeletes) {
+	si.RLock()
+	defer si.RUnlock()
+
+	var deletes int
+	var indexed StorageOpDeletes
+	batches := make(map[*storageIndex]*index.Batch)
+	for _, object := range objects {
+		indices := si.indicesByCollection[object.ObjectID.Collection]
+		matched := false
+		for _, idx := range indices {
+			if idx.Key != "" && idx.Key != object.ObjectID.Key {
+				continue
+			}
+			matched = true
+
+			batch, found := batches[idx]
+			if !found {
+				batch = bluge.NewBatch()
+				batches[idx] = batch
+			}
+			batch.Delete(bluge.Identifier(storageIndexID(object.ObjectID.Collection, object.ObjectID.Key, object.OwnerID)))
+			deletes++
+		}
+		if matched {
+			indexed = append(indexed, object)
+		}
+	}
+
+	for idx, batch := range batches {
+		if err := idx.Index.Batch(batch); err != nil {
+			si.logger.Error("Error deleting from storage index", zap.String("index", idx.Name), zap.Error(err))
+		}
+	}
+
+	return deletes, indexed
+}
+
+func (si *LocalStorageIndex) List(ctx context.Context, callerID uuid.UUID, indexName, query string, limit int) (*api.StorageObjects, error) {
+	si.RLock()
+	idx, found := si.indexByName[indexName]
+	si.RUnlock()
+	if !found {
+		return nil, ErrStorageIndexNotFound
+	}
+
+	if limit > idx.MaxEntries {
+		limit = idx.MaxEntries
+	}
+
+	var q bluge.Query
+	if query == "" {
+		q = bluge.NewMatchAllQuery()
+	} else {
+		parsed, err := ParseQueryString(query)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing query string: %v", err.Error())
+		}
+		q = parsed
+	}
+
+	if callerID != uuid.Nil {
+		// Only public objects, or objects owned by the caller that they can read.
+		publicQuery := bluge.NewNumericRangeInclusiveQuery(2, 2, true, true).SetField("read")
+		ownedQuery := bluge.NewBooleanQuery()
+		ownedQuery.AddMust(bluge.NewTermQuery(callerID.String()).SetField("user_id"))
+		ownedQuery.AddMust(bluge.NewNumericRangeInclusiveQuery(1, 2, true, true).SetField("read"))
+		permissionQuery := bluge.NewBooleanQuery()
+		permissionQuery.AddShould(publicQuery, ownedQuery)
+		permissionQuery.SetMinShould(1)
+
+		multiQuery := bluge.NewBooleanQuery()
+		multiQuery.AddMust(q, permissionQuery)
+		q = multiQuery
+	}
+
+	// Expired objects stay indexed until the storage expiry job deletes them, but are not returned.
+	expiredQuery := bluge.NewNumericRangeInclusiveQuery(0, float64(time.Now().UTC().Unix()), false, true).SetField("expire_time")
+	unexpiredQuery := bluge.NewBooleanQuery()
+	unexpiredQuery.AddMust(q)
+	unexpiredQuery.AddMustNot(expiredQuery)
+	q = unexpiredQuery
+
+	indexReader, err := idx.Index.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("error accessing storage index reader: %v", err.Error())
+	}
+	defer indexReader.Close()
+
+	searchReq := bluge.NewTopNSearch(limit, q)
+	searchReq.SortBy([]string{"-_score", "-update_time"})
+
+	results, err := indexReader.Search(ctx, searchReq)
+	if err != nil {
+		return nil, fmt.Errorf("error listing storage index: %v", err.Error())
+	}
+
+	objects := make([]*api.StorageObject, 0, limit)
+	match, err := results.Next()
+	for match != nil && err == nil {
+		object := &api.StorageObject{CreateTime: &timestamppb.Timestamp{}, UpdateTime: &timestamppb.Timestamp{}}
+		err = match.VisitStoredFields(func(field string, value []byte) bool {
+			switch field {
+			case "collection":
+				object.Collection = string(value)
+			case "key":
+				object.Key = string(value)
+			case "user_id":
+				object.UserId = string(value)
+			case "value":
+				object.Value = string(value)
+			case "version":
+				object.Version = string(value)
+			case "read":
+				read, _ := bluge.DecodeNumericFloat64(value)
+				object.PermissionRead = int32(read)
+			case "write":
+				write, _ := bluge.DecodeNumericFloat64(value)
+				object.PermissionWrite = int32(write)
+			case "create_time":
+				createTime, _ := bluge.DecodeNumericFloat64(value)
+				object.CreateTime.Seconds = int64(createTime)
+			case "update_time":
+				updateTime, _ := bluge.DecodeNumericFloat64(value)
+				object.UpdateTime.Seconds = int64(updateTime)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error visiting stored field: %v", err.Error())
+		}
+		objects = append(objects, object)
+		match, err = results.Next()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error iterating storage index results: %v", err.Error())
+	}
+
+	return &api.StorageObjects{Objects: objects}, nil
+}
+
+func (si *LocalStorageIndex) Load(ctx context.Context) error {
+	si.RLock()
+	indices := make([]*storageIndex, 0, len(si.indexByName))
+	for _, idx := range si.indexByName {
+		indices = append(indices, idx)
+	}
+	si.RUnlock()
+
+	for _, idx := range indices {
+		query := `
+SELECT collection, key, user_id, value, version, read, write, create_time, update_time, expire_time
+FROM storage
+WHERE collection = $1 AND (expire_time IS NULL OR expire_time > now())`
+		params := []interface{}{idx.Collection, idx.MaxEntries}
+		if idx.Key != "" {
+			query += " AND key = $3"
+			params = append(params, idx.Key)
+		}
+		// Only the most recently updated objects would be kept.
+		query += " ORDER BY update_time DESC LIMIT $2"
+
+		rows, err := si.db.QueryContext(ctx, query, params...)
+		if err != nil {
+			return fmt.Errorf("error loading storage index %v: %v", idx.Name, err.Error())
+		}
+
+		objects := make([]*api.StorageObject, 0, idx.MaxEntries)
+		expireTimes := make([]int64, 0, idx.MaxEntries)
+		for rows.Next() {
+			o := &api.StorageObject{CreateTime: &timestamppb.Timestamp{}, UpdateTime: &timestamppb.Timestamp{}}
+			var createTime pgtype.Timestamptz
+			var updateTime pgtype.Timestamptz
+			var expireTime pgtype.Timestamptz
+			if err := rows.Scan(&o.Collection, &o.Key, &o.UserId, &o.Value, &o.Version, &o.PermissionRead, &o.PermissionWrite, &createTime, &updateTime, &expireTime); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("error loading storage index %v: %v", idx.Name, err.Error())
+			}
+			o.CreateTime.Seconds = createTime.Time.Unix()
+			o.UpdateTime.Seconds = updateTime.Time.Unix()
+			objects = append(objects, o)
+			if expireTime.Status == pgtype.Present {
+				expireTimes = append(expireTimes, expireTime.Time.Unix())
+			} else {
+				expireTimes = append(expireTimes, 0)
+			}
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error loading storage index %v: %v", idx.Name, err.Error())
+		}
+
+		// Objects in the collection may also belong to other indexes, re-indexing them there is harmless.
+		creates, _, _, _ := si.write(ctx, objects, expireTimes)
+		si.logger.Info("Loaded storage index", zap.String("index", idx.Name), zap.Int("count", creates))
+	}
+
+	return nil
+}
+
+func (si *LocalStorageIndex) CreateIndex(ctx context.Context, name, collection, key string, fields []string, maxEntries int) error {
+	if name == "" {
+		return ErrStorageIndexInvalidName
+	}
+	if collection == "" || len(fields) == 0 {
+		return ErrStorageIndexInvalidFields
+	}
+	if maxEntries < 1 {
+		return ErrStorageIndexInvalidEntries
+	}
+
+	si.Lock()
+	defer si.Unlock()
+
+	if _, found := si.indexByName[name]; found {
+		return ErrStorageIndexExists
+	}
+
+	writer, err := bluge.OpenWriter(BlugeInMemoryConfig())
+	if err != nil {
+		return err
+	}
+
+	idx := &storageIndex{
+		Name:       name,
+		Collection: collection,
+		Key:        key,
+		Fields:     fields,
+		MaxEntries: maxEntries,
+		Index:      writer,
+	}
+	si.indexByName[name] = idx
+	si.indicesByCollection[collection] = append(si.indicesByCollection[collection], idx)
+
+	return nil
+}
+
+func (si *LocalStorageIndex) RegisterFilters(runtime *Runtime) {
+	si.Lock()
+	defer si.Unlock()
+
+	for name, fn := range runtime.StorageIndexFilterFunctions() {
+		if _, found := si.indexByName[name]; !found {
+			si.logger.Warn("Storage index filter function registered for unknown index", zap.String("index", name))
+			continue
+		}
+		si.filters[name] = fn
+	}
+}
+
+func (si *LocalStorageIndex) Stop() {
+	si.Lock()
+	defer si.Unlock()
+
+	for _, idx := range si.indexByName {
+		if err := idx.Index.Close(); err != nil {
+			si.logger.Warn("Error closing storage index", zap.String("index", idx.Name), zap.Error(err))
+		}
+	}
+}
+
+// Drop the least recently updated objects from the index while it holds more than its maximum number of entries.
+func (si *LocalStorageIndex) evict(ctx context.Context, idx *storageIndex) {
+	indexReader, err := idx.Index.Reader()
+	if err != nil {
+		si.logger.Error("Error accessing storage index reader", zap.String("index", idx.Name), zap.Error(err))
+		return
+	}
+	defer indexReader.Close()
+
+	count, err := indexReader.Count()
+	if err != nil {
+		si.logger.Error("Error counting storage index entries", zap.String("index", idx.Name), zap.Error(err))
+		return
+	}
+	if int(count) <= idx.MaxEntries {
+		return
+	}
+
+	searchReq := bluge.NewTopNSearch(int(count)-idx.MaxEntries, bluge.NewMatchAllQuery())
+	searchReq.SortBy([]string{"update_time"})
+	results, err := indexReader.Search(ctx, searchReq)
+	if err != nil {
+		si.logger.Error("Error searching storage index entries to evict", zap.String("index", idx.Name), zap.Error(err))
+		return
+	}
+
+	batch := bluge.NewBatch()
+	match, err := results.Next()
+	for match != nil && err == nil {
+		err = match.VisitStoredFields(func(field string, value []byte) bool {
+			if field == "_id" {
+				batch.Delete(bluge.Identifier(value))
+				return false
+			}
+			return true
+		})
+		if err != nil {
+			break
+		}
+		match, err = results.Next()
+	}
+	if err != nil {
+		si.logger.Error("Error iterating storage index entries to evict", zap.String("index", idx.Name), zap.Error(err))
+		return
+	}
+	if err := idx.Index.Batch(batch); err != nil {
+		si.logger.Error("Error evicting storage index entries", zap.String("index", idx.Name), zap.Error(err))
+	}
+}
+
+func (si *LocalStorageIndex) mapIndexEntry(idx *storageIndex, id string, object *api.StorageObject, expireTime int64) (*bluge.Document, error) {
+	var value map[string]interface{}
+	if err := json.Unmarshal([]byte(object.Value), &value); err != nil {
+		return nil, err
+	}
+
+	createTime := object.GetCreateTime().GetSeconds()
+	if createTime == 0 {
+		// Updates do not know when the object was created, unless it was already indexed.
+		createTime = object.GetUpdateTime().GetSeconds()
+		if existing, err := si.createTime(idx, id); err == nil && existing != 0 {
+			createTime = existing
+		}
+	}
+
+	rv := bluge.NewDocument(id)
+	rv.AddField(bluge.NewKeywordField("collection", object.Collection).StoreValue())
+	rv.AddField(bluge.NewKeywordField("key", object.Key).StoreValue())
+	rv.AddField(bluge.NewKeywordField("user_id", object.UserId).StoreValue())
+	rv.AddField(bluge.NewKeywordField("version", object.Version).StoreValue())
+	rv.AddField(bluge.NewStoredOnlyField("value", []byte(object.Value)))
+	rv.AddField(bluge.NewNumericField("read", float64(object.PermissionRead)).StoreValue())
+	rv.AddField(bluge.NewNumericField("write", float64(object.PermissionWrite)).StoreValue())
+	rv.AddField(bluge.NewNumericField("create_time", float64(createTime)).StoreValue())
+	rv.AddField(bluge.NewNumericField("update_time", float64(object.GetUpdateTime().GetSeconds())).StoreValue().Sortable())
+	rv.AddField(bluge.NewNumericField("expire_time", float64(expireTime)))
+
+	for _, field := range idx.Fields {
+		if fieldValue, found := value[field]; found {
+			blugeProcessProperty(fieldValue, []string{"value", field}, rv)
+		}
+	}
+
+	return rv, nil
+}
+
+func (si *LocalStorageIndex) createTime(idx *storageIndex, id string) (int64, error) {
+	indexReader, err := idx.Index.Reader()
+	if err != nil {
+		return 0, err
+	}
+	defer indexReader.Close()
+
+	query := bluge.NewTermQuery(id).SetField("_id")
+	results, err := indexReader.Search(context.Background(), bluge.NewTopNSearch(1, query))
+	if err != nil {
+		return 0, err
+	}
+	match, err := results.Next()
+	if err != nil || match == nil {
+		return 0, err
+	}
+	var createTime float64
+	err = match.VisitStoredFields(func(field string, value []byte) bool {
+		if field == "create_time" {
+			createTime, err = bluge.DecodeNumericFloat64(value)
+			return false
+		}
+		return true
+	})
+	return int64(createTime), err
+}
+
+func (si *LocalStorageIndex) broadcast(update *storageIndexClusterUpdate) {
+	payload, err := gobEncode(update)
+	if err != nil {
+		si.logger.Error("Error encoding storage index update", zap.Error(err))
+		return
+	}
+	// Nodes that miss an update while disconnected catch up on their next restart.
+	si.transport.Broadcast(clusterMessageStorageIndexUpdate, payload)
+}
+
+func (si *LocalStorageIndex) handleClusterUpdate(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var update storageIndexClusterUpdate
+	if err := gobDecode(payload, &update); err != nil {
+		return nil, err
+	}
+	if len(update.Writes) != 0 {
+		si.write(ctx, update.Writes, update.WriteExpireTimes)
+	}
+	if len(update.Deletes) != 0 {
+		si.delete(update.Deletes)
+	}
+	return nil, nil
+}
+
+// Objects owned by the system have the nil user ID, the same as in the database.
+func storageIndexID(collection, key, userID string) string {
+	if userID == "" {
+		userID = uuid.Nil.String()
+	}
+	return collection + "." + key + "." + userID
+}
+
+func storageIndexWriteOp(object *api.StorageObject) *StorageOpWrite {
+	return &StorageOpWrite{
+		OwnerID: object.UserId,
+		Object: &api.WriteStorageObject{
+			Collection:      object.Collection,
+			Key:             object.Key,
+			Value:           object.Value,
+			Version:         object.Version,
+			PermissionRead:  &wrapperspb.Int32Value{Value: object.PermissionRead},
+			PermissionWrite: &wrapperspb.Int32Value{Value: object.PermissionWrite},
+		},
+	}
+}
+
+// Build the objects that were written from a batch of write operations, their acks and the values written, along with
+// their expiry times, for indexing.
+func storageIndexObjects(ops StorageOpWrites, acks []*api.StorageObjectAck, values []string) ([]*api.StorageObject, []int64) {
+	now := time.Now().UTC().Unix()
+	objects := make([]*api.StorageObject, 0, len(ops))
+	expireTimes := make([]int64, 0, len(ops))
+	for i, op := range ops {
+		if i >= len(acks) || acks[i] == nil {
+			continue
+		}
+		object := &api.StorageObject{
+			Collection:      op.Object.Collection,
+			Key:             op.Object.Key,
+			UserId:          op.OwnerID,
+			Value:           values[i],
+			Version:         acks[i].Version,
+			PermissionRead:  1,
+			PermissionWrite: 1,
+			UpdateTime:      &timestamppb.Timestamp{Seconds: now},
+		}
+		if op.Object.PermissionRead != nil {
+			object.PermissionRead = op.Object.PermissionRead.Value
+		}
+		if op.Object.PermissionWrite != nil {
+			object.PermissionWrite = op.Object.PermissionWrite.Value
+		}
+		objects = append(objects, object)
+		expireTimes = append(expireTimes, op.ExpireTime)
+	}
+	return objects, expireTimes
+}
@@ -0,0 +1,210 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestStorageObject(userID uuid.UUID, key string, read int32, updateTime int64, value map[string]interface{}) *api.StorageObject {
+	bytes, _ := json.Marshal(value)
+	return &api.StorageObject{
+		Collection:      "players",
+		Key:             key,
+		UserId:          userID.String(),
+		Value:           string(bytes),
+		Version:         "v",
+		PermissionRead:  read,
+		PermissionWrite: 1,
+		UpdateTime:      &timestamppb.Timestamp{Seconds: updateTime},
+	}
+}
+
+func storageIndexKeys(objects *api.StorageObjects) []string {
+	keys := make([]string, 0, len(objects.Objects))
+	for _, o := range objects.Objects {
+		keys = append(keys, o.Key)
+	}
+	return keys
+}
+
+func TestStorageIndexList(t *testing.T) {
+	si := NewLocalStorageIndex(logger, nil, NewLocalClusterTransport("node"))
+	defer si.Stop()
+	if err := si.CreateIndex(context.Background(), "players", "players", "", []string{"level", "region"}, 100); err != nil {
+		t.Fatalf("error creating index: %v", err)
+	}
+	assert.Equal(t, ErrStorageIndexExists, si.CreateIndex(context.Background(), "players", "players", "", []string{"level"}, 100))
+
+	owner := uuid.Must(uuid.NewV4())
+	other := uuid.Must(uuid.NewV4())
+	creates, _ := si.Write(context.Background(), []*api.StorageObject{
+		newTestStorageObject(owner, "a", 2, 1, map[string]interface{}{"level": 5, "region": "eu", "secret": "x"}),
+		newTestStorageObject(owner, "b", 1, 2, map[string]interface{}{"level": 20, "region": "us"}),
+		newTestStorageObject(other, "c", 0, 3, map[string]interface{}{"level": 30, "region": "eu"}),
+		{Collection: "other", Key: "d", UserId: owner.String(), Value: `{"level":50}`},
+	}, nil)
+	assert.Equal(t, 3, creates)
+
+	objects, err := si.List(context.Background(), uuid.Nil, "players", "+value.level:>10", 10)
+	if err != nil {
+		t.Fatalf("error listing index: %v", err)
+	}
+	assert.ElementsMatch(t, []string{"b", "c"}, storageIndexKeys(objects))
+
+	objects, err = si.List(context.Background(), uuid.Nil, "players", "+value.region:eu", 10)
+	if err != nil {
+		t.Fatalf("error listing index: %v", err)
+	}
+	assert.ElementsMatch(t, []string{"a", "c"}, storageIndexKeys(objects))
+	for _, o := range objects.Objects {
+		if o.Key == "a" {
+			// The whole value is returned, including fields that are not indexed.
+			assert.JSONEq(t, `{"level":5,"region":"eu","secret":"x"}`, o.Value)
+			assert.Equal(t, owner.String(), o.UserId)
+			assert.Equal(t, int32(2), o.PermissionRead)
+		}
+	}
+
+	// Callers only see public objects, and their own readable objects.
+	objects, err = si.List(context.Background(), owner, "players", "", 10)
+	if err != nil {
+		t.Fatalf("error listing index: %v", err)
+	}
+	assert.ElementsMatch(t, []string{"a", "b"}, storageIndexKeys(objects))
+	objects, err = si.List(context.Background(), other, "players", "", 10)
+	if err != nil {
+		t.Fatalf("error listing index: %v", err)
+	}
+	assert.Equal(t, []string{"a"}, storageIndexKeys(objects))
+
+	_, err = si.List(context.Background(), uuid.Nil, "unknown", "", 10)
+	assert.Equal(t, ErrStorageIndexNotFound, err)
+}
+
+func TestStorageIndexEvictAndDelete(t *testing.T) {
+	si := NewLocalStorageIndex(logger, nil, NewLocalClusterTransport("node"))
+	defer si.Stop()
+	if err := si.CreateIndex(context.Background(), "players", "players", "", []string{"level"}, 2); err != nil {
+		t.Fatalf("error creating index: %v", err)
+	}
+
+	userID := uuid.Must(uuid.NewV4())
+	si.Write(context.Background(), []*api.StorageObject{
+		newTestStorageObject(userID, "a", 2, 1, map[string]interface{}{"level": 1}),
+		newTestStorageObject(userID, "b", 2, 2, map[string]interface{}{"level": 2}),
+	}, nil)
+	si.Write(context.Background(), []*api.StorageObject{
+		newTestStorageObject(userID, "c", 2, 3, map[string]interface{}{"level": 3}),
+	}, nil)
+
+	// The least recently updated object is dropped.
+	objects, err := si.List(context.Background(), uuid.Nil, "players", "", 10)
+	if err != nil {
+		t.Fatalf("error listing index: %v", err)
+	}
+	assert.ElementsMatch(t, []string{"b", "c"}, storageIndexKeys(objects))
+
+	si.Delete(context.Background(), StorageOpDeletes{{OwnerID: userID.String(), ObjectID: &api.DeleteStorageObjectId{Collection: "players", Key: "c"}}})
+	objects, err = si.List(context.Background(), uuid.Nil, "players", "", 10)
+	if err != nil {
+		t.Fatalf("error listing index: %v", err)
+	}
+	assert.Equal(t, []string{"b"}, storageIndexKeys(objects))
+}
+
+func TestStorageIndexExpiry(t *testing.T) {
+	si := NewLocalStorageIndex(logger, nil, NewLocalClusterTransport("node"))
+	defer si.Stop()
+	if err := si.CreateIndex(context.Background(), "players", "players", "", []string{"level"}, 10); err != nil {
+		t.Fatalf("error creating index: %v", err)
+	}
+
+	userID := uuid.Must(uuid.NewV4())
+	now := time.Now().UTC().Unix()
+	si.Write(context.Background(), []*api.StorageObject{
+		newTestStorageObject(userID, "a", 2, 1, map[string]interface{}{"level": 1}),
+		newTestStorageObject(userID, "b", 2, 2, map[string]interface{}{"level": 2}),
+		newTestStorageObject(userID, "c", 2, 3, map[string]interface{}{"level": 3}),
+	}, []int64{0, now - 1, now + 3600})
+
+	// Expired objects are not returned even before the storage expiry job removes them.
+	objects, err := si.List(context.Background(), uuid.Nil, "players", "", 10)
+	if err != nil {
+		t.Fatalf("error listing index: %v", err)
+	}
+	assert.ElementsMatch(t, []string{"a", "c"}, storageIndexKeys(objects))
+	objects, err = si.List(context.Background(), userID, "players", "+value.level:>1", 10)
+	if err != nil {
+		t.Fatalf("error listing index: %v", err)
+	}
+	assert.Equal(t, []string{"c"}, storageIndexKeys(objects))
+}
+
+func TestStorageIndexFilterAndReplication(t *testing.T) {
+	hub := NewLoopbackClusterHub()
+	transportA := hub.NewTransport("a")
+	transportB := hub.NewTransport("b")
+	siA := NewLocalStorageIndex(logger, nil, transportA)
+	siB := NewLocalStorageIndex(logger, nil, transportB)
+	for _, si := range []StorageIndex{siA, siB} {
+		if err := si.CreateIndex(context.Background(), "players", "players", "", []string{"level"}, 10); err != nil {
+			t.Fatalf("error creating index: %v", err)
+		}
+		// Only objects with a level are indexed.
+		si.(*LocalStorageIndex).filters["players"] = func(ctx context.Context, write *StorageOpWrite) (bool, error) {
+			var value map[string]interface{}
+			_ = json.Unmarshal([]byte(write.Object.Value), &value)
+			_, found := value["level"]
+			return found, nil
+		}
+	}
+	if err := transportA.Start(); err != nil {
+		t.Fatalf("error starting transport: %v", err)
+	}
+	defer transportA.Stop()
+	if err := transportB.Start(); err != nil {
+		t.Fatalf("error starting transport: %v", err)
+	}
+	defer transportB.Stop()
+
+	userID := uuid.Must(uuid.NewV4())
+	creates, deletes := siA.Write(context.Background(), []*api.StorageObject{
+		newTestStorageObject(userID, "a", 2, 1, map[string]interface{}{"level": 1}),
+		newTestStorageObject(userID, "b", 2, 2, map[string]interface{}{"name": "b"}),
+	}, nil)
+	assert.Equal(t, 1, creates)
+	assert.Equal(t, 1, deletes)
+
+	listB := func() []string {
+		objects, err := siB.List(context.Background(), uuid.Nil, "players", "", 10)
+		if err != nil {
+			t.Fatalf("error listing index: %v", err)
+		}
+		return storageIndexKeys(objects)
+	}
+	assert.Eventually(t, func() bool { return assert.ObjectsAreEqual([]string{"a"}, listB()) }, 5*time.Second, 10*time.Millisecond)
+
+	siA.Delete(context.Background(), StorageOpDeletes{{OwnerID: userID.String(), ObjectID: &api.DeleteStorageObjectId{Collection: "players", Key: "a"}}})
+	assert.Eventually(t, func() bool { return len(listB()) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
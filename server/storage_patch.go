@@ -0,0 +1,395 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var ErrStoragePatchRejected = errors.New("Storage write rejected - patch could not be applied.")
+
+// How a storage write value is applied to the stored value.
+type StoragePatch int
+
+const (
+	// The value replaces the stored value.
+	StoragePatchNone StoragePatch = iota
+	// The value is an RFC 7386 JSON merge patch object.
+	StoragePatchMerge
+	// The value is an RFC 6902 JSON patch array of operations.
+	StoragePatchJson
+)
+
+func ParseStoragePatch(name string) (StoragePatch, error) {
+	switch name {
+	case "":
+		return StoragePatchNone, nil
+	case "merge":
+		return StoragePatchMerge, nil
+	case "json":
+		return StoragePatchJson, nil
+	default:
+		return StoragePatchNone, fmt.Errorf("unknown storage patch type %q, expects \"merge\" or \"json\"", name)
+	}
+}
+
+// Check a write value has the shape expected by its patch type, a JSON object for replacements and merge patches or
+// a JSON array for JSON patches.
+func StoragePatchValueValid(patch StoragePatch, value string) bool {
+	maybeJSON := bytes.TrimSpace([]byte(value))
+	if !json.Valid(maybeJSON) {
+		return false
+	}
+	if patch == StoragePatchJson {
+		return maybeJSON[0] == '['
+	}
+	return maybeJSON[0] == byteBracket
+}
+
+type storageJsonPatchOp struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Apply a patch to a stored value, or to an empty object if there is no stored value. The result must still be a
+// JSON object. Errors wrap ErrStoragePatchRejected.
+func storagePatchValue(patch StoragePatch, current, value string) (string, error) {
+	target, err := storagePatchDecode(current)
+	if err != nil {
+		return "", err
+	}
+
+	switch patch {
+	case StoragePatchMerge:
+		merge, err := storagePatchDecode(value)
+		if err != nil {
+			return "", fmt.Errorf("%w Invalid merge patch.", ErrStoragePatchRejected)
+		}
+		target = storageMergePatch(target, merge)
+	case StoragePatchJson:
+		var ops []*storageJsonPatchOp
+		if err := json.Unmarshal([]byte(value), &ops); err != nil {
+			return "", fmt.Errorf("%w Invalid JSON patch.", ErrStoragePatchRejected)
+		}
+		for i, op := range ops {
+			if target, err = storageJsonPatch(target, op); err != nil {
+				return "", fmt.Errorf("%w Operation %d: %s.", ErrStoragePatchRejected, i, err.Error())
+			}
+		}
+	default:
+		return value, nil
+	}
+
+	if _, ok := target.(map[string]interface{}); !ok {
+		return "", fmt.Errorf("%w Value must remain a JSON object.", ErrStoragePatchRejected)
+	}
+	result, err := json.Marshal(target)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+func storagePatchDecode(value string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	// Keep numbers exactly as they were written.
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+func storageMergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		// Anything other than an object replaces the target.
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{}, len(patchObject))
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = storageMergePatch(targetObject[name], value)
+	}
+	return targetObject
+}
+
+func storageJsonPatch(target interface{}, op *storageJsonPatchOp) (interface{}, error) {
+	if op.Path == nil {
+		return nil, errors.New("missing path")
+	}
+	path, err := storageJsonPointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, errors.New("missing value")
+		}
+		if value, err = storagePatchDecode(string(op.Value)); err != nil {
+			return nil, errors.New("invalid value")
+		}
+	case "move", "copy":
+		if op.From == nil {
+			return nil, errors.New("missing from")
+		}
+		from, err := storageJsonPointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+		if value, err = storageJsonPointerGet(target, from); err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			// Values are updated in place, the copy must not share any objects or arrays with its source.
+			value = storageJsonCopy(value)
+			break
+		}
+		if len(from) <= len(path) && storageJsonPointerPrefix(from, path) {
+			if len(from) == len(path) {
+				// Moving a value to itself.
+				return target, nil
+			}
+			return nil, errors.New("cannot move a value into itself")
+		}
+		if target, err = storageJsonPointerUpdate(target, from, "remove", nil); err != nil {
+			return nil, err
+		}
+	case "remove":
+	default:
+		return nil, fmt.Errorf("unknown op %q", op.Op)
+	}
+
+	switch op.Op {
+	case "test":
+		current, err := storageJsonPointerGet(target, path)
+		if err != nil {
+			return nil, err
+		}
+		if !storageJsonEqual(current, value) {
+			return nil, errors.New("test failed")
+		}
+		return target, nil
+	case "replace", "remove":
+		return storageJsonPointerUpdate(target, path, op.Op, value)
+	default:
+		// Add, or the second half of move and copy.
+		return storageJsonPointerUpdate(target, path, "add", value)
+	}
+}
+
+// Split an RFC 6901 JSON pointer into its unescaped reference tokens.
+func storageJsonPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid path %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func storageJsonPointerPrefix(prefix, path []string) bool {
+	for i, token := range prefix {
+		if path[i] != token {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse an array index, which may be equal to the array length when inserting at the end.
+func storageJsonPointerIndex(token string, length int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index >= length {
+		return 0, fmt.Errorf("array index %q out of range", token)
+	}
+	return index, nil
+}
+
+func storageJsonPointerGet(target interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch container := target.(type) {
+		case map[string]interface{}:
+			value, found := container[token]
+			if !found {
+				return nil, fmt.Errorf("path %q not found", token)
+			}
+			target = value
+		case []interface{}:
+			index, err := storageJsonPointerIndex(token, len(container))
+			if err != nil {
+				return nil, err
+			}
+			target = container[index]
+		default:
+			return nil, fmt.Errorf("path %q not found", token)
+		}
+	}
+	return target, nil
+}
+
+// Add, replace or remove the value at a path, returning the updated target. Objects are updated in place, arrays
+// may be reallocated so the updated array is stored back in its parent.
+func storageJsonPointerUpdate(target interface{}, path []string, op string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		if op == "remove" {
+			return nil, errors.New("cannot remove the whole value")
+		}
+		return value, nil
+	}
+
+	token := path[0]
+	switch container := target.(type) {
+	case map[string]interface{}:
+		child, found := container[token]
+		if len(path) == 1 {
+			if !found && op != "add" {
+				return nil, fmt.Errorf("path %q not found", token)
+			}
+			if op == "remove" {
+				delete(container, token)
+			} else {
+				container[token] = value
+			}
+			return container, nil
+		}
+		if !found {
+			return nil, fmt.Errorf("path %q not found", token)
+		}
+		updated, err := storageJsonPointerUpdate(child, path[1:], op, value)
+		if err != nil {
+			return nil, err
+		}
+		container[token] = updated
+		return container, nil
+	case []interface{}:
+		if len(path) == 1 && op == "add" {
+			if token == "-" {
+				return append(container, value), nil
+			}
+			index, err := storageJsonPointerIndex(token, len(container)+1)
+			if err != nil {
+				return nil, err
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		}
+		index, err := storageJsonPointerIndex(token, len(container))
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 1 {
+			if op == "remove" {
+				return append(container[:index], container[index+1:]...), nil
+			}
+			container[index] = value
+			return container, nil
+		}
+		updated, err := storageJsonPointerUpdate(container[index], path[1:], op, value)
+		if err != nil {
+			return nil, err
+		}
+		container[index] = updated
+		return container, nil
+	default:
+		return nil, fmt.Errorf("path %q not found", token)
+	}
+}
+
+func storageJsonCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for name, child := range v {
+			c[name] = storageJsonCopy(child)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, child := range v {
+			c[i] = storageJsonCopy(child)
+		}
+		return c
+	default:
+		return value
+	}
+}
+
+func storageJsonEqual(a, b interface{}) bool {
+	switch va := a.(type) {
+	case map[string]interface{}:
+		vb, ok := b.(map[string]interface{})
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for name, child := range va {
+			other, found := vb[name]
+			if !found || !storageJsonEqual(child, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		vb, ok := b.([]interface{})
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for i := range va {
+			if !storageJsonEqual(va[i], vb[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		vb, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		// Numbers are equal if their values are, however they were written.
+		fa, okA := new(big.Float).SetString(va.String())
+		fb, okB := new(big.Float).SetString(vb.String())
+		return okA && okB && fa.Cmp(fb) == 0
+	default:
+		return a == b
+	}
+}
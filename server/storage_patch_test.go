@@ -0,0 +1,166 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama/v3/apigrpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoragePatchMerge(t *testing.T) {
+	cases := []struct {
+		current  string
+		patch    string
+		expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		// Large numbers are kept exactly as written.
+		{`{"a":12345678901234567890}`, `{"b":1.50}`, `{"a":12345678901234567890,"b":1.50}`},
+	}
+	for _, c := range cases {
+		value, err := storagePatchValue(StoragePatchMerge, c.current, c.patch)
+		if err != nil {
+			t.Fatalf("error applying merge patch %v to %v: %v", c.patch, c.current, err)
+		}
+		assert.JSONEq(t, c.expected, value, c.patch)
+	}
+}
+
+func TestStoragePatchJson(t *testing.T) {
+	cases := []struct {
+		current  string
+		patch    string
+		expected string
+	}{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc"]}]`, `{"foo":["bar",["abc"]]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{`{"a":{"b":[1]}}`, `[{"op":"copy","from":"/a","path":"/c"},{"op":"add","path":"/c/b/-","value":2}]`, `{"a":{"b":[1]},"c":{"b":[1,2]}}`},
+		{`{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2.0}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{`{"a/b":1,"m~n":2}`, `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`, `{"a/b":3}`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"","value":{"baz":"qux"}}]`, `{"baz":"qux"}`},
+	}
+	for _, c := range cases {
+		value, err := storagePatchValue(StoragePatchJson, c.current, c.patch)
+		if err != nil {
+			t.Fatalf("error applying JSON patch %v to %v: %v", c.patch, c.current, err)
+		}
+		assert.JSONEq(t, c.expected, value, c.patch)
+	}
+}
+
+func TestStoragePatchJsonRejected(t *testing.T) {
+	for _, patch := range []string{
+		`[{"op":"add","path":"/baz/bat","value":"qux"}]`,
+		`[{"op":"remove","path":"/missing"}]`,
+		`[{"op":"replace","path":"/missing","value":1}]`,
+		`[{"op":"add","path":"/foo/3","value":1}]`,
+		`[{"op":"add","path":"/foo/01","value":1}]`,
+		`[{"op":"test","path":"/baz","value":"bar"}]`,
+		`[{"op":"move","from":"/obj","path":"/obj/child"}]`,
+		`[{"op":"add","path":"/baz"}]`,
+		`[{"op":"unknown","path":"/baz"}]`,
+		`[{"op":"replace","path":"","value":[1]}]`,
+		`{"op":"add","path":"/baz","value":"qux"}`,
+	} {
+		_, err := storagePatchValue(StoragePatchJson, `{"baz":"qux","foo":[1,2],"obj":{}}`, patch)
+		assert.True(t, errors.Is(err, ErrStoragePatchRejected), patch)
+	}
+}
+
+func TestStoragePatchValueValid(t *testing.T) {
+	assert.True(t, StoragePatchValueValid(StoragePatchNone, ` {"a":1}`))
+	assert.False(t, StoragePatchValueValid(StoragePatchNone, `[1]`))
+	assert.True(t, StoragePatchValueValid(StoragePatchMerge, `{"a":null}`))
+	assert.False(t, StoragePatchValueValid(StoragePatchMerge, `{"a":`))
+	assert.True(t, StoragePatchValueValid(StoragePatchJson, `[]`))
+	assert.False(t, StoragePatchValueValid(StoragePatchJson, `{}`))
+
+	patch, err := ParseStoragePatch("merge")
+	assert.NoError(t, err)
+	assert.Equal(t, StoragePatchMerge, patch)
+	_, err = ParseStoragePatch("other")
+	assert.Error(t, err)
+}
+
+func TestStoragePatchApiWrite(t *testing.T) {
+	runtime, _, err := runtimeWithModules(t, map[string]string{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	apiserver, _ := NewAPIServer(t, runtime)
+	defer apiserver.Stop()
+	conn, client, session, ctx := NewAuthenticatedAPIClient(t, uuid.Must(uuid.NewV4()).String())
+	defer conn.Close()
+	userID, err := UserIDFromSession(session)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(value string, patch apigrpc.WriteStorageObjectWithOptions_StoragePatch) error {
+		_, err := client.WriteStorageObjectsWithOptions(ctx, &apigrpc.WriteStorageObjectsWithOptionsRequest{
+			Objects: []*apigrpc.WriteStorageObjectWithOptions{{
+				Collection: "collection",
+				Key:        "patched",
+				Value:      value,
+				Patch:      patch,
+			}},
+		})
+		return err
+	}
+
+	if err := write(`{"a":"b","c":"d"}`, apigrpc.WriteStorageObjectWithOptions_NONE); err != nil {
+		t.Fatal(err)
+	}
+	if err := write(`{"a":null,"e":"f"}`, apigrpc.WriteStorageObjectWithOptions_MERGE); err != nil {
+		t.Fatal(err)
+	}
+	if err := write(`[{"op":"replace","path":"/c","value":"g"}]`, apigrpc.WriteStorageObjectWithOptions_JSON); err != nil {
+		t.Fatal(err)
+	}
+	// Each object's value must match its own patch type.
+	assert.Error(t, write(`{"a":"b"}`, apigrpc.WriteStorageObjectWithOptions_JSON))
+
+	objects, err := client.ReadStorageObjects(ctx, &api.ReadStorageObjectsRequest{
+		ObjectIds: []*api.ReadStorageObjectId{{Collection: "collection", Key: "patched", UserId: userID.String()}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, objects.Objects, 1) {
+		assert.JSONEq(t, `{"c":"g","e":"f"}`, objects.Objects[0].Value)
+	}
+}
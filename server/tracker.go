@@ -40,6 +40,8 @@ const (
 	StreamModeMatchRelayed
 	StreamModeMatchAuthoritative
 	StreamModeParty
+	StreamModeStorage
+	StreamModeLeaderboard
 )
 
 type PresenceID struct {
@@ -193,11 +195,18 @@ type LocalTracker struct {
 	presencesBySession map[uuid.UUID]map[presenceCompact]*Presence
 	count              *atomic.Int64
 
+	transport        ClusterTransport
+	clusterMutex     sync.Mutex
+	clusterOps       []*trackerClusterOp
+	clusterPeers     map[string]struct{}
+	clusterSyncNodes map[string]bool
+	clusterCh        chan struct{}
+
 	ctx         context.Context
 	ctxCancelFn context.CancelFunc
 }
 
-func StartLocalTracker(logger *zap.Logger, config Config, sessionRegistry SessionRegistry, statusRegistry *StatusRegistry, metrics Metrics, protojsonMarshaler *protojson.MarshalOptions) Tracker {
+func StartLocalTracker(logger *zap.Logger, config Config, sessionRegistry SessionRegistry, statusRegistry *StatusRegistry, metrics Metrics, protojsonMarshaler *protojson.MarshalOptions, transport ClusterTransport) Tracker {
 	ctx, ctxCancelFn := context.WithCancel(context.Background())
 
 	t := &LocalTracker{
@@ -212,10 +221,32 @@ func StartLocalTracker(logger *zap.Logger, config Config, sessionRegistry Sessio
 		presencesBySession: make(map[uuid.UUID]map[presenceCompact]*Presence),
 		count:              atomic.NewInt64(0),
 
+		transport:        transport,
+		clusterOps:       make([]*trackerClusterOp, 0, 16),
+		clusterPeers:     make(map[string]struct{}),
+		clusterSyncNodes: make(map[string]bool),
+		clusterCh:        make(chan struct{}, 1),
+
 		ctx:         ctx,
 		ctxCancelFn: ctxCancelFn,
 	}
 
+	transport.Handle(clusterMessageTrackerDelta, t.handleClusterDelta)
+	transport.Handle(clusterMessageTrackerSync, t.handleClusterSync)
+	transport.AddPeerListener(t.onClusterPeer)
+
+	go func() {
+		// Asynchronously replicate local presence changes to other nodes, in the order they were applied.
+		for {
+			select {
+			case <-t.ctx.Done():
+				return
+			case <-t.clusterCh:
+				t.flushCluster()
+			}
+		}
+	}()
+
 	go func() {
 		// Asynchronously process and dispatch presence events.
 		ticker := time.NewTicker(15 * time.Second)
@@ -306,6 +337,7 @@ func (t *LocalTracker) Track(ctx context.Context, sessionID uuid.UUID, stream Pr
 		byStream[pc] = p
 	}
 
+	t.replicateLocked(&trackerClusterOp{Joins: []Presence{*p}})
 	t.Unlock()
 	if !meta.Hidden {
 		t.queueEvent([]*Presence{p}, nil)
@@ -315,6 +347,7 @@ func (t *LocalTracker) Track(ctx context.Context, sessionID uuid.UUID, stream Pr
 
 func (t *LocalTracker) TrackMulti(ctx context.Context, sessionID uuid.UUID, ops []*TrackerOp, userID uuid.UUID, allowIfFirstForSession bool) bool {
 	joins := make([]*Presence, 0, len(ops))
+	replicated := make([]Presence, 0, len(ops))
 	t.Lock()
 
 	select {
@@ -350,6 +383,7 @@ func (t *LocalTracker) TrackMulti(ctx context.Context, sessionID uuid.UUID, ops
 			t.presencesBySession[sessionID] = bySession
 		}
 		t.count.Inc()
+		replicated = append(replicated, *p)
 
 		// Update tracking for stream.
 		byStreamMode, ok := t.presencesByStream[op.Stream.Mode]
@@ -370,6 +404,7 @@ func (t *LocalTracker) TrackMulti(ctx context.Context, sessionID uuid.UUID, ops
 			joins = append(joins, p)
 		}
 	}
+	t.replicateLocked(&trackerClusterOp{Joins: replicated})
 	t.Unlock()
 
 	if len(joins) != 0 {
@@ -426,6 +461,7 @@ func (t *LocalTracker) Untrack(sessionID uuid.UUID, stream PresenceStream, userI
 		}
 	}
 
+	t.replicateLocked(&trackerClusterOp{Leaves: []Presence{leaveCopy(p, runtime.PresenceReasonLeave)}})
 	t.Unlock()
 	if !p.Meta.Hidden {
 		syncAtomic.StoreUint32(&p.Meta.Reason, uint32(runtime.PresenceReasonLeave))
@@ -435,6 +471,7 @@ func (t *LocalTracker) Untrack(sessionID uuid.UUID, stream PresenceStream, userI
 
 func (t *LocalTracker) UntrackMulti(sessionID uuid.UUID, streams []*PresenceStream, userID uuid.UUID) {
 	leaves := make([]*Presence, 0, len(streams))
+	replicated := make([]Presence, 0, len(streams))
 	t.Lock()
 
 	for _, stream := range streams {
@@ -442,7 +479,9 @@ func (t *LocalTracker) UntrackMulti(sessionID uuid.UUID, streams []*PresenceStre
 
 		bySession, anyTracked := t.presencesBySession[sessionID]
 		if !anyTracked {
-			// Nothing tracked for the session.
+			// Nothing tracked for the session. Presences removed by earlier iterations are gone locally, so peers
+			// must still drop their copies even though no leave events are sent for them.
+			t.replicateLocked(&trackerClusterOp{Leaves: replicated})
 			t.Unlock()
 			return
 		}
@@ -483,11 +522,13 @@ func (t *LocalTracker) UntrackMulti(sessionID uuid.UUID, streams []*PresenceStre
 			}
 		}
 
+		replicated = append(replicated, leaveCopy(p, runtime.PresenceReasonLeave))
 		if !p.Meta.Hidden {
 			syncAtomic.StoreUint32(&p.Meta.Reason, uint32(runtime.PresenceReasonLeave))
 			leaves = append(leaves, p)
 		}
 	}
+	t.replicateLocked(&trackerClusterOp{Leaves: replicated})
 	t.Unlock()
 
 	if len(leaves) != 0 {
@@ -506,6 +547,7 @@ func (t *LocalTracker) UntrackAll(sessionID uuid.UUID, reason runtime.PresenceRe
 	}
 
 	leaves := make([]*Presence, 0, len(bySession))
+	replicated := make([]Presence, 0, len(bySession))
 	for pc, p := range bySession {
 		// Update the tracking for stream.
 		if byStreamMode := t.presencesByStream[pc.Stream.Mode]; len(byStreamMode) == 1 {
@@ -529,6 +571,7 @@ func (t *LocalTracker) UntrackAll(sessionID uuid.UUID, reason runtime.PresenceRe
 		}
 
 		// Check if there should be an event for this presence.
+		replicated = append(replicated, leaveCopy(p, reason))
 		if !p.Meta.Hidden {
 			syncAtomic.StoreUint32(&p.Meta.Reason, uint32(reason))
 			leaves = append(leaves, p)
@@ -539,6 +582,7 @@ func (t *LocalTracker) UntrackAll(sessionID uuid.UUID, reason runtime.PresenceRe
 	// Discard the tracking for session.
 	delete(t.presencesBySession, sessionID)
 
+	t.replicateLocked(&trackerClusterOp{Leaves: replicated})
 	t.Unlock()
 	if len(leaves) != 0 {
 		t.queueEvent(nil, leaves)
@@ -592,6 +636,7 @@ func (t *LocalTracker) Update(ctx context.Context, sessionID uuid.UUID, stream P
 		byStream[pc] = p
 	}
 
+	t.replicateLocked(&trackerClusterOp{Joins: []Presence{*p}})
 	t.Unlock()
 
 	if !meta.Hidden || (alreadyTracked && !previousP.Meta.Hidden) {
@@ -621,38 +666,33 @@ func (t *LocalTracker) UntrackLocalByStream(stream PresenceStream) {
 		return
 	}
 
-	// Drop the presences from tracking for each session.
-	for pc := range byStream {
-		if bySession := t.presencesBySession[pc.ID.SessionID]; len(bySession) == 1 {
-			// This is the only presence for that session, discard the whole list.
-			delete(t.presencesBySession, pc.ID.SessionID)
-		} else {
-			// There were other presences for the session, drop just this one.
-			delete(bySession, pc)
+	// Drop only the presences belonging to this node, other nodes manage their own.
+	removed := make([]Presence, 0, len(byStream))
+	for pc, p := range byStream {
+		if pc.ID.Node != t.name {
+			continue
 		}
-		t.count.Dec()
-	}
-
-	// Discard the tracking for stream.
-	if byStreamMode := t.presencesByStream[stream.Mode]; len(byStreamMode) == 1 {
-		// This is the only stream for this stream mode.
-		delete(t.presencesByStream, stream.Mode)
-	} else {
-		// There are other streams for this stream mode.
-		delete(byStreamMode, stream)
+		removed = append(removed, *p)
+		t.removeLocked(pc)
 	}
 
+	t.replicateLocked(&trackerClusterOp{Removes: removed})
 	t.Unlock()
 }
 
 func (t *LocalTracker) UntrackByStream(stream PresenceStream) {
 	// NOTE: Generates no presence notifications as everyone on the stream is going away all at once.
 	t.Lock()
+	t.untrackByStreamLocked(stream)
+	// Other nodes must drop their own presences for this stream too, whether or not any are known here.
+	t.replicateLocked(&trackerClusterOp{UntrackStream: &stream})
+	t.Unlock()
+}
 
+func (t *LocalTracker) untrackByStreamLocked(stream PresenceStream) {
 	byStream, anyTracked := t.presencesByStream[stream.Mode][stream]
 	if !anyTracked {
 		// Nothing tracked for the stream.
-		t.Unlock()
 		return
 	}
 
@@ -665,7 +705,9 @@ func (t *LocalTracker) UntrackByStream(stream PresenceStream) {
 			// There were other presences for the session, drop just this one.
 			delete(bySession, pc)
 		}
-		t.count.Dec()
+		if pc.ID.Node == t.name {
+			t.count.Dec()
+		}
 	}
 
 	// Discard the tracking for stream.
@@ -676,12 +718,11 @@ func (t *LocalTracker) UntrackByStream(stream PresenceStream) {
 		// There are other streams for this stream mode.
 		delete(byStreamMode, stream)
 	}
-
-	t.Unlock()
 }
 
 func (t *LocalTracker) UntrackLocalByModes(sessionID uuid.UUID, modes map[uint8]struct{}, skipStream PresenceStream) {
 	leaves := make([]*Presence, 0, 1)
+	replicated := make([]Presence, 0, 1)
 
 	t.Lock()
 	bySession, anyTracked := t.presencesBySession[sessionID]
@@ -731,11 +772,13 @@ func (t *LocalTracker) UntrackLocalByModes(sessionID uuid.UUID, modes map[uint8]
 			}
 		}
 
+		replicated = append(replicated, leaveCopy(p, runtime.PresenceReasonLeave))
 		if !p.Meta.Hidden {
 			syncAtomic.StoreUint32(&p.Meta.Reason, uint32(runtime.PresenceReasonLeave))
 			leaves = append(leaves, p)
 		}
 	}
+	t.replicateLocked(&trackerClusterOp{Leaves: replicated})
 	t.Unlock()
 
 	if len(leaves) > 0 {
@@ -744,14 +787,13 @@ func (t *LocalTracker) UntrackLocalByModes(sessionID uuid.UUID, modes map[uint8]
 }
 
 func (t *LocalTracker) ListNodesForStream(stream PresenceStream) map[string]struct{} {
+	nodes := make(map[string]struct{}, 1)
 	t.RLock()
-	_, anyTracked := t.presencesByStream[stream.Mode][stream]
-	t.RUnlock()
-	if anyTracked {
-		// For the local tracker having any presences for this stream is enough.
-		return map[string]struct{}{t.name: {}}
+	for pc := range t.presencesByStream[stream.Mode][stream] {
+		nodes[pc.ID.Node] = struct{}{}
 	}
-	return map[string]struct{}{}
+	t.RUnlock()
+	return nodes
 }
 
 func (t *LocalTracker) StreamExists(stream PresenceStream) bool {
@@ -857,6 +899,10 @@ func (t *LocalTracker) ListLocalSessionIDByStream(stream PresenceStream) []uuid.
 	}
 	ps := make([]uuid.UUID, 0, len(byStream))
 	for pc := range byStream {
+		if pc.ID.Node != t.name {
+			// Presences replicated from other nodes are delivered to by those nodes.
+			continue
+		}
 		ps = append(ps, pc.ID.SessionID)
 	}
 	t.RUnlock()
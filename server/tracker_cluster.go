@@ -0,0 +1,401 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	syncAtomic "sync/atomic"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"go.uber.org/zap"
+)
+
+const (
+	clusterMessageTrackerDelta = "tracker_delta"
+	clusterMessageTrackerSync  = "tracker_sync"
+)
+
+// trackerClusterOp is a single change to the presences owned by a node, replicated to all other nodes.
+type trackerClusterOp struct {
+	// Presences tracked or updated, carrying their full current state.
+	Joins []Presence
+	// Presences untracked, each carrying its leave reason.
+	Leaves []Presence
+	// Presences untracked without generating any presence events.
+	Removes []Presence
+	// A stream closed entirely, all nodes drop any presences they hold for it.
+	UntrackStream *PresenceStream
+}
+
+type trackerClusterDelta struct {
+	Ops []*trackerClusterOp
+}
+
+// trackerClusterSync is a full snapshot of the presences owned by a node. The receiver replaces everything
+// it knows about that node with the snapshot, repairing any changes missed while the nodes were disconnected.
+type trackerClusterSync struct {
+	Presences []Presence
+	// True if the receiver should respond with a snapshot of its own.
+	Request bool
+}
+
+// Queue a change to local presences for replication. Must be called while holding the tracker write lock. Flushes
+// read the presence maps under the tracker lock, so a snapshot always includes every change queued before it was
+// taken, which is why peers receiving a snapshot can skip the queued deltas.
+func (t *LocalTracker) replicateLocked(op *trackerClusterOp) {
+	if len(op.Joins) == 0 && len(op.Leaves) == 0 && len(op.Removes) == 0 && op.UntrackStream == nil {
+		return
+	}
+	t.clusterMutex.Lock()
+	t.clusterOps = append(t.clusterOps, op)
+	t.clusterMutex.Unlock()
+	t.signalCluster()
+}
+
+func (t *LocalTracker) signalCluster() {
+	select {
+	case t.clusterCh <- struct{}{}:
+	default:
+		// A flush is already pending.
+	}
+}
+
+func (t *LocalTracker) onClusterPeer(node string, connected bool) {
+	if connected {
+		// Exchange full snapshots with the peer, regardless of what it may already know.
+		t.clusterMutex.Lock()
+		t.clusterPeers[node] = struct{}{}
+		t.clusterSyncNodes[node] = true
+		t.clusterMutex.Unlock()
+		t.signalCluster()
+		return
+	}
+
+	// The peer is gone, along with all of its sessions.
+	t.Lock()
+	t.clusterMutex.Lock()
+	delete(t.clusterPeers, node)
+	t.clusterMutex.Unlock()
+	leaves := t.replaceNodeLocked(node, nil, runtime.PresenceReasonDisconnect)
+	t.Unlock()
+	if len(leaves) != 0 {
+		t.queueEvent(nil, leaves)
+	}
+}
+
+// Deltas and snapshots from a peer can still arrive after its disconnect removed all of its presences, and would add
+// presences for sessions that are gone. Must be called while holding the tracker lock, the same lock the disconnect
+// holds while removing the presences, so a message is either applied before the removal or dropped.
+func (t *LocalTracker) clusterPeerLocked(node string) bool {
+	t.clusterMutex.Lock()
+	_, found := t.clusterPeers[node]
+	t.clusterMutex.Unlock()
+	return found
+}
+
+// Send all queued changes to peers, and full snapshots to any peers that need one.
+func (t *LocalTracker) flushCluster() {
+	t.RLock()
+	t.clusterMutex.Lock()
+	ops := t.clusterOps
+	t.clusterOps = make([]*trackerClusterOp, 0, 16)
+	syncNodes := t.clusterSyncNodes
+	t.clusterSyncNodes = make(map[string]bool)
+	t.clusterMutex.Unlock()
+
+	var snapshot []Presence
+	if len(syncNodes) != 0 {
+		snapshot = make([]Presence, 0, t.count.Load())
+		for _, bySession := range t.presencesBySession {
+			for pc, p := range bySession {
+				if pc.ID.Node != t.name {
+					// Sessions are only ever connected to a single node.
+					break
+				}
+				snapshot = append(snapshot, *p)
+			}
+		}
+	}
+	t.RUnlock()
+
+	for node, request := range syncNodes {
+		t.sendClusterSync(node, snapshot, request)
+	}
+
+	if len(ops) == 0 {
+		return
+	}
+
+	// Snapshots already include all changes to local presences, but not streams closed by this node.
+	var streamOps []*trackerClusterOp
+	for _, op := range ops {
+		if op.UntrackStream != nil {
+			streamOps = append(streamOps, &trackerClusterOp{UntrackStream: op.UntrackStream})
+		}
+	}
+
+	for _, node := range t.transport.Peers() {
+		deltaOps := ops
+		if _, found := syncNodes[node]; found {
+			if len(streamOps) == 0 {
+				continue
+			}
+			deltaOps = streamOps
+		}
+		t.sendClusterDelta(node, deltaOps)
+	}
+}
+
+func (t *LocalTracker) sendClusterDelta(node string, ops []*trackerClusterOp) {
+	payload, err := gobEncode(&trackerClusterDelta{Ops: ops})
+	if err != nil {
+		t.logger.Error("Failed to encode tracker cluster delta", zap.Error(err))
+		return
+	}
+	if err := t.transport.Send(node, clusterMessageTrackerDelta, payload); err != nil {
+		// The peer drops this node's presences when the connection breaks, and gets a snapshot when it reconnects.
+		t.logger.Debug("Failed to send tracker cluster delta", zap.String("node", node), zap.Error(err))
+	}
+}
+
+func (t *LocalTracker) sendClusterSync(node string, snapshot []Presence, request bool) {
+	payload, err := gobEncode(&trackerClusterSync{Presences: snapshot, Request: request})
+	if err != nil {
+		t.logger.Error("Failed to encode tracker cluster sync", zap.Error(err))
+		return
+	}
+	if err := t.transport.Send(node, clusterMessageTrackerSync, payload); err != nil {
+		t.logger.Debug("Failed to send tracker cluster sync", zap.String("node", node), zap.Error(err))
+	}
+}
+
+func (t *LocalTracker) handleClusterDelta(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var delta trackerClusterDelta
+	if err := gobDecode(payload, &delta); err != nil {
+		return nil, err
+	}
+
+	events := make([]*PresenceEvent, 0, len(delta.Ops))
+	t.Lock()
+	if !t.clusterPeerLocked(node) {
+		t.Unlock()
+		return nil, nil
+	}
+	for _, op := range delta.Ops {
+		joins := make([]*Presence, 0, len(op.Joins))
+		leaves := make([]*Presence, 0, len(op.Leaves))
+		for i := range op.Joins {
+			p := &op.Joins[i]
+			if p.ID.Node != node {
+				// Nodes may only replicate their own presences.
+				continue
+			}
+			if previous := t.addLocked(p); previous != nil && !previous.Meta.Hidden {
+				syncAtomic.StoreUint32(&previous.Meta.Reason, uint32(runtime.PresenceReasonUpdate))
+				leaves = append(leaves, previous)
+			}
+			if !p.Meta.Hidden {
+				joins = append(joins, p)
+			}
+		}
+		for _, p := range op.Leaves {
+			if p.ID.Node != node {
+				continue
+			}
+			if removed := t.removeLocked(presenceCompact{ID: p.ID, Stream: p.Stream, UserID: p.UserID}); removed != nil && !removed.Meta.Hidden {
+				syncAtomic.StoreUint32(&removed.Meta.Reason, p.Meta.Reason)
+				leaves = append(leaves, removed)
+			}
+		}
+		for _, p := range op.Removes {
+			if p.ID.Node != node {
+				continue
+			}
+			t.removeLocked(presenceCompact{ID: p.ID, Stream: p.Stream, UserID: p.UserID})
+		}
+		if op.UntrackStream != nil {
+			t.untrackByStreamLocked(*op.UntrackStream)
+		}
+		if len(joins) != 0 || len(leaves) != 0 {
+			events = append(events, &PresenceEvent{Joins: joins, Leaves: leaves})
+		}
+	}
+	t.Unlock()
+
+	for _, e := range events {
+		t.queueEvent(e.Joins, e.Leaves)
+	}
+	return nil, nil
+}
+
+func (t *LocalTracker) handleClusterSync(ctx context.Context, node string, payload []byte) ([]byte, error) {
+	var sync trackerClusterSync
+	if err := gobDecode(payload, &sync); err != nil {
+		return nil, err
+	}
+
+	presences := make([]*Presence, 0, len(sync.Presences))
+	for i := range sync.Presences {
+		if p := &sync.Presences[i]; p.ID.Node == node {
+			presences = append(presences, p)
+		}
+	}
+
+	t.Lock()
+	if !t.clusterPeerLocked(node) {
+		t.Unlock()
+		return nil, nil
+	}
+	leaves := t.replaceNodeLocked(node, presences, runtime.PresenceReasonLeave)
+	var joins []*Presence
+	for _, p := range presences {
+		if previous := t.addLocked(p); previous != nil {
+			if presenceMetaEqual(&previous.Meta, &p.Meta) {
+				// Unchanged, restore the existing presence and do not generate any events for it.
+				t.addLocked(previous)
+				continue
+			}
+			if !previous.Meta.Hidden {
+				syncAtomic.StoreUint32(&previous.Meta.Reason, uint32(runtime.PresenceReasonUpdate))
+				leaves = append(leaves, previous)
+			}
+		}
+		if !p.Meta.Hidden {
+			joins = append(joins, p)
+		}
+	}
+	t.Unlock()
+
+	if len(joins) != 0 || len(leaves) != 0 {
+		t.queueEvent(joins, leaves)
+	}
+
+	if sync.Request {
+		// Marking the peer for a snapshot also stops the next flush sending it deltas the snapshot already covers.
+		t.clusterMutex.Lock()
+		if _, found := t.clusterSyncNodes[node]; !found {
+			t.clusterSyncNodes[node] = false
+		}
+		t.clusterMutex.Unlock()
+		t.signalCluster()
+	}
+	return nil, nil
+}
+
+// Remove all presences owned by the given node, except those present in the keep list.
+// Returns the visible presences removed, with their leave reason set.
+func (t *LocalTracker) replaceNodeLocked(node string, keep []*Presence, reason runtime.PresenceReason) []*Presence {
+	if node == t.name {
+		return nil
+	}
+
+	keepSet := make(map[presenceCompact]struct{}, len(keep))
+	for _, p := range keep {
+		keepSet[presenceCompact{ID: p.ID, Stream: p.Stream, UserID: p.UserID}] = struct{}{}
+	}
+
+	var remove []presenceCompact
+	for _, bySession := range t.presencesBySession {
+		for pc := range bySession {
+			if pc.ID.Node != node {
+				// Sessions are only ever connected to a single node.
+				break
+			}
+			if _, found := keepSet[pc]; !found {
+				remove = append(remove, pc)
+			}
+		}
+	}
+
+	leaves := make([]*Presence, 0, len(remove))
+	for _, pc := range remove {
+		if p := t.removeLocked(pc); p != nil && !p.Meta.Hidden {
+			syncAtomic.StoreUint32(&p.Meta.Reason, uint32(reason))
+			leaves = append(leaves, p)
+		}
+	}
+	return leaves
+}
+
+// Insert or replace a presence, returning any previous presence with the same identity.
+func (t *LocalTracker) addLocked(p *Presence) *Presence {
+	pc := presenceCompact{ID: p.ID, Stream: p.Stream, UserID: p.UserID}
+
+	bySession, anyTracked := t.presencesBySession[p.ID.SessionID]
+	if !anyTracked {
+		bySession = make(map[presenceCompact]*Presence)
+		t.presencesBySession[p.ID.SessionID] = bySession
+	}
+	previous := bySession[pc]
+	bySession[pc] = p
+	if previous == nil && p.ID.Node == t.name {
+		t.count.Inc()
+	}
+
+	byStreamMode, ok := t.presencesByStream[p.Stream.Mode]
+	if !ok {
+		byStreamMode = make(map[PresenceStream]map[presenceCompact]*Presence)
+		t.presencesByStream[p.Stream.Mode] = byStreamMode
+	}
+	byStream, ok := byStreamMode[p.Stream]
+	if !ok {
+		byStream = make(map[presenceCompact]*Presence)
+		byStreamMode[p.Stream] = byStream
+	}
+	byStream[pc] = p
+
+	return previous
+}
+
+// Remove a single presence, returning it if it was tracked.
+func (t *LocalTracker) removeLocked(pc presenceCompact) *Presence {
+	bySession, anyTracked := t.presencesBySession[pc.ID.SessionID]
+	if !anyTracked {
+		return nil
+	}
+	p, found := bySession[pc]
+	if !found {
+		return nil
+	}
+
+	if len(bySession) == 1 {
+		delete(t.presencesBySession, pc.ID.SessionID)
+	} else {
+		delete(bySession, pc)
+	}
+	if pc.ID.Node == t.name {
+		t.count.Dec()
+	}
+
+	if byStreamMode := t.presencesByStream[pc.Stream.Mode]; len(byStreamMode) == 1 && len(byStreamMode[pc.Stream]) == 1 {
+		delete(t.presencesByStream, pc.Stream.Mode)
+	} else if byStream := byStreamMode[pc.Stream]; len(byStream) == 1 {
+		delete(byStreamMode, pc.Stream)
+	} else {
+		delete(byStream, pc)
+	}
+
+	return p
+}
+
+func leaveCopy(p *Presence, reason runtime.PresenceReason) Presence {
+	c := *p
+	c.Meta.Reason = uint32(reason)
+	return c
+}
+
+func presenceMetaEqual(a, b *PresenceMeta) bool {
+	return a.Hidden == b.Hidden && a.Persistence == b.Persistence && a.Username == b.Username && a.Status == b.Status
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackerClusterReplication(t *testing.T) {
+	hub := NewLoopbackClusterHub()
+	nodeA := newClusterTestNode(t, hub, "a")
+	nodeB := newClusterTestNode(t, hub, "b")
+
+	stream := PresenceStream{Mode: StreamModeChannel, Label: "room"}
+	sessionA := nodeA.addSession(t, &stream)
+	sessionB := newClusterTestSession()
+	nodeB.sessionRegistry.Add(sessionB)
+	userB := uuid.Must(uuid.NewV4())
+	if ok, _ := nodeB.tracker.Track(context.Background(), sessionB.ID(), stream, userB, PresenceMeta{Username: "b"}, true); !ok {
+		t.Fatal("error tracking session")
+	}
+
+	assert.Eventually(t, func() bool { return nodeA.tracker.CountByStream(stream) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return nodeB.tracker.CountByStream(stream) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.NotNil(t, nodeA.tracker.GetBySessionIDStreamUserID("b", sessionB.ID(), stream, userB))
+	assert.Equal(t, map[string]struct{}{"a": {}, "b": {}}, nodeA.tracker.ListNodesForStream(stream))
+	// Remote presences are never listed for local delivery, and not counted as local presences.
+	assert.Equal(t, []uuid.UUID{sessionA.ID()}, nodeA.tracker.ListLocalSessionIDByStream(stream))
+	assert.Equal(t, 1, nodeA.tracker.Count())
+
+	// The join of the presence on node b is delivered to the session on node a.
+	assert.Eventually(t, func() bool {
+		for _, envelope := range sessionA.Received() {
+			if e := envelope.GetChannelPresenceEvent(); e != nil && len(e.Joins) == 1 && e.Joins[0].SessionId == sessionB.ID().String() {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+
+	nodeB.tracker.Untrack(sessionB.ID(), stream, userB)
+	assert.Eventually(t, func() bool { return nodeA.tracker.CountByStream(stream) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		for _, envelope := range sessionA.Received() {
+			if e := envelope.GetChannelPresenceEvent(); e != nil && len(e.Leaves) == 1 && e.Leaves[0].SessionId == sessionB.ID().String() {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestTrackerClusterAntiEntropy(t *testing.T) {
+	hub := NewLoopbackClusterHub()
+	nodeB := newClusterTestNode(t, hub, "b")
+
+	// Tracked while node b has no peers, so only the snapshot exchanged on connect can carry it.
+	stream := PresenceStream{Mode: StreamModeChannel, Label: "room"}
+	nodeB.addSession(t, &stream)
+
+	nodeA := newClusterTestNode(t, hub, "a")
+	assert.Eventually(t, func() bool { return nodeA.tracker.StreamExists(stream) }, 5*time.Second, 10*time.Millisecond)
+
+	// Losing the connection to node b drops all of its presences.
+	nodeB.transport.Stop()
+	assert.Eventually(t, func() bool { return !nodeA.tracker.StreamExists(stream) }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestTrackerClusterUntrackByStream(t *testing.T) {
+	hub := NewLoopbackClusterHub()
+	nodeA := newClusterTestNode(t, hub, "a")
+	nodeB := newClusterTestNode(t, hub, "b")
+
+	stream := PresenceStream{Mode: StreamModeMatchAuthoritative, Subject: uuid.Must(uuid.NewV4()), Label: "a"}
+	nodeA.addSession(t, &stream)
+	nodeB.addSession(t, &stream)
+	assert.Eventually(t, func() bool { return nodeA.tracker.CountByStream(stream) == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	// Closing the stream on one node removes the presences held by every node.
+	nodeA.tracker.UntrackByStream(stream)
+	assert.False(t, nodeA.tracker.StreamExists(stream))
+	assert.Eventually(t, func() bool { return !nodeB.tracker.StreamExists(stream) }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, nodeB.tracker.Count())
+}